@@ -0,0 +1,98 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+)
+
+const adoptDesc = `
+This command creates a release from resources that already exist in the cluster.
+
+The chart is rendered as it would be for 'helm install', but instead of creating
+the resulting objects, Tiller verifies that each of them already exists, patches
+it to the rendered state and records it in the release manifest. The command
+fails if any rendered object is missing from the cluster.
+
+Every adopted object is annotated with the name of the owning release. Objects
+that are already owned by another release cannot be adopted.
+
+	$ helm adopt my-redis stable/redis -f myvalues.yaml
+
+To adopt objects that exist while creating the ones that do not, use
+'helm install --adopt' instead.
+`
+
+func newAdoptCmd(c helm.Interface, out io.Writer) *cobra.Command {
+	inst := &installCmd{
+		out:       out,
+		client:    c,
+		adopt:     true,
+		adoptOnly: true,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "adopt [RELEASE] [CHART]",
+		Short:   "Create a release from resources that already exist in the cluster",
+		Long:    adoptDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name", "chart name"); err != nil {
+				return err
+			}
+
+			inst.name = args[0]
+			if inst.version == "" && inst.devel {
+				debug("setting version to >0.0.0-0")
+				inst.version = ">0.0.0-0"
+			}
+
+			cp, err := locateChartPath(inst.repoURL, inst.username, inst.password, args[1], inst.version, inst.verify, inst.keyring,
+				inst.certFile, inst.keyFile, inst.caFile)
+			if err != nil {
+				return err
+			}
+			inst.chartPath = cp
+			inst.client = ensureHelmClient(inst.client)
+
+			return inst.run()
+		},
+	}
+
+	f := cmd.Flags()
+	inst.addFlags(f)
+	// describe the shared install flags in terms of adoption
+	for name, usage := range map[string]string{
+		"namespace": "Namespace of the adopted resources. Defaults to the current kube config namespace.",
+		"dry-run":   "Simulate an adoption",
+		"no-hooks":  "Prevent hooks from running during adoption",
+		"verify":    "Verify the package before adopting it",
+		"version":   "Specify the exact chart version to use. If this is not specified, the latest version is used",
+	} {
+		f.Lookup(name).Usage = usage
+	}
+	bindOutputFlag(cmd, &inst.output)
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
@@ -0,0 +1,53 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+)
+
+func TestAdoptCmd(t *testing.T) {
+	tests := []releaseCase{
+		{
+			name:     "adopt resources into a release",
+			args:     []string{"aeneas", "testdata/testcharts/alpine"},
+			expected: "aeneas",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+		},
+		{
+			name:     "adopt resources with values",
+			args:     []string{"aeneas", "testdata/testcharts/alpine"},
+			flags:    []string{"--set", "foo=bar"},
+			expected: "aeneas",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+		},
+		{
+			name: "adopt without a chart",
+			args: []string{"aeneas"},
+			err:  true,
+		},
+	}
+
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newAdoptCmd(c, out)
+	})
+}
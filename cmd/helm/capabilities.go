@@ -0,0 +1,113 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s.io/helm/pkg/tiller"
+)
+
+const capabilitiesDesc = `
+This command consists of multiple subcommands to work with the capabilities of
+a Kubernetes cluster, i.e. the values templates see as '.Capabilities'.
+`
+
+const capabilitiesDumpDesc = `
+Write the capabilities of the current Kubernetes cluster to FILE, or to
+standard output if no file is given.
+
+The capabilities are discovered the same way Tiller discovers them when it
+renders a release. Pass the file to 'helm template --capabilities' or
+'helm lint --capabilities' to render charts offline exactly as they would be
+rendered in this cluster:
+
+	$ helm capabilities dump production.yaml
+	$ helm template --capabilities production.yaml mychart
+`
+
+func newCapabilitiesCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "capabilities [FLAGS] dump [ARGS]",
+		Short: "Work with the capabilities of a cluster",
+		Long:  capabilitiesDesc,
+	}
+
+	cmd.AddCommand(newCapabilitiesDumpCmd(nil, out))
+
+	return cmd
+}
+
+type capabilitiesDumpCmd struct {
+	out        io.Writer
+	kubeClient kubernetes.Interface
+	file       string
+}
+
+func newCapabilitiesDumpCmd(client kubernetes.Interface, out io.Writer) *cobra.Command {
+	d := &capabilitiesDumpCmd{
+		out:        out,
+		kubeClient: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "dump [FILE]",
+		Short: "Write the capabilities of the current cluster to a file",
+		Long:  capabilitiesDumpDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return fmt.Errorf("at most one file can be given")
+			}
+			if len(args) == 1 {
+				d.file = args[0]
+			}
+			return d.run()
+		},
+	}
+
+	return cmd
+}
+
+func (d *capabilitiesDumpCmd) run() error {
+	if d.kubeClient == nil {
+		_, c, err := getKubeClient(settings.KubeContext, settings.KubeConfig)
+		if err != nil {
+			return err
+		}
+		d.kubeClient = c
+	}
+
+	caps, err := tiller.Capabilities(d.kubeClient.Discovery())
+	if err != nil {
+		return err
+	}
+	out, err := caps.YAML()
+	if err != nil {
+		return err
+	}
+
+	if d.file == "" {
+		_, err = io.WriteString(d.out, out)
+		return err
+	}
+	return ioutil.WriteFile(d.file, []byte(out), 0644)
+}
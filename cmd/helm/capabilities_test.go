@@ -0,0 +1,99 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+func fakeCapabilitiesClient() *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	disc := client.Discovery().(*fakediscovery.FakeDiscovery)
+	disc.FakedServerVersion = &version.Info{Major: "1", Minor: "16", GitVersion: "v1.16.2"}
+	disc.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "pods", Kind: "Pod"}}},
+		{GroupVersion: "example.com/v1", APIResources: []metav1.APIResource{{Name: "widgets", Kind: "Widget"}}},
+	}
+	return client
+}
+
+func TestCapabilitiesDump(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := newCapabilitiesDumpCmd(fakeCapabilitiesClient(), &buf)
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	caps, err := chartutil.ReadCapabilities(buf.Bytes(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caps.KubeVersion.GitVersion != "v1.16.2" {
+		t.Errorf("expected kube version v1.16.2, got %q", caps.KubeVersion.GitVersion)
+	}
+	for _, v := range []string{"v1", "v1/Pod", "example.com/v1", "example.com/v1/Widget"} {
+		if !caps.APIVersions.Has(v) {
+			t.Errorf("expected api version %q in:\n%s", v, buf.String())
+		}
+	}
+	if caps.TillerVersion == nil {
+		t.Error("expected the tiller version to be set")
+	}
+}
+
+func TestCapabilitiesDumpFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-capabilities-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "caps.yaml")
+
+	var buf bytes.Buffer
+	cmd := newCapabilitiesDumpCmd(fakeCapabilitiesClient(), &buf)
+	cmd.SetArgs([]string{file})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %q", buf.String())
+	}
+
+	// the dumped file renders templates like the cluster would
+	out := bytes.NewBuffer(nil)
+	tcmd := newTemplateCmd(out)
+	tcmd.SetArgs([]string{subchart1ChartPath, "--capabilities", file})
+	if err := tcmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `kube-version/minor: "16"`) {
+		t.Errorf("expected the template to be rendered for kubernetes 1.16, got:\n%s", out.String())
+	}
+}
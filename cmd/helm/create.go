@@ -20,8 +20,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/chartutil"
@@ -59,13 +61,26 @@ will be overwritten, but other files will be left alone.
 The chart that is created by invoking this command contains a Deployment, Ingress
 and a Service. To use other Kubernetes resources with your chart, refer to
 [The Chart Template Developer's Guide](https://helm.sh/docs/chart_template_guide).
+
+The '--starter' flag scaffolds the chart from a starter chart instead. It takes
+the name of a starter in $HELM_HOME/starters, a path, or a chart reference like
+'mycorp/web-starter', which is downloaded from the chart repository. Use
+'--starter-version' to pick a version of a downloaded starter.
+
+Starters may declare parameters in a 'starter.yaml' file. Their values are
+given with '--set' or an answers file passed with '--answers':
+
+	$ helm create web --starter mycorp/web-starter --set team=payments,ingress=true
 `
 
 type createCmd struct {
-	home    helmpath.Home
-	name    string
-	out     io.Writer
-	starter string
+	home           helmpath.Home
+	name           string
+	out            io.Writer
+	starter        string
+	starterVersion string
+	answers        valueFiles
+	values         []string
 }
 
 func newCreateCmd(out io.Writer) *cobra.Command {
@@ -88,7 +103,11 @@ func newCreateCmd(out io.Writer) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&cc.starter, "starter", "p", "", "The name or absolute path to Helm starter scaffold")
+	f := cmd.Flags()
+	f.StringVarP(&cc.starter, "starter", "p", "", "The name, path or chart reference of the Helm starter scaffold")
+	f.StringVar(&cc.starterVersion, "starter-version", "", "The version of the starter to download. If not set, the latest version is used")
+	f.VarP(&cc.answers, "answers", "a", "Specify starter parameters in a YAML file (can specify multiple)")
+	f.StringArrayVar(&cc.values, "set", []string{}, "Set starter parameters on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	return cmd
 }
 
@@ -104,15 +123,35 @@ func (c *createCmd) run() error {
 	}
 
 	if c.starter != "" {
-		// Create from the starter
-		lstarter := filepath.Join(c.home.Starters(), c.starter)
-		// If path is absolute, we don't want to prefix it with helm starters folder
-		if filepath.IsAbs(c.starter) {
-			lstarter = c.starter
+		lstarter, err := c.locateStarter()
+		if err != nil {
+			return err
+		}
+		raw, err := vals(c.answers, c.values, nil, nil, nil, "", "", "")
+		if err != nil {
+			return err
+		}
+		params := map[string]interface{}{}
+		if err := yaml.Unmarshal(raw, &params); err != nil {
+			return err
 		}
-		return chartutil.CreateFrom(cfile, filepath.Dir(c.name), lstarter)
+		return chartutil.CreateFromWithParameters(cfile, filepath.Dir(c.name), lstarter, params)
 	}
 
 	_, err := chartutil.Create(cfile, filepath.Dir(c.name))
 	return err
 }
+
+// locateStarter returns the path of the starter, looking in the starters
+// folder of the helm home before resolving it as a chart reference.
+func (c *createCmd) locateStarter() (string, error) {
+	// If path is absolute, we don't want to prefix it with helm starters folder
+	if filepath.IsAbs(c.starter) {
+		return c.starter, nil
+	}
+	lstarter := filepath.Join(c.home.Starters(), c.starter)
+	if _, err := os.Stat(lstarter); err == nil {
+		return lstarter, nil
+	}
+	return locateChartPath("", "", "", c.starter, c.starterVersion, false, "", "", "", "")
+}
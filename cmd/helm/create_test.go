@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/chartutil"
@@ -255,3 +256,69 @@ func TestCreateStarterAbsoluteCmd(t *testing.T) {
 	}
 
 }
+
+func TestCreateStarterParametersCmd(t *testing.T) {
+	cname := "testchart"
+	// Make a temp dir
+	tdir, err := ioutil.TempDir("", "helm-create-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	thome, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cleanup := resetEnv()
+	defer func() {
+		os.RemoveAll(thome.String())
+		cleanup()
+	}()
+
+	settings.Home = thome
+
+	starter, err := filepath.Abs("../../pkg/chartutil/testdata/webstarter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	answers := filepath.Join(tdir, "answers.yaml")
+	if err := ioutil.WriteFile(answers, []byte("team: payments\nport: 80\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// CD into it
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(tdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	// Run a create
+	cmd := newCreateCmd(ioutil.Discard)
+	cmd.ParseFlags([]string{"--starter", starter, "--answers", answers, "--set", "ingress=true"})
+	if err := cmd.RunE(cmd, []string{cname}); err != nil {
+		t.Fatalf("Failed to run create: %s", err)
+	}
+
+	c, err := chartutil.LoadDir(cname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "# Default values for testchart.\nteam: payments\nservice:\n  port: 80\n"; c.Values.Raw != expect {
+		t.Errorf("Expected values %q, got %q", expect, c.Values.Raw)
+	}
+	if l := len(c.Templates); l != 2 {
+		t.Errorf("Expected 2 templates, got %d", l)
+	}
+
+	// Missing required parameters
+	cmd = newCreateCmd(ioutil.Discard)
+	cmd.ParseFlags([]string{"--starter", starter})
+	if err := cmd.RunE(cmd, []string{"other"}); err == nil || !strings.Contains(err.Error(), "missing required starter parameters: team") {
+		t.Errorf("Expected a missing parameter error, got %v", err)
+	}
+}
@@ -89,7 +89,7 @@ if it cannot find a requirements.yaml.
 
 func newDependencyCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "dependency update|build|list",
+		Use:     "dependency update|build|list|tree",
 		Aliases: []string{"dep", "dependencies"},
 		Short:   "Manage a chart's dependencies",
 		Long:    dependencyDesc,
@@ -98,6 +98,7 @@ func newDependencyCmd(out io.Writer) *cobra.Command {
 	cmd.AddCommand(newDependencyListCmd(out))
 	cmd.AddCommand(newDependencyUpdateCmd(out))
 	cmd.AddCommand(newDependencyBuildCmd(out))
+	cmd.AddCommand(newDependencyTreeCmd(out))
 
 	return cmd
 }
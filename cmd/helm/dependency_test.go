@@ -17,6 +17,7 @@ package main
 
 import (
 	"io"
+	"regexp"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -56,3 +57,41 @@ func TestDependencyListCmd(t *testing.T) {
 		return newDependencyListCmd(out)
 	})
 }
+
+func TestDependencyTreeCmd(t *testing.T) {
+	tests := []releaseCase{
+		{
+			name: "No such chart",
+			args: []string{"/no/such/chart"},
+			err:  true,
+		},
+		{
+			name:     "No requirements.yaml",
+			args:     []string{"testdata/testcharts/alpine"},
+			expected: "alpine 0.1.0\n",
+		},
+		{
+			name: "Requirements in chart dir",
+			args: []string{"testdata/testcharts/reqtest"},
+			expected: regexp.QuoteMeta("reqtest 0.1.0\n" +
+				"├── reqsubchart 0.1.0 (requires 0.1.0, https://example.com/charts)\n" +
+				"├── reqsubchart2 0.2.0 (requires 0.2.0, https://example.com/charts)\n" +
+				"└── reqsubchart3 0.2.0 (requires >=0.1.0, https://example.com/charts)\n"),
+		},
+		{
+			name: "Transitive dependencies with conflicts",
+			args: []string{"../../pkg/resolver/testdata/graph"},
+			expected: regexp.QuoteMeta("mychart 0.1.0\n" +
+				"├── common 1.2.3 (requires ~1.2.0, https://example.com/charts, sha256:e70e41f8922e19558a8bf62f591a8b70c8e4622e3c03e5415f09aba881f13885)\n" +
+				"├── redis 2.1.0 (requires ^2.0.0, https://example.com/charts)\n" +
+				"│   └── common 2.0.0 (requires ^2.0.0, https://example.com/charts)\n" +
+				"├── postgresql 1.0.0 (requires 1.0.0, https://example.com/charts, missing)\n" +
+				"└── vendored 0.3.0 (vendored)\n" +
+				"WARNING: conflicting versions of common: mychart requires ~1.2.0 (1.2.3), mychart/redis requires ^2.0.0 (2.0.0)\n"),
+		},
+	}
+
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newDependencyTreeCmd(out)
+	})
+}
@@ -0,0 +1,124 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/resolver"
+)
+
+const dependencyTreeDesc = `
+Print the dependency graph of a chart.
+
+Every chart is printed with the version in the charts/ directory, followed by
+the version constraint it is required with, its repository, and the digest it
+is locked with in requirements.lock. The dependencies of charts in charts/ are
+followed, so charts pulled in by dependencies show up as well.
+
+Charts required at versions that do not satisfy the constraints of every chart
+requiring them are reported as conflicts. Charts that are required, but not
+in charts/, are marked as missing; run 'helm dependency build' to fetch them.
+`
+
+type dependencyTreeCmd struct {
+	out       io.Writer
+	chartpath string
+}
+
+func newDependencyTreeCmd(out io.Writer) *cobra.Command {
+	dtc := &dependencyTreeCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "tree [flags] CHART",
+		Short: "Print the resolved dependency graph of the given chart",
+		Long:  dependencyTreeDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cp := "."
+			if len(args) > 0 {
+				cp = args[0]
+			}
+
+			var err error
+			dtc.chartpath, err = filepath.Abs(cp)
+			if err != nil {
+				return err
+			}
+			return dtc.run()
+		},
+	}
+	return cmd
+}
+
+func (d *dependencyTreeCmd) run() error {
+	c, err := chartutil.Load(d.chartpath)
+	if err != nil {
+		return err
+	}
+	g, err := resolver.Graph(c)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(d.out, "%s %s\n", g.Name, g.Version)
+	printTree(d.out, g.Dependencies, "")
+	for _, c := range g.Conflicts() {
+		fmt.Fprintf(d.out, "WARNING: conflicting versions of %s\n", c)
+	}
+	return nil
+}
+
+func printTree(out io.Writer, nodes []*resolver.Node, prefix string) {
+	for i, n := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Fprintf(out, "%s%s%s\n", prefix, branch, formatNode(n))
+		printTree(out, n.Dependencies, prefix+indent)
+	}
+}
+
+func formatNode(n *resolver.Node) string {
+	var details []string
+	if n.Constraint != "" {
+		details = append(details, "requires "+n.Constraint)
+	}
+	switch {
+	case n.Repository != "":
+		details = append(details, n.Repository)
+	case n.Constraint == "":
+		details = append(details, "vendored")
+	}
+	if n.Digest != "" {
+		details = append(details, n.Digest)
+	}
+	if n.Missing {
+		details = append(details, "missing")
+	}
+	s := fmt.Sprintf("%s %s", n.Name, n.Version)
+	if len(details) > 0 {
+		s += " (" + strings.Join(details, ", ") + ")"
+	}
+	return s
+}
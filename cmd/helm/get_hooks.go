@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/releaseutil"
 )
 
 const getHooksHelp = `
@@ -74,7 +75,11 @@ func (g *getHooksCmd) run() error {
 		return prettyError(err)
 	}
 
-	for _, hook := range res.Release.Hooks {
+	rel, err := releaseutil.Redact(res.Release)
+	if err != nil {
+		return err
+	}
+	for _, hook := range rel.Hooks {
 		fmt.Fprintf(g.out, "---\n# %s\n%s\n", hook.Name, hook.Manifest)
 	}
 	return nil
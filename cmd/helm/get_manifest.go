@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/releaseutil"
 )
 
 var getManifestHelp = `
@@ -76,6 +77,10 @@ func (g *getManifestCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
-	fmt.Fprintln(g.out, res.Release.Manifest)
+	rel, err := releaseutil.Redact(res.Release)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(g.out, rel.Manifest)
 	return nil
 }
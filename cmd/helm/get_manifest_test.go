@@ -23,10 +23,18 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 )
 
 func TestGetManifest(t *testing.T) {
+	sensitive := helm.ReleaseMock(&helm.MockReleaseOptions{
+		Name:            "juno",
+		Config:          &chart.Config{Raw: "password: hunter22\n"},
+		SensitiveValues: []string{"password"},
+	})
+	sensitive.Manifest = "kind: Secret\ndata:\n  password: aHVudGVyMjI=\nstringData:\n  url: db://admin:hunter22@db\n"
+
 	tests := []releaseCase{
 		{
 			name:     "get manifest with release",
@@ -35,6 +43,13 @@ func TestGetManifest(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"}),
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"})},
 		},
+		{
+			name:     "get manifest with sensitive values",
+			args:     []string{"juno"},
+			expected: "kind: Secret\ndata:\n  password: REDACTED\nstringData:\n  url: db://admin:REDACTED@db\n",
+			resp:     sensitive,
+			rels:     []*release.Release{sensitive},
+		},
 		{
 			name: "get manifest without args",
 			args: []string{},
@@ -91,6 +91,7 @@ func (g *getValuesCmd) run() error {
 			return err
 		}
 	}
+	chartutil.RedactValues(values, res.Release.SensitiveValues)
 
 	result, err := formatValues(g.output, values)
 	if err != nil {
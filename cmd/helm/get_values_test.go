@@ -36,6 +36,15 @@ func TestGetValuesCmd(t *testing.T) {
 		},
 		Config: &chart.Config{Raw: `foo: "bar"`},
 	})
+	releaseWithSensitiveValues := helm.ReleaseMock(&helm.MockReleaseOptions{
+		Name: "thomas-guide",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "thomas-guide-chart-name"},
+			Values:   &chart.Config{Raw: "auth:\n  password: changeme\n"},
+		},
+		Config:          &chart.Config{Raw: "auth:\n  user: admin\ntoken: hunter22\n"},
+		SensitiveValues: []string{"auth.password", "token"},
+	})
 
 	tests := []releaseCase{
 		{
@@ -61,6 +70,22 @@ func TestGetValuesCmd(t *testing.T) {
 			expected: "{\"foo\":\"bar\",\"foo2\":\"bar2\"}",
 			rels:     []*release.Release{releaseWithValues},
 		},
+		{
+			name:     "get values with sensitive values",
+			resp:     releaseWithSensitiveValues,
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--output", "json"},
+			expected: `{"auth":{"user":"admin"},"token":"REDACTED"}`,
+			rels:     []*release.Release{releaseWithSensitiveValues},
+		},
+		{
+			name:     "get all values with sensitive values",
+			resp:     releaseWithSensitiveValues,
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--all", "--output", "json"},
+			expected: `{"auth":{"password":"REDACTED","user":"admin"},"token":"REDACTED"}`,
+			rels:     []*release.Release{releaseWithSensitiveValues},
+		},
 		{
 			name: "get values requires release name arg",
 			err:  true,
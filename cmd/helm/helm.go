@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/grpc/status"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -35,8 +36,11 @@ import (
 	"k8s.io/helm/pkg/helm"
 	helm_env "k8s.io/helm/pkg/helm/environment"
 	"k8s.io/helm/pkg/helm/portforwarder"
+	"k8s.io/helm/pkg/helm/tillerless"
 	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/logging"
 	"k8s.io/helm/pkg/tlsutil"
+	"k8s.io/helm/pkg/tracing"
 )
 
 const (
@@ -139,7 +143,13 @@ __helm_custom_func()
 
 var (
 	tillerTunnel *kube.Tunnel
-	settings     helm_env.EnvSettings
+	// tillerlessClient serves releases in-process when running in tillerless mode.
+	tillerlessClient *tillerless.Client
+	// tracingParent is the trace context of this invocation, see traceParent.
+	tracingParent tracing.SpanContext
+	// tillerRequestID identifies the calls of this invocation in Tiller's logs.
+	tillerRequestID string
+	settings        helm_env.EnvSettings
 )
 
 var globalUsage = `The Kubernetes package manager
@@ -164,6 +174,7 @@ Environment:
 - $HELM_HOST:           Set an alternative Tiller host. The format is host:port
 - $HELM_NO_PLUGINS:     Disable plugins. Set HELM_NO_PLUGINS=1 to disable plugins.
 - $TILLER_NAMESPACE:    Set an alternative Tiller namespace (default "kube-system")
+- $HELM_TILLERLESS:     Run the release engine inside Helm instead of connecting to Tiller (default "false")
 - $KUBECONFIG:          Set an alternative Kubernetes configuration file (default "~/.kube/config")
 - $HELM_TLS_CA_CERT:    Path to TLS CA certificate used to verify the Helm client and Tiller server certificates (default "$HELM_HOME/ca.pem")
 - $HELM_TLS_CERT:       Path to TLS client certificate file for authenticating to Tiller (default "$HELM_HOME/cert.pem")
@@ -171,7 +182,10 @@ Environment:
 - $HELM_TLS_ENABLE:     Enable TLS connection between Helm and Tiller (default "false")
 - $HELM_TLS_VERIFY:     Enable TLS connection between Helm and Tiller and verify Tiller server certificate (default "false")
 - $HELM_TLS_HOSTNAME:   The hostname or IP address used to verify the Tiller server certificate (default "127.0.0.1")
+- $TRACEPARENT:         W3C trace context the operations in Tiller are traced as part of. By default, a new trace is started
 - $HELM_KEY_PASSPHRASE: Set HELM_KEY_PASSPHRASE to the passphrase of your PGP private key. If set, you will not be prompted for the passphrase while signing helm charts
+- $HELM_SECRETS_AGE_KEY_FILE: Path to the age identities that decrypt encrypted values files (default "$HELM_HOME/secrets/age.key")
+- $HELM_SECRETS_KEYRING: Path to the PGP secret keyring that decrypts encrypted values files (default "~/.gnupg/secring.gpg")
 
 `
 
@@ -211,6 +225,7 @@ func newRootCmd(args []string) *cobra.Command {
 
 	cmd.AddCommand(
 		// chart commands
+		newCapabilitiesCmd(out),
 		newCreateCmd(out),
 		newDependencyCmd(out),
 		newFetchCmd(out),
@@ -218,16 +233,19 @@ func newRootCmd(args []string) *cobra.Command {
 		newLintCmd(out),
 		newPackageCmd(out),
 		newRepoCmd(out),
+		newSecretsCmd(out),
 		newSearchCmd(out),
 		newServeCmd(out),
 		newVerifyCmd(out),
 
 		// release commands
+		newAdoptCmd(nil, out),
 		newDeleteCmd(nil, out),
 		newGetCmd(nil, out),
 		newHistoryCmd(nil, out),
 		newInstallCmd(nil, out),
 		newListCmd(nil, out),
+		newReleaseCmd(out),
 		newRollbackCmd(nil, out),
 		newStatusCmd(nil, out),
 		newUpgradeCmd(nil, out),
@@ -283,6 +301,9 @@ func markDeprecated(cmd *cobra.Command, notice string) *cobra.Command {
 }
 
 func setupConnection() error {
+	if settings.Tillerless {
+		return setupTillerless()
+	}
 	if settings.TillerHost == "" {
 		config, client, err := getKubeClient(settings.KubeContext, settings.KubeConfig)
 		if err != nil {
@@ -305,10 +326,80 @@ func setupConnection() error {
 	return nil
 }
 
+// setupTillerless starts the in-process release engine, which stores release
+// records in the namespace of each release using the user's kubeconfig.
+func setupTillerless() error {
+	if tillerlessClient != nil {
+		return nil
+	}
+	flags := genericclioptions.NewConfigFlags(true)
+	flags.Context = &settings.KubeContext
+	flags.KubeConfig = &settings.KubeConfig
+
+	instance, err := os.Hostname()
+	if err != nil {
+		instance = "helm"
+	}
+	var namespaces []string
+	for _, ns := range strings.Split(settings.TillerlessNamespaces, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	log := logging.Discard
+	if settings.Debug {
+		log = logging.New(os.Stdout, logging.TextFormat, logging.DebugLevel)
+	}
+	c, err := tillerless.New(tillerless.Config{
+		Getter:     flags,
+		Storage:    settings.TillerlessStorage,
+		Namespaces: namespaces,
+		Instance:   instance,
+		Log:        log,
+	}, helm.ConnectTimeout(settings.TillerConnectionTimeout), helm.TraceParent(traceParent()), helm.RequestID(requestID()))
+	if err != nil {
+		return err
+	}
+	tillerlessClient = c
+	debug("Running tillerless, storing releases in their namespaces (%s)\n", settings.TillerlessStorage)
+	return nil
+}
+
+// traceParent returns the trace context the calls to Tiller are part of: the
+// one in $TRACEPARENT, or else a new one.
+func traceParent() tracing.SpanContext {
+	if tracingParent.IsValid() {
+		return tracingParent
+	}
+	if v := os.Getenv("TRACEPARENT"); v != "" {
+		sc, err := tracing.ParseTraceparent(v)
+		if err == nil {
+			tracingParent = sc
+			return sc
+		}
+		debug("ignoring TRACEPARENT: %s\n", err)
+	}
+	tracingParent = tracing.NewSpanContext()
+	debug("Tracing as part of trace %s\n", tracingParent.TraceID)
+	return tracingParent
+}
+
+// requestID returns the ID Tiller logs the calls of this invocation with.
+func requestID() string {
+	if tillerRequestID == "" {
+		tillerRequestID = logging.NewRequestID()
+		debug("Request ID %s\n", tillerRequestID)
+	}
+	return tillerRequestID
+}
+
 func teardown() {
 	if tillerTunnel != nil {
 		tillerTunnel.Close()
 	}
+	if tillerlessClient != nil {
+		tillerlessClient.Close()
+	}
 }
 
 func checkArgsLength(argsReceived int, requiredArgs ...string) error {
@@ -368,7 +459,15 @@ func ensureHelmClient(h helm.Interface) helm.Interface {
 }
 
 func newClient() helm.Interface {
-	options := []helm.Option{helm.Host(settings.TillerHost), helm.ConnectTimeout(settings.TillerConnectionTimeout)}
+	if tillerlessClient != nil {
+		return tillerlessClient
+	}
+	options := []helm.Option{
+		helm.Host(settings.TillerHost),
+		helm.ConnectTimeout(settings.TillerConnectionTimeout),
+		helm.TraceParent(traceParent()),
+		helm.RequestID(requestID()),
+	}
 
 	if settings.TLSVerify || settings.TLSEnable {
 		debug("Host=%q, Key=%q, Cert=%q, CA=%q\n", settings.TLSServerName, settings.TLSKeyFile, settings.TLSCertFile, settings.TLSCaCertFile)
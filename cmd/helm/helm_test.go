@@ -49,6 +49,7 @@ func runReleaseCases(t *testing.T, tests []releaseCase, rcmd releaseCmd) {
 			c := &helm.FakeClient{
 				Rels:      tt.rels,
 				Responses: tt.responses,
+				TestRuns:  tt.testRuns,
 			}
 			cmd := rcmd(c, &buf)
 			cmd.ParseFlags(tt.flags)
@@ -77,6 +78,7 @@ type releaseCase struct {
 	// Rels are the available releases at the start of the test.
 	rels      []*release.Release
 	responses map[string]release.TestRun_Status
+	testRuns  []*release.TestRun
 }
 
 // tempHelmHome sets up a Helm Home in a temp dir.
@@ -256,6 +258,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -275,6 +278,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -294,6 +298,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -313,6 +318,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -332,6 +338,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -351,6 +358,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -370,6 +378,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -390,6 +399,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -410,6 +420,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -430,6 +441,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -450,6 +462,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -470,6 +483,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -490,6 +504,7 @@ func TestTLSFlags(t *testing.T) {
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
 				TillerNamespace:         "kube-system",
+				TillerlessStorage:       "secret",
 				Home:                    home,
 				Debug:                   false,
 				KubeContext:             "",
@@ -31,6 +31,7 @@ import (
 	"github.com/Masterminds/sprig"
 	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/helm/pkg/chartutil"
@@ -126,7 +127,9 @@ type installCmd struct {
 	client         helm.Interface
 	values         []string
 	stringValues   []string
+	jsonValues     []string
 	fileValues     []string
+	sensitiveVals  []string
 	nameTemplate   string
 	version        string
 	timeout        int64
@@ -138,6 +141,8 @@ type installCmd struct {
 	devel          bool
 	depUp          bool
 	subNotes       bool
+	adopt          bool
+	adoptOnly      bool
 	description    string
 
 	certFile string
@@ -199,34 +204,15 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	}
 
 	f := cmd.Flags()
-	settings.AddFlagsTLS(f)
-	f.VarP(&inst.valueFiles, "values", "f", "Specify values in a YAML file or a URL(can specify multiple)")
+	inst.addFlags(f)
 	f.StringVarP(&inst.name, "name", "n", "", "The release name. If unspecified, it will autogenerate one for you")
-	f.StringVar(&inst.namespace, "namespace", "", "Namespace to install the release into. Defaults to the current kube config namespace.")
-	f.BoolVar(&inst.dryRun, "dry-run", false, "Simulate an install")
-	f.BoolVar(&inst.disableHooks, "no-hooks", false, "Prevent hooks from running during install")
 	f.BoolVar(&inst.disableCRDHook, "no-crd-hook", false, "Prevent CRD hooks from running, but run other hooks")
 	f.BoolVar(&inst.replace, "replace", false, "Re-use the given name, even if that name is already used. This is unsafe in production")
-	f.StringArrayVar(&inst.values, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
-	f.StringArrayVar(&inst.stringValues, "set-string", []string{}, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
-	f.StringArrayVar(&inst.fileValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
 	f.StringVar(&inst.nameTemplate, "name-template", "", "Specify template used to name the release")
-	f.BoolVar(&inst.verify, "verify", false, "Verify the package before installing it")
-	f.StringVar(&inst.keyring, "keyring", defaultKeyring(), "Location of public keys used for verification")
-	f.StringVar(&inst.version, "version", "", "Specify the exact chart version to install. If this is not specified, the latest version is installed")
-	f.Int64Var(&inst.timeout, "timeout", 300, "Time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
-	f.BoolVar(&inst.wait, "wait", false, "If set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
 	f.BoolVar(&inst.atomic, "atomic", false, "If set, installation process purges chart on fail, also sets --wait flag")
-	f.StringVar(&inst.repoURL, "repo", "", "Chart repository url where to locate the requested chart")
-	f.StringVar(&inst.username, "username", "", "Chart repository username where to locate the requested chart")
-	f.StringVar(&inst.password, "password", "", "Chart repository password where to locate the requested chart")
-	f.StringVar(&inst.certFile, "cert-file", "", "Identify HTTPS client using this SSL certificate file")
-	f.StringVar(&inst.keyFile, "key-file", "", "Identify HTTPS client using this SSL key file")
-	f.StringVar(&inst.caFile, "ca-file", "", "Verify certificates of HTTPS-enabled servers using this CA bundle")
-	f.BoolVar(&inst.devel, "devel", false, "Use development versions, too. Equivalent to version '>0.0.0-0'. If --version is set, this is ignored.")
 	f.BoolVar(&inst.depUp, "dep-up", false, "Run helm dependency update before installing the chart")
 	f.BoolVar(&inst.subNotes, "render-subchart-notes", false, "Render subchart notes along with the parent")
-	f.StringVar(&inst.description, "description", "", "Specify a description for the release")
+	f.BoolVar(&inst.adopt, "adopt", false, "Take over resources of the chart that already exist in the cluster instead of failing")
 	bindOutputFlag(cmd, &inst.output)
 
 	// set defaults from environment
@@ -235,6 +221,34 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	return cmd
 }
 
+// addFlags adds the flags locating a chart and configuring its release,
+// which 'helm adopt' shares with 'helm install'.
+func (i *installCmd) addFlags(f *pflag.FlagSet) {
+	settings.AddFlagsTLS(f)
+	f.VarP(&i.valueFiles, "values", "f", "Specify values in a YAML file or a URL(can specify multiple)")
+	f.StringVar(&i.namespace, "namespace", "", "Namespace to install the release into. Defaults to the current kube config namespace.")
+	f.BoolVar(&i.dryRun, "dry-run", false, "Simulate an install")
+	f.BoolVar(&i.disableHooks, "no-hooks", false, "Prevent hooks from running during install")
+	f.StringArrayVar(&i.values, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&i.stringValues, "set-string", []string{}, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&i.jsonValues, "set-json", []string{}, "Set JSON values on the command line (can specify multiple or separate values with commas: key1={\"a\":1},key2=[1,2])")
+	f.StringArrayVar(&i.sensitiveVals, "set-sensitive", []string{}, "Set STRING values on the command line that are masked when the release is printed, or mark values at paths without '=' as sensitive (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&i.fileValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	f.BoolVar(&i.verify, "verify", false, "Verify the package before installing it")
+	f.StringVar(&i.keyring, "keyring", defaultKeyring(), "Location of public keys used for verification")
+	f.StringVar(&i.version, "version", "", "Specify the exact chart version to install. If this is not specified, the latest version is installed")
+	f.Int64Var(&i.timeout, "timeout", 300, "Time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
+	f.BoolVar(&i.wait, "wait", false, "If set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
+	f.StringVar(&i.repoURL, "repo", "", "Chart repository url where to locate the requested chart")
+	f.StringVar(&i.username, "username", "", "Chart repository username where to locate the requested chart")
+	f.StringVar(&i.password, "password", "", "Chart repository password where to locate the requested chart")
+	f.StringVar(&i.certFile, "cert-file", "", "Identify HTTPS client using this SSL certificate file")
+	f.StringVar(&i.keyFile, "key-file", "", "Identify HTTPS client using this SSL key file")
+	f.StringVar(&i.caFile, "ca-file", "", "Verify certificates of HTTPS-enabled servers using this CA bundle")
+	f.BoolVar(&i.devel, "devel", false, "Use development versions, too. Equivalent to version '>0.0.0-0'. If --version is set, this is ignored.")
+	f.StringVar(&i.description, "description", "", "Specify a description for the release")
+}
+
 func (i *installCmd) run() error {
 	debug("CHART PATH: %s\n", i.chartPath)
 
@@ -242,7 +256,7 @@ func (i *installCmd) run() error {
 		i.namespace = defaultNamespace()
 	}
 
-	rawVals, err := vals(i.valueFiles, i.values, i.stringValues, i.fileValues, i.certFile, i.keyFile, i.caFile)
+	rawVals, sensitive, err := valsWithSensitive(i.valueFiles, i.values, i.stringValues, i.jsonValues, i.fileValues, i.sensitiveVals, i.certFile, i.keyFile, i.caFile)
 	if err != nil {
 		return err
 	}
@@ -266,6 +280,9 @@ func (i *installCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
+	if chartutil.IsLibraryChart(chartRequested) {
+		return chartutil.ErrLibraryChart
+	}
 
 	if req, err := chartutil.LoadRequirements(chartRequested); err == nil {
 		// If checkDependencies returns an error, we have unfulfilled dependencies.
@@ -309,6 +326,9 @@ func (i *installCmd) run() error {
 		helm.InstallDisableHooks(i.disableHooks),
 		helm.InstallDisableCRDHook(i.disableCRDHook),
 		helm.InstallSubNotes(i.subNotes),
+		helm.InstallAdopt(i.adopt),
+		helm.InstallAdoptOnly(i.adoptOnly),
+		helm.InstallSensitiveValues(sensitive),
 		helm.InstallTimeout(i.timeout),
 		helm.InstallWait(i.wait),
 		helm.InstallDescription(i.description))
@@ -388,8 +408,49 @@ func mergeValues(dest map[string]interface{}, src map[string]interface{}) map[st
 }
 
 // vals merges values from files specified via -f/--values and
-// directly via --set or --set-string or --set-file, marshaling them to YAML
-func vals(valueFiles valueFiles, values []string, stringValues []string, fileValues []string, CertFile, KeyFile, CAFile string) ([]byte, error) {
+// directly via --set or --set-string or --set-json or --set-file, marshaling them to YAML
+func vals(valueFiles valueFiles, values []string, stringValues []string, jsonValues []string, fileValues []string, CertFile, KeyFile, CAFile string) ([]byte, error) {
+	base, err := mergeVals(valueFiles, values, stringValues, jsonValues, fileValues, fileReader(CertFile, KeyFile, CAFile))
+	if err != nil {
+		return []byte{}, err
+	}
+	return yaml.Marshal(base)
+}
+
+// valsWithSensitive is like vals, with the values set via --set-sensitive
+// taking precedence. It also returns the paths that --set-sensitive marks as
+// sensitive: the keys of its values, or its comma separated paths if it does
+// not set a value.
+func valsWithSensitive(valueFiles valueFiles, values []string, stringValues []string, jsonValues []string, fileValues []string, sensitiveValues []string, CertFile, KeyFile, CAFile string) ([]byte, []string, error) {
+	base, err := mergeVals(valueFiles, values, stringValues, jsonValues, fileValues, fileReader(CertFile, KeyFile, CAFile))
+	if err != nil {
+		return []byte{}, nil, err
+	}
+
+	// User specified a value via --set-sensitive
+	var paths []string
+	for _, value := range sensitiveValues {
+		if !strings.Contains(value, "=") {
+			paths = append(paths, strings.Split(value, ",")...)
+			continue
+		}
+		set := map[string]interface{}{}
+		if err := strvals.ParseIntoString(value, set); err != nil {
+			return []byte{}, nil, fmt.Errorf("failed parsing --set-sensitive data: %s", err)
+		}
+		paths = append(paths, chartutil.LeafPaths(set)...)
+		if err := strvals.ParseIntoString(value, base); err != nil {
+			return []byte{}, nil, fmt.Errorf("failed parsing --set-sensitive data: %s", err)
+		}
+	}
+
+	b, err := yaml.Marshal(base)
+	return b, paths, err
+}
+
+// mergeVals merges the values given to vals, reading the files given with
+// -f/--values and --set-file with read.
+func mergeVals(valueFiles valueFiles, values []string, stringValues []string, jsonValues []string, fileValues []string, read func(string) ([]byte, error)) (map[string]interface{}, error) {
 	base := map[string]interface{}{}
 
 	// User specified a values files via -f/--values
@@ -401,15 +462,18 @@ func vals(valueFiles valueFiles, values []string, stringValues []string, fileVal
 		if strings.TrimSpace(filePath) == "-" {
 			bytes, err = ioutil.ReadAll(os.Stdin)
 		} else {
-			bytes, err = readFile(filePath, CertFile, KeyFile, CAFile)
+			bytes, err = read(filePath)
 		}
 
 		if err != nil {
-			return []byte{}, err
+			return nil, err
+		}
+		if bytes, err = decryptValues(filePath, bytes); err != nil {
+			return nil, err
 		}
 
 		if err := yaml.Unmarshal(bytes, &currentMap); err != nil {
-			return []byte{}, fmt.Errorf("failed to parse %s: %s", filePath, err)
+			return nil, fmt.Errorf("failed to parse %s: %s", filePath, err)
 		}
 		// Merge with the previous map
 		base = mergeValues(base, currentMap)
@@ -418,29 +482,36 @@ func vals(valueFiles valueFiles, values []string, stringValues []string, fileVal
 	// User specified a value via --set
 	for _, value := range values {
 		if err := strvals.ParseInto(value, base); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set data: %s", err)
+			return nil, fmt.Errorf("failed parsing --set data: %s", err)
 		}
 	}
 
 	// User specified a value via --set-string
 	for _, value := range stringValues {
 		if err := strvals.ParseIntoString(value, base); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set-string data: %s", err)
+			return nil, fmt.Errorf("failed parsing --set-string data: %s", err)
+		}
+	}
+
+	// User specified a value via --set-json
+	for _, value := range jsonValues {
+		if err := strvals.ParseIntoJSON(value, base); err != nil {
+			return nil, fmt.Errorf("failed parsing --set-json data: %s", err)
 		}
 	}
 
 	// User specified a value via --set-file
 	for _, value := range fileValues {
 		reader := func(rs []rune) (interface{}, error) {
-			bytes, err := readFile(string(rs), CertFile, KeyFile, CAFile)
+			bytes, err := read(string(rs))
 			return string(bytes), err
 		}
 		if err := strvals.ParseIntoFile(value, base, reader); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set-file data: %s", err)
+			return nil, fmt.Errorf("failed parsing --set-file data: %s", err)
 		}
 	}
 
-	return yaml.Marshal(base)
+	return base, nil
 }
 
 // printRelease prints info about a release if the Debug is true.
@@ -553,7 +624,14 @@ func defaultNamespace() string {
 	return "default"
 }
 
-//readFile load a file from the local directory or a remote file with a url.
+// readFile load a file from the local directory or a remote file with a url.
+// fileReader returns a func reading files with readFile.
+func fileReader(CertFile, KeyFile, CAFile string) func(string) ([]byte, error) {
+	return func(filePath string) ([]byte, error) {
+		return readFile(filePath, CertFile, KeyFile, CAFile)
+	}
+}
+
 func readFile(filePath, CertFile, KeyFile, CAFile string) ([]byte, error) {
 	u, _ := url.Parse(filePath)
 	p := getter.All(settings)
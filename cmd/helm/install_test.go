@@ -75,6 +75,14 @@ func TestInstall(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "virgil"}),
 			expected: "virgil",
 		},
+		// Install, values from cli via --set-json
+		{
+			name:     "install with json values",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    []string{"--name", "virgil", "--set-json", `resources={"limits":{"cpu":"100m"}}`, "--set", "args[+]=-v"},
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "virgil"}),
+			expected: "virgil",
+		},
 		// Install, values from multiple yaml
 		{
 			name:     "install with values",
@@ -166,6 +174,12 @@ func TestInstall(t *testing.T) {
 			args: []string{"testdata/testcharts/chart-bad-requirements"},
 			err:  true,
 		},
+		// Install, library chart
+		{
+			name: "install library chart",
+			args: []string{"testdata/testcharts/library"},
+			err:  true,
+		},
 		// Install, using a bad release name
 		{
 			name:  "install chart with release name using capitals",
@@ -199,6 +213,14 @@ func TestInstall(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "virgil"}),
 			expected: regexp.QuoteMeta(`{"name":"virgil","info":{"status":{"code":1},"first_deployed":{"seconds":242085845},"last_deployed":{"seconds":242085845},"Description":"Release mock"},"namespace":"default"}`),
 		},
+		// Install, adopting existing resources
+		{
+			name:     "install with adopt",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--name aeneas --adopt", " "),
+			expected: "aeneas",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+		},
 		// Install, using --output yaml
 		{
 			name:     "install using output yaml",
@@ -343,3 +365,120 @@ func TestMergeValues(t *testing.T) {
 		t.Errorf("Expected a map with different keys to merge properly with another map. Expected: %v, got %v", expectedMap, testMap)
 	}
 }
+
+// setValuesTests are the values set on the command line by install, upgrade,
+// template and lint, which all merge them with mergeVals.
+var setValuesTests = []struct {
+	name       string
+	values     []string
+	strValues  []string
+	jsonValues []string
+	expect     string
+	err        bool
+}{
+	{
+		name:   "escaped dots",
+		values: []string{`podAnnotations.prometheus\.io/scrape=true`},
+		expect: "podAnnotations:\n  prometheus.io/scrape: true\n",
+	},
+	{
+		name:   "list append",
+		values: []string{"args[+]=-v", "args[+]=--debug"},
+		expect: "args:\n- -v\n- --debug\n",
+	},
+	{
+		name:   "null",
+		values: []string{"name=null"},
+		expect: "name: null\n",
+	},
+	{
+		name:       "json",
+		values:     []string{"resources.requests.cpu=10m"},
+		jsonValues: []string{`resources={"limits":{"cpu":"100m"}},tolerations[+]={"operator":"Exists"}`},
+		expect:     "resources:\n  limits:\n    cpu: 100m\ntolerations:\n- operator: Exists\n",
+	},
+	{
+		name:       "json after string",
+		strValues:  []string{"replicas=1"},
+		jsonValues: []string{"replicas=2"},
+		expect:     "replicas: 2\n",
+	},
+	{
+		name:       "invalid json",
+		jsonValues: []string{`resources={"limits":}`},
+		err:        true,
+	},
+}
+
+func TestVals(t *testing.T) {
+	for _, tt := range setValuesTests {
+		b, err := vals(nil, tt.values, tt.strValues, tt.jsonValues, nil, "", "", "")
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: expected error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if string(b) != tt.expect {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expect, b)
+		}
+	}
+}
+
+func TestValsWithSensitive(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    []string
+		sensitive []string
+		expect    string
+		paths     []string
+		err       bool
+	}{
+		{
+			name:      "set sensitive values",
+			values:    []string{"db.user=admin,db.port=5432"},
+			sensitive: []string{"db.password=0123,token=abcd"},
+			expect:    "db:\n  password: \"0123\"\n  port: 5432\n  user: admin\ntoken: abcd\n",
+			paths:     []string{"db.password", "token"},
+		},
+		{
+			name:      "mark paths as sensitive",
+			values:    []string{"db.password=hunter22"},
+			sensitive: []string{"db.password,users[0]"},
+			expect:    "db:\n  password: hunter22\n",
+			paths:     []string{"db.password", "users[0]"},
+		},
+		{
+			name:      "escaped keys",
+			sensitive: []string{`annotations.example\.com/token=abcd`},
+			expect:    "annotations:\n  example.com/token: abcd\n",
+			paths:     []string{`annotations.example\.com/token`},
+		},
+		{
+			name:      "invalid sensitive values",
+			sensitive: []string{"a[-1]=b"},
+			err:       true,
+		},
+	}
+	for _, tt := range tests {
+		b, paths, err := valsWithSensitive(nil, tt.values, nil, nil, nil, tt.sensitive, "", "", "")
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: expected error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if string(b) != tt.expect {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expect, b)
+		}
+		if !reflect.DeepEqual(paths, tt.paths) {
+			t.Errorf("%s: expected paths %v, got %v", tt.name, tt.paths, paths)
+		}
+	}
+}
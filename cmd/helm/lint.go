@@ -24,13 +24,17 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/Masterminds/semver"
 	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/lint"
+	"k8s.io/helm/pkg/lint/rules"
 	"k8s.io/helm/pkg/lint/support"
-	"k8s.io/helm/pkg/strvals"
+	"k8s.io/helm/pkg/plugin"
+	"k8s.io/helm/pkg/schema"
+	"k8s.io/helm/pkg/version"
 )
 
 var longLintHelp = `
@@ -40,17 +44,54 @@ the chart is well-formed.
 If the linter encounters things that will cause the chart to fail installation,
 it will emit [ERROR] messages. If it encounters issues that break with convention
 or recommendation, it will emit [WARNING] messages.
+
+With --kube-version, every rendered object is also validated against the
+schema of its kind in that Kubernetes version, and against the schemas of the
+CustomResourceDefinitions in the chart's templates and crds/ directory.
+Invalid fields are [ERROR] messages, kinds without a schema are [WARNING]
+messages. The schemas bundled with Helm are those of Kubernetes %s; use
+--schema-location to validate against the OpenAPI document of another version,
+as served by the API server at /openapi/v2:
+
+	$ kubectl get --raw /openapi/v2 > schemas/v1.16.json
+	$ helm lint --kube-version 1.16 --schema-location schemas mychart
+
+--kube-version also reports objects using apiVersions that are removed in that
+Kubernetes version as [ERROR] messages, and those that are deprecated as
+[WARNING] messages.
+
+Every message names the rule that produced it. The severity of rules can be
+changed, or rules disabled, in a .helmlint.yaml file in the chart:
+
+	rules:
+	  chart-icon: "off"
+	  template-api-deprecated: error
+
+Rules are suppressed in a single file with a comment naming them, like
+'# helm-lint: disable=chart-icon' in Chart.yaml, or
+'{{/* helm-lint: disable=template-extension */}}' in a template.
+
+Plugins declaring 'lintRules' in their plugin.yaml add their rules to every
+run, see 'helm help plugin'.
+
+A machine readable report of the results can be written to stdout with
+'--output', either as JSON or as SARIF for code scanning tools.
 `
 
 type lintCmd struct {
-	valueFiles valueFiles
-	values     []string
-	sValues    []string
-	fValues    []string
-	namespace  string
-	strict     bool
-	paths      []string
-	out        io.Writer
+	valueFiles     valueFiles
+	values         []string
+	sValues        []string
+	jValues        []string
+	fValues        []string
+	namespace      string
+	strict         bool
+	capabilities   string
+	kubeVersion    string
+	schemaLocation string
+	output         string
+	paths          []string
+	out            io.Writer
 }
 
 func newLintCmd(out io.Writer) *cobra.Command {
@@ -61,11 +102,16 @@ func newLintCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "lint [flags] PATH",
 		Short: "Examines a chart for possible issues",
-		Long:  longLintHelp,
+		Long:  fmt.Sprintf(longLintHelp, schema.BundledKubeVersion),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				l.paths = args
 			}
+			switch l.output {
+			case "", lint.ReportJSON, lint.ReportSARIF:
+			default:
+				return fmt.Errorf("unknown output format %q, allowed values: %s, %s", l.output, lint.ReportJSON, lint.ReportSARIF)
+			}
 			return l.run()
 		},
 	}
@@ -73,9 +119,14 @@ func newLintCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().VarP(&l.valueFiles, "values", "f", "Specify values in a YAML file (can specify multiple)")
 	cmd.Flags().StringArrayVar(&l.values, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	cmd.Flags().StringArrayVar(&l.sValues, "set-string", []string{}, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	cmd.Flags().StringArrayVar(&l.jValues, "set-json", []string{}, "Set JSON values on the command line (can specify multiple or separate values with commas: key1={\"a\":1},key2=[1,2])")
 	cmd.Flags().StringArrayVar(&l.fValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
 	cmd.Flags().StringVar(&l.namespace, "namespace", "default", "Namespace to put the release into")
 	cmd.Flags().BoolVar(&l.strict, "strict", false, "Fail on lint warnings")
+	cmd.Flags().StringVar(&l.capabilities, "capabilities", "", "Render templates with the cluster capabilities written by 'helm capabilities dump' to this file")
+	cmd.Flags().StringVar(&l.kubeVersion, "kube-version", "", "Validate rendered objects against the schemas and supported apiVersions of this Kubernetes version, and use it as Capabilities.KubeVersion.Major/Minor")
+	cmd.Flags().StringVar(&l.schemaLocation, "schema-location", "", "Validate rendered objects against this OpenAPI document, or the document named v<major>.<minor>.json in this directory, instead of the bundled schemas")
+	cmd.Flags().StringVarP(&l.output, "output", "o", "", fmt.Sprintf("Write a report of the results in the specified format instead of the regular output. Allowed values: %s, %s", lint.ReportJSON, lint.ReportSARIF))
 
 	return cmd
 }
@@ -94,15 +145,66 @@ func (l *lintCmd) run() error {
 		return err
 	}
 
+	var caps *chartutil.Capabilities
+	if l.capabilities != "" {
+		if caps, err = chartutil.ReadCapabilitiesFile(l.capabilities, version.GetVersionProto()); err != nil {
+			return err
+		}
+	}
+	if l.kubeVersion != "" {
+		if caps, err = withKubeVersion(caps, l.kubeVersion); err != nil {
+			return err
+		}
+	}
+
+	var schemas *schema.Set
+	if l.kubeVersion != "" || l.schemaLocation != "" {
+		if schemas, err = schema.Load(l.schemaLocation, l.kubeVersion); err != nil {
+			return err
+		}
+	}
+
+	plugins, err := lintPlugins()
+	if err != nil {
+		return err
+	}
+
+	opts := lint.Options{
+		TemplateOptions: rules.TemplateOptions{
+			Namespace:    l.namespace,
+			Strict:       l.strict,
+			Capabilities: caps,
+			Schemas:      schemas,
+			KubeVersion:  l.kubeVersion,
+		},
+		Plugins:     plugins,
+		Settings:    settings,
+		ValuesFiles: l.valueFiles,
+	}
+
+	report := lint.NewReport()
 	var total int
 	var failures int
 	for _, path := range l.paths {
-		linter, err := lintChart(path, rvals, l.namespace, l.strict)
+		linter, err := lintChart(path, rvals, opts)
+		if l.output != "" {
+			report.Add(path, linter, err)
+		}
 		if err != nil {
 			failures = failures + 1
-			fmt.Println("==> Skipping", path)
-			fmt.Println(err)
-			fmt.Println("")
+			if l.output == "" {
+				fmt.Println("==> Skipping", path)
+				fmt.Println(err)
+				fmt.Println("")
+			}
+			continue
+		}
+
+		total = total + 1
+		if linter.HighestSeverity >= lowestTolerance {
+			failures = failures + 1
+		}
+		if l.output != "" {
 			continue
 		}
 
@@ -112,14 +214,15 @@ func (l *lintCmd) run() error {
 		}
 
 		for _, msg := range linter.Messages {
-			fmt.Println(msg)
+			fmt.Println(formatLintMessage(msg))
 		}
+		fmt.Println("")
+	}
 
-		total = total + 1
-		if linter.HighestSeverity >= lowestTolerance {
-			failures = failures + 1
+	if l.output != "" {
+		if err := report.Write(l.out, l.output); err != nil {
+			return err
 		}
-		fmt.Println("")
 	}
 
 	msg := fmt.Sprintf("%d chart(s) linted", total)
@@ -127,12 +230,62 @@ func (l *lintCmd) run() error {
 		return fmt.Errorf("%s, %d chart(s) failed", msg, failures)
 	}
 
-	fmt.Fprintf(l.out, "%s, no failures\n", msg)
+	if l.output == "" {
+		fmt.Fprintf(l.out, "%s, no failures\n", msg)
+	}
 
 	return nil
 }
 
-func lintChart(path string, vals []byte, namespace string, strict bool) (support.Linter, error) {
+// formatLintMessage appends the ID of the rule producing a message, which is
+// what .helmlint.yaml files and suppression comments refer to.
+func formatLintMessage(msg support.Message) string {
+	if id := msg.RuleID(); id != "" {
+		return fmt.Sprintf("%s (%s)", msg, id)
+	}
+	return msg.Error()
+}
+
+// lintPlugins returns the installed plugins supplying lint rules.
+func lintPlugins() ([]*plugin.Plugin, error) {
+	found, err := findPlugins(settings.PluginDirs())
+	if err != nil {
+		return nil, err
+	}
+	var plugins []*plugin.Plugin
+	for _, p := range found {
+		if p.Metadata.LintRules != nil {
+			plugins = append(plugins, p)
+		}
+	}
+	return plugins, nil
+}
+
+// withKubeVersion returns a copy of caps, or of the default capabilities if
+// caps is nil, with the given Kubernetes version.
+func withKubeVersion(caps *chartutil.Capabilities, kubeVersion string) (*chartutil.Capabilities, error) {
+	kv, err := semver.NewVersion(kubeVersion)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse a kubernetes version: %v", err)
+	}
+
+	c := chartutil.Capabilities{
+		APIVersions:   chartutil.DefaultVersionSet,
+		KubeVersion:   chartutil.DefaultKubeVersion,
+		TillerVersion: version.GetVersionProto(),
+	}
+	if caps != nil {
+		c = *caps
+	}
+	info := *c.KubeVersion
+	info.Major = fmt.Sprint(kv.Major())
+	info.Minor = fmt.Sprint(kv.Minor())
+	info.GitVersion = fmt.Sprintf("v%d.%d.0", kv.Major(), kv.Minor())
+	c.KubeVersion = &info
+	return &c, nil
+}
+
+func lintChart(path string, vals []byte, opts lint.Options) (support.Linter, error) {
 	var chartPath string
 	linter := support.Linter{}
 
@@ -171,57 +324,18 @@ func lintChart(path string, vals []byte, namespace string, strict bool) (support
 		return linter, fmt.Errorf("unable to check Chart.yaml file in chart: %s", err.Error())
 	}
 
-	return lint.All(chartPath, vals, namespace, strict), nil
+	return lint.AllWithOptions(chartPath, vals, opts), nil
 }
 
 // vals merges values from files specified via -f/--values and
-// directly via --set or --set-string or --set-file, marshaling them to YAML
+// directly via --set or --set-string or --set-json or --set-file, marshaling them to YAML
 //
-// This func is implemented intentionally and separately from the `vals` func for the `install` and `upgrade` commands.
-// Compared to the alternative func, this func lacks the parameters for tls opts - ca key, cert, and ca cert.
+// Unlike the `vals` func for the `install` and `upgrade` commands, this func only reads local files.
 // That's because this command, `lint`, is explicitly forbidden from making server connections.
 func (l *lintCmd) vals() ([]byte, error) {
-	base := map[string]interface{}{}
-
-	// User specified a values files via -f/--values
-	for _, filePath := range l.valueFiles {
-		currentMap := map[string]interface{}{}
-		bytes, err := ioutil.ReadFile(filePath)
-		if err != nil {
-			return []byte{}, err
-		}
-
-		if err := yaml.Unmarshal(bytes, &currentMap); err != nil {
-			return []byte{}, fmt.Errorf("failed to parse %s: %s", filePath, err)
-		}
-		// Merge with the previous map
-		base = mergeValues(base, currentMap)
-	}
-
-	// User specified a value via --set
-	for _, value := range l.values {
-		if err := strvals.ParseInto(value, base); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set data: %s", err)
-		}
-	}
-
-	// User specified a value via --set-string
-	for _, value := range l.sValues {
-		if err := strvals.ParseIntoString(value, base); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set-string data: %s", err)
-		}
-	}
-
-	// User specified a value via --set-file
-	for _, value := range l.fValues {
-		reader := func(rs []rune) (interface{}, error) {
-			bytes, err := ioutil.ReadFile(string(rs))
-			return string(bytes), err
-		}
-		if err := strvals.ParseIntoFile(value, base, reader); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set-file data: %s", err)
-		}
+	base, err := mergeVals(l.valueFiles, l.values, l.sValues, l.jValues, l.fValues, ioutil.ReadFile)
+	if err != nil {
+		return []byte{}, err
 	}
-
 	return yaml.Marshal(base)
 }
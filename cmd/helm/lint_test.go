@@ -18,8 +18,15 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"k8s.io/helm/pkg/lint"
+	"k8s.io/helm/pkg/lint/rules"
 )
 
 func TestLintChart(t *testing.T) {
@@ -57,12 +64,11 @@ func TestLintChart(t *testing.T) {
 	}
 
 	values := []byte{}
-	namespace := "testNamespace"
-	strict := false
+	opts := lint.Options{TemplateOptions: rules.TemplateOptions{Namespace: "testNamespace"}}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := lintChart(tt.chartPath, values, namespace, strict)
+			_, err := lintChart(tt.chartPath, values, opts)
 			switch {
 			case err != nil && !tt.err:
 				t.Errorf("%s", err)
@@ -107,3 +113,128 @@ func TestLinRunForNonExistentChart(t *testing.T) {
 		}
 	})
 }
+
+func TestLintSchemas(t *testing.T) {
+	chartPath := "testdata/testcharts/chart-with-schema-errors"
+
+	tests := []struct {
+		name           string
+		kubeVersion    string
+		schemaLocation string
+		err            string
+	}{
+		{
+			name: "without validation",
+		},
+		{
+			name:        "bundled schemas",
+			kubeVersion: "1.18",
+			err:         "1 chart(s) linted, 1 chart(s) failed",
+		},
+		{
+			name:           "missing schemas",
+			kubeVersion:    "1.18",
+			schemaLocation: "testdata/testcharts",
+			err:            "no schemas for Kubernetes v1.18",
+		},
+		{
+			name:        "invalid kube version",
+			kubeVersion: "latest",
+			err:         "could not parse a kubernetes version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &lintCmd{
+				paths:          []string{chartPath},
+				kubeVersion:    tt.kubeVersion,
+				schemaLocation: tt.schemaLocation,
+				out:            bytes.NewBufferString(""),
+			}
+			err := l.run()
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("expected no error, got %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestLintReport(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		expect string
+	}{
+		{
+			name:   "json",
+			output: lint.ReportJSON,
+			expect: `"rule": "chart-icon"`,
+		},
+		{
+			name:   "sarif",
+			output: lint.ReportSARIF,
+			expect: `"ruleId": "chart-icon"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := &lintCmd{
+				paths:  []string{"testdata/testcharts/decompressedchart/", "non-existent-chart.tgz"},
+				output: tt.output,
+				out:    &buf,
+			}
+			if err := l.run(); err == nil || err.Error() != "1 chart(s) linted, 2 chart(s) failed" {
+				t.Errorf("expected both charts to fail, got %v", err)
+			}
+			var report map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+				t.Fatalf("expected a JSON report, got %q: %s", buf.String(), err)
+			}
+			if !strings.Contains(buf.String(), tt.expect) {
+				t.Errorf("expected the report to contain %s, got\n%s", tt.expect, buf.String())
+			}
+		})
+	}
+}
+
+func TestLintVals(t *testing.T) {
+	for _, tt := range setValuesTests {
+		l := &lintCmd{values: tt.values, sValues: tt.strValues, jValues: tt.jsonValues}
+		b, err := l.vals()
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: expected error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if string(b) != tt.expect {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expect, b)
+		}
+	}
+}
+
+func TestLintValsLocalOnly(t *testing.T) {
+	fetched := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched = true
+		fmt.Fprintln(w, "name: remote")
+	}))
+	defer srv.Close()
+
+	l := &lintCmd{valueFiles: []string{srv.URL + "/values.yaml"}}
+	if _, err := l.vals(); err == nil {
+		t.Error("expected an error for a remote values file")
+	}
+	if fetched {
+		t.Error("expected lint not to fetch remote values files")
+	}
+}
@@ -113,6 +113,10 @@ func loadPlugins(baseCmd *cobra.Command, out io.Writer) {
 				if _, err := processParent(cmd, args); err != nil {
 					return err
 				}
+				// A tillerless plugin runs its own release engine through $HELM_BIN.
+				if settings.Tillerless {
+					return nil
+				}
 				return setupConnection()
 			}
 		}
@@ -128,7 +132,7 @@ func loadPlugins(baseCmd *cobra.Command, out io.Writer) {
 func manuallyProcessArgs(args []string) ([]string, []string) {
 	known := []string{}
 	unknown := []string{}
-	kvargs := []string{"--host", "--kube-context", "--home", "--tiller-namespace"}
+	kvargs := []string{"--host", "--kube-context", "--home", "--tiller-namespace", "--tillerless-storage"}
 	knownArg := func(a string) bool {
 		for _, pre := range kvargs {
 			if strings.HasPrefix(a, pre+"=") {
@@ -139,9 +143,9 @@ func manuallyProcessArgs(args []string) ([]string, []string) {
 	}
 	for i := 0; i < len(args); i++ {
 		switch a := args[i]; a {
-		case "--debug":
+		case "--debug", "--tillerless":
 			known = append(known, a)
-		case "--host", "--kube-context", "--home", "--tiller-namespace":
+		case "--host", "--kube-context", "--home", "--tiller-namespace", "--tillerless-storage":
 			known = append(known, a, args[i+1])
 			i++
 		default:
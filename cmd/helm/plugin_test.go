@@ -37,11 +37,14 @@ func TestManuallyProcessArgs(t *testing.T) {
 		"--kube-context", "test1",
 		"--home=/tmp",
 		"--tiller-namespace=hello",
+		"--tillerless",
+		"--tillerless-storage", "configmap",
 		"command",
 	}
 
 	expectKnown := []string{
 		"--debug", "--host", "example.com", "--kube-context", "test1", "--home=/tmp", "--tiller-namespace=hello",
+		"--tillerless", "--tillerless-storage", "configmap",
 	}
 
 	expectUnknown := []string{
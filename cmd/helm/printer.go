@@ -29,6 +29,7 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/timeconv"
 )
 
@@ -63,12 +64,16 @@ func printRelease(out io.Writer, rel *release.Release) error {
 	if rel == nil {
 		return nil
 	}
+	rel, err := releaseutil.Redact(rel)
+	if err != nil {
+		return err
+	}
 
 	cfg, err := chartutil.CoalesceValues(rel.Chart, rel.Config)
 	if err != nil {
 		return err
 	}
-	cfgStr, err := cfg.YAML()
+	cfgStr, err := chartutil.RedactValues(cfg, rel.SensitiveValues).YAML()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,49 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+const releaseDesc = `
+This command consists of multiple subcommands to maintain the releases stored
+by Tiller.
+
+Example usage:
+    $ helm release rewrite-apis [RELEASE]
+    $ helm release export [RELEASE]
+    $ helm release import [BUNDLE]
+    $ helm release unlock [RELEASE]
+`
+
+func newReleaseCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release [FLAGS] rewrite-apis|export|import|unlock [ARGS]",
+		Short: "Maintain stored releases",
+		Long:  releaseDesc,
+	}
+
+	cmd.AddCommand(newReleaseRewriteAPIsCmd(nil, out))
+	cmd.AddCommand(newReleaseExportCmd(nil, out))
+	cmd.AddCommand(newReleaseImportCmd(nil, out))
+	cmd.AddCommand(newReleaseUnlockCmd(nil, out))
+
+	return cmd
+}
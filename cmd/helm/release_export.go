@@ -0,0 +1,111 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+const releaseExportDesc = `
+This command writes the stored revisions of a release to a bundle, which
+'helm release import' stores in another Tiller. Use it to move releases, along
+with their history, to another cluster, or to back them up.
+
+By default, only the latest revision is exported. Use '--all-revisions' to
+export the whole history, so that it can be rolled back to after an import:
+
+	$ helm release export --all-revisions my-release
+	$ helm release import --tiller-namespace other my-release.release.tgz
+
+The bundle is written to RELEASE.release.tgz unless '--output' names another
+file. Use '--output -' to write it to stdout.
+`
+
+type releaseExportCmd struct {
+	release      string
+	allRevisions bool
+	output       string
+	out          io.Writer
+	client       helm.Interface
+}
+
+func newReleaseExportCmd(c helm.Interface, out io.Writer) *cobra.Command {
+	r := &releaseExportCmd{
+		out:    out,
+		client: c,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "export [flags] RELEASE",
+		Short:   "Write the stored revisions of a release to a bundle",
+		Long:    releaseExportDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name"); err != nil {
+				return err
+			}
+			r.release = args[0]
+			r.client = ensureHelmClient(r.client)
+			return r.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.BoolVar(&r.allRevisions, "all-revisions", false, "Export every stored revision instead of the latest one")
+	f.StringVarP(&r.output, "output", "o", "", "The file to write the bundle to, or - for stdout. Defaults to RELEASE.release.tgz")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (r *releaseExportCmd) run() error {
+	rels, err := r.client.ExportRelease(r.release, helm.ExportAllRevisions(r.allRevisions))
+	if err != nil {
+		return prettyError(err)
+	}
+
+	if r.output == "-" {
+		return releaseutil.WriteBundle(r.out, rels)
+	}
+	output := r.output
+	if output == "" {
+		output = r.release + ".release.tgz"
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	if err := releaseutil.WriteBundle(f, rels); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(r.out, "Exported %d revision(s) of release %q to %s\n", len(rels), r.release, output)
+	return nil
+}
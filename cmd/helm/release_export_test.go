@@ -0,0 +1,89 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+func TestReleaseExportCmd(t *testing.T) {
+	tdir, err := ioutil.TempDir("", "helm-export-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	rels := []*release.Release{
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "web", Version: 1, StatusCode: release.Status_SUPERSEDED}),
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "web", Version: 2}),
+	}
+	bundle := filepath.Join(tdir, "web.release.tgz")
+
+	tests := []releaseCase{
+		{
+			name:     "export the latest revision",
+			args:     []string{"web"},
+			flags:    []string{"--output", bundle},
+			rels:     rels,
+			expected: `Exported 1 revision\(s\) of release "web" to .*web.release.tgz\n`,
+		},
+		{
+			name:     "export all revisions",
+			args:     []string{"web"},
+			flags:    []string{"--all-revisions", "--output", bundle},
+			rels:     rels,
+			expected: `Exported 2 revision\(s\) of release "web"`,
+		},
+		{
+			name: "release name is required",
+			err:  true,
+		},
+		{
+			name:  "release must exist",
+			args:  []string{"nope"},
+			flags: []string{"--output", bundle},
+			err:   true,
+		},
+	}
+
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newReleaseExportCmd(c, out)
+	})
+
+	f, err := os.Open(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	exported, err := releaseutil.ReadBundle(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exported) != 2 || exported[0].Version != 1 || exported[1].Version != 2 {
+		t.Errorf("expected both revisions in the bundle, got %v", exported)
+	}
+}
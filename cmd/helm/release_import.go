@@ -0,0 +1,129 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+const releaseImportDesc = `
+This command stores the revisions of releases in a bundle written by
+'helm release export'.
+
+Only the release records are stored, the resources in the cluster are left
+alone. When moving a release to another cluster, roll back to the imported
+revision afterwards to create its resources:
+
+	$ helm release import my-release.release.tgz
+	$ helm rollback my-release 3
+
+Use '--namespace' to move the releases to another namespace, and '--dry-run'
+to check the import without storing anything.
+
+By default, the import fails when a revision in the bundle is already stored.
+'--on-conflict skip' keeps the stored revisions, and '--on-conflict overwrite'
+replaces them.
+`
+
+type releaseImportCmd struct {
+	bundle     string
+	namespace  string
+	dryRun     bool
+	onConflict string
+	out        io.Writer
+	client     helm.Interface
+}
+
+func newReleaseImportCmd(c helm.Interface, out io.Writer) *cobra.Command {
+	r := &releaseImportCmd{
+		out:    out,
+		client: c,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "import [flags] BUNDLE",
+		Short:   "Store the revisions of releases in a bundle",
+		Long:    releaseImportDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "bundle path"); err != nil {
+				return err
+			}
+			r.bundle = args[0]
+			r.client = ensureHelmClient(r.client)
+			return r.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.StringVar(&r.namespace, "namespace", "", "Move the imported releases to this namespace")
+	f.BoolVar(&r.dryRun, "dry-run", false, "Check the import without storing anything")
+	f.StringVar(&r.onConflict, "on-conflict", "fail", "What to do with revisions that are already stored: fail, skip or overwrite")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (r *releaseImportCmd) run() error {
+	conflict, ok := services.ImportReleaseRequest_Conflict_value[strings.ToUpper(r.onConflict)]
+	if !ok {
+		return fmt.Errorf("invalid --on-conflict %q, must be one of: fail, skip, overwrite", r.onConflict)
+	}
+
+	f, err := os.Open(r.bundle)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	rels, err := releaseutil.ReadBundle(f)
+	if err != nil {
+		return fmt.Errorf("%s: %s", r.bundle, err)
+	}
+
+	res, err := r.client.ImportRelease(rels,
+		helm.ImportNamespace(r.namespace),
+		helm.ImportDryRun(r.dryRun),
+		helm.ImportOnConflict(services.ImportReleaseRequest_Conflict(conflict)),
+	)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	for _, s := range res.Skipped {
+		fmt.Fprintf(r.out, "SKIPPED: %s is already stored\n", s)
+	}
+	verb := "Imported"
+	if r.dryRun {
+		verb = "Would import"
+	}
+	for _, rel := range res.Releases {
+		fmt.Fprintf(r.out, "%s %s.v%d to namespace %s (%s)\n", verb, rel.Name, rel.Version, rel.Namespace, rel.GetInfo().GetStatus().GetCode())
+	}
+	return nil
+}
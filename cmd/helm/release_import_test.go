@@ -0,0 +1,102 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+func TestReleaseImportCmd(t *testing.T) {
+	tdir, err := ioutil.TempDir("", "helm-import-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	var buf bytes.Buffer
+	if err := releaseutil.WriteBundle(&buf, []*release.Release{
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "web", Version: 1, StatusCode: release.Status_SUPERSEDED}),
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "web", Version: 2}),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	bundle := filepath.Join(tdir, "web.release.tgz")
+	if err := ioutil.WriteFile(bundle, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stored := func() []*release.Release {
+		return []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "web", Version: 1})}
+	}
+
+	tests := []releaseCase{
+		{
+			name:     "import a bundle",
+			args:     []string{bundle},
+			flags:    []string{"--namespace", "restored"},
+			expected: "Imported web.v1 to namespace restored \\(SUPERSEDED\\)\nImported web.v2 to namespace restored \\(DEPLOYED\\)\n",
+		},
+		{
+			name:     "dry run",
+			args:     []string{bundle},
+			flags:    []string{"--dry-run"},
+			expected: "Would import web.v1 to namespace default",
+		},
+		{
+			name: "fail on conflicts",
+			args: []string{bundle},
+			rels: stored(),
+			err:  true,
+		},
+		{
+			name:     "skip conflicts",
+			args:     []string{bundle},
+			flags:    []string{"--on-conflict", "skip"},
+			rels:     stored(),
+			expected: "SKIPPED: web.v1 is already stored\nImported web.v2 to namespace default \\(DEPLOYED\\)\n",
+		},
+		{
+			name:  "invalid conflict policy",
+			args:  []string{bundle},
+			flags: []string{"--on-conflict", "merge"},
+			err:   true,
+		},
+		{
+			name: "bundle is required",
+			err:  true,
+		},
+		{
+			name: "not a bundle",
+			args: []string{"testdata/testcharts/compressedchart-0.1.0.tgz"},
+			err:  true,
+		},
+	}
+
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newReleaseImportCmd(c, out)
+	})
+}
@@ -0,0 +1,126 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+)
+
+const releaseRewriteAPIsDesc = `
+This command moves the objects of the deployed revision of a release from
+deprecated apiVersions to the ones replacing them, e.g. Deployments from
+extensions/v1beta1 to apps/v1.
+
+Tiller builds the objects of the deployed revision on every upgrade. Once the
+cluster stops serving their apiVersions, upgrades fail. After the stored
+release is rewritten, the release can be upgraded again.
+
+Only the stored release changes, the objects in the cluster are left alone.
+Objects whose replacement apiVersion has a different schema, like
+CustomResourceDefinitions, are listed but not rewritten; update the chart
+instead.
+
+By default, the apiVersions deprecated by the Kubernetes version of the cluster
+are rewritten. Use '--dry-run' to print the changes as a diff without storing
+them:
+
+	$ helm release rewrite-apis --dry-run my-release
+`
+
+type releaseRewriteAPIsCmd struct {
+	release     string
+	dryRun      bool
+	kubeVersion string
+	out         io.Writer
+	client      helm.Interface
+}
+
+func newReleaseRewriteAPIsCmd(c helm.Interface, out io.Writer) *cobra.Command {
+	r := &releaseRewriteAPIsCmd{
+		out:    out,
+		client: c,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "rewrite-apis [flags] RELEASE",
+		Short:   "Move a stored release from deprecated apiVersions to supported ones",
+		Long:    releaseRewriteAPIsDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name"); err != nil {
+				return err
+			}
+			r.release = args[0]
+			r.client = ensureHelmClient(r.client)
+			return r.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.BoolVar(&r.dryRun, "dry-run", false, "Print the changes as a diff without storing them")
+	f.StringVar(&r.kubeVersion, "kube-version", "", "Rewrite the apiVersions deprecated in this Kubernetes version instead of the version of the cluster")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (r *releaseRewriteAPIsCmd) run() error {
+	res, err := r.client.RewriteReleaseAPIs(r.release,
+		helm.RewriteAPIsDryRun(r.dryRun),
+		helm.RewriteAPIsKubeVersion(r.kubeVersion),
+	)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	rel := res.GetRelease()
+	for _, msg := range res.Rewritten {
+		fmt.Fprintf(r.out, "REWRITTEN: %s\n", msg)
+	}
+	for _, msg := range res.Remaining {
+		fmt.Fprintf(r.out, "WARNING: %s\n", msg)
+	}
+
+	switch {
+	case len(res.Rewritten) == 0:
+		fmt.Fprintf(r.out, "Release %q (revision %d) uses no apiVersions to rewrite\n", r.release, rel.GetVersion())
+	case r.dryRun:
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(res.OriginalManifest),
+			B:        difflib.SplitLines(rel.GetManifest()),
+			FromFile: fmt.Sprintf("%s.v%d", r.release, rel.GetVersion()),
+			ToFile:   fmt.Sprintf("%s.v%d (rewritten)", r.release, rel.GetVersion()),
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(r.out, diff)
+	default:
+		fmt.Fprintf(r.out, "Release %q (revision %d) has been rewritten\n", r.release, rel.GetVersion())
+	}
+	return nil
+}
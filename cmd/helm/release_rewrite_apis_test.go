@@ -0,0 +1,86 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+const deprecatedManifest = `apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+`
+
+func TestReleaseRewriteAPIsCmd(t *testing.T) {
+	mk := func() []*release.Release {
+		rel := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "web", Version: 2})
+		rel.Manifest = deprecatedManifest
+		return []*release.Release{rel}
+	}
+
+	tests := []releaseCase{
+		{
+			name:     "rewrite a release",
+			args:     []string{"web"},
+			flags:    []string{"--kube-version", "v1.16.0"},
+			rels:     mk(),
+			expected: `REWRITTEN: Deployment "web" uses extensions/v1beta1, which is removed in Kubernetes v1.16; use apps/v1 instead\nRelease "web" \(revision 2\) has been rewritten\n`,
+		},
+		{
+			name:     "print the changes of a dry run",
+			args:     []string{"web"},
+			flags:    []string{"--dry-run", "--kube-version", "v1.16.0"},
+			rels:     mk(),
+			expected: `--- web.v2\n\+\+\+ web.v2 \(rewritten\)\n@@ -1,4 \+1,4 @@\n-apiVersion: extensions/v1beta1\n\+apiVersion: apps/v1\n`,
+		},
+		{
+			name:     "nothing to rewrite before the deprecation",
+			args:     []string{"web"},
+			flags:    []string{"--kube-version", "v1.8.0"},
+			rels:     mk(),
+			expected: `Release "web" \(revision 2\) uses no apiVersions to rewrite\n`,
+		},
+		{
+			name: "release name is required",
+			err:  true,
+		},
+		{
+			name: "release must exist",
+			args: []string{"nope"},
+			err:  true,
+		},
+	}
+
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newReleaseRewriteAPIsCmd(c, out)
+	})
+}
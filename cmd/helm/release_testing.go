@@ -19,11 +19,15 @@ package main
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	reltesting "k8s.io/helm/pkg/releasetesting"
 )
 
 const releaseTestDesc = `
@@ -31,6 +35,15 @@ The test command runs the tests for a release.
 
 The argument this command takes is the name of a deployed release.
 The tests to be run are defined in the chart that was installed.
+
+Tests can be selected with '--filter name=TEST', or excluded with
+'--filter !name=TEST'. The flag can be repeated. A test pod annotated with
+'helm.sh/test-retries: "N"' is run up to N more times before it is reported
+as failed.
+
+A machine readable report of the test results can be produced with '--output'
+in either 'junit' or 'json' format. The report is written to '--report-file'
+if it is set, otherwise it replaces the regular output on stdout.
 `
 
 type releaseTestCmd struct {
@@ -42,6 +55,9 @@ type releaseTestCmd struct {
 	parallel    bool
 	maxParallel uint32
 	logs        bool
+	output      string
+	reportFile  string
+	filters     []string
 }
 
 func newReleaseTestCmd(c helm.Interface, out io.Writer) *cobra.Command {
@@ -60,6 +76,12 @@ func newReleaseTestCmd(c helm.Interface, out io.Writer) *cobra.Command {
 				return err
 			}
 
+			switch rlsTest.output {
+			case "", reltesting.ReportJUnit, reltesting.ReportJSON:
+			default:
+				return fmt.Errorf("unknown output format %q, allowed values: %s, %s", rlsTest.output, reltesting.ReportJUnit, reltesting.ReportJSON)
+			}
+
 			rlsTest.name = args[0]
 			rlsTest.client = ensureHelmClient(rlsTest.client)
 			return rlsTest.run()
@@ -73,6 +95,9 @@ func newReleaseTestCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&rlsTest.parallel, "parallel", false, "Run test pods in parallel")
 	f.Uint32Var(&rlsTest.maxParallel, "max", 20, "Maximum number of test pods to run in parallel")
 	f.BoolVar(&rlsTest.logs, "logs", false, "Dump the logs from test pods (this runs after all tests are complete, but before any cleanup")
+	f.StringArrayVar(&rlsTest.filters, "filter", []string{}, "Select tests by attribute using attribute=value, or exclude them with !attribute=value (currently only \"name\" is supported)")
+	f.StringVarP(&rlsTest.output, "output", "o", "", fmt.Sprintf("Write a test report in the specified format. Allowed values: %s, %s", reltesting.ReportJUnit, reltesting.ReportJSON))
+	f.StringVar(&rlsTest.reportFile, "report-file", "", "Write the test report to this file instead of stdout")
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -88,30 +113,80 @@ func (t *releaseTestCmd) run() (err error) {
 		helm.ReleaseTestParallel(t.parallel),
 		helm.ReleaseTestMaxParallel(t.maxParallel),
 		helm.ReleaseTestLogs(t.logs),
+		helm.ReleaseTestResults(t.output != ""),
+		helm.ReleaseTestFilters(t.filters),
 	)
 	testErr := &testErr{}
+	results := []*release.TestRun{}
+
+	// when the report goes to stdout, it replaces the streamed messages
+	out := t.out
+	if t.output != "" && t.reportFile == "" {
+		out = ioutil.Discard
+	}
+
+	handle := func(res *services.TestReleaseResponse) {
+		if res.Result != nil {
+			results = append(results, res.Result)
+			return
+		}
+
+		if res.Status == release.TestRun_FAILURE {
+			testErr.failed++
+		}
+
+		fmt.Fprintf(out, res.Msg+"\n")
+	}
 
 	for {
 		select {
 		case err := <-errc:
-			if prettyError(err) == nil && testErr.failed > 0 {
+			// the response channel is closed before the error channel, so any
+			// messages still buffered belong to this run. It is nil if the
+			// client could not connect.
+			if c != nil {
+				for res := range c {
+					handle(res)
+				}
+			}
+			// write the report of the tests that ran even if the suite failed
+			if t.output != "" && (err == nil || len(results) > 0) {
+				if rerr := t.writeReport(results); rerr != nil && err == nil {
+					return rerr
+				}
+			}
+			if err = prettyError(err); err != nil {
+				return err
+			}
+			if testErr.failed > 0 {
 				return testErr.Error()
 			}
-			return prettyError(err)
+			return nil
 		case res, ok := <-c:
 			if !ok {
 				break
 			}
+			handle(res)
+		}
+	}
 
-			if res.Status == release.TestRun_FAILURE {
-				testErr.failed++
-			}
-
-			fmt.Fprintf(t.out, res.Msg+"\n")
+}
 
-		}
+func (t *releaseTestCmd) writeReport(results []*release.TestRun) error {
+	report := reltesting.NewReport(t.name, results)
+	if t.reportFile == "" {
+		return report.Write(t.out, t.output)
 	}
 
+	f, err := os.Create(t.reportFile)
+	if err != nil {
+		return err
+	}
+	if err := report.Write(f, t.output); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
 }
 
 type testErr struct {
@@ -17,12 +17,17 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"io"
+	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
 )
 
 func TestReleaseTesting(t *testing.T) {
@@ -75,9 +80,71 @@ func TestReleaseTesting(t *testing.T) {
 				"PASSED: feel free to party again":            release.TestRun_SUCCESS},
 			err: true,
 		},
+		{
+			name:      "json report",
+			args:      []string{"example-report"},
+			flags:     []string{"--output", "json"},
+			responses: map[string]release.TestRun_Status{"PASSED: green lights everywhere": release.TestRun_SUCCESS},
+			testRuns: []*release.TestRun{
+				{Name: "green-lights", Status: release.TestRun_SUCCESS, Logs: "all good"},
+			},
+			expected: `(?s)^\{\n  "release": "example-report",.*"name": "green-lights",\n      "status": "SUCCESS",.*"logs": "all good"`,
+		},
+		{
+			name:      "junit report",
+			args:      []string{"example-report"},
+			flags:     []string{"--output", "junit"},
+			responses: map[string]release.TestRun_Status{"FAILED: red lights everywhere": release.TestRun_FAILURE},
+			testRuns: []*release.TestRun{
+				{Name: "red-lights", Status: release.TestRun_FAILURE, Info: "pod failed"},
+			},
+			expected: `<testsuite name="example-report" tests="1" failures="1" errors="0".*\n.*<testcase name="red-lights" classname="example-report" time="0.000">\n.*<failure message="pod failed" type="FAILURE">pod failed</failure>`,
+			err:      true,
+		},
+		{
+			name:      "filtered tests",
+			args:      []string{"example-release"},
+			flags:     []string{"--filter", "name=green-lights", "--filter", "!name=red-lights"},
+			responses: map[string]release.TestRun_Status{"PASSED: green-lights": release.TestRun_SUCCESS},
+			expected:  "PASSED: green-lights",
+		},
+		{
+			name:  "unknown report format",
+			args:  []string{"example-report"},
+			flags: []string{"--output", "tap"},
+			err:   true,
+		},
 	}
 
 	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
 		return newReleaseTestCmd(c, out)
 	})
 }
+
+// unreachableClient fails to connect to Tiller when running tests.
+type unreachableClient struct {
+	*helm.FakeClient
+}
+
+func (c unreachableClient) RunReleaseTest(rlsName string, opts ...helm.ReleaseTestOption) (<-chan *services.TestReleaseResponse, <-chan error) {
+	errc := make(chan error, 1)
+	errc <- errors.New("could not find tiller")
+	return nil, errc
+}
+
+func TestReleaseTestingConnectError(t *testing.T) {
+	done := make(chan error)
+	go func() {
+		cmd := newReleaseTestCmd(unreachableClient{&helm.FakeClient{}}, ioutil.Discard)
+		done <- cmd.RunE(cmd, []string{"example-release"})
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "could not find tiller") {
+			t.Errorf("expected the connect error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("helm test did not return when Tiller could not be reached")
+	}
+}
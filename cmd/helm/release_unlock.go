@@ -0,0 +1,91 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+)
+
+const releaseUnlockDesc = `
+This command marks the pending revision of a release as failed.
+
+A revision stays PENDING_INSTALL, PENDING_UPGRADE or PENDING_ROLLBACK while
+Tiller works on it. If Tiller dies in the middle of the operation, the revision
+stays pending, and the release cannot be upgraded or rolled back. Tiller marks
+such revisions as failed on its own once their operation timed out; use this
+command to do so right away.
+
+The resources of the release are left alone. Afterwards, upgrade the release
+again or roll it back to a previous revision:
+
+	$ helm release unlock my-release
+	$ helm rollback my-release 2
+
+Make sure no Tiller is still working on the release, or the outcome of its
+operation will be overwritten.
+`
+
+type releaseUnlockCmd struct {
+	release string
+	out     io.Writer
+	client  helm.Interface
+}
+
+func newReleaseUnlockCmd(c helm.Interface, out io.Writer) *cobra.Command {
+	r := &releaseUnlockCmd{
+		out:    out,
+		client: c,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "unlock [flags] RELEASE",
+		Short:   "Mark the pending revision of a release as failed",
+		Long:    releaseUnlockDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name"); err != nil {
+				return err
+			}
+			r.release = args[0]
+			r.client = ensureHelmClient(r.client)
+			return r.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (r *releaseUnlockCmd) run() error {
+	res, err := r.client.UnlockRelease(r.release)
+	if err != nil {
+		return prettyError(err)
+	}
+	rel := res.GetRelease()
+	fmt.Fprintf(r.out, "Release %q (revision %d) has been marked %s: %s\n", rel.GetName(), rel.GetVersion(), rel.GetInfo().GetStatus().GetCode(), rel.GetInfo().GetDescription())
+	return nil
+}
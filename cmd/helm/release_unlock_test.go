@@ -0,0 +1,60 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestReleaseUnlockCmd(t *testing.T) {
+	tests := []releaseCase{
+		{
+			name: "unlock a pending release",
+			args: []string{"web"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "web", Version: 1}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "web", Version: 2, StatusCode: release.Status_PENDING_UPGRADE}),
+			},
+			expected: `Release "web" \(revision 2\) has been marked FAILED: PENDING_UPGRADE interrupted: unlocked on request\n`,
+		},
+		{
+			name: "release is not pending",
+			args: []string{"web"},
+			rels: []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "web", Version: 1})},
+			err:  true,
+		},
+		{
+			name: "release name is required",
+			err:  true,
+		},
+		{
+			name: "release must exist",
+			args: []string{"nope"},
+			err:  true,
+		},
+	}
+
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newReleaseUnlockCmd(c, out)
+	})
+}
@@ -0,0 +1,141 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8s.io/helm/pkg/provenance"
+	"k8s.io/helm/pkg/secrets"
+)
+
+const secretsDesc = `
+This command consists of multiple subcommands to manage values files whose
+values are encrypted with age or PGP keys.
+
+'helm install', 'helm upgrade', 'helm template' and 'helm lint' decrypt the
+encrypted values files given with -f/--values in memory, with the age
+identities of $HELM_SECRETS_AGE_KEY_FILE and the PGP secret keys of
+$HELM_SECRETS_KEYRING.
+
+Example usage:
+    $ helm secrets encrypt --age-recipient age1... --in-place secrets.yaml
+    $ helm secrets edit secrets.yaml
+    $ helm secrets decrypt secrets.yaml
+`
+
+func newSecretsCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secrets [FLAGS] encrypt|decrypt|edit [ARGS]",
+		Short: "Encrypt, decrypt and edit encrypted values files",
+		Long:  secretsDesc,
+	}
+
+	cmd.AddCommand(newSecretsEncryptCmd(out))
+	cmd.AddCommand(newSecretsDecryptCmd(out))
+	cmd.AddCommand(newSecretsEditCmd(out))
+
+	return cmd
+}
+
+// secretsKeys are the flags locating the keys that decrypt values files.
+type secretsKeys struct {
+	ageKeyFile string
+	keyring    string
+}
+
+func (k *secretsKeys) addFlags(f *pflag.FlagSet) {
+	f.StringVar(&k.ageKeyFile, "age-key-file", settings.SecretsAgeKeyFile(), "Path to the age identities that decrypt values files. Overrides $HELM_SECRETS_AGE_KEY_FILE")
+	f.StringVar(&k.keyring, "secret-keyring", settings.SecretsKeyring(), "Path to the PGP secret keyring that decrypts values files. Overrides $HELM_SECRETS_KEYRING")
+}
+
+// identities loads the age identities and the PGP secret keys that exist.
+func (k *secretsKeys) identities() (secrets.Identities, error) {
+	ids := secrets.Identities{Passphrase: passphraseFetcher}
+	if f, err := os.Open(k.ageKeyFile); err == nil {
+		defer f.Close()
+		parsed, err := age.ParseIdentities(f)
+		if err != nil {
+			return ids, fmt.Errorf("failed to read age identities from %s: %s", k.ageKeyFile, err)
+		}
+		for _, id := range parsed {
+			x, ok := id.(*age.X25519Identity)
+			if !ok {
+				return ids, fmt.Errorf("failed to read age identities from %s: unsupported identity type %T", k.ageKeyFile, id)
+			}
+			ids.Age = append(ids.Age, x)
+		}
+	} else if !os.IsNotExist(err) {
+		return ids, err
+	}
+	if _, err := os.Stat(k.keyring); err == nil {
+		signer, err := provenance.NewFromKeyring(k.keyring, "")
+		if err != nil {
+			return ids, fmt.Errorf("failed to read keyring %s: %s", k.keyring, err)
+		}
+		ids.PGP = signer.KeyRing
+	}
+	return ids, nil
+}
+
+// decrypt decrypts the values file read from name.
+func (k *secretsKeys) decrypt(name string, data []byte) ([]byte, error) {
+	ids, err := k.identities()
+	if err != nil {
+		return nil, err
+	}
+	plain, err := secrets.Decrypt(data, ids)
+	if err != nil {
+		return nil, k.decryptError(name, err)
+	}
+	return plain, nil
+}
+
+// decryptError describes why the values file read from name cannot be
+// decrypted.
+func (k *secretsKeys) decryptError(name string, err error) error {
+	if err == secrets.ErrNoIdentity {
+		return fmt.Errorf("failed to decrypt %s: none of the age identities in %s or the PGP secret keys in %s can decrypt it", name, k.ageKeyFile, k.keyring)
+	}
+	return fmt.Errorf("failed to decrypt %s: %s", name, err)
+}
+
+// decryptValues decrypts the values file read from name if it is encrypted,
+// with the keys of the environment.
+func decryptValues(name string, data []byte) ([]byte, error) {
+	if !secrets.IsEncrypted(data) {
+		return data, nil
+	}
+	k := &secretsKeys{ageKeyFile: settings.SecretsAgeKeyFile(), keyring: settings.SecretsKeyring()}
+	return k.decrypt(name, data)
+}
+
+// readSecretsFile reads a values file, or stdin if name is -.
+func readSecretsFile(name string) ([]byte, error) {
+	if strings.TrimSpace(name) == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(name)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+const secretsDecryptDesc = `
+This command decrypts an encrypted values file and writes it to stdout. Use '-'
+as FILE to read the encrypted values file from stdin.
+
+The decrypted values file is never written to disk by Helm; to change the
+values of an encrypted file, use 'helm secrets edit'.
+`
+
+type secretsDecryptCmd struct {
+	file string
+	keys secretsKeys
+	out  io.Writer
+}
+
+func newSecretsDecryptCmd(out io.Writer) *cobra.Command {
+	s := &secretsDecryptCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "decrypt [flags] FILE",
+		Short: "Write the decrypted values of a values file to stdout",
+		Long:  secretsDecryptDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "values file"); err != nil {
+				return err
+			}
+			s.file = args[0]
+			return s.run()
+		},
+	}
+
+	s.keys.addFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (s *secretsDecryptCmd) run() error {
+	data, err := readSecretsFile(s.file)
+	if err != nil {
+		return err
+	}
+	plain, err := s.keys.decrypt(s.file, data)
+	if err != nil {
+		return err
+	}
+	_, err = s.out.Write(plain)
+	return err
+}
@@ -0,0 +1,167 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/secrets"
+)
+
+const secretsEditDesc = `
+This command decrypts an encrypted values file, opens it in $VISUAL or $EDITOR,
+and encrypts the edited values back into the file for the same recipients.
+
+While it is edited, the decrypted values file is kept in a memory-backed
+directory, /dev/shm by default, and removed afterwards. Use '--tmpdir' to choose
+another memory-backed directory on systems without /dev/shm. Directories which
+are not on a tmpfs or ramfs file system are refused unless '--allow-disk-tmpdir'
+is set, as the decrypted values may then be left on the disk.
+`
+
+// defaultSecretsTmpDir is the memory-backed directory of decrypted values files
+// while they are edited.
+const defaultSecretsTmpDir = "/dev/shm"
+
+var errSecretsUnchanged = errors.New("values file is unchanged")
+
+type secretsEditCmd struct {
+	file            string
+	tmpDir          string
+	allowDiskTmpDir bool
+	keys            secretsKeys
+	editor          func(path string) error
+	out             io.Writer
+}
+
+func newSecretsEditCmd(out io.Writer) *cobra.Command {
+	s := &secretsEditCmd{out: out, editor: runEditor}
+
+	cmd := &cobra.Command{
+		Use:   "edit [flags] FILE",
+		Short: "Edit the decrypted values of an encrypted values file",
+		Long:  secretsEditDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "values file"); err != nil {
+				return err
+			}
+			s.file = args[0]
+			return s.run()
+		},
+	}
+
+	f := cmd.Flags()
+	s.keys.addFlags(f)
+	f.StringVar(&s.tmpDir, "tmpdir", defaultSecretsTmpDir, "Memory-backed directory holding the decrypted values file while it is edited")
+	f.BoolVar(&s.allowDiskTmpDir, "allow-disk-tmpdir", false, "Allow a --tmpdir which is not memory-backed")
+
+	return cmd
+}
+
+func (s *secretsEditCmd) run() error {
+	if fi, err := os.Stat(s.tmpDir); err != nil || !fi.IsDir() {
+		return fmt.Errorf("%s is not a directory: the decrypted values file is only written to a memory-backed directory, use --tmpdir to choose one", s.tmpDir)
+	}
+	if !s.allowDiskTmpDir {
+		if err := checkMemoryBacked(s.tmpDir); err != nil {
+			return fmt.Errorf("%s, use --tmpdir to choose a memory-backed directory or --allow-disk-tmpdir to use it anyway", err)
+		}
+	}
+	fi, err := os.Stat(s.file)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(s.file)
+	if err != nil {
+		return err
+	}
+	ids, err := s.keys.identities()
+	if err != nil {
+		return err
+	}
+
+	enc, err := secrets.Edit(data, ids, s.edit)
+	if err == errSecretsUnchanged {
+		fmt.Fprintf(s.out, "%s is unchanged\n", s.file)
+		return nil
+	}
+	if err == secrets.ErrNoIdentity {
+		return s.keys.decryptError(s.file, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to edit %s: %s", s.file, err)
+	}
+	return ioutil.WriteFile(s.file, enc, fi.Mode())
+}
+
+// edit writes the decrypted values file to the temporary directory, runs the
+// editor on it, and returns the edited values file.
+func (s *secretsEditCmd) edit(plain []byte) ([]byte, error) {
+	f, err := ioutil.TempFile(s.tmpDir, "helm-secrets-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(plain); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := s.editor(f.Name()); err != nil {
+		return nil, err
+	}
+	edited, err := ioutil.ReadFile(f.Name())
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(edited, plain) {
+		return nil, errSecretsUnchanged
+	}
+	return edited, nil
+}
+
+// runEditor runs $VISUAL or $EDITOR, or vi, on the file at path.
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %s", editor, err)
+	}
+	return nil
+}
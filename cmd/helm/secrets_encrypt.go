@@ -0,0 +1,165 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/openpgp"
+
+	"k8s.io/helm/pkg/provenance"
+	"k8s.io/helm/pkg/secrets"
+)
+
+const secretsEncryptDesc = `
+This command encrypts the values of a values file for age or PGP recipients,
+and writes the encrypted values file to stdout, or back to the file with
+'--in-place'. Use '-' as FILE to read the values file from stdin.
+
+Each value is encrypted separately, so that the keys of the file stay readable.
+Any one of the recipients can decrypt the file:
+
+	$ helm secrets encrypt --age-recipient age1... --pgp-recipient ops@example.com \
+	    --in-place secrets.yaml
+
+PGP recipients are the names, emails or fingerprints of keys in '--keyring'.
+Without recipients, the file is encrypted for the identities of the age key
+file, see 'helm secrets --help'.
+`
+
+type secretsEncryptCmd struct {
+	file          string
+	ageRecipients []string
+	pgpRecipients []string
+	keyring       string
+	inPlace       bool
+	keys          secretsKeys
+	out           io.Writer
+}
+
+func newSecretsEncryptCmd(out io.Writer) *cobra.Command {
+	s := &secretsEncryptCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "encrypt [flags] FILE",
+		Short: "Encrypt the values of a values file",
+		Long:  secretsEncryptDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "values file"); err != nil {
+				return err
+			}
+			s.file = args[0]
+			return s.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringArrayVar(&s.ageRecipients, "age-recipient", []string{}, "Encrypt for the age public key (can specify multiple)")
+	f.StringArrayVar(&s.pgpRecipients, "pgp-recipient", []string{}, "Encrypt for the PGP key of the keyring with this name, email or fingerprint (can specify multiple)")
+	f.StringVar(&s.keyring, "keyring", defaultKeyring(), "Keyring containing the public keys of the PGP recipients")
+	f.BoolVarP(&s.inPlace, "in-place", "i", false, "Write the encrypted values file back to FILE instead of stdout")
+	f.StringVar(&s.keys.ageKeyFile, "age-key-file", settings.SecretsAgeKeyFile(), "Encrypt for the age identities of this file when no recipient is given. Overrides $HELM_SECRETS_AGE_KEY_FILE")
+
+	return cmd
+}
+
+func (s *secretsEncryptCmd) run() error {
+	if s.inPlace && s.file == "-" {
+		return errors.New("cannot encrypt stdin in place")
+	}
+	data, err := readSecretsFile(s.file)
+	if err != nil {
+		return err
+	}
+	r, err := s.recipients()
+	if err != nil {
+		return err
+	}
+
+	enc, err := secrets.Encrypt(data, r)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt %s: %s", s.file, err)
+	}
+	if !s.inPlace {
+		_, err := s.out.Write(enc)
+		return err
+	}
+	fi, err := os.Stat(s.file)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(s.file, enc, fi.Mode())
+}
+
+func (s *secretsEncryptCmd) recipients() (secrets.Recipients, error) {
+	var r secrets.Recipients
+	for _, a := range s.ageRecipients {
+		ar, err := age.ParseX25519Recipient(a)
+		if err != nil {
+			return r, err
+		}
+		r.Age = append(r.Age, ar)
+	}
+	for _, id := range s.pgpRecipients {
+		signer, err := provenance.NewFromKeyring(s.keyring, id)
+		if err != nil {
+			return r, err
+		}
+		e := signer.Entity
+		if e == nil {
+			e = entityByFingerprint(signer.KeyRing, id)
+		}
+		if e == nil {
+			return r, fmt.Errorf("no PGP key matching %q in %s", id, s.keyring)
+		}
+		r.PGP = append(r.PGP, e)
+	}
+	if len(r.Age) > 0 || len(r.PGP) > 0 {
+		return r, nil
+	}
+
+	ids, err := s.keys.identities()
+	if err != nil {
+		return r, err
+	}
+	if len(ids.Age) == 0 {
+		return r, fmt.Errorf("no recipients given with --age-recipient or --pgp-recipient, and no age identities in %s", s.keys.ageKeyFile)
+	}
+	for _, id := range ids.Age {
+		r.Age = append(r.Age, id.Recipient())
+	}
+	return r, nil
+}
+
+// entityByFingerprint returns the entity of the ring whose primary key has the
+// fingerprint fp, in hex with optional spaces.
+func entityByFingerprint(ring openpgp.EntityList, fp string) *openpgp.Entity {
+	fp = strings.ToUpper(strings.Replace(fp, " ", "", -1))
+	for _, e := range ring {
+		if fmt.Sprintf("%X", e.PrimaryKey.Fingerprint[:]) == fp {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+
+	"k8s.io/helm/pkg/secrets"
+)
+
+const secretValues = "db:\n  password: hunter2\n  port: 5432\n"
+
+// secretsTestFiles writes a plain values file and an age key file to a
+// temporary directory.
+func secretsTestFiles(t *testing.T) (dir, values, keyFile string, id *age.X25519Identity) {
+	dir, err := ioutil.TempDir("", "helm-secrets-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err = age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values = filepath.Join(dir, "secrets.yaml")
+	keyFile = filepath.Join(dir, "age.key")
+	if err := ioutil.WriteFile(values, []byte(secretValues), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, []byte("# test key\n"+id.String()+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return dir, values, keyFile, id
+}
+
+func TestSecretsEncryptDecrypt(t *testing.T) {
+	dir, values, keyFile, id := secretsTestFiles(t)
+	defer os.RemoveAll(dir)
+
+	// to stdout, for an explicit recipient
+	out := &bytes.Buffer{}
+	cmd := newSecretsEncryptCmd(out)
+	cmd.SetArgs([]string{"--age-recipient", id.Recipient().String(), values})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !secrets.IsEncrypted(out.Bytes()) || strings.Contains(out.String(), "hunter2") {
+		t.Errorf("expected encrypted values, got\n%s", out)
+	}
+	if b, _ := ioutil.ReadFile(values); string(b) != secretValues {
+		t.Errorf("expected %s to be unchanged, got\n%s", values, b)
+	}
+
+	// in place, for the identities of the key file
+	cmd = newSecretsEncryptCmd(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--age-key-file", keyFile, "--in-place", values})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	enc, err := ioutil.ReadFile(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !secrets.IsEncrypted(enc) {
+		t.Errorf("expected %s to be encrypted, got\n%s", values, enc)
+	}
+
+	out.Reset()
+	cmd = newSecretsDecryptCmd(out)
+	cmd.SetArgs([]string{"--age-key-file", keyFile, "--secret-keyring", filepath.Join(dir, "none.gpg"), values})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != secretValues {
+		t.Errorf("expected\n%s\ngot\n%s", secretValues, out)
+	}
+
+	other := filepath.Join(dir, "other.key")
+	otherID, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(other, []byte(otherID.String()+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cmd = newSecretsDecryptCmd(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--age-key-file", other, values})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "none of the age identities in "+other) {
+		t.Errorf("expected an error decrypting with another identity, got %v", err)
+	}
+
+	cmd = newSecretsEncryptCmd(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--age-key-file", filepath.Join(dir, "none.key"), values})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "no recipients") {
+		t.Errorf("expected an error without recipients, got %v", err)
+	}
+}
+
+func TestSecretsEdit(t *testing.T) {
+	dir, values, keyFile, id := secretsTestFiles(t)
+	defer os.RemoveAll(dir)
+
+	enc, err := secrets.Encrypt([]byte(secretValues), secrets.Recipients{Age: []*age.X25519Recipient{id.Recipient()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(values, enc, 0600); err != nil {
+		t.Fatal(err)
+	}
+	tmpDir := filepath.Join(dir, "shm")
+	if err := os.Mkdir(tmpDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	edit := func(content string) func(string) error {
+		return func(path string) error {
+			if filepath.Dir(path) != tmpDir {
+				t.Errorf("expected the decrypted file in %s, got %s", tmpDir, path)
+			}
+			if b, _ := ioutil.ReadFile(path); string(b) != secretValues {
+				t.Errorf("expected the decrypted values\n%s\ngot\n%s", secretValues, b)
+			}
+			return ioutil.WriteFile(path, []byte(content), 0600)
+		}
+	}
+	newCmd := func(out *bytes.Buffer, editor func(string) error) *secretsEditCmd {
+		return &secretsEditCmd{
+			file:            values,
+			tmpDir:          tmpDir,
+			allowDiskTmpDir: true,
+			keys:            secretsKeys{ageKeyFile: keyFile},
+			editor:          editor,
+			out:             out,
+		}
+	}
+
+	out := &bytes.Buffer{}
+	if err := newCmd(out, edit(secretValues)).run(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "is unchanged") {
+		t.Errorf("expected the file to be unchanged, got %q", out)
+	}
+	if b, _ := ioutil.ReadFile(values); !bytes.Equal(b, enc) {
+		t.Error("expected the unchanged file not to be rewritten")
+	}
+
+	if err := newCmd(out, edit("db:\n  password: correct-horse\n")).run(); err != nil {
+		t.Fatal(err)
+	}
+	edited, err := ioutil.ReadFile(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(edited), "correct-horse") {
+		t.Errorf("expected the edited file to be encrypted, got\n%s", edited)
+	}
+	plain, err := secrets.Decrypt(edited, secrets.Identities{Age: []*age.X25519Identity{id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plain) != "db:\n  password: correct-horse\n" {
+		t.Errorf("unexpected edited values\n%s", plain)
+	}
+
+	if fis, _ := ioutil.ReadDir(tmpDir); len(fis) != 0 {
+		t.Errorf("expected the decrypted file to be removed, found %s", fis[0].Name())
+	}
+
+	cmd := newCmd(out, edit(secretValues))
+	cmd.tmpDir = filepath.Join(dir, "none")
+	if err := cmd.run(); err == nil || !strings.Contains(err.Error(), "memory-backed") {
+		t.Errorf("expected an error without a temporary directory, got %v", err)
+	}
+
+	if checkMemoryBacked(tmpDir) != nil {
+		cmd = newCmd(out, edit(secretValues))
+		cmd.allowDiskTmpDir = false
+		if err := cmd.run(); err == nil || !strings.Contains(err.Error(), "--allow-disk-tmpdir") {
+			t.Errorf("expected an error with a temporary directory on the disk, got %v", err)
+		}
+	}
+}
+
+func TestValsEncrypted(t *testing.T) {
+	dir, values, keyFile, id := secretsTestFiles(t)
+	defer os.RemoveAll(dir)
+
+	enc, err := secrets.Encrypt([]byte(secretValues), secrets.Recipients{Age: []*age.X25519Recipient{id.Recipient()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(values, enc, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Setenv("HELM_SECRETS_AGE_KEY_FILE", os.Getenv("HELM_SECRETS_AGE_KEY_FILE"))
+	os.Setenv("HELM_SECRETS_AGE_KEY_FILE", keyFile)
+
+	b, err := vals([]string{values}, []string{"db.user=admin"}, nil, nil, nil, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := "db:\n  password: hunter2\n  port: 5432\n  user: admin\n"
+	if string(b) != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, b)
+	}
+
+	l := &lintCmd{valueFiles: []string{values}}
+	if b, err = l.vals(); err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != secretValues {
+		t.Errorf("expected\n%s\ngot\n%s", secretValues, b)
+	}
+
+	os.Setenv("HELM_SECRETS_AGE_KEY_FILE", filepath.Join(dir, "none.key"))
+	if _, err := vals([]string{values}, nil, nil, nil, nil, "", "", ""); err == nil || !strings.Contains(err.Error(), "failed to decrypt "+values) {
+		t.Errorf("expected a decryption error, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+// +build !linux
+
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// checkMemoryBacked returns an error, as whether dir is memory-backed is only
+// known on Linux.
+func checkMemoryBacked(dir string) error {
+	return fmt.Errorf("cannot check that %s is memory-backed on %s", dir, runtime.GOOS)
+}
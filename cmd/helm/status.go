@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/gosuri/uitable"
@@ -135,6 +136,7 @@ func PrintStatus(out io.Writer, res *services.GetReleaseStatusResponse) {
 			fmt.Sprintf("Last Started: %s", timeconv.String(lastRun.StartedAt)),
 			fmt.Sprintf("Last Completed: %s", timeconv.String(lastRun.CompletedAt)),
 			formatTestResults(lastRun.Results))
+		printTestFailureLogs(out, lastRun.Results)
 	}
 
 	if len(res.Info.Status.Notes) > 0 {
@@ -142,6 +144,22 @@ func PrintStatus(out io.Writer, res *services.GetReleaseStatusResponse) {
 	}
 }
 
+// maxStatusLogLines is the number of log lines shown for each failed test
+const maxStatusLogLines = 20
+
+func printTestFailureLogs(out io.Writer, results []*release.TestRun) {
+	for _, r := range results {
+		if r.Status == release.TestRun_SUCCESS || r.Logs == "" {
+			continue
+		}
+		lines := strings.Split(strings.TrimRight(r.Logs, "\n"), "\n")
+		if len(lines) > maxStatusLogLines {
+			lines = lines[len(lines)-maxStatusLogLines:]
+		}
+		fmt.Fprintf(out, "TEST LOGS: %s\n%s\n\n", r.Name, strings.Join(lines, "\n"))
+	}
+}
+
 func formatTestResults(results []*release.TestRun) string {
 	tbl := uitable.New()
 	tbl.MaxColWidth = 50
@@ -125,6 +125,41 @@ func TestStatusCmd(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name: "get status of a deployed release with failed test logs",
+			args: []string{"flummoxed-chickadee"},
+			expected: outputWithStatus(
+				fmt.Sprintf("DEPLOYED\n\nTEST SUITE:\nLast Started: %s\nLast Completed: %s\n\n", dateString, dateString) +
+					"TEST      \tSTATUS (.*)\tINFO(.*)\tSTARTED (.*)\tCOMPLETED (.*)\n" +
+					fmt.Sprintf("test run 1\tSUCCESS (.*)\t(.*)\t%s\t%s\n", dateString, dateString) +
+					fmt.Sprintf("test run 2\tFAILURE (.*)\t(.*)\t%s\t%s\n", dateString, dateString) +
+					"TEST LOGS: test run 2\nconnection refused\n\n$"),
+			rels: []*release.Release{
+				releaseMockWithStatus(&release.Status{
+					Code: release.Status_DEPLOYED,
+					LastTestSuiteRun: &release.TestSuite{
+						StartedAt:   &date,
+						CompletedAt: &date,
+						Results: []*release.TestRun{
+							{
+								Name:        "test run 1",
+								Status:      release.TestRun_SUCCESS,
+								StartedAt:   &date,
+								CompletedAt: &date,
+								Logs:        "all good\n",
+							},
+							{
+								Name:        "test run 2",
+								Status:      release.TestRun_FAILURE,
+								StartedAt:   &date,
+								CompletedAt: &date,
+								Logs:        "connection refused\n",
+							},
+						},
+					},
+				}),
+			},
+		},
 	}
 
 	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
@@ -34,9 +34,12 @@ import (
 	"k8s.io/helm/pkg/manifest"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/renderutil"
+	"k8s.io/helm/pkg/schema"
 	"k8s.io/helm/pkg/tiller"
 	"k8s.io/helm/pkg/timeconv"
+	"k8s.io/helm/pkg/version"
 )
 
 const defaultDirectoryPermission = 0755
@@ -59,6 +62,13 @@ is done.
 To render just one template in a chart, use '-x':
 
 	$ helm template mychart -x templates/deployment.yaml
+
+To check the rendered objects without a cluster, use '--validate-offline'. Each
+object is validated against the schema of its kind in the Kubernetes version
+given by --kube-version, and against the schemas of the CustomResourceDefinitions
+in the chart. The schemas bundled with Helm are those of Kubernetes %s, which
+are used if --kube-version is not given; use --schema-location to validate
+against the OpenAPI document of another version.
 `
 
 type templateCmd struct {
@@ -68,6 +78,7 @@ type templateCmd struct {
 	out              io.Writer
 	values           []string
 	stringValues     []string
+	jsonValues       []string
 	fileValues       []string
 	nameTemplate     string
 	showNotes        bool
@@ -76,7 +87,10 @@ type templateCmd struct {
 	renderFiles      []string
 	kubeVersion      string
 	apiVersions      []string
+	capabilities     string
 	outputDir        string
+	validateOffline  bool
+	schemaLocation   string
 }
 
 func newTemplateCmd(out io.Writer) *cobra.Command {
@@ -88,7 +102,7 @@ func newTemplateCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "template [flags] CHART",
 		Short: "Locally render templates",
-		Long:  templateDesc,
+		Long:  fmt.Sprintf(templateDesc, schema.BundledKubeVersion),
 		RunE:  t.run,
 	}
 
@@ -102,11 +116,15 @@ func newTemplateCmd(out io.Writer) *cobra.Command {
 	f.StringVar(&t.namespace, "namespace", "", "Namespace to install the release into")
 	f.StringArrayVar(&t.values, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&t.stringValues, "set-string", []string{}, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&t.jsonValues, "set-json", []string{}, "Set JSON values on the command line (can specify multiple or separate values with commas: key1={\"a\":1},key2=[1,2])")
 	f.StringArrayVar(&t.fileValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
 	f.StringVar(&t.nameTemplate, "name-template", "", "Specify template used to name the release")
 	f.StringVar(&t.kubeVersion, "kube-version", defaultKubeVersion, "Kubernetes version used as Capabilities.KubeVersion.Major/Minor")
 	f.StringArrayVarP(&t.apiVersions, "api-versions", "a", []string{}, "Kubernetes api versions used for Capabilities.APIVersions")
+	f.StringVar(&t.capabilities, "capabilities", "", "Render with the cluster capabilities written by 'helm capabilities dump' to this file. --kube-version and --api-versions are applied on top")
 	f.StringVar(&t.outputDir, "output-dir", "", "Writes the executed templates to files in output-dir instead of stdout")
+	f.BoolVar(&t.validateOffline, "validate-offline", false, "Validate the rendered objects against the schemas of the Kubernetes version given by --kube-version")
+	f.StringVar(&t.schemaLocation, "schema-location", "", "Validate against this OpenAPI document, or the document named v<major>.<minor>.json in this directory, instead of the bundled schemas")
 
 	return cmd
 }
@@ -136,7 +154,7 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		t.namespace = defaultNamespace()
 	}
 	// get combined values and create config
-	rawVals, err := vals(t.valueFiles, t.values, t.stringValues, t.fileValues, "", "", "")
+	rawVals, err := vals(t.valueFiles, t.values, t.stringValues, t.jsonValues, t.fileValues, "", "", "")
 	if err != nil {
 		return err
 	}
@@ -159,6 +177,20 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return prettyError(err)
 	}
+	if chartutil.IsLibraryChart(c) {
+		return chartutil.ErrLibraryChart
+	}
+
+	var caps *chartutil.Capabilities
+	if t.capabilities != "" {
+		if caps, err = chartutil.ReadCapabilitiesFile(t.capabilities, version.GetVersionProto()); err != nil {
+			return err
+		}
+		// only an explicit --kube-version overrides the one from the file
+		if !cmd.Flags().Changed("kube-version") {
+			t.kubeVersion = ""
+		}
+	}
 
 	renderOpts := renderutil.Options{
 		ReleaseOptions: chartutil.ReleaseOptions{
@@ -168,8 +200,9 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 			Time:      timeconv.Now(),
 			Namespace: t.namespace,
 		},
-		KubeVersion: t.kubeVersion,
-		APIVersions: t.apiVersions,
+		KubeVersion:  t.kubeVersion,
+		APIVersions:  t.apiVersions,
+		Capabilities: caps,
 	}
 
 	renderedTemplates, err := renderutil.Render(c, config, renderOpts)
@@ -230,6 +263,19 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		manifestsToRender = listManifests
 	}
 
+	if t.validateOffline {
+		// the default --kube-version leaves the version to the schemas
+		var kubeVersion string
+		if cmd.Flags().Changed("kube-version") {
+			kubeVersion = t.kubeVersion
+		} else if caps != nil {
+			kubeVersion = caps.KubeVersion.GitVersion
+		}
+		if err := validateManifests(c, listManifests, manifestsToRender, t.schemaLocation, kubeVersion); err != nil {
+			return err
+		}
+	}
+
 	for _, m := range tiller.SortByKind(manifestsToRender) {
 		data := m.Content
 		b := filepath.Base(m.Name)
@@ -257,6 +303,46 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// validateManifests validates the objects in manifests against the schemas
+// for kubeVersion from location, adding the schemas of the
+// CustomResourceDefinitions in the chart and in all rendered manifests.
+// Kinds without a schema are only warned about.
+func validateManifests(c *chart.Chart, all, manifests []manifest.Manifest, location, kubeVersion string) error {
+	schemas, err := schema.Load(location, kubeVersion)
+	if err != nil {
+		return err
+	}
+	crds := schema.ChartCRDs(c)
+	for _, m := range all {
+		for _, doc := range releaseutil.SplitManifestDocs(m.Content) {
+			crds = append(crds, []byte(doc))
+		}
+	}
+	if schemas, err = schemas.WithCRDs(crds...); err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, m := range manifests {
+		if filepath.Ext(m.Name) != ".yaml" && filepath.Ext(m.Name) != ".yml" {
+			continue
+		}
+		for _, doc := range releaseutil.SplitManifestDocs(m.Content) {
+			for _, err := range schemas.Validate([]byte(doc)) {
+				if _, ok := err.(*schema.UnknownKindError); ok {
+					fmt.Fprintf(os.Stderr, "WARNING: %s: %s\n", m.Name, err)
+					continue
+				}
+				failures = append(failures, fmt.Sprintf("%s: %s", m.Name, err))
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("rendered manifests are invalid:\n%s", strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
 // write the <data> to <output-dir>/<name>
 func writeToFile(outputDir string, name string, data string, out io.Writer) error {
 	outfileName := strings.Join([]string{outputDir, name}, string(filepath.Separator))
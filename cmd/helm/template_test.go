@@ -182,6 +182,57 @@ func TestTemplateCmd(t *testing.T) {
 			expectKey:   "subchart1/templates/service.yaml",
 			expectValue: "kube-api-version/test: v1",
 		},
+		{
+			name:        "check_capabilities",
+			desc:        "verify --capabilities replaces the default capabilities",
+			args:        []string{subchart1ChartPath, "--capabilities", "testdata/capabilities.yaml"},
+			expectKey:   "subchart1/templates/service.yaml",
+			expectValue: "kube-version/major: \"1\"\n    kube-version/minor: \"16\"\n    kube-version/gitversion: \"v1.16.0\"\n\n    kube-api-version/test: v1",
+		},
+		{
+			name:        "check_capabilities_kube_version",
+			desc:        "verify --kube-version overrides the kubernetes version of --capabilities",
+			args:        []string{subchart1ChartPath, "--capabilities", "testdata/capabilities.yaml", "--kube-version", "1.17"},
+			expectKey:   "subchart1/templates/service.yaml",
+			expectValue: "kube-version/minor: \"17\"\n    kube-version/gitversion: \"v1.17.0\"\n\n    kube-api-version/test: v1",
+		},
+		{
+			name:        "check_capabilities_missing",
+			desc:        "verify a missing --capabilities file fails",
+			args:        []string{subchart1ChartPath, "--capabilities", "testdata/missing.yaml"},
+			expectError: "no such file or directory",
+		},
+		{
+			name:        "check_validate_offline",
+			desc:        "verify --validate-offline accepts valid objects",
+			args:        []string{subchart1ChartPath, "--validate-offline"},
+			expectKey:   "subchart1/templates/service.yaml",
+			expectValue: "protocol: TCP\n    name: nginx",
+		},
+		{
+			name:        "check_validate_offline_invalid",
+			desc:        "verify --validate-offline rejects invalid objects",
+			args:        []string{"testdata/testcharts/chart-with-schema-errors", "--validate-offline"},
+			expectError: `chart-with-schema-errors/templates/deployment.yaml: Deployment "release-name": spec.replicas: expected integer, got string`,
+		},
+		{
+			name:        "check_library_chart",
+			desc:        "verify library charts are not rendered",
+			args:        []string{"testdata/testcharts/library"},
+			expectError: "library charts are not installable",
+		},
+		{
+			name:        "check_validate_offline_bundled_version",
+			desc:        "verify --validate-offline fails without bundled schemas for the kubernetes version",
+			args:        []string{subchart1ChartPath, "--validate-offline", "--kube-version", "1.16"},
+			expectError: "no bundled schemas for Kubernetes v1.16, only for v1.18",
+		},
+		{
+			name:        "check_validate_offline_schema_location",
+			desc:        "verify --validate-offline fails without schemas for the kubernetes version",
+			args:        []string{subchart1ChartPath, "--validate-offline", "--kube-version", "1.17", "--schema-location", "testdata"},
+			expectError: "no schemas for Kubernetes v1.17 in testdata",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,3 @@
+{{- define "library.fullname" -}}
+{{ .Release.Name }}-{{ .Chart.Name }}
+{{- end -}}
@@ -0,0 +1,70 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/helm/pkg/helm/tillerless"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+func TestTillerlessCommands(t *testing.T) {
+	env := environment.New()
+	env.Releases = storage.Init(driver.NewMemory())
+	env.KubeClient = &environment.PrintingKubeClient{Out: ioutil.Discard}
+
+	tillerlessClient = tillerless.NewFromEnvironment(env, fake.NewSimpleClientset())
+	settings.Tillerless = true
+	defer func() {
+		teardown()
+		tillerlessClient = nil
+		settings.Tillerless = false
+	}()
+
+	if err := setupConnection(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	install := newInstallCmd(nil, &buf)
+	install.ParseFlags([]string{"--name", "aeneas"})
+	if err := install.RunE(install, []string{"testdata/testcharts/signtest"}); err != nil {
+		t.Fatalf("install failed: %s", err)
+	}
+
+	buf.Reset()
+	list := newListCmd(nil, &buf)
+	list.ParseFlags([]string{"-q"})
+	if err := list.RunE(list, nil); err != nil {
+		t.Fatalf("list failed: %s", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "aeneas" {
+		t.Errorf("expected the installed release to be listed, got %q", got)
+	}
+
+	if _, err := env.Releases.Deployed("aeneas"); err != nil {
+		t.Errorf("expected the release to be stored by the in-process engine: %s", err)
+	}
+}
@@ -87,6 +87,7 @@ type upgradeCmd struct {
 	release       string
 	chart         string
 	out           io.Writer
+	errOut        io.Writer
 	client        helm.Interface
 	dryRun        bool
 	recreate      bool
@@ -95,7 +96,9 @@ type upgradeCmd struct {
 	valueFiles    valueFiles
 	values        []string
 	stringValues  []string
+	jsonValues    []string
 	fileValues    []string
+	sensitiveVals []string
 	verify        bool
 	keyring       string
 	install       bool
@@ -113,6 +116,7 @@ type upgradeCmd struct {
 	subNotes      bool
 	description   string
 	cleanupOnFail bool
+	adopt         bool
 
 	certFile string
 	keyFile  string
@@ -144,6 +148,7 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 
 			upgrade.release = args[0]
 			upgrade.chart = args[1]
+			upgrade.errOut = cmd.ErrOrStderr()
 			upgrade.client = ensureHelmClient(upgrade.client)
 			upgrade.wait = upgrade.wait || upgrade.atomic
 
@@ -159,6 +164,8 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&upgrade.force, "force", false, "Force resource update through delete/recreate if needed")
 	f.StringArrayVar(&upgrade.values, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&upgrade.stringValues, "set-string", []string{}, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&upgrade.jsonValues, "set-json", []string{}, "Set JSON values on the command line (can specify multiple or separate values with commas: key1={\"a\":1},key2=[1,2])")
+	f.StringArrayVar(&upgrade.sensitiveVals, "set-sensitive", []string{}, "Set STRING values on the command line that are masked when the release is printed, or mark values at paths without '=' as sensitive (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&upgrade.fileValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
 	f.BoolVar(&upgrade.disableHooks, "disable-hooks", false, "Disable pre/post upgrade hooks. DEPRECATED. Use no-hooks")
 	f.BoolVar(&upgrade.disableHooks, "no-hooks", false, "Disable pre/post upgrade hooks")
@@ -182,6 +189,7 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&upgrade.subNotes, "render-subchart-notes", false, "Render subchart notes along with parent")
 	f.StringVar(&upgrade.description, "description", "", "Specify the description to use for the upgrade, rather than the default")
 	f.BoolVar(&upgrade.cleanupOnFail, "cleanup-on-fail", false, "Allow deletion of new resources created in this upgrade when upgrade failed")
+	f.BoolVar(&upgrade.adopt, "adopt", false, "Take over new resources of the chart that already exist in the cluster instead of failing")
 	bindOutputFlag(cmd, &upgrade.output)
 
 	f.MarkDeprecated("disable-hooks", "Use --no-hooks instead")
@@ -224,30 +232,33 @@ func (u *upgradeCmd) run() error {
 		if err != nil && strings.Contains(err.Error(), storageerrors.ErrReleaseNotFound(u.release).Error()) {
 			fmt.Fprintf(u.out, "Release %q does not exist. Installing it now.\n", u.release)
 			ic := &installCmd{
-				chartPath:    chartPath,
-				client:       u.client,
-				out:          u.out,
-				name:         u.release,
-				valueFiles:   u.valueFiles,
-				dryRun:       u.dryRun,
-				verify:       u.verify,
-				disableHooks: u.disableHooks,
-				keyring:      u.keyring,
-				values:       u.values,
-				stringValues: u.stringValues,
-				fileValues:   u.fileValues,
-				namespace:    u.namespace,
-				timeout:      u.timeout,
-				wait:         u.wait,
-				description:  u.description,
-				atomic:       u.atomic,
-				output:       u.output,
+				chartPath:     chartPath,
+				client:        u.client,
+				out:           u.out,
+				name:          u.release,
+				valueFiles:    u.valueFiles,
+				dryRun:        u.dryRun,
+				verify:        u.verify,
+				disableHooks:  u.disableHooks,
+				keyring:       u.keyring,
+				values:        u.values,
+				stringValues:  u.stringValues,
+				jsonValues:    u.jsonValues,
+				fileValues:    u.fileValues,
+				sensitiveVals: u.sensitiveVals,
+				namespace:     u.namespace,
+				timeout:       u.timeout,
+				wait:          u.wait,
+				description:   u.description,
+				atomic:        u.atomic,
+				adopt:         u.adopt,
+				output:        u.output,
 			}
 			return ic.run()
 		}
 	}
 
-	rawVals, err := vals(u.valueFiles, u.values, u.stringValues, u.fileValues, u.certFile, u.keyFile, u.caFile)
+	rawVals, sensitive, err := valsWithSensitive(u.valueFiles, u.values, u.stringValues, u.jsonValues, u.fileValues, u.sensitiveVals, u.certFile, u.keyFile, u.caFile)
 	if err != nil {
 		return err
 	}
@@ -255,6 +266,9 @@ func (u *upgradeCmd) run() error {
 	// Check chart requirements to make sure all dependencies are present in /charts
 	ch, err := chartutil.Load(chartPath)
 	if err == nil {
+		if chartutil.IsLibraryChart(ch) {
+			return chartutil.ErrLibraryChart
+		}
 		if req, err := chartutil.LoadRequirements(ch); err == nil {
 			if err := renderutil.CheckDependencies(ch, req); err != nil {
 				return err
@@ -278,9 +292,11 @@ func (u *upgradeCmd) run() error {
 		helm.ResetValues(u.resetValues),
 		helm.ReuseValues(u.reuseValues),
 		helm.UpgradeSubNotes(u.subNotes),
+		helm.UpgradeSensitiveValues(sensitive),
 		helm.UpgradeWait(u.wait),
 		helm.UpgradeDescription(u.description),
-		helm.UpgradeCleanupOnFail(u.cleanupOnFail))
+		helm.UpgradeCleanupOnFail(u.cleanupOnFail),
+		helm.UpgradeAdopt(u.adopt))
 	if err != nil {
 		fmt.Fprintf(u.out, "UPGRADE FAILED\nError: %v\n", prettyError(err))
 		if u.atomic {
@@ -310,6 +326,15 @@ func (u *upgradeCmd) run() error {
 		printRelease(u.out, resp.Release)
 	}
 
+	// keep warnings out of structured output
+	warnOut := u.out
+	if outputFormat(u.output) != outputTable {
+		warnOut = u.errOut
+	}
+	for _, w := range resp.GetWarnings() {
+		fmt.Fprintf(warnOut, "WARNING: %s\n", w)
+	}
+
 	if outputFormat(u.output) == outputTable {
 		fmt.Fprintf(u.out, "Release %q has been upgraded.\n", u.release)
 	}
@@ -17,10 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -171,6 +173,14 @@ func TestUpgradeCmd(t *testing.T) {
 			expected: "Release \"crazy-bunny\" has been upgraded.\n",
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-bunny", Version: 2, Chart: ch2, Description: "foo"})},
 		},
+		{
+			name:     "upgrade a release with adopt",
+			args:     []string{"crazy-bunny", chartPath},
+			flags:    []string{"--adopt"},
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-bunny", Version: 2, Chart: ch2}),
+			expected: "Release \"crazy-bunny\" has been upgraded.\n",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-bunny", Version: 2, Chart: ch2})},
+		},
 		{
 			name: "upgrade a release with missing dependencies",
 			args: []string{"bonkers-bunny", missingDepsPath},
@@ -192,3 +202,38 @@ func TestUpgradeCmd(t *testing.T) {
 	runReleaseCases(t, tests, cmd)
 
 }
+
+func TestUpgradeWarnings(t *testing.T) {
+	tmpChart, err := ioutil.TempDir("", "helm-upgrade-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpChart)
+	chartPath, err := chartutil.Create(&chart.Metadata{Name: "warnings", Version: "0.1.0"}, tmpChart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		output            string
+		inOut, inErrorOut bool
+	}{
+		{"table", true, false},
+		{"json", false, true},
+	} {
+		c := &helm.FakeClient{
+			Rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny"})},
+			Warnings: []string{"extensions/v1beta1 Ingress is deprecated"},
+		}
+		var out, errOut bytes.Buffer
+		cmd := newUpgradeCmd(c, &out)
+		cmd.SetErr(&errOut)
+		cmd.ParseFlags([]string{"--output", tt.output})
+		if err := cmd.RunE(cmd, []string{"funny-bunny", chartPath}); err != nil {
+			t.Fatal(err)
+		}
+		const warning = "WARNING: extensions/v1beta1 Ingress is deprecated"
+		if strings.Contains(out.String(), warning) != tt.inOut || strings.Contains(errOut.String(), warning) != tt.inErrorOut {
+			t.Errorf("%s: expected the warning in the output %t and in the error output %t, got %q and %q", tt.output, tt.inOut, tt.inErrorOut, out.String(), errOut.String())
+		}
+	}
+}
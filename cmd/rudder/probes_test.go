@@ -0,0 +1,51 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestProbesServer(t *testing.T) {
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus(healthService, healthpb.HealthCheckResponse_NOT_SERVING)
+	srv := httptest.NewServer(newProbesMux(healthSrv))
+	defer srv.Close()
+
+	expect := func(path string, code int) {
+		t.Helper()
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s returned an error (%s)", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != code {
+			t.Errorf("GET %s returned status code %d, expected %d", path, resp.StatusCode, code)
+		}
+	}
+
+	expect("/readiness", http.StatusServiceUnavailable)
+	expect("/liveness", http.StatusOK)
+
+	healthSrv.SetServingStatus(healthService, healthpb.HealthCheckResponse_SERVING)
+	expect("/readiness", http.StatusOK)
+}
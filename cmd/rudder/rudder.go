@@ -18,31 +18,89 @@ package main
 
 import (
 	"bytes"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
+	"os"
 
 	"github.com/spf13/pflag"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"k8s.io/client-go/kubernetes"
 
 	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/logging"
+	"k8s.io/helm/pkg/proto/hapi/release"
 	rudderAPI "k8s.io/helm/pkg/proto/hapi/rudder"
+	"k8s.io/helm/pkg/rudder"
 	"k8s.io/helm/pkg/tiller"
+	"k8s.io/helm/pkg/tlsutil"
 	"k8s.io/helm/pkg/version"
 )
 
 var kubeClient *kube.Client
 var clientset kubernetes.Interface
+var logger logging.Logger
 
 type options struct {
-	listen string
+	listen      string
+	probeListen string
+	logFormat   string
+	logLevel    string
+
+	tlsEnable  bool
+	tlsVerify  bool
+	keyFile    string
+	certFile   string
+	caCertFile string
 }
 
 func (opts *options) registerFlags() {
 	pflag.StringVarP(&opts.listen, "listen", "l", "127.0.0.1:10001",
 		"Socket for rudder grpc server (default: 127.0.0.1:10001).")
+	pflag.StringVar(&opts.probeListen, "probe-listen", "127.0.0.1:10002",
+		"Socket for the HTTP readiness and liveness probes, or '' to disable them.")
+	pflag.StringVar(&opts.logFormat, "log-format", "text", "Format of the log: 'text' or 'json'.")
+	pflag.StringVar(&opts.logLevel, "log-level", "info", "Minimum level of the entries logged: 'debug', 'info', 'warn' or 'error'.")
+	pflag.BoolVar(&opts.tlsEnable, "tls", false, "Enable TLS.")
+	pflag.BoolVar(&opts.tlsVerify, "tls-verify", false, "Enable TLS and require client certificates signed by the CA of --tls-ca-cert.")
+	pflag.StringVar(&opts.keyFile, "tls-key", "", "Path to the TLS private key file.")
+	pflag.StringVar(&opts.certFile, "tls-cert", "", "Path to the TLS certificate file.")
+	pflag.StringVar(&opts.caCertFile, "tls-ca-cert", "", "Trust client certificates signed by this CA.")
+}
+
+// serverOptions returns the options of the grpc server, with the server TLS
+// configuration if TLS is enabled.
+func (opts *options) serverOptions() ([]grpc.ServerOption, error) {
+	if !opts.tlsEnable && !opts.tlsVerify {
+		return nil, nil
+	}
+	tlsOpts := tlsutil.Options{CertFile: opts.certFile, KeyFile: opts.keyFile}
+	if opts.tlsVerify {
+		tlsOpts.CaCertFile = opts.caCertFile
+		tlsOpts.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	cfg, err := tlsutil.ServerConfig(tlsOpts)
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(cfg))}, nil
+}
+
+func (opts *options) newLogger() (logging.Logger, error) {
+	format, err := logging.ParseFormat(opts.logFormat)
+	if err != nil {
+		return nil, err
+	}
+	level, err := logging.ParseLevel(opts.logLevel)
+	if err != nil {
+		return nil, err
+	}
+	return logging.New(os.Stderr, format, level).With(logging.Component("rudder")), nil
 }
 
 func (opts *options) parseFlags() {
@@ -58,21 +116,89 @@ func main() {
 	opts := new(options)
 	opts.regAndParseFlags()
 	var err error
+	logger, err = opts.newLogger()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
 	kubeClient = kube.New(nil)
+	kubeClient.Log = logger.With(logging.Component("kube"))
 	clientset, err = kubeClient.KubernetesClientSet()
 	if err != nil {
-		grpclog.Fatalf("Cannot initialize Kubernetes connection: %s", err)
+		logger.Errorf("Cannot initialize Kubernetes connection: %s", err)
+		os.Exit(1)
 	}
-	grpclog.Printf("Creating tcp socket on %s\n", opts.listen)
+	logger.Infof("Creating tcp socket on %s", opts.listen)
 	lis, err := net.Listen("tcp", opts.listen)
 	if err != nil {
-		grpclog.Fatalf("failed to listen: %v", err)
+		logger.Errorf("failed to listen: %v", err)
+		os.Exit(1)
 	}
-	grpcServer := grpc.NewServer()
+	serverOpts, err := opts.serverOptions()
+	if err != nil {
+		logger.Errorf("Could not create server TLS configuration: %v", err)
+		os.Exit(1)
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
 	rudderAPI.RegisterReleaseModuleServiceServer(grpcServer, &ReleaseModuleServiceServer{})
 
-	grpclog.Printf("Starting server on %s\n", opts.listen)
-	grpcServer.Serve(lis)
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthSrv)
+
+	srvErrCh := make(chan error)
+	probeErrCh := make(chan error)
+	go func() {
+		logger.Infof("Starting server on %s (tls=%t)", opts.listen, opts.tlsEnable || opts.tlsVerify)
+		if err := grpcServer.Serve(lis); err != nil {
+			srvErrCh <- err
+		}
+	}()
+	if opts.probeListen != "" {
+		go func() {
+			logger.Infof("Probes listening on %s", opts.probeListen)
+			if err := http.ListenAndServe(opts.probeListen, newProbesMux(healthSrv)); err != nil {
+				probeErrCh <- err
+			}
+		}()
+	}
+
+	healthSrv.SetServingStatus(healthService, healthpb.HealthCheckResponse_SERVING)
+
+	select {
+	case err := <-srvErrCh:
+		logger.Errorf("Server died: %s", err)
+		os.Exit(1)
+	case err := <-probeErrCh:
+		logger.Errorf("Probes server died: %s", err)
+		os.Exit(1)
+	}
+}
+
+// callLogger returns the logger of a call, with the request ID of the caller
+// and the release the call operates on as fields.
+func callLogger(ctx context.Context, operation string, rel *release.Release) logging.Logger {
+	ctx = logging.ExtractRequestID(ctx)
+	l := logger.With(
+		logging.F(logging.OperationKey, operation),
+		logging.F(logging.RequestIDKey, logging.RequestIDFromContext(ctx)),
+	)
+	if rel != nil {
+		l = l.With(
+			logging.F(logging.ReleaseKey, rel.Name),
+			logging.F(logging.NamespaceKey, rel.Namespace),
+			logging.F(logging.RevisionKey, rel.Version),
+		)
+	}
+	return l
+}
+
+// logResult logs the outcome of a call.
+func logResult(l logging.Logger, err error) {
+	if err != nil {
+		l.With(logging.F(logging.ErrorKey, err)).Errorf("failed")
+		return
+	}
+	l.Infof("complete")
 }
 
 // ReleaseModuleServiceServer provides implementation for rudderAPI.ReleaseModuleServiceServer
@@ -80,7 +206,7 @@ type ReleaseModuleServiceServer struct{}
 
 // Version returns Rudder version based on helm version
 func (r *ReleaseModuleServiceServer) Version(ctx context.Context, in *rudderAPI.VersionReleaseRequest) (*rudderAPI.VersionReleaseResponse, error) {
-	grpclog.Print("version")
+	callLogger(ctx, "version", nil).Debugf("version")
 	return &rudderAPI.VersionReleaseResponse{
 		Name:    "helm-rudder-native",
 		Version: version.Version,
@@ -89,24 +215,29 @@ func (r *ReleaseModuleServiceServer) Version(ctx context.Context, in *rudderAPI.
 
 // InstallRelease creates a release using kubeClient.Create
 func (r *ReleaseModuleServiceServer) InstallRelease(ctx context.Context, in *rudderAPI.InstallReleaseRequest) (*rudderAPI.InstallReleaseResponse, error) {
-	grpclog.Print("install")
+	l := callLogger(ctx, "install", in.Release)
 	b := bytes.NewBufferString(in.Release.Manifest)
-	err := kubeClient.Create(in.Release.Namespace, b, 500, false)
-	if err != nil {
-		grpclog.Printf("error when creating release: %v", err)
-	}
+	err := kubeClient.CreateWithOptions(in.Release.Namespace, b, kube.CreateOptions{
+		Timeout:     500,
+		Adopt:       in.Adopt,
+		AdoptOnly:   in.AdoptOnly,
+		ReleaseName: in.Release.Name,
+	})
+	logResult(l, err)
 	return &rudderAPI.InstallReleaseResponse{}, err
 }
 
 // DeleteRelease deletes a provided release
 func (r *ReleaseModuleServiceServer) DeleteRelease(ctx context.Context, in *rudderAPI.DeleteReleaseRequest) (*rudderAPI.DeleteReleaseResponse, error) {
-	grpclog.Print("delete")
+	l := callLogger(ctx, "delete", in.Release)
 
 	resp := &rudderAPI.DeleteReleaseResponse{}
 	rel := in.Release
 	vs, err := tiller.GetVersionSet(clientset.Discovery())
 	if err != nil {
-		return resp, fmt.Errorf("Could not get apiVersions from Kubernetes: %v", err)
+		err = fmt.Errorf("Could not get apiVersions from Kubernetes: %v", err)
+		logResult(l, err)
+		return resp, err
 	}
 
 	kept, errs := tiller.DeleteRelease(rel, vs, kubeClient)
@@ -120,6 +251,7 @@ func (r *ReleaseModuleServiceServer) DeleteRelease(ctx context.Context, in *rudd
 	if len(allErrors) > 0 {
 		err = fmt.Errorf(allErrors)
 	}
+	logResult(l, err)
 
 	return &rudderAPI.DeleteReleaseResponse{
 		Release: rel,
@@ -128,38 +260,54 @@ func (r *ReleaseModuleServiceServer) DeleteRelease(ctx context.Context, in *rudd
 
 // RollbackRelease rolls back the release
 func (r *ReleaseModuleServiceServer) RollbackRelease(ctx context.Context, in *rudderAPI.RollbackReleaseRequest) (*rudderAPI.RollbackReleaseResponse, error) {
-	grpclog.Print("rollback")
-	c := bytes.NewBufferString(in.Current.Manifest)
-	t := bytes.NewBufferString(in.Target.Manifest)
-	err := kubeClient.UpdateWithOptions(in.Target.Namespace, c, t, kube.UpdateOptions{
+	l := callLogger(ctx, "rollback", in.Target)
+	err := rollout(ctx, l, in.Current, in.Target, kube.UpdateOptions{
 		Force:         in.Force,
 		Recreate:      in.Recreate,
 		Timeout:       in.Timeout,
 		ShouldWait:    in.Wait,
 		CleanupOnFail: in.CleanupOnFail,
+		ReleaseName:   in.Target.Name,
 	})
+	logResult(l, err)
 	return &rudderAPI.RollbackReleaseResponse{}, err
 }
 
 // UpgradeRelease upgrades manifests using kubernetes client
 func (r *ReleaseModuleServiceServer) UpgradeRelease(ctx context.Context, in *rudderAPI.UpgradeReleaseRequest) (*rudderAPI.UpgradeReleaseResponse, error) {
-	grpclog.Print("upgrade")
-	c := bytes.NewBufferString(in.Current.Manifest)
-	t := bytes.NewBufferString(in.Target.Manifest)
-	err := kubeClient.UpdateWithOptions(in.Target.Namespace, c, t, kube.UpdateOptions{
+	l := callLogger(ctx, "upgrade", in.Target)
+	err := rollout(ctx, l, in.Current, in.Target, kube.UpdateOptions{
 		Force:         in.Force,
 		Recreate:      in.Recreate,
 		Timeout:       in.Timeout,
 		ShouldWait:    in.Wait,
 		CleanupOnFail: in.CleanupOnFail,
+		Adopt:         in.Adopt,
+		ReleaseName:   in.Target.Name,
 	})
+	logResult(l, err)
 	// upgrade response object should be changed to include status
 	return &rudderAPI.UpgradeReleaseResponse{}, err
 }
 
+// rollout moves the resources of a release from current to target with the
+// strategy of the chart of target.
+func rollout(ctx context.Context, l logging.Logger, current, target *release.Release, opts kube.UpdateOptions) error {
+	s, err := rudder.StrategyFor(target.Chart)
+	if err != nil {
+		return err
+	}
+	c := &rudder.Cluster{
+		Clientset: clientset,
+		Applier:   kubeClient,
+		Log:       l.Infof,
+	}
+	return s.Rollout(ctx, c, current, target, opts)
+}
+
 // ReleaseStatus retrieves release status
 func (r *ReleaseModuleServiceServer) ReleaseStatus(ctx context.Context, in *rudderAPI.ReleaseStatusRequest) (*rudderAPI.ReleaseStatusResponse, error) {
-	grpclog.Print("status")
+	callLogger(ctx, "status", in.Release).Debugf("status")
 
 	resp, err := kubeClient.Get(in.Release.Namespace, bytes.NewBufferString(in.Release.Manifest))
 	in.Release.Info.Status.Resources = resp
@@ -0,0 +1,267 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+var _ driver.Driver = (*Filesystem)(nil)
+
+// FilesystemDriverName is the string name of the driver.
+const FilesystemDriverName = "Filesystem"
+
+// releaseExt is the extension of the files holding releases.
+const releaseExt = ".release"
+
+// Filesystem is a storage driver keeping every release in a file named after
+// its key. Files are written to a temporary file first and then moved into
+// place, so a release is never read half written.
+type Filesystem struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// NewFilesystem initializes a new Filesystem driver storing releases in dir.
+func NewFilesystem(dir string) (*Filesystem, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &Filesystem{dir: dir}, nil
+}
+
+// Name returns the name of the driver.
+func (fs *Filesystem) Name() string {
+	return FilesystemDriverName
+}
+
+// Get returns the release named by key or returns ErrReleaseNotFound.
+func (fs *Filesystem) Get(key string) (*rspb.Release, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	path, err := fs.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return readRelease(path, key)
+}
+
+// List returns the list of all releases such that filter(release) == true.
+func (fs *Filesystem) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	rels, err := fs.all()
+	if err != nil {
+		return nil, err
+	}
+	var results []*rspb.Release
+	for _, rls := range rels {
+		if filter(rls) {
+			results = append(results, rls)
+		}
+	}
+	return results, nil
+}
+
+// Query returns the set of releases that match the provided set of labels,
+// or ErrReleaseNotFound if there are none.
+func (fs *Filesystem) Query(labels map[string]string) ([]*rspb.Release, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	rels, err := fs.all()
+	if err != nil {
+		return nil, err
+	}
+	var results []*rspb.Release
+	for _, rls := range rels {
+		if matchLabels(rls, labels) {
+			results = append(results, rls)
+		}
+	}
+	if len(results) == 0 {
+		return nil, storageerrors.ErrReleaseNotFound(labels["NAME"])
+	}
+	return results, nil
+}
+
+// Create creates a new release or returns ErrReleaseExists.
+func (fs *Filesystem) Create(key string, rls *rspb.Release) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	path, err := fs.path(key)
+	if err != nil {
+		return err
+	}
+	tmp, err := fs.writeTemp(rls)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	// unlike a rename, a link fails if the release already exists
+	if err := os.Link(tmp, path); err != nil {
+		if os.IsExist(err) {
+			return storageerrors.ErrReleaseExists(key)
+		}
+		return err
+	}
+	return nil
+}
+
+// Update updates a release or returns ErrReleaseNotFound.
+func (fs *Filesystem) Update(key string, rls *rspb.Release) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	path, err := fs.path(key)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return storageerrors.ErrReleaseNotFound(key)
+	}
+	tmp, err := fs.writeTemp(rls)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// Delete deletes a release or returns ErrReleaseNotFound.
+func (fs *Filesystem) Delete(key string) (*rspb.Release, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	path, err := fs.path(key)
+	if err != nil {
+		return nil, err
+	}
+	rls, err := readRelease(path, key)
+	if err != nil {
+		return nil, err
+	}
+	return rls, os.Remove(path)
+}
+
+// path returns the file holding the release stored under key.
+func (fs *Filesystem) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, ".") || key != filepath.Base(key) {
+		return "", storageerrors.ErrInvalidKey(key)
+	}
+	return filepath.Join(fs.dir, key+releaseExt), nil
+}
+
+// all reads every stored release.
+func (fs *Filesystem) all() ([]*rspb.Release, error) {
+	files, err := ioutil.ReadDir(fs.dir)
+	if err != nil {
+		return nil, err
+	}
+	var rels []*rspb.Release
+	for _, fi := range files {
+		name := fi.Name()
+		if fi.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, releaseExt) {
+			continue
+		}
+		rls, err := readRelease(filepath.Join(fs.dir, name), strings.TrimSuffix(name, releaseExt))
+		if err != nil {
+			return nil, err
+		}
+		rels = append(rels, rls)
+	}
+	return rels, nil
+}
+
+// writeTemp writes rls to a new temporary file in the driver's directory and
+// returns its path.
+func (fs *Filesystem) writeTemp(rls *rspb.Release) (string, error) {
+	b, err := proto.Marshal(rls)
+	if err != nil {
+		return "", err
+	}
+	f, err := ioutil.TempFile(fs.dir, ".tmp-")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(b); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func readRelease(path, key string) (*rspb.Release, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, storageerrors.ErrReleaseNotFound(key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rls rspb.Release
+	if err := proto.Unmarshal(b, &rls); err != nil {
+		return nil, err
+	}
+	return &rls, nil
+}
+
+// matchLabels reports whether rls has all of labels. Releases carry the same
+// labels as in the built-in drivers: NAME, OWNER, STATUS and VERSION.
+func matchLabels(rls *rspb.Release, labels map[string]string) bool {
+	for k, v := range labels {
+		var actual string
+		switch k {
+		case "NAME":
+			actual = rls.Name
+		case "OWNER":
+			actual = "TILLER"
+		case "STATUS":
+			actual = rls.GetInfo().GetStatus().GetCode().String()
+		case "VERSION":
+			actual = strconv.Itoa(int(rls.Version))
+		}
+		if actual != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/storage/driver/drivertest"
+)
+
+func newTestFilesystem(t *testing.T) *Filesystem {
+	dir, err := ioutil.TempDir("", "helm-storage-fs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	fs, err := NewFilesystem(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
+
+func TestFilesystemConformance(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T) driver.Driver {
+		return newTestFilesystem(t)
+	})
+}
+
+func TestFilesystemPluginConformance(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T) driver.Driver {
+		sock := filepath.Join(newTestFilesystem(t).dir, ".sock")
+		lis, err := net.Listen("unix", sock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		go driver.ServePlugin(lis, newTestFilesystem(t))
+
+		p, err := driver.DialPlugin("unix://"+sock, 5*time.Second)
+		if err != nil {
+			lis.Close()
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			p.Close()
+			lis.Close()
+		})
+		return p
+	})
+}
+
+func TestFilesystemInvalidKey(t *testing.T) {
+	fs := newTestFilesystem(t)
+	rls := drivertest.ReleaseStub("smug-pigeon", 1, rspb.Status_DEPLOYED)
+
+	for _, key := range []string{"", "../smug-pigeon.v1", ".smug-pigeon.v1", "a/b"} {
+		if err := fs.Create(key, rls); err == nil || !strings.Contains(err.Error(), "invalid key") {
+			t.Errorf("expected invalid key error for %q, got %v", key, err)
+		}
+	}
+}
+
+func TestFilesystemIgnoresTempFiles(t *testing.T) {
+	fs := newTestFilesystem(t)
+	rls := drivertest.ReleaseStub("smug-pigeon", 1, rspb.Status_DEPLOYED)
+	if err := fs.Create(drivertest.Key(rls), rls); err != nil {
+		t.Fatal(err)
+	}
+	// a temporary file left behind by an interrupted write
+	if err := ioutil.WriteFile(filepath.Join(fs.dir, ".tmp-123"), []byte("garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	rels, err := fs.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 1 {
+		t.Errorf("expected 1 release, got %d", len(rels))
+	}
+}
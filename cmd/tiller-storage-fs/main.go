@@ -0,0 +1,62 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// tiller-storage-fs is the reference storage plugin for Tiller. It stores
+// releases as files in a local directory:
+//
+//	tiller-storage-fs --listen unix:///var/run/tiller-storage.sock --dir /var/lib/tiller
+//	tiller --storage=plugin --storage-plugin=unix:///var/run/tiller-storage.sock
+package main // import "k8s.io/helm/cmd/tiller-storage-fs"
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"strings"
+
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+var (
+	listenAddr = flag.String("listen", "unix:///var/run/tiller/storage.sock", "Unix socket to listen on, as unix:///path/to/socket")
+	dir        = flag.String("dir", "releases", "directory to store releases in")
+)
+
+func main() {
+	flag.Parse()
+	logger := log.New(os.Stderr, "[storage/fs] ", log.Flags())
+
+	fs, err := NewFilesystem(*dir)
+	if err != nil {
+		logger.Fatalf("Cannot initialize storage directory: %s", err)
+	}
+
+	path := strings.TrimPrefix(*listenAddr, "unix://")
+	if path == *listenAddr || path == "" {
+		logger.Fatalf("Cannot listen on %s: not a Unix socket", *listenAddr)
+	}
+	os.Remove(path)
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		logger.Fatalf("Server died: %s", err)
+	}
+
+	logger.Printf("Storing releases in %s, listening on %s", *dir, *listenAddr)
+	if err := driver.ServePlugin(lis, fs); err != nil {
+		logger.Fatalf("Server died: %s", err)
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/storage/encryption"
+	"k8s.io/helm/pkg/storage/migrate"
+)
+
+// migrateStorageCmd is the argument that runs Tiller as a one-off storage migration.
+const migrateStorageCmd = "migrate-storage"
+
+// migrateStorage copies all releases between two storage drivers and returns the exit code.
+//
+//	tiller migrate-storage --from configmap --to sql --sql-connection-string ...
+func migrateStorage(args []string) int {
+	fs := flag.NewFlagSet(migrateStorageCmd, flag.ContinueOnError)
+	from := fs.String("from", storageConfigMap, "storage driver to copy releases from. One of 'configmap', 'sql', 'secret' or 'plugin'")
+	to := fs.String("to", "", "storage driver to copy releases to. One of 'configmap', 'sql', 'secret' or 'plugin'")
+	dryRun := fs.Bool("dry-run", false, "report the releases that would be copied without writing them")
+	deleteSource := fs.Bool("delete-source", false, "delete the releases from the source driver once the copy is verified")
+	fs.StringVar(sqlDialect, "sql-dialect", *sqlDialect, "SQL dialect to use (only postgres is supported for now")
+	fs.StringVar(sqlConnectionString, "sql-connection-string", *sqlConnectionString, "SQL connection string to use")
+	fs.StringVar(storagePluginAddr, "storage-plugin", *storagePluginAddr, "Unix socket of the storage plugin used by the 'plugin' driver")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *to == "" {
+		fmt.Fprintln(os.Stderr, "--to is required")
+		return 2
+	}
+	if *from == *to {
+		fmt.Fprintf(os.Stderr, "cannot migrate %s storage to itself\n", *from)
+		return 2
+	}
+	if *from == storageMemory || *to == storageMemory {
+		fmt.Fprintln(os.Stderr, "memory storage cannot be migrated")
+		return 2
+	}
+
+	clientset, err := kube.New(nil).KubernetesClientSet()
+	if err != nil {
+		logger.Errorf("Cannot initialize Kubernetes connection: %s", err)
+		return 1
+	}
+
+	src, err := newStorageDriver(*from, clientset)
+	if err != nil {
+		logger.Errorf("%s", err)
+		return 1
+	}
+	dst, err := newStorageDriver(*to, clientset)
+	if err != nil {
+		logger.Errorf("%s", err)
+		return 1
+	}
+
+	res, err := migrate.Migrate(src, dst, migrate.Options{
+		DryRun:       *dryRun,
+		DeleteSource: *deleteSource,
+		Log:          newLogger("migrate").Infof,
+	})
+	if res != nil {
+		fmt.Printf("copied: %d, already present: %d, deleted from source: %d\n", res.Copied, res.Skipped, res.Deleted)
+	}
+	if err != nil {
+		logger.Errorf("Migration failed: %s", err)
+		return 1
+	}
+	return 0
+}
+
+// reencryptStorageCmd is the argument that runs Tiller as a one-off re-encryption of the stored releases.
+const reencryptStorageCmd = "reencrypt-storage"
+
+// reencryptStorage rewrites all stored releases with the current encryption key and returns the exit code.
+//
+//	tiller --storage secret --storage-encryption-keyfile keys.yaml reencrypt-storage
+func reencryptStorage(args []string) int {
+	if len(args) > 0 {
+		fmt.Fprintf(os.Stderr, "%s takes no arguments\n", reencryptStorageCmd)
+		return 2
+	}
+	if *storageKeyFile == "" {
+		fmt.Fprintln(os.Stderr, "--storage-encryption-keyfile is required")
+		return 2
+	}
+
+	clientset, err := kube.New(nil).KubernetesClientSet()
+	if err != nil {
+		logger.Errorf("Cannot initialize Kubernetes connection: %s", err)
+		return 1
+	}
+	d, err := newStorageDriver(*store, clientset)
+	if err != nil {
+		logger.Errorf("%s", err)
+		return 1
+	}
+
+	n, err := d.(*encryption.Driver).ReEncrypt(newLogger("reencrypt").Infof)
+	fmt.Printf("re-encrypted: %d\n", n)
+	if err != nil {
+		logger.Errorf("Re-encryption failed: %s", err)
+		return 1
+	}
+	return 0
+}
@@ -18,6 +18,7 @@ package main // import "k8s.io/helm/cmd/tiller"
 
 import (
 	"crypto/tls"
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -36,15 +37,19 @@ import (
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog"
 
 	// Import to initialize client auth plugins.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/logging"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/rudder"
 	"k8s.io/helm/pkg/storage"
 	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/storage/encryption"
 	"k8s.io/helm/pkg/tiller"
 	"k8s.io/helm/pkg/tiller/environment"
 	"k8s.io/helm/pkg/tlsutil"
@@ -67,6 +72,7 @@ const (
 	storageConfigMap = "configmap"
 	storageSecret    = "secret"
 	storageSQL       = "sql"
+	storagePlugin    = "plugin"
 
 	traceAddr = ":44136"
 
@@ -79,20 +85,36 @@ var (
 	probeAddr     = flag.String("probe-listen", fmt.Sprintf(":%v", environment.DefaultTillerProbePort), "address:port to listen on for probes")
 	enableProbing = flag.Bool("probe", true, "enable probing over http")
 	enableTracing = flag.Bool("trace", false, "enable rpc tracing")
-	store         = flag.String("storage", storageConfigMap, "storage driver to use. One of 'configmap', 'memory', 'sql' or 'secret'")
+	traceSpans    = flag.String("trace-spans", "", "file to write the spans of release operations to as OTLP JSON lines, or '-' for stderr")
+	store         = flag.String("storage", storageConfigMap, "storage driver to use. One of 'configmap', 'memory', 'sql', 'secret' or 'plugin'")
 
 	sqlDialect          = flag.String("sql-dialect", "postgres", "SQL dialect to use (only postgres is supported for now")
 	sqlConnectionString = flag.String("sql-connection-string", "", "SQL connection string to use")
 
-	remoteReleaseModules = flag.Bool("experimental-release", false, "enable experimental release modules")
+	storagePluginAddr = flag.String("storage-plugin", "", "Unix socket of the storage plugin used by the 'plugin' driver, as unix:///path/to/socket")
+
+	storageKeyFile = flag.String("storage-encryption-keyfile", "", "path to a file with the keys used to encrypt stored releases")
 
-	tlsEnable    = flag.Bool("tls", tlsEnableEnvVarDefault(), "enable TLS")
-	tlsVerify    = flag.Bool("tls-verify", tlsVerifyEnvVarDefault(), "enable TLS and verify remote certificate")
-	keyFile      = flag.String("tls-key", tlsDefaultsFromEnv("tls-key"), "path to TLS private key file")
-	certFile     = flag.String("tls-cert", tlsDefaultsFromEnv("tls-cert"), "path to TLS certificate file")
-	caCertFile   = flag.String("tls-ca-cert", tlsDefaultsFromEnv("tls-ca-cert"), "trust certificates signed by this CA")
-	maxHistory   = flag.Int("history-max", historyMaxFromEnv(), "maximum number of releases kept in release history, with 0 meaning no limit")
-	printVersion = flag.Bool("version", false, "print the version number")
+	remoteReleaseModules = flag.Bool("experimental-release", false, "enable experimental release modules")
+	rudderKeyFile        = flag.String("rudder-tls-key", "", "path to the TLS private key file of the client certificate for Rudder. Calls to Rudder use TLS if any --rudder-tls flag is set")
+	rudderCertFile       = flag.String("rudder-tls-cert", "", "path to the TLS client certificate file for Rudder")
+	rudderCaCertFile     = flag.String("rudder-tls-ca-cert", "", "verify the certificate of Rudder with this CA. Required to call Rudder with TLS")
+	rudderServerName     = flag.String("rudder-tls-hostname", "", "the server name the certificate of Rudder is verified with")
+
+	tlsEnable  = flag.Bool("tls", tlsEnableEnvVarDefault(), "enable TLS")
+	tlsVerify  = flag.Bool("tls-verify", tlsVerifyEnvVarDefault(), "enable TLS and verify remote certificate")
+	keyFile    = flag.String("tls-key", tlsDefaultsFromEnv("tls-key"), "path to TLS private key file")
+	certFile   = flag.String("tls-cert", tlsDefaultsFromEnv("tls-cert"), "path to TLS certificate file")
+	caCertFile = flag.String("tls-ca-cert", tlsDefaultsFromEnv("tls-ca-cert"), "trust certificates signed by this CA")
+	maxHistory = flag.Int("history-max", historyMaxFromEnv(), "maximum number of releases kept in release history, with 0 meaning no limit")
+
+	recoverPending         = flag.Bool("recover-pending", true, "mark releases left pending by an interrupted operation as failed, on startup and periodically")
+	recoverPendingInterval = flag.Duration("recover-pending-interval", 5*time.Minute, "how often to look for releases left pending, with 0 meaning only on startup")
+	recoverPendingGrace    = flag.Duration("recover-pending-grace", 5*time.Minute, "how long after the timeout of their operation releases are considered left pending")
+	printVersion           = flag.Bool("version", false, "print the version number")
+
+	logFormat = flag.String("log-format", "text", "format of the log: 'text' or 'json'")
+	logLevel  = flag.String("log-level", "info", "minimum level of the entries logged: 'debug', 'info', 'warn' or 'error'")
 
 	// rootServer is the root gRPC server.
 	//
@@ -104,7 +126,9 @@ var (
 	// Any changes to env should be done before rootServer.Serve() is called.
 	env = environment.New()
 
-	logger *log.Logger
+	// rootLogger writes the log of Tiller in the configured format.
+	rootLogger logging.Logger
+	logger     logging.Logger
 )
 
 func main() {
@@ -120,8 +144,19 @@ func main() {
 	if *enableTracing {
 		log.SetFlags(log.Lshortfile)
 	}
+	if err := setupLogging(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
 	logger = newLogger("main")
 
+	switch flag.Arg(0) {
+	case migrateStorageCmd:
+		os.Exit(migrateStorage(flag.Args()[1:]))
+	case reencryptStorageCmd:
+		os.Exit(reencryptStorage(flag.Args()[1:]))
+	}
+
 	start()
 }
 
@@ -132,36 +167,16 @@ func start() {
 
 	clientset, err := kube.New(nil).KubernetesClientSet()
 	if err != nil {
-		logger.Fatalf("Cannot initialize Kubernetes connection: %s", err)
+		fatalf("Cannot initialize Kubernetes connection: %s", err)
 	}
 
-	switch *store {
-	case storageMemory:
-		env.Releases = storage.Init(driver.NewMemory())
-	case storageConfigMap:
-		cfgmaps := driver.NewConfigMaps(clientset.CoreV1().ConfigMaps(namespace()))
-		cfgmaps.Log = newLogger("storage/driver").Printf
-
-		env.Releases = storage.Init(cfgmaps)
-		env.Releases.Log = newLogger("storage").Printf
-	case storageSecret:
-		secrets := driver.NewSecrets(clientset.CoreV1().Secrets(namespace()))
-		secrets.Log = newLogger("storage/driver").Printf
-
-		env.Releases = storage.Init(secrets)
-		env.Releases.Log = newLogger("storage").Printf
-	case storageSQL:
-		sqlDriver, err := driver.NewSQL(
-			*sqlDialect,
-			*sqlConnectionString,
-			newLogger("storage/driver").Printf,
-		)
-		if err != nil {
-			logger.Fatalf("Cannot initialize SQL storage driver: %v", err)
-		}
-
-		env.Releases = storage.Init(sqlDriver)
-		env.Releases.Log = newLogger("storage").Printf
+	d, err := newStorageDriver(*store, clientset)
+	if err != nil {
+		fatalf("Cannot initialize storage driver: %v", err)
+	}
+	env.Releases = storage.Init(d)
+	if *store != storageMemory {
+		env.Releases.Log = newLogger("storage")
 	}
 
 	if *maxHistory > 0 {
@@ -169,7 +184,7 @@ func start() {
 	}
 
 	kubeClient := kube.New(nil)
-	kubeClient.Log = newLogger("kube").Printf
+	kubeClient.Log = newLogger("kube")
 	env.KubeClient = kubeClient
 
 	if *tlsEnable || *tlsVerify {
@@ -179,11 +194,19 @@ func start() {
 		}
 	}
 
+	if *remoteReleaseModules {
+		cfg, err := rudderTLSConfig()
+		if err != nil {
+			fatalf("Could not create Rudder client TLS configuration: %v", err)
+		}
+		rudder.TLSConfig = cfg
+	}
+
 	var opts []grpc.ServerOption
 	if *tlsEnable || *tlsVerify {
 		cfg, err := tlsutil.ServerConfig(tlsOptions())
 		if err != nil {
-			logger.Fatalf("Could not create server TLS configuration: %v", err)
+			fatalf("Could not create server TLS configuration: %v", err)
 		}
 		opts = append(opts, grpc.Creds(credentials.NewTLS(cfg)))
 	}
@@ -201,27 +224,36 @@ func start() {
 
 	lstn, err := net.Listen("tcp", *grpcAddr)
 	if err != nil {
-		logger.Fatalf("Server died: %s", err)
+		fatalf("Server died: %s", err)
 	}
 
-	logger.Printf("Starting Tiller %s (tls=%t)", version.GetVersion(), *tlsEnable || *tlsVerify)
-	logger.Printf("GRPC listening on %s", *grpcAddr)
+	logger.Infof("Starting Tiller %s (tls=%t)", version.GetVersion(), *tlsEnable || *tlsVerify)
+	logger.Infof("GRPC listening on %s", *grpcAddr)
 	if *enableProbing {
-		logger.Printf("Probes listening on %s", *probeAddr)
+		logger.Infof("Probes listening on %s", *probeAddr)
 	}
-	logger.Printf("Storage driver is %s", env.Releases.Name())
-	logger.Printf("Max history per release is %d", *maxHistory)
+	logger.Infof("Storage driver is %s", env.Releases.Name())
+	logger.Infof("Max history per release is %d", *maxHistory)
 
 	if *enableTracing {
 		startTracing(traceAddr)
 	}
+	if *traceSpans != "" {
+		if err := exportSpans(*traceSpans); err != nil {
+			fatalf("Cannot export spans: %s", err)
+		}
+	}
 
 	srvErrCh := make(chan error)
 	probeErrCh := make(chan error)
 	go func() {
 		svc := tiller.NewReleaseServer(env, clientset, *remoteReleaseModules)
-		svc.Log = newLogger("tiller").Printf
+		svc.Log = newLogger("tiller")
+		svc.Instance = instance()
 		services.RegisterReleaseServiceServer(rootServer, svc)
+		if *recoverPending {
+			go recoverPendingReleases(svc)
+		}
 		if err := rootServer.Serve(lstn); err != nil {
 			srvErrCh <- err
 		}
@@ -247,17 +279,129 @@ func start() {
 
 	select {
 	case err := <-srvErrCh:
-		logger.Fatalf("Server died: %s", err)
+		fatalf("Server died: %s", err)
 	case err := <-probeErrCh:
-		logger.Printf("Probes server died: %s", err)
+		logger.Errorf("Probes server died: %s", err)
+	}
+}
+
+// newStorageDriver returns the release storage driver with the given name.
+//
+// If a key file is configured, the driver is wrapped by an encryption.Driver
+// encrypting the releases it stores.
+func newStorageDriver(name string, clientset kubernetes.Interface) (driver.Driver, error) {
+	d, err := newPlainStorageDriver(name, clientset)
+	if err != nil || *storageKeyFile == "" {
+		return d, err
+	}
+	provider, err := encryption.LoadKeyFile(*storageKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	enc := encryption.NewDriver(d, encryption.New(provider))
+	enc.Log = newLogger("storage/encryption")
+	return enc, nil
+}
+
+// newPlainStorageDriver returns the release storage driver with the given
+// name, without encryption.
+func newPlainStorageDriver(name string, clientset kubernetes.Interface) (driver.Driver, error) {
+	switch name {
+	case storageMemory:
+		return driver.NewMemory(), nil
+	case storageConfigMap:
+		cfgmaps := driver.NewConfigMaps(clientset.CoreV1().ConfigMaps(namespace()))
+		cfgmaps.Log = newLogger("storage/driver")
+		return cfgmaps, nil
+	case storageSecret:
+		secrets := driver.NewSecrets(clientset.CoreV1().Secrets(namespace()))
+		secrets.Log = newLogger("storage/driver")
+		return secrets, nil
+	case storageSQL:
+		sqlDriver, err := driver.NewSQL(
+			*sqlDialect,
+			*sqlConnectionString,
+			newLogger("storage/driver"),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("cannot initialize SQL storage driver: %v", err)
+		}
+		return sqlDriver, nil
+	case storagePlugin:
+		if *storagePluginAddr == "" {
+			return nil, fmt.Errorf("--storage-plugin is required by the %s storage driver", name)
+		}
+		plugin, err := driver.DialPlugin(*storagePluginAddr, driver.DefaultPluginTimeout)
+		if err != nil {
+			return nil, err
+		}
+		plugin.Log = newLogger("storage/driver")
+		return plugin, nil
+	}
+	return nil, fmt.Errorf("unknown storage driver %q", name)
+}
+
+// recoverPendingReleases marks releases left pending by interrupted
+// operations as failed, on startup and then every recover-pending-interval.
+func recoverPendingReleases(svc *tiller.ReleaseServer) {
+	l := newLogger("recover")
+	for {
+		rels, err := svc.RecoverPendingReleases(*recoverPendingGrace)
+		if err != nil {
+			l.Warnf("cannot recover pending releases: %s", err)
+		}
+		for _, rel := range rels {
+			l.Infof("marked %s (v%d) failed: %s", rel.Name, rel.Version, rel.Info.Description)
+		}
+		if *recoverPendingInterval <= 0 {
+			return
+		}
+		time.Sleep(*recoverPendingInterval)
+	}
+}
+
+// instance returns the name identifying this Tiller, which is the name of
+// its pod when running in a cluster.
+func instance() string {
+	if name := os.Getenv("POD_NAME"); name != "" {
+		return name
+	}
+	name, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// setupLogging creates the root logger from the log flags.
+func setupLogging() error {
+	format, err := logging.ParseFormat(*logFormat)
+	if err != nil {
+		return err
 	}
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		return err
+	}
+	rootLogger = logging.New(os.Stderr, format, level)
+	return nil
 }
 
-func newLogger(prefix string) *log.Logger {
-	if len(prefix) > 0 {
-		prefix = fmt.Sprintf("[%s] ", prefix)
+// newLogger returns the logger of a component of Tiller.
+func newLogger(component string) logging.Logger {
+	if rootLogger == nil {
+		rootLogger = logging.New(os.Stderr, logging.TextFormat, logging.InfoLevel)
+	}
+	if component == "" {
+		return rootLogger
 	}
-	return log.New(os.Stderr, prefix, log.Flags())
+	return rootLogger.With(logging.Component(component))
+}
+
+// fatalf logs an error and exits.
+func fatalf(format string, args ...interface{}) {
+	logger.Errorf(format, args...)
+	os.Exit(1)
 }
 
 // namespace returns the namespace of tiller
@@ -289,6 +433,26 @@ func tlsOptions() tlsutil.Options {
 	return opts
 }
 
+// rudderTLSConfig returns the TLS configuration of calls to Rudder, or nil if
+// no --rudder-tls flag is set. The certificate of Rudder is always verified.
+func rudderTLSConfig() (*tls.Config, error) {
+	if *rudderKeyFile == "" && *rudderCertFile == "" && *rudderCaCertFile == "" && *rudderServerName == "" {
+		return nil, nil
+	}
+	if *rudderKeyFile == "" || *rudderCertFile == "" {
+		return nil, errors.New("--rudder-tls-key and --rudder-tls-cert are required to call Rudder with TLS")
+	}
+	if *rudderCaCertFile == "" {
+		return nil, errors.New("--rudder-tls-ca-cert is required to verify the certificate of Rudder")
+	}
+	return tlsutil.ClientConfig(tlsutil.Options{
+		KeyFile:    *rudderKeyFile,
+		CertFile:   *rudderCertFile,
+		CaCertFile: *rudderCaCertFile,
+		ServerName: *rudderServerName,
+	})
+}
+
 func tlsDefaultsFromEnv(name string) (value string) {
 	switch certsDir := os.Getenv(tlsCertsEnvVar); name {
 	case "tls-key":
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/engine"
@@ -45,3 +46,42 @@ func TestInit(t *testing.T) {
 		t.Fatalf("Template engine GoTplEngine returned nil.")
 	}
 }
+
+func TestRudderTLSConfig(t *testing.T) {
+	defer func(key, cert, ca, name string) {
+		*rudderKeyFile, *rudderCertFile, *rudderCaCertFile, *rudderServerName = key, cert, ca, name
+	}(*rudderKeyFile, *rudderCertFile, *rudderCaCertFile, *rudderServerName)
+
+	for _, tt := range []struct {
+		key, cert, ca, name string
+		err                 string
+	}{
+		{"", "", "", "", ""},
+		{"../../testdata/key.pem", "../../testdata/crt.pem", "../../testdata/ca.pem", "127.0.0.1", ""},
+		{"../../testdata/key.pem", "../../testdata/crt.pem", "", "", "--rudder-tls-ca-cert is required"},
+		{"", "", "", "127.0.0.1", "--rudder-tls-key and --rudder-tls-cert are required"},
+		{"", "", "../../testdata/ca.pem", "", "--rudder-tls-key and --rudder-tls-cert are required"},
+	} {
+		*rudderKeyFile, *rudderCertFile, *rudderCaCertFile, *rudderServerName = tt.key, tt.cert, tt.ca, tt.name
+		cfg, err := rudderTLSConfig()
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%+v: expected error %q, got %v", tt, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%+v: %s", tt, err)
+			continue
+		}
+		if tt.key == "" {
+			if cfg != nil {
+				t.Errorf("expected no TLS without flags, got %v", cfg)
+			}
+			continue
+		}
+		if cfg.InsecureSkipVerify || cfg.RootCAs == nil || cfg.ServerName != tt.name {
+			t.Errorf("expected the certificate of Rudder to be verified, got %+v", cfg)
+		}
+	}
+}
@@ -17,15 +17,19 @@ limitations under the License.
 package main // import "k8s.io/helm/cmd/tiller"
 
 import (
+	"io"
 	"net/http"
+	"os"
 
 	_ "net/http/pprof"
 
 	"google.golang.org/grpc"
+
+	"k8s.io/helm/pkg/tracing"
 )
 
 func startTracing(addr string) {
-	logger.Printf("Tracing server is listening on %s\n", addr)
+	logger.Infof("Tracing server is listening on %s", addr)
 	grpc.EnableTracing = true
 
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -39,11 +43,27 @@ func startTracing(addr string) {
 
 	go func() {
 		if err := http.ListenAndServe(addr, nil); err != nil {
-			logger.Printf("tracing error: %s", err)
+			logger.Errorf("tracing error: %s", err)
 		}
 	}()
 }
 
+// exportSpans writes the spans of release operations as OTLP JSON lines to path,
+// or to stderr if path is "-".
+func exportSpans(path string) error {
+	var out io.Writer = os.Stderr
+	if path != "-" {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return err
+		}
+		out = f
+	}
+	tracing.SetExporter(tracing.NewJSONExporter(out, "tiller"))
+	logger.Infof("Exporting spans to %s", path)
+	return nil
+}
+
 const traceIndexHTML = `<!DOCTYPE html>
 <html>
   <body>
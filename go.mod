@@ -3,6 +3,7 @@ module k8s.io/helm
 go 1.14
 
 require (
+	filippo.io/age v1.0.0
 	github.com/BurntSushi/toml v0.3.1
 	github.com/DATA-DOG/go-sqlmock v1.4.1
 	github.com/Masterminds/goutils v1.1.0 // indirect
@@ -31,6 +32,7 @@ require (
 	github.com/mitchellh/copystructure v1.0.0 // indirect
 	github.com/mitchellh/reflectwalk v1.0.1 // indirect
 	github.com/pkg/errors v0.8.2-0.20190227000051-27936f6d90f9
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/prometheus/client_golang v1.0.0
 	github.com/rubenv/sql-migrate v0.0.0-20191025130928-9355dd04f4b3
 	github.com/spf13/cobra v0.0.5
@@ -38,9 +40,11 @@ require (
 	github.com/stretchr/testify v1.4.0
 	github.com/technosophos/moniker v0.0.0-20180509230615-a5dbd03a2245
 	github.com/ziutek/mymysql v1.5.4 // indirect
-	golang.org/x/crypto v0.0.0-20200220183623-bac4c82f6975
-	golang.org/x/net v0.0.0-20191004110552-13f9640d40b9
-	golang.org/x/sync v0.0.0-20190911185100-cd5d95a43a6e
+	golang.org/x/crypto v0.14.0
+	golang.org/x/net v0.17.0
+	golang.org/x/sync v0.2.0
+	golang.org/x/sys v0.14.0
+	golang.org/x/term v0.14.0 // indirect
 	google.golang.org/genproto v0.0.0-20191028173616-919d9bdd9fe6 // indirect
 	google.golang.org/grpc v1.26.0
 	gopkg.in/gorp.v1 v1.7.2 // indirect
@@ -18,9 +18,13 @@ package chartutil
 
 import (
 	"fmt"
+	"io/ioutil"
 	"runtime"
+	"sort"
 
+	"github.com/ghodss/yaml"
 	"k8s.io/apimachinery/pkg/version"
+
 	tversion "k8s.io/helm/pkg/proto/hapi/version"
 )
 
@@ -71,3 +75,66 @@ func (v VersionSet) Has(apiVersion string) bool {
 	_, ok := v[apiVersion]
 	return ok
 }
+
+// capabilitiesFile is the serialized form of Capabilities.
+type capabilitiesFile struct {
+	KubeVersion   *version.Info     `json:"kubeVersion,omitempty"`
+	TillerVersion *tversion.Version `json:"tillerVersion,omitempty"`
+	APIVersions   []string          `json:"apiVersions,omitempty"`
+}
+
+// YAML encodes the capabilities into a YAML string, with the API versions
+// sorted.
+func (c *Capabilities) YAML() (string, error) {
+	f := capabilitiesFile{
+		KubeVersion:   c.KubeVersion,
+		TillerVersion: c.TillerVersion,
+	}
+	for v := range c.APIVersions {
+		f.APIVersions = append(f.APIVersions, v)
+	}
+	sort.Strings(f.APIVersions)
+
+	b, err := yaml.Marshal(f)
+	return string(b), err
+}
+
+// ReadCapabilities parses capabilities encoded by Capabilities.YAML.
+//
+// Fields missing from data are set to their defaults: DefaultVersionSet,
+// DefaultKubeVersion and the given Tiller version.
+func ReadCapabilities(data []byte, tillerVersion *tversion.Version) (*Capabilities, error) {
+	var f capabilitiesFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	caps := &Capabilities{
+		APIVersions:   DefaultVersionSet,
+		KubeVersion:   DefaultKubeVersion,
+		TillerVersion: tillerVersion,
+	}
+	if len(f.APIVersions) > 0 {
+		caps.APIVersions = NewVersionSet(f.APIVersions...)
+	}
+	if f.KubeVersion != nil {
+		caps.KubeVersion = f.KubeVersion
+	}
+	if f.TillerVersion != nil {
+		caps.TillerVersion = f.TillerVersion
+	}
+	return caps, nil
+}
+
+// ReadCapabilitiesFile parses a file written from Capabilities.YAML, such as
+// the output of 'helm capabilities dump'.
+func ReadCapabilitiesFile(filename string, tillerVersion *tversion.Version) (*Capabilities, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	caps, err := ReadCapabilities(data, tillerVersion)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse capabilities file %s: %s", filename, err)
+	}
+	return caps, nil
+}
@@ -16,7 +16,13 @@ limitations under the License.
 package chartutil
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+
+	"k8s.io/apimachinery/pkg/version"
+
+	tversion "k8s.io/helm/pkg/proto/hapi/version"
 )
 
 func TestVersionSet(t *testing.T) {
@@ -53,3 +59,47 @@ func TestCapabilities(t *testing.T) {
 		t.Error("APIVersions should have apps/v1/Deployment")
 	}
 }
+
+func TestCapabilitiesYAML(t *testing.T) {
+	caps := &Capabilities{
+		APIVersions:   NewVersionSet("v1", "apps/v1", "apps/v1/Deployment", "example.com/v1alpha1"),
+		KubeVersion:   &version.Info{Major: "1", Minor: "16", GitVersion: "v1.16.2"},
+		TillerVersion: &tversion.Version{SemVer: "v2.16.1"},
+	}
+	out, err := caps.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "- apps/v1\n- apps/v1/Deployment\n- example.com/v1alpha1\n- v1\n") {
+		t.Errorf("expected sorted api versions, got:\n%s", out)
+	}
+
+	got, err := ReadCapabilities([]byte(out), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, caps) {
+		t.Errorf("expected %v, got %v", caps, got)
+	}
+}
+
+func TestReadCapabilitiesDefaults(t *testing.T) {
+	tv := &tversion.Version{SemVer: "v2.16.1"}
+	caps, err := ReadCapabilities([]byte("apiVersions: [v1, batch/v1]\n"), tv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caps.APIVersions.Has("apps/v1") || !caps.APIVersions.Has("batch/v1") {
+		t.Errorf("unexpected api versions %v", caps.APIVersions)
+	}
+	if caps.KubeVersion != DefaultKubeVersion {
+		t.Errorf("expected the default kube version, got %v", caps.KubeVersion)
+	}
+	if caps.TillerVersion != tv {
+		t.Errorf("expected tiller version %v, got %v", tv, caps.TillerVersion)
+	}
+
+	if _, err := ReadCapabilities([]byte("apiVersions: v1: nope"), tv); err == nil {
+		t.Error("expected invalid YAML to fail")
+	}
+}
@@ -33,6 +33,22 @@ import (
 // This is ApiVersionV1 instead of APIVersionV1 to match the protobuf-generated name.
 const ApiVersionV1 = "v1" // nolint
 
+const (
+	// TypeApplication is the type of charts that can be installed.
+	TypeApplication = "application"
+	// TypeLibrary is the type of charts that only provide named templates to
+	// the charts depending on them, and cannot be installed.
+	TypeLibrary = "library"
+)
+
+// ErrLibraryChart is returned when installing or rendering a library chart.
+var ErrLibraryChart = errors.New("library charts are not installable")
+
+// IsLibraryChart reports whether c is a library chart.
+func IsLibraryChart(c *chart.Chart) bool {
+	return c.GetMetadata().GetType() == TypeLibrary
+}
+
 // UnmarshalChartfile takes raw Chart.yaml data and unmarshals it.
 func UnmarshalChartfile(data []byte) (*chart.Metadata, error) {
 	y := &chart.Metadata{}
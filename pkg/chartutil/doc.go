@@ -38,6 +38,11 @@ For accepting raw compressed tar file data from an io.Reader, the
 'chartutil.LoadArchive()' will read in the data, uncompress it, and unpack it
 into a Chart.
 
+The loaders bound the number and the decompressed size of the files of a
+chart by DefaultLimits, and reject archives whose files are outside of the
+chart. Use the variants ending in WithLimits, like 'chartutil.LoadWithLimits()',
+to load charts from untrusted sources with stricter limits.
+
 When creating charts in memory, use the 'k8s.io/helm/pkg/proto/hapi/chart'
 package directly.
 */
@@ -28,7 +28,13 @@ import (
 
 // Expand uncompresses and extracts a chart into the specified directory.
 func Expand(dir string, r io.Reader) error {
-	files, err := loadArchiveFiles(r)
+	return ExpandWithLimits(dir, r, DefaultLimits)
+}
+
+// ExpandWithLimits is Expand with the given limits instead of DefaultLimits.
+// Nothing is written if the chart exceeds the limits.
+func ExpandWithLimits(dir string, r io.Reader, limits Limits) error {
+	files, err := loadArchiveFiles(r, newLoadState(limits))
 	if err != nil {
 		return err
 	}
@@ -132,3 +132,25 @@ func TestExpandFile(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandWithLimits(t *testing.T) {
+	dest, err := ioutil.TempDir("", "helm-testing-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dest)
+
+	reader, err := os.Open("testdata/frobnitz-1.2.3.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	err = ExpandWithLimits(dest, reader, Limits{MaxFileSize: 1000})
+	if _, ok := err.(*LimitError); !ok {
+		t.Fatalf("expected a limit error, got %v", err)
+	}
+	if fis, _ := ioutil.ReadDir(dest); len(fis) != 0 {
+		t.Errorf("expected nothing to be expanded, got %d files", len(fis))
+	}
+}
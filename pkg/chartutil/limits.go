@@ -0,0 +1,128 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import "fmt"
+
+// Limits bound the files read when a chart is loaded or expanded. The files
+// of the archives of dependencies count towards the limits of the chart that
+// holds them. A zero field is not limited.
+type Limits struct {
+	// MaxFiles is the maximum number of files of a chart.
+	MaxFiles int
+	// MaxFileSize is the maximum size in bytes of a single file, decompressed.
+	MaxFileSize int64
+	// MaxTotalSize is the maximum size in bytes of all files of a chart,
+	// decompressed.
+	MaxTotalSize int64
+}
+
+// DefaultLimits are the limits of Load, LoadDir, LoadArchive, LoadFiles and
+// Expand. Use the variants ending in WithLimits to load charts with others.
+var DefaultLimits = Limits{
+	MaxFiles:     10000,
+	MaxFileSize:  5 << 20,
+	MaxTotalSize: 100 << 20,
+}
+
+// The limits named by a LimitError.
+const (
+	LimitFiles     = "MaxFiles"
+	LimitFileSize  = "MaxFileSize"
+	LimitTotalSize = "MaxTotalSize"
+)
+
+// LimitError is returned when a chart exceeds its Limits.
+type LimitError struct {
+	// Name is the file at which the limit was exceeded.
+	Name string
+	// Limit is the exceeded field of the Limits, e.g. LimitFileSize.
+	Limit string
+	// Max is the value of the limit.
+	Max int64
+}
+
+func (e *LimitError) Error() string {
+	switch e.Limit {
+	case LimitFiles:
+		return fmt.Sprintf("chart has more than %d files", e.Max)
+	case LimitFileSize:
+		return fmt.Sprintf("chart file %q is larger than %d bytes", e.Name, e.Max)
+	}
+	return fmt.Sprintf("chart is larger than %d bytes at file %q", e.Max, e.Name)
+}
+
+// IllegalPathError is returned when a chart archive holds a file whose name
+// is absolute or outside of the chart.
+type IllegalPathError struct {
+	// Name is the name of the file in the archive.
+	Name   string
+	Reason string
+}
+
+func (e *IllegalPathError) Error() string {
+	return fmt.Sprintf("%s: %q", e.Reason, e.Name)
+}
+
+// LinkError is returned when a chart archive holds a link to a file outside
+// of the chart.
+type LinkError struct {
+	// Name is the name of the link in the archive.
+	Name   string
+	Target string
+}
+
+func (e *LinkError) Error() string {
+	return fmt.Sprintf("chart illegally links %q to %q outside the base directory", e.Name, e.Target)
+}
+
+// DuplicateFileError is returned when a chart archive holds a file more than
+// once.
+type DuplicateFileError struct {
+	// Name is the path of the file in the chart.
+	Name string
+}
+
+func (e *DuplicateFileError) Error() string {
+	return fmt.Sprintf("chart contains %q more than once", e.Name)
+}
+
+// loadState counts the files read while loading a chart against its limits.
+type loadState struct {
+	limits Limits
+	files  int
+	size   int64
+}
+
+func newLoadState(limits Limits) *loadState {
+	return &loadState{limits: limits}
+}
+
+// add counts a file of size bytes named name.
+func (s *loadState) add(name string, size int64) error {
+	s.files++
+	s.size += size
+	switch {
+	case s.limits.MaxFiles > 0 && s.files > s.limits.MaxFiles:
+		return &LimitError{Name: name, Limit: LimitFiles, Max: int64(s.limits.MaxFiles)}
+	case s.limits.MaxFileSize > 0 && size > s.limits.MaxFileSize:
+		return &LimitError{Name: name, Limit: LimitFileSize, Max: s.limits.MaxFileSize}
+	case s.limits.MaxTotalSize > 0 && s.size > s.limits.MaxTotalSize:
+		return &LimitError{Name: name, Limit: LimitTotalSize, Max: s.limits.MaxTotalSize}
+	}
+	return nil
+}
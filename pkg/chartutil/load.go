@@ -29,6 +29,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"k8s.io/helm/pkg/ignore"
@@ -44,6 +45,11 @@ import (
 // If a .helmignore file is present, the directory loader will skip loading any files
 // matching it. But .helmignore is not evaluated when reading out of an archive.
 func Load(name string) (*chart.Chart, error) {
+	return LoadWithLimits(name, DefaultLimits)
+}
+
+// LoadWithLimits is Load with the given limits instead of DefaultLimits.
+func LoadWithLimits(name string, limits Limits) (*chart.Chart, error) {
 	name = filepath.FromSlash(name)
 	fi, err := os.Stat(name)
 	if err != nil {
@@ -53,9 +59,9 @@ func Load(name string) (*chart.Chart, error) {
 		if validChart, err := IsChartDir(name); !validChart {
 			return nil, err
 		}
-		return LoadDir(name)
+		return LoadDirWithLimits(name, limits)
 	}
-	return LoadFile(name)
+	return loadFile(name, limits)
 }
 
 // BufferedFile represents an archive file buffered for later processing.
@@ -66,8 +72,10 @@ type BufferedFile struct {
 
 var drivePathPattern = regexp.MustCompile(`^[a-zA-Z]:/`)
 
-// loadArchiveFiles loads files out of an archive
-func loadArchiveFiles(in io.Reader) ([]*BufferedFile, error) {
+// loadArchiveFiles loads files out of an archive, counting them against the
+// limits of state. Links to files of the chart are loaded as copies of the
+// files.
+func loadArchiveFiles(in io.Reader, state *loadState) ([]*BufferedFile, error) {
 	unzipped, err := gzip.NewReader(in)
 	if err != nil {
 		return nil, err
@@ -75,6 +83,9 @@ func loadArchiveFiles(in io.Reader) ([]*BufferedFile, error) {
 	defer unzipped.Close()
 
 	files := []*BufferedFile{}
+	data := map[string][]byte{}
+	links := map[string]string{}
+	var linkNames []string
 	tr := tar.NewReader(unzipped)
 	for {
 		b := bytes.NewBuffer(nil)
@@ -98,49 +109,59 @@ func loadArchiveFiles(in io.Reader) ([]*BufferedFile, error) {
 			continue
 		}
 
-		// Archive could contain \ if generated on Windows
-		delimiter := "/"
-		if strings.ContainsRune(hd.Name, '\\') {
-			delimiter = "\\"
-		}
-
-		parts := strings.Split(hd.Name, delimiter)
-		n := strings.Join(parts[1:], delimiter)
-
-		// Normalize the path to the / delimiter
-		n = strings.Replace(n, delimiter, "/", -1)
-
-		if path.IsAbs(n) {
-			return nil, errors.New("chart illegally contains absolute paths")
+		n, err := archivePath(hd.Name)
+		if err != nil {
+			return nil, err
 		}
-
-		n = path.Clean(n)
-		if n == "." {
-			// In this case, the original path was relative when it should have been absolute.
-			return nil, fmt.Errorf("chart illegally contains content outside the base directory: %q", hd.Name)
+		if _, ok := data[n]; ok {
+			return nil, &DuplicateFileError{Name: n}
 		}
-		if strings.HasPrefix(n, "..") {
-			return nil, errors.New("chart illegally references parent directory")
+		if _, ok := links[n]; ok {
+			return nil, &DuplicateFileError{Name: n}
 		}
 
-		// In some particularly arcane acts of path creativity, it is possible to intermix
-		// UNIX and Windows style paths in such a way that you produce a result of the form
-		// c:/foo even after all the built-in absolute path checks. So we explicitly check
-		// for this condition.
-		if drivePathPattern.MatchString(n) {
-			return nil, errors.New("chart contains illegally named files")
+		switch hd.Typeflag {
+		case tar.TypeSymlink, tar.TypeLink:
+			target, err := linkTarget(hd, n)
+			if err != nil {
+				return nil, err
+			}
+			links[n] = target
+			linkNames = append(linkNames, n)
+			continue
 		}
 
-		if parts[0] == "Chart.yaml" {
-			return nil, errors.New("chart yaml not in base directory")
+		// The size in the header is checked before the file is read, the
+		// tar reader does not return more.
+		if err := state.add(n, hd.Size); err != nil {
+			return nil, err
 		}
-
 		if _, err := io.Copy(b, tr); err != nil {
 			return files, err
 		}
 
 		files = append(files, &BufferedFile{Name: n, Data: b.Bytes()})
-		b.Reset()
+		data[n] = b.Bytes()
+	}
+
+	for _, n := range linkNames {
+		target := links[n]
+		// Follow links to links, as long as they do not loop.
+		for i := 0; i < len(links); i++ {
+			next, ok := links[target]
+			if !ok {
+				break
+			}
+			target = next
+		}
+		d, ok := data[target]
+		if !ok {
+			return nil, fmt.Errorf("chart link %q to %q does not name a file of the chart", n, links[n])
+		}
+		if err := state.add(n, int64(len(d))); err != nil {
+			return nil, err
+		}
+		files = append(files, &BufferedFile{Name: n, Data: d})
 	}
 
 	if len(files) == 0 {
@@ -149,17 +170,96 @@ func loadArchiveFiles(in io.Reader) ([]*BufferedFile, error) {
 	return files, nil
 }
 
+// archivePath returns the path in the chart of the archive entry name, which
+// is within the base directory of the chart.
+func archivePath(name string) (string, error) {
+	// Archive could contain \ if generated on Windows
+	delimiter := "/"
+	if strings.ContainsRune(name, '\\') {
+		delimiter = "\\"
+	}
+
+	// Normalize the path to the / delimiter
+	if full := strings.Replace(name, delimiter, "/", -1); path.IsAbs(full) || drivePathPattern.MatchString(full) {
+		return "", &IllegalPathError{Name: name, Reason: "chart illegally contains absolute paths"}
+	}
+
+	parts := strings.Split(name, delimiter)
+	n := strings.Join(parts[1:], delimiter)
+	n = strings.Replace(n, delimiter, "/", -1)
+
+	n = path.Clean(n)
+	if n == "." {
+		// In this case, the original path was relative when it should have been absolute.
+		return "", &IllegalPathError{Name: name, Reason: "chart illegally contains content outside the base directory"}
+	}
+	if n == ".." || strings.HasPrefix(n, "../") {
+		return "", &IllegalPathError{Name: name, Reason: "chart illegally references parent directory"}
+	}
+
+	// In some particularly arcane acts of path creativity, it is possible to intermix
+	// UNIX and Windows style paths in such a way that you produce a result of the form
+	// c:/foo even after all the built-in absolute path checks. So we explicitly check
+	// for this condition.
+	if drivePathPattern.MatchString(n) {
+		return "", &IllegalPathError{Name: name, Reason: "chart contains illegally named files"}
+	}
+
+	if parts[0] == "Chart.yaml" {
+		return "", errors.New("chart yaml not in base directory")
+	}
+	return n, nil
+}
+
+// linkTarget returns the path in the chart of the file that the link hd, at
+// path n of the chart, points to.
+func linkTarget(hd *tar.Header, n string) (string, error) {
+	if hd.Typeflag == tar.TypeLink {
+		// Hard links name the target like any other entry of the archive.
+		target, err := archivePath(hd.Linkname)
+		if err != nil {
+			return "", &LinkError{Name: hd.Name, Target: hd.Linkname}
+		}
+		return target, nil
+	}
+
+	target := strings.Replace(hd.Linkname, "\\", "/", -1)
+	if path.IsAbs(target) || drivePathPattern.MatchString(target) {
+		return "", &LinkError{Name: hd.Name, Target: hd.Linkname}
+	}
+	target = path.Join(path.Dir(n), target)
+	if target == ".." || strings.HasPrefix(target, "../") {
+		return "", &LinkError{Name: hd.Name, Target: hd.Linkname}
+	}
+	return target, nil
+}
+
 // LoadArchive loads from a reader containing a compressed tar archive.
 func LoadArchive(in io.Reader) (*chart.Chart, error) {
-	files, err := loadArchiveFiles(in)
+	return LoadArchiveWithLimits(in, DefaultLimits)
+}
+
+// LoadArchiveWithLimits is LoadArchive with the given limits instead of
+// DefaultLimits.
+func LoadArchiveWithLimits(in io.Reader, limits Limits) (*chart.Chart, error) {
+	return loadArchive(in, newLoadState(limits))
+}
+
+func loadArchive(in io.Reader, state *loadState) (*chart.Chart, error) {
+	files, err := loadArchiveFiles(in, state)
 	if err != nil {
 		return nil, err
 	}
-	return LoadFiles(files)
+	return loadFiles(files, state)
 }
 
-// LoadFiles loads from in-memory files.
+// LoadFiles loads from in-memory files. The archives of dependencies are
+// loaded with DefaultLimits.
 func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
+	return loadFiles(files, newLoadState(DefaultLimits))
+}
+
+func loadFiles(files []*BufferedFile, state *loadState) (*chart.Chart, error) {
 	c := &chart.Chart{}
 	subcharts := map[string][]*BufferedFile{}
 
@@ -218,7 +318,7 @@ func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 			}
 			// Untar the chart and add to c.Dependencies
 			b := bytes.NewBuffer(file.Data)
-			sc, err = LoadArchive(b)
+			sc, err = loadArchive(b, state)
 		} else {
 			// We have to trim the prefix off of every file, and ignore any file
 			// that is in charts/, but isn't actually a chart.
@@ -231,11 +331,11 @@ func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 				f.Name = parts[1]
 				buff = append(buff, f)
 			}
-			sc, err = LoadFiles(buff)
+			sc, err = loadFiles(buff, state)
 		}
 
 		if err != nil {
-			return c, fmt.Errorf("error unpacking %s in %s: %s", n, c.Metadata.Name, err)
+			return c, fmt.Errorf("error unpacking %s in %s: %w", n, c.Metadata.Name, err)
 		}
 
 		c.Dependencies = append(c.Dependencies, sc)
@@ -246,6 +346,10 @@ func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 
 // LoadFile loads from an archive file.
 func LoadFile(name string) (*chart.Chart, error) {
+	return loadFile(name, DefaultLimits)
+}
+
+func loadFile(name string, limits Limits) (*chart.Chart, error) {
 	if fi, err := os.Stat(name); err != nil {
 		return nil, err
 	} else if fi.IsDir() {
@@ -263,7 +367,7 @@ func LoadFile(name string) (*chart.Chart, error) {
 		return nil, err
 	}
 
-	c, err := LoadArchive(raw)
+	c, err := LoadArchiveWithLimits(raw, limits)
 	if err != nil {
 		if err == gzip.ErrHeader {
 			return nil, fmt.Errorf("file '%s' does not appear to be a valid chart file (details: %s)", name, err)
@@ -303,6 +407,11 @@ func ensureArchive(name string, raw *os.File) error {
 //
 // This loads charts only from directories.
 func LoadDir(dir string) (*chart.Chart, error) {
+	return LoadDirWithLimits(dir, DefaultLimits)
+}
+
+// LoadDirWithLimits is LoadDir with the given limits instead of DefaultLimits.
+func LoadDirWithLimits(dir string, limits Limits) (*chart.Chart, error) {
 	topdir, err := filepath.Abs(dir)
 	if err != nil {
 		return nil, err
@@ -311,18 +420,42 @@ func LoadDir(dir string) (*chart.Chart, error) {
 	// Just used for errors.
 	c := &chart.Chart{}
 
+	state := newLoadState(limits)
+	files := []*BufferedFile{}
+	err = walkDir(topdir, func(n, name string, fi os.FileInfo) error {
+		if err := state.add(n, fi.Size()); err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %s", n, err)
+		}
+
+		files = append(files, &BufferedFile{Name: n, Data: data})
+		return nil
+	})
+	if err != nil {
+		return c, err
+	}
+
+	return loadFiles(files, state)
+}
+
+// walkDir calls fn with the path n in the chart, the path name on disk and
+// the file info of every file of the chart in topdir that is not ignored by
+// its .helmignore file.
+func walkDir(topdir string, fn func(n, name string, fi os.FileInfo) error) error {
 	rules := ignore.Empty()
 	ifile := filepath.Join(topdir, ignore.HelmIgnore)
 	if _, err := os.Stat(ifile); err == nil {
 		r, err := ignore.ParseFile(ifile)
 		if err != nil {
-			return c, err
+			return err
 		}
 		rules = r
 	}
 	rules.AddDefaults()
 
-	files := []*BufferedFile{}
 	topdir += string(filepath.Separator)
 
 	walk := func(name string, fi os.FileInfo, err error) error {
@@ -360,17 +493,97 @@ func LoadDir(dir string) (*chart.Chart, error) {
 			return fmt.Errorf("cannot load irregular file %s as it has file mode type bits set", name)
 		}
 
-		data, err := ioutil.ReadFile(name)
+		return fn(n, name, fi)
+	}
+	return sympath.Walk(topdir, walk)
+}
+
+// FileSize is the decompressed size of a file of a chart.
+type FileSize struct {
+	// Name is the path of the file in the chart. The files of the archives
+	// of dependencies are named after the archive, e.g.
+	// "charts/mariadb-0.6.0.tgz/templates/secrets.yaml".
+	Name string
+	Size int64
+}
+
+// DirFileSizes returns the sizes of the files that LoadDir loads from dir,
+// and of the files in the archives of its dependencies, largest first. The
+// archives are read without keeping their files in memory, and without
+// limits.
+func DirFileSizes(dir string) ([]FileSize, error) {
+	topdir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var sizes []FileSize
+	err = walkDir(topdir, func(n, name string, fi os.FileInfo) error {
+		sizes = append(sizes, FileSize{Name: n, Size: fi.Size()})
+		if !isChartArchive(n) {
+			return nil
+		}
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		s, err := archiveFileSizes(f, n+"/")
 		if err != nil {
 			return fmt.Errorf("error reading %s: %s", n, err)
 		}
-
-		files = append(files, &BufferedFile{Name: n, Data: data})
+		sizes = append(sizes, s...)
 		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	if err = sympath.Walk(topdir, walk); err != nil {
-		return c, err
+
+	sort.SliceStable(sizes, func(i, j int) bool { return sizes[i].Size > sizes[j].Size })
+	return sizes, nil
+}
+
+// archiveFileSizes returns the sizes of the files of the chart archive read
+// from in, with their paths prefixed by prefix.
+func archiveFileSizes(in io.Reader, prefix string) ([]FileSize, error) {
+	unzipped, err := gzip.NewReader(in)
+	if err != nil {
+		return nil, err
+	}
+	defer unzipped.Close()
+
+	var sizes []FileSize
+	tr := tar.NewReader(unzipped)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hd.FileInfo().IsDir() || hd.Typeflag == tar.TypeXGlobalHeader || hd.Typeflag == tar.TypeXHeader {
+			continue
+		}
+
+		n, err := archivePath(hd.Name)
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, FileSize{Name: prefix + n, Size: hd.Size})
+		if isChartArchive(n) {
+			s, err := archiveFileSizes(tr, prefix+n+"/")
+			if err != nil {
+				return nil, err
+			}
+			sizes = append(sizes, s...)
+		}
 	}
+	return sizes, nil
+}
 
-	return LoadFiles(files)
+// isChartArchive returns whether the file at path n of a chart is the archive
+// of a dependency.
+func isChartArchive(n string) bool {
+	return path.Base(path.Dir(n)) == "charts" && path.Ext(n) == ".tgz" && strings.IndexAny(path.Base(n), "._") != 0
 }
@@ -18,7 +18,9 @@ package chartutil
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
+	"errors"
 	"io/ioutil"
 	"os"
 	"path"
@@ -133,24 +135,26 @@ func TestLoadArchive_InvalidArchive(t *testing.T) {
 		internal    string
 		expectError string
 	}{
-		{"illegal-dots.tgz", "../../malformed-helm-test", "chart illegally references parent directory"},
-		{"illegal-dots2.tgz", "/foo/../../malformed-helm-test", "chart illegally references parent directory"},
-		{"illegal-dots3.tgz", "/../../malformed-helm-test", "chart illegally references parent directory"},
-		{"illegal-dots4.tgz", "./../../malformed-helm-test", "chart illegally references parent directory"},
+		{"illegal-dots.tgz", "../../malformed-helm-test", "chart illegally references parent directory: \"../../malformed-helm-test\""},
+		{"illegal-dots2.tgz", "/foo/../../malformed-helm-test", "chart illegally contains absolute paths: \"/foo/../../malformed-helm-test\""},
+		{"illegal-dots3.tgz", "/../../malformed-helm-test", "chart illegally contains absolute paths: \"/../../malformed-helm-test\""},
+		{"illegal-dots4.tgz", "./../../malformed-helm-test", "chart illegally references parent directory: \"./../../malformed-helm-test\""},
 		{"illegal-name.tgz", "./.", "chart illegally contains content outside the base directory: \"./.\""},
-		{"illegal-name2.tgz", "/./.", "chart illegally contains content outside the base directory: \"/./.\""},
+		{"illegal-name2.tgz", "/./.", "chart illegally contains absolute paths: \"/./.\""},
 		{"illegal-name3.tgz", "missing-leading-slash", "chart illegally contains content outside the base directory: \"missing-leading-slash\""},
 		{"illegal-name5.tgz", "content-outside-base-dir", "chart illegally contains content outside the base directory: \"content-outside-base-dir\""},
-		{"illegal-name4.tgz", "/missing-leading-slash", "chart metadata (Chart.yaml) missing"},
-		{"illegal-abspath.tgz", "//foo", "chart illegally contains absolute paths"},
-		{"illegal-abspath2.tgz", "///foo", "chart illegally contains absolute paths"},
-		{"illegal-abspath3.tgz", "\\\\foo", "chart illegally contains absolute paths"},
-		{"illegal-abspath3.tgz", "\\..\\..\\foo", "chart illegally references parent directory"},
+		{"illegal-name4.tgz", "/missing-leading-slash", "chart illegally contains absolute paths: \"/missing-leading-slash\""},
+		{"illegal-abspath.tgz", "//foo", "chart illegally contains absolute paths: \"//foo\""},
+		{"illegal-abspath2.tgz", "///foo", "chart illegally contains absolute paths: \"///foo\""},
+		{"illegal-abspath3.tgz", "\\\\foo", "chart illegally contains absolute paths: \"\\\\\\\\foo\""},
+		{"illegal-abspath3.tgz", "\\..\\..\\foo", "chart illegally contains absolute paths: \"\\\\..\\\\..\\\\foo\""},
+		{"illegal-abspath4.tgz", "foo\\..\\..\\bar", "chart illegally references parent directory: \"foo\\\\..\\\\..\\\\bar\""},
 
 		// Under special circumstances, this can get normalized to things that look like absolute Windows paths
-		{"illegal-abspath4.tgz", "\\.\\c:\\\\foo", "chart contains illegally named files"},
-		{"illegal-abspath5.tgz", "/./c://foo", "chart contains illegally named files"},
-		{"illegal-abspath6.tgz", "\\\\?\\Some\\windows\\magic", "chart illegally contains absolute paths"},
+		{"illegal-abspath5.tgz", "foo\\.\\c:\\\\foo", "chart contains illegally named files: \"foo\\\\.\\\\c:\\\\\\\\foo\""},
+		{"illegal-abspath6.tgz", "foo/./c://foo", "chart contains illegally named files: \"foo/./c://foo\""},
+		{"illegal-abspath7.tgz", "\\\\?\\Some\\windows\\magic", "chart illegally contains absolute paths: \"\\\\\\\\?\\\\Some\\\\windows\\\\magic\""},
+		{"illegal-abspath8.tgz", "c:\\Chart.yaml", "chart illegally contains absolute paths: \"c:\\\\Chart.yaml\""},
 	} {
 		illegalChart := filepath.Join(tmpdir, tt.chartname)
 		writeTar(illegalChart, tt.internal, []byte("hello: world"))
@@ -163,9 +167,9 @@ func TestLoadArchive_InvalidArchive(t *testing.T) {
 		}
 	}
 
-	// Make sure that absolute path gets interpreted as relative
+	// Make sure that a file in the base directory is loaded
 	illegalChart := filepath.Join(tmpdir, "abs-path.tgz")
-	writeTar(illegalChart, "/Chart.yaml", []byte("hello: world"))
+	writeTar(illegalChart, "hello/Chart.yaml", []byte("hello: world"))
 	_, err = Load(illegalChart)
 	if err.Error() != "invalid chart (Chart.yaml): name must not be empty" {
 		t.Error(err)
@@ -178,16 +182,161 @@ func TestLoadArchive_InvalidArchive(t *testing.T) {
 	if err.Error() != "chart metadata (Chart.yaml) missing" {
 		t.Error(err)
 	}
+}
 
-	// Finally, test that drive letter gets stripped off on Windows
-	illegalChart = filepath.Join(tmpdir, "abs-winpath.tgz")
-	writeTar(illegalChart, "c:\\Chart.yaml", []byte("hello: world"))
-	_, err = Load(illegalChart)
-	if err.Error() != "invalid chart (Chart.yaml): name must not be empty" {
+type archiveEntry struct {
+	name     string
+	body     string
+	typeflag byte
+	linkname string
+}
+
+// writeArchive returns a chart archive holding entries, which are regular
+// files unless they have a type.
+func writeArchive(t *testing.T, entries ...archiveEntry) []byte {
+	var b bytes.Buffer
+	zipper := gzip.NewWriter(&b)
+	tw := tar.NewWriter(zipper)
+	for _, e := range entries {
+		h := &tar.Header{Name: e.name, Mode: 0644, Typeflag: e.typeflag, Linkname: e.linkname, ModTime: time.Now()}
+		if e.typeflag == 0 {
+			h.Typeflag = tar.TypeReg
+			h.Size = int64(len(e.body))
+		}
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	zipper.Close()
+	return b.Bytes()
+}
+
+func TestLoadArchiveWithLimits(t *testing.T) {
+	sub := writeArchive(t,
+		archiveEntry{name: "sub/Chart.yaml", body: "name: sub\nversion: 0.1.0\n"},
+		archiveEntry{name: "sub/templates/big.yaml", body: strings.Repeat("a", 200)},
+	)
+	data := writeArchive(t,
+		archiveEntry{name: "top/Chart.yaml", body: "name: top\nversion: 0.1.0\n"},
+		archiveEntry{name: "top/values.yaml", body: "a: b\n"},
+		archiveEntry{name: "top/charts/sub-0.1.0.tgz", body: string(sub)},
+	)
+
+	if _, err := LoadArchiveWithLimits(bytes.NewReader(data), Limits{}); err != nil {
+		t.Fatalf("expected the chart to load without limits, got %s", err)
+	}
+
+	for _, tt := range []struct {
+		limits Limits
+		expect LimitError
+	}{
+		{Limits{MaxFiles: 4}, LimitError{Name: "templates/big.yaml", Limit: LimitFiles, Max: 4}},
+		{Limits{MaxFileSize: 100}, LimitError{Name: "charts/sub-0.1.0.tgz", Limit: LimitFileSize, Max: 100}},
+		{Limits{MaxFileSize: 199}, LimitError{Name: "templates/big.yaml", Limit: LimitFileSize, Max: 199}},
+		{Limits{MaxTotalSize: int64(len(sub)) + 100}, LimitError{Name: "templates/big.yaml", Limit: LimitTotalSize, Max: int64(len(sub)) + 100}},
+	} {
+		_, err := LoadArchiveWithLimits(bytes.NewReader(data), tt.limits)
+		var lerr *LimitError
+		if !errors.As(err, &lerr) {
+			t.Errorf("expected a limit error for %+v, got %v", tt.limits, err)
+			continue
+		}
+		if *lerr != tt.expect {
+			t.Errorf("expected %+v, got %+v", tt.expect, *lerr)
+		}
+	}
+}
+
+func TestLoadArchive_Links(t *testing.T) {
+	c, err := LoadArchive(bytes.NewReader(writeArchive(t,
+		archiveEntry{name: "top/Chart.yaml", body: "name: top\nversion: 0.1.0\n"},
+		archiveEntry{name: "top/files/config.yaml", body: "config"},
+		archiveEntry{name: "top/templates/config.yaml", typeflag: tar.TypeSymlink, linkname: "../files/config.yaml"},
+		archiveEntry{name: "top/hard.yaml", typeflag: tar.TypeLink, linkname: "top/templates/config.yaml"},
+	)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Templates) != 1 || string(c.Templates[0].Data) != "config" {
+		t.Errorf("expected the link to be loaded as a copy of its target, got %v", c.Templates)
+	}
+	if len(c.Files) != 2 || string(c.Files[1].Value) != "config" {
+		t.Errorf("expected the hard link to be loaded as a copy of its target, got %v", c.Files)
+	}
+
+	for _, link := range []archiveEntry{
+		{name: "top/passwd", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"},
+		{name: "top/templates/passwd", typeflag: tar.TypeSymlink, linkname: "../../../etc/passwd"},
+		{name: "top/passwd", typeflag: tar.TypeLink, linkname: "/etc/passwd"},
+	} {
+		_, err := LoadArchive(bytes.NewReader(writeArchive(t,
+			archiveEntry{name: "top/Chart.yaml", body: "name: top\nversion: 0.1.0\n"},
+			link,
+		)))
+		var lerr *LinkError
+		if !errors.As(err, &lerr) || lerr.Name != link.name || lerr.Target != link.linkname {
+			t.Errorf("expected a link error for %s to %s, got %v", link.name, link.linkname, err)
+		}
+	}
+
+	_, err = LoadArchive(bytes.NewReader(writeArchive(t,
+		archiveEntry{name: "top/Chart.yaml", body: "name: top\nversion: 0.1.0\n"},
+		archiveEntry{name: "top/missing", typeflag: tar.TypeSymlink, linkname: "templates"},
+	)))
+	if err == nil || err.Error() != `chart link "missing" to "templates" does not name a file of the chart` {
+		t.Errorf("expected an error for a link to a missing file, got %v", err)
+	}
+}
+
+func TestLoadArchive_Duplicates(t *testing.T) {
+	_, err := LoadArchive(bytes.NewReader(writeArchive(t,
+		archiveEntry{name: "top/Chart.yaml", body: "name: top\nversion: 0.1.0\n"},
+		archiveEntry{name: "top/values.yaml", body: "a: b\n"},
+		archiveEntry{name: "top/./values.yaml", body: "a: c\n"},
+	)))
+	var derr *DuplicateFileError
+	if !errors.As(err, &derr) || derr.Name != "values.yaml" {
+		t.Errorf("expected a duplicate file error, got %v", err)
+	}
+}
+
+func TestLoadDirWithLimits(t *testing.T) {
+	_, err := LoadDirWithLimits("testdata/frobnitz", Limits{MaxFiles: 3})
+	var lerr *LimitError
+	if !errors.As(err, &lerr) || lerr.Limit != LimitFiles {
+		t.Errorf("expected a limit error, got %v", err)
+	}
+	if _, err := LoadDirWithLimits("testdata/frobnitz", DefaultLimits); err != nil {
 		t.Error(err)
 	}
 }
 
+func TestDirFileSizes(t *testing.T) {
+	sizes, err := DirFileSizes("testdata/frobnitz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for i, s := range sizes {
+		if i > 0 && s.Size > sizes[i-1].Size {
+			t.Errorf("expected the largest files first, got %v", sizes)
+		}
+		found[s.Name] = true
+	}
+	for _, n := range []string{"Chart.yaml", "charts/mariner-4.3.2.tgz", "charts/mariner-4.3.2.tgz/Chart.yaml", "charts/alpine/Chart.yaml"} {
+		if !found[n] {
+			t.Errorf("expected the size of %s, got %v", n, sizes)
+		}
+	}
+	if found["ignore/me.txt"] {
+		t.Errorf("expected ignored files to be skipped, got %v", sizes)
+	}
+}
+
 func TestLoadFiles(t *testing.T) {
 	goodFiles := []*BufferedFile{
 		{
@@ -0,0 +1,255 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// RedactedValue replaces sensitive values when they are printed.
+const RedactedValue = "REDACTED"
+
+// ValuesSchemaFile is the name of the JSON schema of a chart's values.
+//
+// Properties of the schema with "writeOnly": true are sensitive values.
+const ValuesSchemaFile = "values.schema.json"
+
+// minRedactLength is the length below which sensitive values are not
+// redacted from text, as they would match too much of it.
+const minRedactLength = 4
+
+// SensitiveValues returns the paths of the values that a chart and its
+// dependencies mark as sensitive.
+//
+// A chart marks values as sensitive by listing their paths in the
+// sensitiveValues field of its Chart.yaml, or with "writeOnly": true in its
+// values.schema.json. The paths of a dependency are prefixed with the name of
+// the dependency.
+//
+// Paths are keys separated by periods, like "auth.password". A key containing
+// a period escapes it with a backslash, and the key "*" matches every key of a
+// table and every element of a list.
+func SensitiveValues(chrt *chart.Chart) ([]string, error) {
+	paths := map[string]bool{}
+	if err := sensitiveValues(chrt, nil, paths); err != nil {
+		return nil, err
+	}
+	return sortedKeys(paths), nil
+}
+
+func sensitiveValues(chrt *chart.Chart, prefix []string, paths map[string]bool) error {
+	if chrt.Metadata != nil {
+		for _, p := range chrt.Metadata.SensitiveValues {
+			paths[JoinValuesPath(append(prefix[:len(prefix):len(prefix)], ParseValuesPath(p)...))] = true
+		}
+	}
+	for _, f := range chrt.Files {
+		if f.TypeUrl != ValuesSchemaFile {
+			continue
+		}
+		var schema map[string]interface{}
+		if err := json.Unmarshal(f.Value, &schema); err != nil {
+			return fmt.Errorf("failed to read %s of chart %s: %s", ValuesSchemaFile, chrt.Metadata.GetName(), err)
+		}
+		schemaSensitiveValues(schema, prefix, paths)
+	}
+	for _, dep := range chrt.Dependencies {
+		if err := sensitiveValues(dep, append(prefix[:len(prefix):len(prefix)], dep.Metadata.GetName()), paths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemaSensitiveValues adds the paths of the write-only properties of schema.
+func schemaSensitiveValues(schema map[string]interface{}, path []string, paths map[string]bool) {
+	if writeOnly, _ := schema["writeOnly"].(bool); writeOnly && len(path) > 0 {
+		paths[JoinValuesPath(path)] = true
+		return
+	}
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		for k, v := range props {
+			if sub, ok := v.(map[string]interface{}); ok {
+				schemaSensitiveValues(sub, append(path[:len(path):len(path)], k), paths)
+			}
+		}
+	}
+	for _, key := range []string{"additionalProperties", "items"} {
+		if sub, ok := schema[key].(map[string]interface{}); ok {
+			schemaSensitiveValues(sub, append(path[:len(path):len(path)], "*"), paths)
+		}
+	}
+}
+
+// LeafPaths returns the paths of the values of v that are not tables. Lists
+// are not descended into, so the path of a list covers all its elements.
+func LeafPaths(v map[string]interface{}) []string {
+	paths := map[string]bool{}
+	leafPaths(v, nil, paths)
+	return sortedKeys(paths)
+}
+
+func leafPaths(v map[string]interface{}, path []string, paths map[string]bool) {
+	for k, val := range v {
+		p := append(path[:len(path):len(path)], k)
+		if t, ok := val.(map[string]interface{}); ok && len(t) > 0 {
+			leafPaths(t, p, paths)
+			continue
+		}
+		paths[JoinValuesPath(p)] = true
+	}
+}
+
+// ParseValuesPath splits a path into its keys.
+func ParseValuesPath(path string) []string {
+	var keys []string
+	var key strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			key.WriteByte('.')
+			i++
+		case path[i] == '.':
+			keys = append(keys, key.String())
+			key.Reset()
+		default:
+			key.WriteByte(path[i])
+		}
+	}
+	return append(keys, key.String())
+}
+
+// JoinValuesPath joins keys into a path, escaping their periods.
+func JoinValuesPath(keys []string) string {
+	escaped := make([]string, len(keys))
+	for i, k := range keys {
+		escaped[i] = strings.Replace(k, ".", `\.`, -1)
+	}
+	return strings.Join(escaped, ".")
+}
+
+// RedactValues replaces every value of v at one of paths with RedactedValue.
+//
+// v is modified in place and returned.
+func RedactValues(v Values, paths []string) Values {
+	for _, p := range paths {
+		visitPath(v, ParseValuesPath(p), func(val interface{}) interface{} {
+			if val == nil {
+				return nil
+			}
+			return RedactedValue
+		})
+	}
+	return v
+}
+
+// SensitiveStrings returns the text of the values of v at one of paths. The
+// scalars of tables and lists at the paths are included.
+func SensitiveStrings(v Values, paths []string) []string {
+	strs := map[string]bool{}
+	for _, p := range paths {
+		visitPath(v, ParseValuesPath(p), func(val interface{}) interface{} {
+			scalarStrings(val, strs)
+			return val
+		})
+	}
+	return sortedKeys(strs)
+}
+
+func scalarStrings(v interface{}, strs map[string]bool) {
+	switch t := v.(type) {
+	case nil:
+	case Values:
+		scalarStrings(map[string]interface{}(t), strs)
+	case map[string]interface{}:
+		for _, val := range t {
+			scalarStrings(val, strs)
+		}
+	case []interface{}:
+		for _, val := range t {
+			scalarStrings(val, strs)
+		}
+	default:
+		if s := fmt.Sprint(t); s != "" {
+			strs[s] = true
+		}
+	}
+}
+
+// RedactText replaces the sensitive strings in s, and their base64 encodings,
+// with RedactedValue. Strings shorter than four characters are kept.
+func RedactText(s string, sensitive []string) string {
+	strs := make([]string, 0, len(sensitive))
+	for _, str := range sensitive {
+		if len(str) >= minRedactLength {
+			strs = append(strs, str, base64.StdEncoding.EncodeToString([]byte(str)))
+		}
+	}
+	// Longer strings first, so that a string containing another one is
+	// replaced as a whole.
+	sort.Slice(strs, func(i, j int) bool { return len(strs[i]) > len(strs[j]) })
+	for _, str := range strs {
+		s = strings.Replace(s, str, RedactedValue, -1)
+	}
+	return s
+}
+
+// visitPath replaces every value of v at the path keys with the result of fn.
+func visitPath(v interface{}, keys []string, fn func(interface{}) interface{}) {
+	key, last := keys[0], len(keys) == 1
+	switch t := v.(type) {
+	case Values:
+		visitPath(map[string]interface{}(t), keys, fn)
+	case map[string]interface{}:
+		for k, val := range t {
+			if key != "*" && key != k {
+				continue
+			}
+			if last {
+				t[k] = fn(val)
+			} else {
+				visitPath(val, keys[1:], fn)
+			}
+		}
+	case []interface{}:
+		if key != "*" {
+			return
+		}
+		for i, val := range t {
+			if last {
+				t[i] = fn(val)
+			} else {
+				visitPath(val, keys[1:], fn)
+			}
+		}
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,151 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestSensitiveValues(t *testing.T) {
+	schema := `{
+  "properties": {
+    "auth": {
+      "properties": {
+        "password": {"type": "string", "writeOnly": true},
+        "user": {"type": "string"}
+      }
+    },
+    "users": {"items": {"properties": {"token": {"writeOnly": true}}}},
+    "keys": {"additionalProperties": {"writeOnly": true}}
+  }
+}`
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "app", SensitiveValues: []string{"apiKey", `annotations.example\.com/token`}},
+		Files:    []*chart.Any{{TypeUrl: ValuesSchemaFile, Value: []byte(schema)}},
+		Dependencies: []*chart.Chart{
+			{Metadata: &chart.Metadata{Name: "db", SensitiveValues: []string{"rootPassword"}}},
+		},
+	}
+
+	paths, err := SensitiveValues(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{
+		`annotations.example\.com/token`,
+		"apiKey",
+		"auth.password",
+		"db.rootPassword",
+		"keys.*",
+		"users.*.token",
+	}
+	if !reflect.DeepEqual(paths, expect) {
+		t.Errorf("expected %v, got %v", expect, paths)
+	}
+
+	c.Files[0].Value = []byte("{")
+	if _, err := SensitiveValues(c); err == nil {
+		t.Error("expected an error for an invalid schema")
+	}
+}
+
+func TestValuesPath(t *testing.T) {
+	keys := ParseValuesPath(`metadata.annotations.example\.com/token`)
+	expect := []string{"metadata", "annotations", "example.com/token"}
+	if !reflect.DeepEqual(keys, expect) {
+		t.Errorf("expected %v, got %v", expect, keys)
+	}
+	if p := JoinValuesPath(keys); p != `metadata.annotations.example\.com/token` {
+		t.Errorf("unexpected path %s", p)
+	}
+}
+
+func TestLeafPaths(t *testing.T) {
+	v := map[string]interface{}{
+		"db":    map[string]interface{}{"password": "secret", "hosts": []interface{}{"a", "b"}},
+		"empty": map[string]interface{}{},
+		"a.b":   "c",
+	}
+	expect := []string{`a\.b`, "db.hosts", "db.password", "empty"}
+	if paths := LeafPaths(v); !reflect.DeepEqual(paths, expect) {
+		t.Errorf("expected %v, got %v", expect, paths)
+	}
+}
+
+func TestRedactValues(t *testing.T) {
+	v, err := ReadValues([]byte(`
+db:
+  password: secret
+  port: 5432
+users:
+- name: alice
+  token: t0ken-a
+- name: bob
+  token: t0ken-b
+keys:
+  a: key-a
+  b: key-b
+empty: null
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{"db.password", "users.*.token", "keys", "empty", "missing.value"}
+
+	expectStrs := []string{"key-a", "key-b", "secret", "t0ken-a", "t0ken-b"}
+	if strs := SensitiveStrings(v, paths); !reflect.DeepEqual(strs, expectStrs) {
+		t.Errorf("expected %v, got %v", expectStrs, strs)
+	}
+
+	out, err := RedactValues(v, paths).YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `db:
+  password: REDACTED
+  port: 5432
+empty: null
+keys: REDACTED
+users:
+- name: alice
+  token: REDACTED
+- name: bob
+  token: REDACTED
+`
+	if out != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, out)
+	}
+}
+
+func TestRedactText(t *testing.T) {
+	text := `password: hunter22
+encoded: aHVudGVyMjI=
+url: postgres://admin:hunter22-prod@db
+port: 543
+`
+	expect := `password: REDACTED
+encoded: REDACTED
+url: postgres://admin:REDACTED@db
+port: 543
+`
+	if out := RedactText(text, []string{"hunter22", "hunter22-prod", "543"}); out != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, out)
+	}
+}
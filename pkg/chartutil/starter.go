@@ -0,0 +1,257 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const (
+	// StarterfileName is the name of the file declaring the parameters of a
+	// starter. It is not copied to the created chart.
+	StarterfileName = "starter.yaml"
+
+	// Starter files are rendered with these delimiters, so that the
+	// templates of the created chart can be written as usual.
+	starterLeftDelim  = "<%"
+	starterRightDelim = "%>"
+)
+
+// Starter describes the parameters of a starter chart.
+type Starter struct {
+	// Parameters are the parameters the starter is rendered with.
+	Parameters []*StarterParameter `json:"parameters,omitempty"`
+	// Files are files that are only created under a condition.
+	Files []*StarterFile `json:"files,omitempty"`
+}
+
+// StarterParameter is a parameter of a starter chart.
+type StarterParameter struct {
+	// Name is the name of the parameter, as used in --set.
+	Name string `json:"name"`
+	// Description describes the parameter.
+	Description string `json:"description,omitempty"`
+	// Default is the value of the parameter when it is not set.
+	Default interface{} `json:"default,omitempty"`
+	// Required parameters must be set when there is no default.
+	Required bool `json:"required,omitempty"`
+}
+
+// StarterFile is a file or directory of a starter chart that is only created
+// when a condition holds.
+type StarterFile struct {
+	// Path is the path of the file or directory, relative to the starter.
+	Path string `json:"path"`
+	// If is a template pipeline, e.g. ".ingress" or `eq .database "postgres"`,
+	// evaluated against the parameters. The file is created when it is true.
+	If string `json:"if"`
+}
+
+// LoadStarter reads the starter.yaml file of a starter chart. It returns nil
+// for starters without one.
+func LoadStarter(c *chart.Chart) (*Starter, error) {
+	var s *Starter
+	for _, f := range c.Files {
+		if f.TypeUrl == StarterfileName {
+			s = &Starter{}
+			if err := yaml.Unmarshal(f.Value, s); err != nil {
+				return nil, fmt.Errorf("cannot parse %s: %s", StarterfileName, err)
+			}
+			break
+		}
+	}
+	if s == nil {
+		return nil, nil
+	}
+	for _, p := range s.Parameters {
+		if p.Name == "" {
+			return nil, fmt.Errorf("%s: every parameter needs a name", StarterfileName)
+		}
+	}
+	return s, nil
+}
+
+// Values returns the parameters of the starter, set to the given values or
+// their defaults. It fails when a value is given for an unknown parameter, or
+// no value is given for a required one.
+func (s *Starter) Values(vals map[string]interface{}) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	known := map[string]bool{}
+	var missing []string
+	for _, p := range s.Parameters {
+		known[p.Name] = true
+		if v, ok := vals[p.Name]; ok {
+			out[p.Name] = v
+			continue
+		}
+		if p.Default == nil && p.Required {
+			missing = append(missing, p.Name)
+			continue
+		}
+		out[p.Name] = p.Default
+	}
+
+	var unknown []string
+	for k := range vals {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown starter parameters: %s", strings.Join(unknown, ", "))
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required starter parameters: %s", strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+// starterRenderer renders the files of a starter chart. Files of starters
+// without parameters are left as they are.
+type starterRenderer struct {
+	data map[string]interface{}
+}
+
+func (r *starterRenderer) render(name string, text []byte) ([]byte, error) {
+	if r.data == nil {
+		return text, nil
+	}
+	t, err := template.New(name).
+		Funcs(sprig.TxtFuncMap()).
+		Delims(starterLeftDelim, starterRightDelim).
+		Option("missingkey=zero").
+		Parse(string(text))
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, r.data); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// skipped returns the paths of the conditional files whose condition does
+// not hold.
+func (r *starterRenderer) skipped(files []*StarterFile) ([]string, error) {
+	var paths []string
+	for _, f := range files {
+		out, err := r.render(f.Path, []byte(starterLeftDelim+" if "+f.If+" "+starterRightDelim+"true"+starterLeftDelim+" end "+starterRightDelim))
+		if err != nil {
+			return nil, fmt.Errorf("condition of %s: %s", f.Path, err)
+		}
+		if string(out) != "true" {
+			paths = append(paths, strings.TrimSuffix(f.Path, "/"))
+		}
+	}
+	return paths, nil
+}
+
+func skip(name string, paths []string) bool {
+	for _, p := range paths {
+		if name == p || strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateFromWithParameters creates a new chart like CreateFrom, rendering
+// the files of the src chart with the parameters declared in its
+// starter.yaml file. Starters without one are copied like CreateFrom does.
+//
+// The files are rendered as Go templates using the "<%" and "%>" delimiters.
+// The parameters are available as .Params, and the created chart as .Chart.
+// Conditional files are only created when their condition holds.
+func CreateFromWithParameters(chartfile *chart.Metadata, dest string, src string, params map[string]interface{}) error {
+	schart, err := Load(src)
+	if err != nil {
+		return fmt.Errorf("could not load %s: %s", src, err)
+	}
+	starter, err := LoadStarter(schart)
+	if err != nil {
+		return err
+	}
+
+	schart.Metadata = chartfile
+	r := &starterRenderer{}
+	var skipped []string
+	if starter != nil {
+		vals, err := starter.Values(params)
+		if err != nil {
+			return err
+		}
+		r.data = map[string]interface{}{"Params": vals, "Chart": chartfile}
+		if skipped, err = r.skipped(starter.Files); err != nil {
+			return err
+		}
+	} else if len(params) > 0 {
+		return fmt.Errorf("starter %s has no parameters", src)
+	}
+	transform := func(name string, data []byte) ([]byte, error) {
+		out, err := r.render(name, data)
+		if err != nil {
+			return nil, fmt.Errorf("could not render %s: %s", name, err)
+		}
+		return Transform(string(out), "<CHARTNAME>", chartfile.Name), nil
+	}
+
+	var templates []*chart.Template
+	for _, t := range schart.Templates {
+		if skip(t.Name, skipped) {
+			continue
+		}
+		data, err := transform(t.Name, t.Data)
+		if err != nil {
+			return err
+		}
+		templates = append(templates, &chart.Template{Name: t.Name, Data: data})
+	}
+	schart.Templates = templates
+
+	var files []*chart.Any
+	for _, f := range schart.Files {
+		if f.TypeUrl == StarterfileName || skip(f.TypeUrl, skipped) {
+			continue
+		}
+		data, err := transform(f.TypeUrl, f.Value)
+		if err != nil {
+			return err
+		}
+		files = append(files, &chart.Any{TypeUrl: f.TypeUrl, Value: data})
+	}
+	schart.Files = files
+
+	if schart.Values != nil {
+		data, err := transform(ValuesfileName, []byte(schart.Values.Raw))
+		if err != nil {
+			return err
+		}
+		schart.Values = &chart.Config{Raw: string(data)}
+	}
+	return SaveDir(schart, dest)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestCreateFromWithParameters(t *testing.T) {
+	tdir, err := ioutil.TempDir("", "helm-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	cf := &chart.Metadata{Name: "web", Version: "0.1.0"}
+	params := map[string]interface{}{"team": "payments", "ingress": true}
+	if err := CreateFromWithParameters(cf, tdir, "testdata/webstarter", params); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := LoadDir(filepath.Join(tdir, "web"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "# Default values for web.\nteam: payments\nservice:\n  port: 8080\n"; c.Values.Raw != expect {
+		t.Errorf("expected values\n%s\ngot\n%s", expect, c.Values.Raw)
+	}
+	if len(c.Templates) != 2 {
+		t.Fatalf("expected 2 templates, got %d", len(c.Templates))
+	}
+	for _, tpl := range c.Templates {
+		if tpl.Name == "templates/ingress.yaml" && !strings.Contains(string(tpl.Data), "name: {{ .Release.Name }}-web\n") {
+			t.Errorf("expected the ingress to be rendered, got\n%s", tpl.Data)
+		}
+	}
+	for _, f := range c.Files {
+		if f.TypeUrl == StarterfileName {
+			t.Errorf("expected %s not to be copied", StarterfileName)
+		}
+	}
+}
+
+func TestCreateFromWithParametersConditionalFiles(t *testing.T) {
+	tdir, err := ioutil.TempDir("", "helm-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	cf := &chart.Metadata{Name: "web", Version: "0.1.0"}
+	if err := CreateFromWithParameters(cf, tdir, "testdata/webstarter", map[string]interface{}{"team": "payments"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(tdir, "web", "templates", "ingress.yaml")); !os.IsNotExist(err) {
+		t.Errorf("expected no ingress without the ingress parameter, got %v", err)
+	}
+}
+
+func TestStarterValues(t *testing.T) {
+	s := &Starter{Parameters: []*StarterParameter{
+		{Name: "team", Required: true},
+		{Name: "port", Default: 8080},
+	}}
+
+	if _, err := s.Values(map[string]interface{}{}); err == nil || err.Error() != "missing required starter parameters: team" {
+		t.Errorf("expected a missing parameter, got %v", err)
+	}
+	if _, err := s.Values(map[string]interface{}{"team": "a", "tema": "b"}); err == nil || err.Error() != "unknown starter parameters: tema" {
+		t.Errorf("expected an unknown parameter, got %v", err)
+	}
+
+	vals, err := s.Values(map[string]interface{}{"team": "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vals["team"] != "a" || vals["port"] != 8080 {
+		t.Errorf("unexpected values %v", vals)
+	}
+}
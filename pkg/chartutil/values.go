@@ -176,19 +176,76 @@ func CoalesceValues(chrt *chart.Chart, vals *chart.Config) (Values, error) {
 //
 // This is a helper function for CoalesceValues.
 func coalesce(ch *chart.Chart, dest map[string]interface{}) (map[string]interface{}, error) {
+	// Coalescing with the values of the chart drops the nulls that override
+	// them, including those set for subcharts. Keep the latter, so that they
+	// also delete the defaults of the subcharts.
+	subNulls := map[string]map[string]interface{}{}
+	for _, subchart := range ch.Dependencies {
+		if t, ok := dest[subchart.Metadata.Name].(map[string]interface{}); ok {
+			if n := nulls(t); n != nil {
+				subNulls[subchart.Metadata.Name] = n
+			}
+		}
+	}
+
 	var err error
 	dest, err = coalesceValues(ch, dest)
 	if err != nil {
 		return dest, err
 	}
+	for name, n := range subNulls {
+		if t, ok := dest[name].(map[string]interface{}); ok {
+			setNulls(t, n)
+		}
+	}
 	return coalesceDeps(ch, dest)
 }
 
+// nulls returns the keys of v and its tables that are set to null, as a
+// table of nulls, or nil if there are none.
+func nulls(v map[string]interface{}) map[string]interface{} {
+	var n map[string]interface{}
+	for key, val := range v {
+		var nv interface{}
+		switch val := val.(type) {
+		case nil:
+		case map[string]interface{}:
+			t := nulls(val)
+			if t == nil {
+				continue
+			}
+			nv = t
+		default:
+			continue
+		}
+		if n == nil {
+			n = map[string]interface{}{}
+		}
+		n[key] = nv
+	}
+	return n
+}
+
+// setNulls sets the keys of the table of nulls n to null in v, as far as
+// the tables holding them are in v.
+func setNulls(v, n map[string]interface{}) {
+	for key, val := range n {
+		if t, ok := val.(map[string]interface{}); ok {
+			if vt, ok := v[key].(map[string]interface{}); ok {
+				setNulls(vt, t)
+			}
+			continue
+		}
+		v[key] = nil
+	}
+}
+
 // coalesceDeps coalesces the dependencies of the given chart.
 func coalesceDeps(chrt *chart.Chart, dest map[string]interface{}) (map[string]interface{}, error) {
 	for _, subchart := range chrt.Dependencies {
-		if c, ok := dest[subchart.Metadata.Name]; !ok {
-			// If dest doesn't already have the key, create it.
+		if c, ok := dest[subchart.Metadata.Name]; !ok || c == nil {
+			// If dest doesn't already have the key, create it. A null
+			// resets the values of the subchart to its defaults.
 			dest[subchart.Metadata.Name] = map[string]interface{}{}
 		} else if !istable(c) {
 			return dest, fmt.Errorf("type mismatch on %s: %t", subchart.Metadata.Name, c)
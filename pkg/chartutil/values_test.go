@@ -495,6 +495,85 @@ func TestCoalesceSubchart(t *testing.T) {
 	}
 }
 
+func TestCoalesceNulls(t *testing.T) {
+	sub := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "sub"},
+		Values:   &chart.Config{Raw: "port: 80\nannotations:\n  a: b\n  c: d\n"},
+	}
+	parent := &chart.Chart{
+		Metadata:     &chart.Metadata{Name: "parent"},
+		Values:       &chart.Config{Raw: "name: web\nsub:\n  port: 8080\nother:\n  port: 1\n"},
+		Dependencies: []*chart.Chart{sub},
+	}
+
+	tests := []struct {
+		name   string
+		vals   string
+		expect map[string]interface{}
+	}{
+		{
+			name: "parent default",
+			vals: "name: null\n",
+			expect: map[string]interface{}{
+				"other": map[string]interface{}{"port": 1.0},
+				"sub": map[string]interface{}{
+					"global":      map[string]interface{}{},
+					"port":        8080.0,
+					"annotations": map[string]interface{}{"a": "b", "c": "d"},
+				},
+			},
+		},
+		{
+			name: "subchart default overridden by the parent",
+			vals: "sub:\n  port: null\n  annotations:\n    a: null\n",
+			expect: map[string]interface{}{
+				"name":  "web",
+				"other": map[string]interface{}{"port": 1.0},
+				"sub": map[string]interface{}{
+					"global":      map[string]interface{}{},
+					"annotations": map[string]interface{}{"c": "d"},
+				},
+			},
+		},
+		{
+			name: "no default",
+			vals: "sub:\n  extra:\n    x: null\n",
+			expect: map[string]interface{}{
+				"name":  "web",
+				"other": map[string]interface{}{"port": 1.0},
+				"sub": map[string]interface{}{
+					"global":      map[string]interface{}{},
+					"port":        8080.0,
+					"annotations": map[string]interface{}{"a": "b", "c": "d"},
+					"extra":       map[string]interface{}{"x": nil},
+				},
+			},
+		},
+		{
+			name: "whole subchart",
+			vals: "sub: null\nother: null\nnew: null\n",
+			expect: map[string]interface{}{
+				"name": "web",
+				"sub": map[string]interface{}{
+					"global":      map[string]interface{}{},
+					"port":        80.0,
+					"annotations": map[string]interface{}{"a": "b", "c": "d"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		v, err := CoalesceValues(parent, &chart.Config{Raw: tt.vals})
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if !reflect.DeepEqual(map[string]interface{}(v), tt.expect) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expect, v)
+		}
+	}
+}
+
 func TestPathValue(t *testing.T) {
 	doc := `
 title: "Moby Dick"
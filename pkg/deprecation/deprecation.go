@@ -0,0 +1,177 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deprecation finds objects in manifests that use apiVersions
+// Kubernetes deprecates or removes, and moves them to supported apiVersions.
+package deprecation // import "k8s.io/helm/pkg/deprecation"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+// API is an apiVersion of a kind that Kubernetes deprecates, and eventually
+// stops serving.
+type API struct {
+	APIVersion string
+	Kind       string
+	// DeprecatedIn is the Kubernetes version deprecating the API, e.g. "v1.9".
+	DeprecatedIn string
+	// RemovedIn is the Kubernetes version no longer serving the API.
+	RemovedIn string
+	// Replacement is the apiVersion to use instead.
+	Replacement string
+	// Rewritable tells whether objects can be moved to Replacement by changing
+	// their apiVersion. Otherwise their schema differs and the chart has to be
+	// changed.
+	Rewritable bool
+}
+
+// APIs are the known deprecated APIs.
+var APIs = []API{
+	{"extensions/v1beta1", "Deployment", "v1.9", "v1.16", "apps/v1", true},
+	{"extensions/v1beta1", "DaemonSet", "v1.9", "v1.16", "apps/v1", true},
+	{"extensions/v1beta1", "ReplicaSet", "v1.9", "v1.16", "apps/v1", true},
+	{"extensions/v1beta1", "NetworkPolicy", "v1.9", "v1.16", "networking.k8s.io/v1", true},
+	{"extensions/v1beta1", "PodSecurityPolicy", "v1.10", "v1.16", "policy/v1beta1", true},
+	{"extensions/v1beta1", "Ingress", "v1.14", "v1.22", "networking.k8s.io/v1beta1", true},
+	{"apps/v1beta1", "Deployment", "v1.9", "v1.16", "apps/v1", true},
+	{"apps/v1beta1", "StatefulSet", "v1.9", "v1.16", "apps/v1", true},
+	{"apps/v1beta2", "Deployment", "v1.9", "v1.16", "apps/v1", true},
+	{"apps/v1beta2", "StatefulSet", "v1.9", "v1.16", "apps/v1", true},
+	{"apps/v1beta2", "DaemonSet", "v1.9", "v1.16", "apps/v1", true},
+	{"apps/v1beta2", "ReplicaSet", "v1.9", "v1.16", "apps/v1", true},
+	{"networking.k8s.io/v1beta1", "Ingress", "v1.19", "v1.22", "networking.k8s.io/v1", false},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRole", "v1.17", "v1.22", "rbac.authorization.k8s.io/v1", true},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRoleBinding", "v1.17", "v1.22", "rbac.authorization.k8s.io/v1", true},
+	{"rbac.authorization.k8s.io/v1beta1", "Role", "v1.17", "v1.22", "rbac.authorization.k8s.io/v1", true},
+	{"rbac.authorization.k8s.io/v1beta1", "RoleBinding", "v1.17", "v1.22", "rbac.authorization.k8s.io/v1", true},
+	{"scheduling.k8s.io/v1beta1", "PriorityClass", "v1.14", "v1.22", "scheduling.k8s.io/v1", true},
+	{"apiregistration.k8s.io/v1beta1", "APIService", "v1.19", "v1.22", "apiregistration.k8s.io/v1", true},
+	{"apiextensions.k8s.io/v1beta1", "CustomResourceDefinition", "v1.16", "v1.22", "apiextensions.k8s.io/v1", false},
+	{"admissionregistration.k8s.io/v1beta1", "MutatingWebhookConfiguration", "v1.16", "v1.22", "admissionregistration.k8s.io/v1", false},
+	{"admissionregistration.k8s.io/v1beta1", "ValidatingWebhookConfiguration", "v1.16", "v1.22", "admissionregistration.k8s.io/v1", false},
+	{"batch/v1beta1", "CronJob", "v1.21", "v1.25", "batch/v1", true},
+	{"policy/v1beta1", "PodDisruptionBudget", "v1.21", "v1.25", "policy/v1", false},
+}
+
+// Lookup returns the deprecated API of kind in apiVersion, or nil if the
+// apiVersion is not deprecated.
+func Lookup(apiVersion, kind string) *API {
+	for i := range APIs {
+		if APIs[i].APIVersion == apiVersion && APIs[i].Kind == kind {
+			return &APIs[i]
+		}
+	}
+	return nil
+}
+
+// Deprecated reports whether the API is deprecated in kubeVersion.
+func (a *API) Deprecated(kubeVersion string) bool {
+	return atLeast(kubeVersion, a.DeprecatedIn)
+}
+
+// Removed reports whether the API is no longer served in kubeVersion.
+func (a *API) Removed(kubeVersion string) bool {
+	return atLeast(kubeVersion, a.RemovedIn)
+}
+
+// Finding is an object using a deprecated API.
+type Finding struct {
+	*API
+	// Name is the name of the object.
+	Name string
+	// Removed is whether the API is no longer served in the Kubernetes
+	// version checked against.
+	Removed bool
+}
+
+func (f *Finding) Error() string {
+	obj := f.Kind
+	if f.Name != "" {
+		obj = fmt.Sprintf("%s %q", f.Kind, f.Name)
+	}
+	if f.Removed {
+		return fmt.Sprintf("%s uses %s, which is removed in Kubernetes %s; use %s instead", obj, f.APIVersion, f.RemovedIn, f.Replacement)
+	}
+	return fmt.Sprintf("%s uses %s, which is deprecated since Kubernetes %s and removed in %s; use %s instead", obj, f.APIVersion, f.DeprecatedIn, f.RemovedIn, f.Replacement)
+}
+
+// Check returns a Finding for every object in manifest using an API that is
+// deprecated in kubeVersion. Documents that cannot be parsed are skipped.
+func Check(manifest, kubeVersion string) []Finding {
+	var findings []Finding
+	for _, doc := range releaseutil.SplitManifestDocs(manifest) {
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err != nil {
+			continue
+		}
+		if f := check(&head, kubeVersion); f != nil {
+			findings = append(findings, *f)
+		}
+	}
+	return findings
+}
+
+func check(head *releaseutil.SimpleHead, kubeVersion string) *Finding {
+	api := Lookup(head.Version, head.Kind)
+	if api == nil || !api.Deprecated(kubeVersion) {
+		return nil
+	}
+	f := &Finding{API: api, Removed: api.Removed(kubeVersion)}
+	if head.Metadata != nil {
+		f.Name = head.Metadata.Name
+	}
+	return f
+}
+
+// atLeast reports whether Kubernetes version v is at least min. Both are
+// compared by their major and minor versions only, so that versions of
+// managed clusters like "v1.16.8-eks-e16311" compare as expected. An
+// invalid v is never at least min.
+func atLeast(v, min string) bool {
+	major, minor, ok := majorMinor(v)
+	if !ok {
+		return false
+	}
+	minMajor, minMinor, ok := majorMinor(min)
+	if !ok {
+		return false
+	}
+	return major > minMajor || (major == minMajor && minor >= minMinor)
+}
+
+func majorMinor(v string) (int, int, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(v, "v"), ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	// a minor version may carry a suffix, e.g. "16+" on GKE
+	minor, err := strconv.Atoi(strings.TrimRight(parts[1], "+"))
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
@@ -0,0 +1,261 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"strings"
+	"testing"
+)
+
+const manifest = `
+---
+# Source: web/templates/deployment.yaml
+apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+---
+# Source: web/templates/ingress.yaml
+apiVersion: extensions/v1beta1
+kind: Ingress
+metadata:
+  name: web
+spec:
+  backend:
+    serviceName: web
+    servicePort: 80
+---
+# Source: web/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - port: 80
+`
+
+func TestAtLeast(t *testing.T) {
+	tests := []struct {
+		v, min string
+		expect bool
+	}{
+		{"v1.16.0", "v1.16", true},
+		{"1.16", "v1.16", true},
+		{"v1.15.9", "v1.16", false},
+		{"v1.16.8-eks-e16311", "v1.16", true},
+		{"v1.16+", "v1.16", true},
+		{"v2.0", "v1.16", true},
+		{"latest", "v1.16", false},
+	}
+	for _, tt := range tests {
+		if got := atLeast(tt.v, tt.min); got != tt.expect {
+			t.Errorf("atLeast(%q, %q): expected %t, got %t", tt.v, tt.min, tt.expect, got)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if findings := Check(manifest, "v1.8.0"); len(findings) != 0 {
+		t.Errorf("expected no findings before the deprecation, got %v", findings)
+	}
+
+	findings := Check(manifest, "v1.14.0")
+	expect := []string{
+		`Deployment "web" uses extensions/v1beta1, which is deprecated since Kubernetes v1.9 and removed in v1.16; use apps/v1 instead`,
+		`Ingress "web" uses extensions/v1beta1, which is deprecated since Kubernetes v1.14 and removed in v1.22; use networking.k8s.io/v1beta1 instead`,
+	}
+	if len(findings) != len(expect) {
+		t.Fatalf("expected %d findings, got %v", len(expect), findings)
+	}
+	for i, f := range findings {
+		if f.Error() != expect[i] {
+			t.Errorf("expected %q, got %q", expect[i], f.Error())
+		}
+	}
+
+	findings = Check(manifest, "v1.16.0")
+	if len(findings) != 2 || !findings[0].Removed || findings[1].Removed {
+		t.Errorf("expected only the deployment API to be removed, got %v", findings)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	out, findings, err := Rewrite(manifest, "v1.16.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 rewritten objects, got %v", findings)
+	}
+
+	expect := `
+---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+---
+# Source: web/templates/ingress.yaml
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  name: web
+spec:
+  backend:
+    serviceName: web
+    servicePort: 80
+---
+# Source: web/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - port: 80
+`
+	if out != expect {
+		t.Errorf("expected manifest\n%s\ngot\n%s", expect, out)
+	}
+
+	if findings := Check(out, "v1.16.0"); len(findings) != 0 {
+		t.Errorf("expected no deprecated APIs after the rewrite, got %v", findings)
+	}
+}
+
+func TestRewriteChained(t *testing.T) {
+	// networking.k8s.io/v1 Ingresses have a different schema, so the
+	// rewrite stops at networking.k8s.io/v1beta1
+	out, findings, err := Rewrite(manifest, "v1.22.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "apiVersion: networking.k8s.io/v1beta1\nkind: Ingress") {
+		t.Errorf("expected the ingress to be rewritten to networking.k8s.io/v1beta1, got\n%s", out)
+	}
+	if len(findings) != 2 {
+		t.Errorf("expected 2 rewritten objects, got %v", findings)
+	}
+	remaining := Check(out, "v1.22.0")
+	if len(remaining) != 1 || remaining[0].Rewritable {
+		t.Errorf("expected the ingress to need manual changes, got %v", remaining)
+	}
+}
+
+func TestRewriteKeepsSelector(t *testing.T) {
+	in := `apiVersion: apps/v1beta2
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  selector:
+    matchLabels:
+      app: db
+  # comments survive when nothing but the apiVersion changes
+  template:
+    metadata:
+      labels:
+        app: db
+        tier: data
+`
+	out, _, err := Rewrite(in, "v1.16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := strings.Replace(in, "apps/v1beta2", "apps/v1", 1); out != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, out)
+	}
+}
+
+func TestRewriteAddsSelectorInPlace(t *testing.T) {
+	in := `apiVersion: extensions/v1beta1
+kind: DaemonSet
+metadata:
+  name: agent
+spec:   # the agent runs on every node
+    # comments survive the added selector
+    updateStrategy: {type: RollingUpdate}
+    template:
+      metadata:
+        labels:
+          tier: "01"
+          app: agent
+`
+	out, _, err := Rewrite(in, "v1.16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+spec:   # the agent runs on every node
+    selector:
+      matchLabels:
+        app: agent
+        tier: "01"
+    # comments survive the added selector
+    updateStrategy: {type: RollingUpdate}
+    template:
+      metadata:
+        labels:
+          tier: "01"
+          app: agent
+`
+	if out != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, out)
+	}
+}
+
+func TestRewriteWithoutLabels(t *testing.T) {
+	in := `apiVersion: extensions/v1beta1
+kind: DaemonSet
+metadata:
+  name: agent
+spec:
+  template:
+    spec:
+      containers:
+      - name: agent
+`
+	if _, _, err := Rewrite(in, "v1.16"); err == nil || !strings.Contains(err.Error(), "requires spec.selector") {
+		t.Errorf("expected a missing selector error, got %v", err)
+	}
+}
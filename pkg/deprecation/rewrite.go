@@ -0,0 +1,175 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+var (
+	docSep         = regexp.MustCompile(`(?m)^---[ \t]*$`)
+	apiVersionLine = regexp.MustCompile(`(?m)^apiVersion:[ \t]*\S+[ \t]*$`)
+	specLine       = regexp.MustCompile(`(?m)^spec:[ \t]*(#.*)?$`)
+)
+
+// workloads need an explicit selector in apps/v1, which older APIs defaulted
+// to the labels of the pod template.
+var workloads = map[string]bool{
+	"Deployment":  true,
+	"DaemonSet":   true,
+	"ReplicaSet":  true,
+	"StatefulSet": true,
+}
+
+// Rewrite moves every object in manifest that uses an API deprecated in
+// kubeVersion to the API replacing it, as long as the API is Rewritable. It
+// returns the rewritten manifest and a Finding for every rewritten object.
+//
+// Only the apiVersion of rewritten objects changes, so the rest of the
+// manifest is left as it is. The one exception are workloads moved to
+// apps/v1 without a selector, which get the selector older APIs defaulted to
+// inserted into their spec.
+func Rewrite(manifest, kubeVersion string) (string, []Finding, error) {
+	var (
+		b        strings.Builder
+		findings []Finding
+		start    int
+	)
+	seps := docSep.FindAllStringIndex(manifest, -1)
+	for i := 0; i <= len(seps); i++ {
+		end := len(manifest)
+		if i < len(seps) {
+			end = seps[i][0]
+		}
+		doc, f, err := rewriteDoc(manifest[start:end], kubeVersion)
+		if err != nil {
+			return "", nil, err
+		}
+		findings = append(findings, f...)
+		b.WriteString(doc)
+		if i < len(seps) {
+			b.WriteString(manifest[seps[i][0]:seps[i][1]])
+			start = seps[i][1]
+		}
+	}
+	return b.String(), findings, nil
+}
+
+func rewriteDoc(doc, kubeVersion string) (string, []Finding, error) {
+	var head releaseutil.SimpleHead
+	if err := yaml.Unmarshal([]byte(doc), &head); err != nil || head.Kind == "" {
+		return doc, nil, nil
+	}
+
+	// follow replacements until the API is current, or cannot be rewritten
+	var findings []Finding
+	for {
+		f := check(&head, kubeVersion)
+		if f == nil || !f.Rewritable {
+			break
+		}
+		findings = append(findings, *f)
+		head.Version = f.Replacement
+	}
+	if len(findings) == 0 {
+		return doc, nil, nil
+	}
+
+	loc := apiVersionLine.FindStringIndex(doc)
+	if loc == nil {
+		return "", nil, fmt.Errorf("%s: cannot find the apiVersion to rewrite", findings[0].Error())
+	}
+	doc = doc[:loc[0]] + "apiVersion: " + head.Version + doc[loc[1]:]
+
+	if head.Version == "apps/v1" && workloads[head.Kind] {
+		var err error
+		if doc, err = defaultSelector(doc); err != nil {
+			return "", nil, fmt.Errorf("%s %q: %s", head.Kind, findings[0].Name, err)
+		}
+	}
+	return doc, findings, nil
+}
+
+// defaultSelector sets the selector of a workload to the labels of its pod
+// template, unless it has a selector. The selector is inserted as the first
+// field of spec, so the rest of the document is left as it is.
+func defaultSelector(doc string) (string, error) {
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+		return "", err
+	}
+	spec, _ := obj["spec"].(map[string]interface{})
+	if spec == nil {
+		return doc, nil
+	}
+	if _, ok := spec["selector"]; ok {
+		return doc, nil
+	}
+	template, _ := spec["template"].(map[string]interface{})
+	meta, _ := template["metadata"].(map[string]interface{})
+	labels, _ := meta["labels"].(map[string]interface{})
+	if len(labels) == 0 {
+		return "", fmt.Errorf("apps/v1 requires spec.selector, but the pod template has no labels to select")
+	}
+
+	loc := specLine.FindStringIndex(doc)
+	if loc == nil {
+		return "", fmt.Errorf("apps/v1 requires spec.selector, but spec is not a block mapping it can be added to")
+	}
+	insert := loc[1] + 1
+	indent := fieldIndent(doc[insert:])
+	if indent == "" {
+		return "", fmt.Errorf("apps/v1 requires spec.selector, but spec is not a block mapping it can be added to")
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(indent + "selector:\n")
+	b.WriteString(indent + "  matchLabels:\n")
+	for _, k := range keys {
+		label, err := yaml.Marshal(map[string]interface{}{k: labels[k]})
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(indent + "    " + string(label))
+	}
+	return doc[:insert] + b.String() + doc[insert:], nil
+}
+
+// fieldIndent returns the indentation of the first field of a block mapping
+// starting at the beginning of s.
+func fieldIndent(s string) string {
+	for _, l := range strings.SplitAfter(s, "\n") {
+		t := strings.TrimLeft(l, " ")
+		if strings.TrimSpace(t) == "" || strings.HasPrefix(t, "#") {
+			continue
+		}
+		return l[:len(l)-len(t)]
+	}
+	return ""
+}
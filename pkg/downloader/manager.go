@@ -35,6 +35,7 @@ import (
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/provenance"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/resolver"
 	"k8s.io/helm/pkg/urlutil"
@@ -100,7 +101,10 @@ func (m *Manager) Build() error {
 	}
 
 	// Now we need to fetch every package here into charts/
-	return m.downloadAll(lock.Dependencies)
+	if err := m.downloadAll(lock.Dependencies); err != nil {
+		return err
+	}
+	return m.warnConflicts()
 }
 
 // Update updates a local charts directory.
@@ -156,10 +160,13 @@ func (m *Manager) Update() error {
 	if err := m.downloadAll(lock.Dependencies); err != nil {
 		return err
 	}
+	if err := m.warnConflicts(); err != nil {
+		return err
+	}
 
 	// If the lock file hasn't changed, don't write a new one.
 	oldLock, err := chartutil.LoadRequirementsLock(c)
-	if err == nil && oldLock.Digest == lock.Digest {
+	if err == nil && !lockChanged(oldLock, lock) {
 		return nil
 	}
 
@@ -167,6 +174,39 @@ func (m *Manager) Update() error {
 	return writeLock(m.ChartPath, lock)
 }
 
+// lockChanged reports whether a new lock pins other versions or archives than
+// the old one.
+func lockChanged(old, lock *chartutil.RequirementsLock) bool {
+	if old.Digest != lock.Digest || len(old.Dependencies) != len(lock.Dependencies) {
+		return true
+	}
+	for i, d := range lock.Dependencies {
+		o := old.Dependencies[i]
+		if o.Name != d.Name || o.Version != d.Version || o.Digest != d.Digest {
+			return true
+		}
+	}
+	return false
+}
+
+// warnConflicts walks the dependencies of the dependencies in charts/ and
+// warns about the charts that are required at incompatible versions. As each
+// subchart bundles its own copy of its dependencies, these do not fail.
+func (m *Manager) warnConflicts() error {
+	c, err := m.loadChartDir()
+	if err != nil {
+		return err
+	}
+	g, err := resolver.Graph(c)
+	if err != nil {
+		return err
+	}
+	for _, c := range g.Conflicts() {
+		fmt.Fprintf(m.Out, "WARNING: conflicting versions of %s\n", c)
+	}
+	return nil
+}
+
 func (m *Manager) loadChartDir() (*chart.Chart, error) {
 	if fi, err := os.Stat(m.ChartPath); err != nil {
 		return nil, fmt.Errorf("could not find %s: %s", m.ChartPath, err)
@@ -275,10 +315,15 @@ func (m *Manager) downloadAll(deps []*chartutil.Dependency) error {
 			Password: password,
 		}
 
-		if _, _, err := dl.DownloadTo(churl, "", destPath); err != nil {
+		saved, _, err := dl.DownloadTo(churl, "", destPath)
+		if err != nil {
 			saveError = fmt.Errorf("could not download %s: %s", churl, err)
 			break
 		}
+		if err := verifyDigest(dep, saved); err != nil {
+			saveError = err
+			break
+		}
 	}
 
 	if saveError == nil {
@@ -316,6 +361,23 @@ func (m *Manager) downloadAll(deps []*chartutil.Dependency) error {
 	return nil
 }
 
+// verifyDigest checks that a downloaded archive matches the digest the
+// dependency was locked with. Dependencies locked before digests were
+// recorded are not checked.
+func verifyDigest(dep *chartutil.Dependency, archive string) error {
+	if dep.Digest == "" {
+		return nil
+	}
+	sum, err := provenance.DigestFile(archive)
+	if err != nil {
+		return err
+	}
+	if "sha256:"+sum != dep.Digest {
+		return fmt.Errorf("digest of %s %s does not match requirements.lock (expected %s, got sha256:%s). The chart was republished; run 'helm dependency update' to lock the new archive", dep.Name, dep.Version, dep.Digest, sum)
+	}
+	return nil
+}
+
 // safeDeleteDep deletes any versions of the given dependency in the given directory.
 //
 // It does this by first matching the file name to an expected pattern, then loading
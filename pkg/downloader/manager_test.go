@@ -180,3 +180,60 @@ func TestGetRepoNames(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifyDigest(t *testing.T) {
+	archive := "testdata/signtest-0.1.0.tgz"
+	tests := []struct {
+		name, digest string
+		err          bool
+	}{
+		{name: "not locked", digest: ""},
+		{name: "match", digest: "sha256:dee72947753628425b82814516bdaa37aef49f25e8820dd2a6e15a33a007823b"},
+		{name: "republished", digest: "sha256:e70e41f8922e19558a8bf62f591a8b70c8e4622e3c03e5415f09aba881f13885", err: true},
+	}
+	for _, tt := range tests {
+		dep := &chartutil.Dependency{Name: "signtest", Version: "0.1.0", Digest: tt.digest}
+		err := verifyDigest(dep, archive)
+		if tt.err {
+			if err == nil || !strings.Contains(err.Error(), "helm dependency update") {
+				t.Errorf("%s: expected a digest mismatch, got %v", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+		}
+	}
+}
+
+func TestLockChanged(t *testing.T) {
+	lock := func(digest string) *chartutil.RequirementsLock {
+		return &chartutil.RequirementsLock{
+			Digest: "sha256:abc",
+			Dependencies: []*chartutil.Dependency{
+				{Name: "alpine", Version: "0.1.0", Digest: digest},
+			},
+		}
+	}
+	if lockChanged(lock("sha256:1"), lock("sha256:1")) {
+		t.Error("expected equal locks to be unchanged")
+	}
+	if !lockChanged(lock("sha256:1"), lock("sha256:2")) {
+		t.Error("expected a new archive digest to change the lock")
+	}
+	if !lockChanged(lock(""), lock("sha256:1")) {
+		t.Error("expected a lock without digests to be rewritten")
+	}
+}
+
+func TestWarnConflicts(t *testing.T) {
+	out := &bytes.Buffer{}
+	m := &Manager{Out: out, ChartPath: "../resolver/testdata/graph"}
+	if err := m.warnConflicts(); err != nil {
+		t.Fatal(err)
+	}
+	expect := "WARNING: conflicting versions of common: mychart requires ~1.2.0 (1.2.3), mychart/redis requires ^2.0.0 (2.0.0)\n"
+	if out.String() != expect {
+		t.Errorf("expected %q, got %q", expect, out.String())
+	}
+}
@@ -132,6 +132,9 @@ type renderable struct {
 	vals chartutil.Values
 	// basePath namespace prefix to the templates of the current chart
 	basePath string
+	// library is set for the templates of library charts, which only
+	// provide named templates.
+	library bool
 }
 
 // alterFuncMap takes the Engine's FuncMap and adds context-specific functions.
@@ -272,6 +275,10 @@ func (e *Engine) renderWithReferences(tpls map[string]renderable, referenceTpls
 		if strings.HasPrefix(path.Base(file), "_") {
 			continue
 		}
+		// Library charts only contribute the templates they define.
+		if tpls[file].library {
+			continue
+		}
 		// At render time, add information about the template that is being rendered.
 		vals := tpls[file].vals
 		vals["Template"] = map[string]interface{}{"Name": file, "BasePath": tpls[file].basePath}
@@ -369,6 +376,7 @@ func recAllTpls(c *chart.Chart, templates map[string]renderable, parentVals char
 			tpl:      string(t.Data),
 			vals:     cvals,
 			basePath: path.Join(newParentID, "templates"),
+			library:  chartutil.IsLibraryChart(c),
 		}
 	}
 }
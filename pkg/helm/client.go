@@ -19,17 +19,24 @@ package helm // import "k8s.io/helm/pkg/helm"
 import (
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/logging"
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tracing"
 )
 
 // maxMsgSize use 20MB as the default message size limit.
@@ -37,15 +44,27 @@ import (
 const maxMsgSize = 1024 * 1024 * 20
 
 // Client manages client side of the Helm-Tiller protocol.
+//
+// A Client keeps its connection to Tiller open between calls, and is safe for
+// concurrent use by multiple goroutines. Call Close when done with it.
 type Client struct {
 	opts options
+
+	// mu guards conn, dialing and refs.
+	mu   sync.Mutex
+	conn *grpc.ClientConn
+	// dialing is closed when the dial in progress, if any, is done.
+	dialing chan struct{}
+	// refs counts the calls using each connection, so that a connection that
+	// was dropped is closed only once the last call on it returns.
+	refs map[*grpc.ClientConn]int
 }
 
 // NewClient creates a new client.
 func NewClient(opts ...Option) *Client {
 	var c Client
 	// set some sane defaults
-	c.Option(ConnectTimeout(5))
+	c.Option(ConnectTimeout(5), MaxRetries(3), RetryBackoff(200*time.Millisecond))
 	return c.Option(opts...)
 }
 
@@ -64,7 +83,7 @@ func (h *Client) ListReleases(opts ...ReleaseListOption) (*rls.ListReleasesRespo
 		opt(&reqOpts)
 	}
 	req := &reqOpts.listReq
-	ctx := NewContext()
+	ctx := h.newContext()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -98,7 +117,7 @@ func (h *Client) InstallReleaseWithContext(ctx context.Context, chstr, ns string
 
 // InstallReleaseFromChart installs a new chart and returns the release response.
 func (h *Client) InstallReleaseFromChart(chart *chart.Chart, ns string, opts ...InstallOption) (*rls.InstallReleaseResponse, error) {
-	return h.installReleaseFromChartWithContext(NewContext(), chart, ns, opts...)
+	return h.installReleaseFromChartWithContext(h.newContext(), chart, ns, opts...)
 }
 
 // InstallReleaseFromChartWithContext installs a new chart and returns the release response while accepting a context.
@@ -120,7 +139,7 @@ func (h *Client) installReleaseFromChartWithContext(ctx context.Context, chart *
 	req.DisableHooks = reqOpts.disableHooks
 	req.DisableCrdHook = reqOpts.disableCRDHook
 	req.ReuseName = reqOpts.reuseName
-	ctx = FromContext(ctx)
+	ctx = h.fromContext(ctx)
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -159,7 +178,7 @@ func (h *Client) DeleteRelease(rlsName string, opts ...DeleteOption) (*rls.Unins
 	req := &reqOpts.uninstallReq
 	req.Name = rlsName
 	req.DisableHooks = reqOpts.disableHooks
-	ctx := NewContext()
+	ctx := h.newContext()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -193,7 +212,7 @@ func (h *Client) UpdateReleaseWithContext(ctx context.Context, rlsName string, c
 
 // UpdateReleaseFromChart updates a release to a new/different chart.
 func (h *Client) UpdateReleaseFromChart(rlsName string, chart *chart.Chart, opts ...UpdateOption) (*rls.UpdateReleaseResponse, error) {
-	return h.updateReleaseFromChartWithContext(NewContext(), rlsName, chart, opts...)
+	return h.updateReleaseFromChartWithContext(h.newContext(), rlsName, chart, opts...)
 }
 
 // UpdateReleaseFromChartWithContext updates a release to a new/different chart while accepting a context.
@@ -217,7 +236,7 @@ func (h *Client) updateReleaseFromChartWithContext(ctx context.Context, rlsName
 	req.Force = reqOpts.force
 	req.ResetValues = reqOpts.resetValues
 	req.ReuseValues = reqOpts.reuseValues
-	ctx = FromContext(ctx)
+	ctx = h.fromContext(ctx)
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -243,7 +262,7 @@ func (h *Client) GetVersion(opts ...VersionOption) (*rls.GetVersionResponse, err
 		opt(&reqOpts)
 	}
 	req := &rls.GetVersionRequest{}
-	ctx := NewContext()
+	ctx := h.newContext()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -265,7 +284,7 @@ func (h *Client) RollbackRelease(rlsName string, opts ...RollbackOption) (*rls.R
 	req.DisableHooks = reqOpts.disableHooks
 	req.DryRun = reqOpts.dryRun
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx := h.newContext()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -283,7 +302,7 @@ func (h *Client) ReleaseStatus(rlsName string, opts ...StatusOption) (*rls.GetRe
 	}
 	req := &reqOpts.statusReq
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx := h.newContext()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -301,7 +320,7 @@ func (h *Client) ReleaseContent(rlsName string, opts ...ContentOption) (*rls.Get
 	}
 	req := &reqOpts.contentReq
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx := h.newContext()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -311,6 +330,75 @@ func (h *Client) ReleaseContent(rlsName string, opts ...ContentOption) (*rls.Get
 	return h.content(ctx, req)
 }
 
+// RewriteReleaseAPIs moves the objects of the latest revision of a release
+// from deprecated apiVersions to supported ones.
+func (h *Client) RewriteReleaseAPIs(rlsName string, opts ...RewriteAPIsOption) (*rls.RewriteReleaseAPIsResponse, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	req := &reqOpts.rewriteReq
+	req.Name = rlsName
+	ctx := h.newContext()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.rewriteAPIs(ctx, req)
+}
+
+// ExportRelease returns the latest revision of a release, or all of its
+// stored revisions, oldest first.
+func (h *Client) ExportRelease(rlsName string, opts ...ExportOption) ([]*release.Release, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	req := &reqOpts.exportReq
+	req.Name = rlsName
+	ctx := h.newContext()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.export(ctx, req)
+}
+
+// ImportRelease stores revisions of releases exported from another Tiller.
+func (h *Client) ImportRelease(rels []*release.Release, opts ...ImportOption) (*rls.ImportReleaseResponse, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	req := &reqOpts.importReq
+	req.Releases = rels
+	ctx := h.newContext()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.importRelease(ctx, req)
+}
+
+// UnlockRelease marks the pending revision of a release as failed.
+func (h *Client) UnlockRelease(rlsName string) (*rls.UnlockReleaseResponse, error) {
+	req := &rls.UnlockReleaseRequest{Name: rlsName}
+	ctx := h.newContext()
+
+	if h.opts.before != nil {
+		if err := h.opts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.unlock(ctx, req)
+}
+
 // ReleaseHistory returns a release's revision history.
 func (h *Client) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.GetHistoryResponse, error) {
 	reqOpts := h.opts
@@ -320,7 +408,7 @@ func (h *Client) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.Get
 
 	req := &reqOpts.histReq
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx := h.newContext()
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -339,20 +427,139 @@ func (h *Client) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-ch
 
 	req := &reqOpts.testReq
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx := h.newContext()
 
 	return h.test(ctx, req)
 }
 
 // PingTiller pings the Tiller pod and ensures that it is up and running
 func (h *Client) PingTiller() error {
-	ctx := NewContext()
+	ctx := h.newContext()
 	return h.ping(ctx)
 }
 
-// connect returns a gRPC connection to Tiller or error. The gRPC dial options
-// are constructed here.
-func (h *Client) connect(ctx context.Context) (conn *grpc.ClientConn, err error) {
+// newContext creates a versioned context for a call to Tiller.
+func (h *Client) newContext() context.Context {
+	return h.fromContext(context.TODO())
+}
+
+// fromContext returns a versioned context from a parent context. A parent
+// without a trace context or request ID of its own gets the client's.
+func (h *Client) fromContext(ctx context.Context) context.Context {
+	if !tracing.SpanContextFromContext(ctx).IsValid() && h.opts.traceParent.IsValid() {
+		ctx = tracing.ContextWithRemoteSpanContext(ctx, h.opts.traceParent)
+	}
+	if logging.RequestIDFromContext(ctx) == "" && h.opts.requestID != "" {
+		ctx = logging.WithRequestID(ctx, h.opts.requestID)
+	}
+	return FromContext(ctx)
+}
+
+// Close closes the connection to Tiller. The client dials a new connection
+// if it is used again.
+func (h *Client) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.conn == nil {
+		return nil
+	}
+	err := h.conn.Close()
+	h.conn = nil
+	return err
+}
+
+// connect returns the connection to Tiller, dialing a new one if there is
+// none yet or if the last one was lost, and a func that the caller must call
+// once it is done with the connection. Tiller is dialed without holding h.mu,
+// and calls made while a dial is in progress wait for it.
+func (h *Client) connect(ctx context.Context) (*grpc.ClientConn, func(), error) {
+	ctx, cancel := context.WithTimeout(ctx, h.opts.connectTimeout)
+	defer cancel()
+
+	for {
+		h.mu.Lock()
+		if conn := h.current(); conn != nil {
+			h.refs[conn]++
+			h.mu.Unlock()
+			return conn, func() { h.release(conn) }, nil
+		}
+		if dialing := h.dialing; dialing != nil {
+			h.mu.Unlock()
+			select {
+			case <-dialing:
+				// Dial again if it failed, as it may have failed only
+				// because the context of the call that dialed was done.
+				continue
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			}
+		}
+		dialing := make(chan struct{})
+		h.dialing = dialing
+		h.mu.Unlock()
+
+		conn, err := h.dial(ctx)
+
+		h.mu.Lock()
+		h.dialing = nil
+		close(dialing)
+		if err != nil {
+			h.mu.Unlock()
+			return nil, nil, err
+		}
+		if h.refs == nil {
+			h.refs = map[*grpc.ClientConn]int{}
+		}
+		h.conn = conn
+		h.refs[conn]++
+		h.mu.Unlock()
+		return conn, func() { h.release(conn) }, nil
+	}
+}
+
+// current returns the connection to Tiller if it is usable, and drops it if
+// it was lost. h.mu must be held.
+func (h *Client) current() *grpc.ClientConn {
+	if h.conn == nil {
+		return nil
+	}
+	switch h.conn.GetState() {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		h.drop()
+		return nil
+	}
+	return h.conn
+}
+
+// drop forgets the connection to Tiller so that the next call dials a new
+// one. The connection is closed now if no call is using it, or else by the
+// last call on it to return. h.mu must be held.
+func (h *Client) drop() {
+	conn := h.conn
+	h.conn = nil
+	if h.refs[conn] == 0 {
+		conn.Close()
+	}
+}
+
+// release ends the use of a connection returned by connect.
+func (h *Client) release(conn *grpc.ClientConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.refs[conn]--
+	if h.refs[conn] > 0 {
+		return
+	}
+	delete(h.refs, conn)
+	if conn != h.conn {
+		conn.Close()
+	}
+}
+
+// dial dials Tiller. The gRPC dial options are constructed here.
+func (h *Client) dial(ctx context.Context) (*grpc.ClientConn, error) {
 	opts := []grpc.DialOption{
 		grpc.WithBlock(),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
@@ -362,58 +569,125 @@ func (h *Client) connect(ctx context.Context) (conn *grpc.ClientConn, err error)
 		}),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMsgSize)),
 	}
+	if h.opts.dialer != nil {
+		opts = append(opts, grpc.WithContextDialer(h.opts.dialer))
+	}
 	switch {
 	case h.opts.useTLS:
 		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(h.opts.tlsConfig)))
 	default:
 		opts = append(opts, grpc.WithInsecure())
 	}
-	ctx, cancel := context.WithTimeout(ctx, h.opts.connectTimeout)
-	defer cancel()
-	if conn, err = grpc.DialContext(ctx, h.opts.host, opts...); err != nil {
-		return nil, err
+	return grpc.DialContext(ctx, h.opts.host, opts...)
+}
+
+// disconnect drops a connection after a call on it failed, unless it has
+// recovered in the meantime, so that the next call dials a new one.
+func (h *Client) disconnect(conn *grpc.ClientConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.conn == conn && conn.GetState() != connectivity.Ready {
+		h.drop()
 	}
-	return conn, nil
 }
 
-// list executes tiller.ListReleases RPC.
-func (h *Client) list(ctx context.Context, req *rls.ListReleasesRequest) (*rls.ListReleasesResponse, error) {
-	c, err := h.connect(ctx)
-	if err != nil {
-		return nil, err
+// retry runs an idempotent call until it succeeds, fails with an error that
+// is not transient or runs out of retries, backing off exponentially between
+// attempts. Each attempt has its own deadline if a call timeout is set.
+func (h *Client) retry(ctx context.Context, call func(context.Context, rls.ReleaseServiceClient) error) error {
+	backoff := h.opts.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err := h.attempt(ctx, call)
+		if err == nil || attempt >= h.opts.maxRetries || !retryable(ctx, err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
 	}
-	defer c.Close()
+}
 
-	rlc := rls.NewReleaseServiceClient(c)
-	s, err := rlc.ListReleases(ctx, req)
+// attempt runs a single attempt of a call for retry.
+func (h *Client) attempt(ctx context.Context, call func(context.Context, rls.ReleaseServiceClient) error) error {
+	if h.opts.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.opts.callTimeout)
+		defer cancel()
+	}
+
+	c, done, err := h.connect(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	var resp *rls.ListReleasesResponse
-	for {
-		r, err := s.Recv()
-		if err == io.EOF {
-			break
+	defer done()
+	if err := call(ctx, rls.NewReleaseServiceClient(c)); err != nil {
+		if status.Code(err) == codes.Unavailable {
+			h.disconnect(c)
 		}
+		return err
+	}
+	return nil
+}
+
+// retryable reports whether a failed call may succeed when tried again. Calls
+// are not retried once the caller's context is done.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	// Dialing timed out.
+	if err == context.DeadlineExceeded {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// list executes tiller.ListReleases RPC.
+func (h *Client) list(ctx context.Context, req *rls.ListReleasesRequest) (*rls.ListReleasesResponse, error) {
+	var resp *rls.ListReleasesResponse
+	err := h.retry(ctx, func(ctx context.Context, rlc rls.ReleaseServiceClient) error {
+		resp = nil
+		s, err := rlc.ListReleases(ctx, req)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		if resp == nil {
-			resp = r
-			continue
+		for {
+			r, err := s.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if resp == nil {
+				resp = r
+				continue
+			}
+			resp.Releases = append(resp.Releases, r.GetReleases()...)
 		}
-		resp.Releases = append(resp.Releases, r.GetReleases()...)
+	})
+	if err != nil {
+		return nil, err
 	}
 	return resp, nil
 }
 
 // install executes tiller.InstallRelease RPC.
 func (h *Client) install(ctx context.Context, req *rls.InstallReleaseRequest) (*rls.InstallReleaseResponse, error) {
-	c, err := h.connect(ctx)
+	c, done, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer done()
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.InstallRelease(ctx, req)
@@ -421,11 +695,11 @@ func (h *Client) install(ctx context.Context, req *rls.InstallReleaseRequest) (*
 
 // delete executes tiller.UninstallRelease RPC.
 func (h *Client) delete(ctx context.Context, req *rls.UninstallReleaseRequest) (*rls.UninstallReleaseResponse, error) {
-	c, err := h.connect(ctx)
+	c, done, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer done()
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.UninstallRelease(ctx, req)
@@ -433,11 +707,11 @@ func (h *Client) delete(ctx context.Context, req *rls.UninstallReleaseRequest) (
 
 // update executes tiller.UpdateRelease RPC.
 func (h *Client) update(ctx context.Context, req *rls.UpdateReleaseRequest) (*rls.UpdateReleaseResponse, error) {
-	c, err := h.connect(ctx)
+	c, done, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer done()
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.UpdateRelease(ctx, req)
@@ -445,11 +719,11 @@ func (h *Client) update(ctx context.Context, req *rls.UpdateReleaseRequest) (*rl
 
 // rollback executes tiller.RollbackRelease RPC.
 func (h *Client) rollback(ctx context.Context, req *rls.RollbackReleaseRequest) (*rls.RollbackReleaseResponse, error) {
-	c, err := h.connect(ctx)
+	c, done, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer done()
 
 	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.RollbackRelease(ctx, req)
@@ -457,56 +731,111 @@ func (h *Client) rollback(ctx context.Context, req *rls.RollbackReleaseRequest)
 
 // status executes tiller.GetReleaseStatus RPC.
 func (h *Client) status(ctx context.Context, req *rls.GetReleaseStatusRequest) (*rls.GetReleaseStatusResponse, error) {
-	c, err := h.connect(ctx)
+	var resp *rls.GetReleaseStatusResponse
+	err := h.retry(ctx, func(ctx context.Context, rlc rls.ReleaseServiceClient) (err error) {
+		resp, err = rlc.GetReleaseStatus(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// content executes tiller.GetReleaseContent RPC.
+func (h *Client) content(ctx context.Context, req *rls.GetReleaseContentRequest) (*rls.GetReleaseContentResponse, error) {
+	var resp *rls.GetReleaseContentResponse
+	err := h.retry(ctx, func(ctx context.Context, rlc rls.ReleaseServiceClient) (err error) {
+		resp, err = rlc.GetReleaseContent(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// rewriteAPIs executes tiller.RewriteReleaseAPIs RPC.
+func (h *Client) rewriteAPIs(ctx context.Context, req *rls.RewriteReleaseAPIsRequest) (*rls.RewriteReleaseAPIsResponse, error) {
+	c, done, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer done()
 
 	rlc := rls.NewReleaseServiceClient(c)
-	return rlc.GetReleaseStatus(ctx, req)
+	return rlc.RewriteReleaseAPIs(ctx, req)
 }
 
-// content executes tiller.GetReleaseContent RPC.
-func (h *Client) content(ctx context.Context, req *rls.GetReleaseContentRequest) (*rls.GetReleaseContentResponse, error) {
-	c, err := h.connect(ctx)
+// export executes tiller.ExportRelease RPC.
+func (h *Client) export(ctx context.Context, req *rls.ExportReleaseRequest) ([]*release.Release, error) {
+	c, done, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer done()
 
 	rlc := rls.NewReleaseServiceClient(c)
-	return rlc.GetReleaseContent(ctx, req)
+	s, err := rlc.ExportRelease(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var rels []*release.Release
+	for {
+		msg, err := s.Recv()
+		if err == io.EOF {
+			return rels, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rels = append(rels, msg.Release)
+	}
 }
 
-// version executes tiller.GetVersion RPC.
-func (h *Client) version(ctx context.Context, req *rls.GetVersionRequest) (*rls.GetVersionResponse, error) {
-	c, err := h.connect(ctx)
+// importRelease executes tiller.ImportRelease RPC.
+func (h *Client) importRelease(ctx context.Context, req *rls.ImportReleaseRequest) (*rls.ImportReleaseResponse, error) {
+	c, done, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer done()
 
 	rlc := rls.NewReleaseServiceClient(c)
-	return rlc.GetVersion(ctx, req)
+	return rlc.ImportRelease(ctx, req)
 }
 
-// history executes tiller.GetHistory RPC.
-func (h *Client) history(ctx context.Context, req *rls.GetHistoryRequest) (*rls.GetHistoryResponse, error) {
-	c, err := h.connect(ctx)
+// unlock executes tiller.UnlockRelease RPC.
+func (h *Client) unlock(ctx context.Context, req *rls.UnlockReleaseRequest) (*rls.UnlockReleaseResponse, error) {
+	c, done, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer done()
 
 	rlc := rls.NewReleaseServiceClient(c)
-	return rlc.GetHistory(ctx, req)
+	return rlc.UnlockRelease(ctx, req)
+}
+
+// version executes tiller.GetVersion RPC.
+func (h *Client) version(ctx context.Context, req *rls.GetVersionRequest) (*rls.GetVersionResponse, error) {
+	var resp *rls.GetVersionResponse
+	err := h.retry(ctx, func(ctx context.Context, rlc rls.ReleaseServiceClient) (err error) {
+		resp, err = rlc.GetVersion(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// history executes tiller.GetHistory RPC.
+func (h *Client) history(ctx context.Context, req *rls.GetHistoryRequest) (*rls.GetHistoryResponse, error) {
+	var resp *rls.GetHistoryResponse
+	err := h.retry(ctx, func(ctx context.Context, rlc rls.ReleaseServiceClient) (err error) {
+		resp, err = rlc.GetHistory(ctx, req)
+		return err
+	})
+	return resp, err
 }
 
 // test executes tiller.TestRelease RPC.
 func (h *Client) test(ctx context.Context, req *rls.TestReleaseRequest) (<-chan *rls.TestReleaseResponse, <-chan error) {
 	errc := make(chan error, 1)
-	c, err := h.connect(ctx)
+	c, done, err := h.connect(ctx)
 	if err != nil {
 		errc <- err
 		return nil, errc
@@ -516,7 +845,7 @@ func (h *Client) test(ctx context.Context, req *rls.TestReleaseRequest) (<-chan
 	go func() {
 		defer close(errc)
 		defer close(ch)
-		defer c.Close()
+		defer done()
 
 		rlc := rls.NewReleaseServiceClient(c)
 		s, err := rlc.RunReleaseTest(ctx, req)
@@ -543,11 +872,11 @@ func (h *Client) test(ctx context.Context, req *rls.TestReleaseRequest) (<-chan
 
 // ping executes tiller.Ping RPC.
 func (h *Client) ping(ctx context.Context) error {
-	c, err := h.connect(ctx)
+	c, done, err := h.connect(ctx)
 	if err != nil {
 		return err
 	}
-	defer c.Close()
+	defer done()
 
 	healthClient := healthpb.NewHealthClient(c)
 	resp, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: "Tiller"})
@@ -17,8 +17,22 @@ limitations under the License.
 package helm
 
 import (
+	"net"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	rls "k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tracing"
 )
 
 func TestNewClient(t *testing.T) {
@@ -32,3 +46,245 @@ func TestNewClient(t *testing.T) {
 		t.Errorf("expected timeout duration to be 1 minute, got %v", helmClient.opts.connectTimeout)
 	}
 }
+
+// statusServer serves release status, failing the first calls with the
+// given errors.
+type statusServer struct {
+	rls.ReleaseServiceServer
+
+	mu    sync.Mutex
+	calls int
+	errs  []error
+	delay time.Duration
+	md    metadata.MD
+}
+
+func (s *statusServer) GetReleaseStatus(ctx context.Context, req *rls.GetReleaseStatusRequest) (*rls.GetReleaseStatusResponse, error) {
+	s.mu.Lock()
+	s.calls++
+	call := s.calls
+	s.md, _ = metadata.FromIncomingContext(ctx)
+	s.mu.Unlock()
+
+	if call == 1 && s.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, context.Canceled
+		case <-time.After(s.delay):
+		}
+	}
+	if call <= len(s.errs) {
+		return nil, s.errs[call-1]
+	}
+	return &rls.GetReleaseStatusResponse{Name: req.Name}, nil
+}
+
+func (s *statusServer) ListReleases(req *rls.ListReleasesRequest, stream rls.ReleaseService_ListReleasesServer) error {
+	s.mu.Lock()
+	s.calls++
+	call := s.calls
+	s.mu.Unlock()
+
+	if call <= len(s.errs) {
+		return s.errs[call-1]
+	}
+	return stream.Send(&rls.ListReleasesResponse{Count: 1})
+}
+
+// serve starts a release server on an in-memory listener and returns a
+// client for it, along with the number of connections it dialed.
+func serve(t *testing.T, srv rls.ReleaseServiceServer, opts ...Option) (*Client, *int32) {
+	s := grpc.NewServer()
+	rls.RegisterReleaseServiceServer(s, srv)
+	lis := bufconn.Listen(1024 * 1024)
+	go s.Serve(lis)
+
+	var dials int32
+	dial := func(context.Context, string) (net.Conn, error) {
+		atomic.AddInt32(&dials, 1)
+		return lis.Dial()
+	}
+	c := NewClient(append([]Option{Host("test"), WithDialer(dial), RetryBackoff(time.Millisecond)}, opts...)...)
+	t.Cleanup(func() {
+		c.Close()
+		s.Stop()
+	})
+	return c, &dials
+}
+
+func TestClientReusesConnection(t *testing.T) {
+	c, dials := serve(t, &statusServer{})
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.ReleaseStatus("web"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if *dials != 1 {
+		t.Errorf("expected 1 connection, got %d", *dials)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ReleaseStatus("web"); err != nil {
+		t.Fatal(err)
+	}
+	if *dials != 2 {
+		t.Errorf("expected a new connection after close, got %d connections", *dials)
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection reset")
+	notFound := status.Error(codes.NotFound, "release: \"web\" not found")
+
+	tests := []struct {
+		name  string
+		errs  []error
+		opts  []Option
+		calls int
+		err   bool
+	}{
+		{
+			name:  "retries transient failures",
+			errs:  []error{unavailable, unavailable},
+			calls: 3,
+		},
+		{
+			name:  "gives up after max retries",
+			errs:  []error{unavailable, unavailable, unavailable},
+			opts:  []Option{MaxRetries(1)},
+			calls: 2,
+			err:   true,
+		},
+		{
+			name:  "does not retry other errors",
+			errs:  []error{notFound},
+			calls: 1,
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &statusServer{errs: tt.errs}
+			c, _ := serve(t, srv, tt.opts...)
+
+			_, err := c.ReleaseStatus("web")
+			if (err != nil) != tt.err {
+				t.Errorf("expected error %t, got %v", tt.err, err)
+			}
+			if srv.calls != tt.calls {
+				t.Errorf("expected %d calls, got %d", tt.calls, srv.calls)
+			}
+		})
+	}
+}
+
+func TestClientRetriesStreams(t *testing.T) {
+	srv := &statusServer{errs: []error{status.Error(codes.Unavailable, "connection reset")}}
+	c, _ := serve(t, srv)
+
+	resp, err := c.ListReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Count != 1 || srv.calls != 2 {
+		t.Errorf("expected one release after 2 calls, got %d after %d calls", resp.Count, srv.calls)
+	}
+}
+
+func TestClientCallTimeout(t *testing.T) {
+	srv := &statusServer{delay: time.Second}
+	c, _ := serve(t, srv, CallTimeout(50*time.Millisecond))
+
+	if _, err := c.ReleaseStatus("web"); err != nil {
+		t.Fatal(err)
+	}
+	if srv.calls != 2 {
+		t.Errorf("expected the slow call to be retried, got %d calls", srv.calls)
+	}
+}
+
+func TestClientConcurrentUse(t *testing.T) {
+	c, dials := serve(t, &statusServer{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.ReleaseStatus("web"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if *dials != 1 {
+		t.Errorf("expected 1 connection, got %d", *dials)
+	}
+}
+
+func TestClientSendsRequestContext(t *testing.T) {
+	sc := tracing.NewSpanContext()
+	srv := &statusServer{}
+	c, _ := serve(t, srv, TraceParent(sc), RequestID("0123456789abcdef"))
+
+	if _, err := c.ReleaseStatus("web"); err != nil {
+		t.Fatal(err)
+	}
+	if v := srv.md.Get("x-helm-request-id"); len(v) != 1 || v[0] != "0123456789abcdef" {
+		t.Errorf("expected the request ID to be sent, got %v", v)
+	}
+	if v := srv.md.Get("traceparent"); len(v) != 1 || v[0] != sc.Traceparent() {
+		t.Errorf("expected traceparent %s to be sent, got %v", sc.Traceparent(), v)
+	}
+}
+
+func TestClientKeepsDroppedConnectionInUse(t *testing.T) {
+	c, _ := serve(t, &statusServer{})
+
+	conn, release, err := c.connect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.mu.Lock()
+	c.drop()
+	c.mu.Unlock()
+	if conn.GetState() == connectivity.Shutdown {
+		t.Fatal("expected the connection to stay open while it is in use")
+	}
+
+	release()
+	if conn.GetState() != connectivity.Shutdown {
+		t.Errorf("expected the connection to be closed after its last use, got %s", conn.GetState())
+	}
+}
+
+func TestClientDialsWithoutLock(t *testing.T) {
+	dialing := make(chan struct{})
+	unblock := make(chan struct{})
+	dial := func(ctx context.Context, _ string) (net.Conn, error) {
+		close(dialing)
+		<-unblock
+		return nil, context.Canceled
+	}
+	c := NewClient(Host("test"), WithDialer(dial), ConnectTimeout(1))
+
+	go c.ReleaseStatus("web")
+	<-dialing
+	defer close(unblock)
+
+	closed := make(chan struct{})
+	go func() {
+		c.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("expected Close not to wait for the dial in progress")
+	}
+}
@@ -56,6 +56,12 @@ type EnvSettings struct {
 	TillerConnectionTimeout int64
 	// TillerNamespace is the namespace in which Tiller runs.
 	TillerNamespace string
+	// Tillerless runs the release engine inside the helm client instead of connecting to Tiller.
+	Tillerless bool
+	// TillerlessStorage is the storage driver used for release records in tillerless mode.
+	TillerlessStorage string
+	// TillerlessNamespaces is a comma-separated list of the namespaces releases are installed into and looked up in, in tillerless mode.
+	TillerlessNamespaces string
 	// Home is the local path to the Helm home directory.
 	Home helmpath.Home
 	// Debug indicates whether or not Helm is running in Debug mode.
@@ -86,6 +92,9 @@ func (s *EnvSettings) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.KubeConfig, "kubeconfig", "", "Absolute path of the kubeconfig file to be used")
 	fs.BoolVar(&s.Debug, "debug", false, "Enable verbose output")
 	fs.StringVar(&s.TillerNamespace, "tiller-namespace", "kube-system", "Namespace of Tiller")
+	fs.BoolVar(&s.Tillerless, "tillerless", false, "Run the release engine inside Helm instead of connecting to Tiller. Overrides $HELM_TILLERLESS")
+	fs.StringVar(&s.TillerlessStorage, "tillerless-storage", "secret", "Storage driver for release records in tillerless mode, stored in the namespace of each release. Values: secret, configmap. Overrides $HELM_TILLERLESS_STORAGE")
+	fs.StringVar(&s.TillerlessNamespaces, "tillerless-namespaces", "", "Comma-separated namespaces releases are installed into and looked up in, in tillerless mode. Defaults to the namespace of the kubeconfig context. Overrides $HELM_TILLERLESS_NAMESPACES")
 	fs.Int64Var(&s.TillerConnectionTimeout, "tiller-connection-timeout", int64(300), "The duration (in seconds) Helm will wait to establish a connection to Tiller")
 }
 
@@ -115,10 +124,13 @@ func (s *EnvSettings) InitTLS(fs *pflag.FlagSet) {
 
 // envMap maps flag names to envvars
 var envMap = map[string]string{
-	"debug":            "HELM_DEBUG",
-	"home":             "HELM_HOME",
-	"host":             "HELM_HOST",
-	"tiller-namespace": "TILLER_NAMESPACE",
+	"debug":                 "HELM_DEBUG",
+	"home":                  "HELM_HOME",
+	"host":                  "HELM_HOST",
+	"tiller-namespace":      "TILLER_NAMESPACE",
+	"tillerless":            "HELM_TILLERLESS",
+	"tillerless-storage":    "HELM_TILLERLESS_STORAGE",
+	"tillerless-namespaces": "HELM_TILLERLESS_NAMESPACES",
 }
 
 var tlsEnvMap = map[string]string{
@@ -146,6 +158,24 @@ func (s EnvSettings) HelmKeyPassphrase() string {
 	return ""
 }
 
+// SecretsAgeKeyFile is the path to the age identities that decrypt encrypted
+// values files.
+func (s EnvSettings) SecretsAgeKeyFile() string {
+	if d, ok := os.LookupEnv("HELM_SECRETS_AGE_KEY_FILE"); ok {
+		return d
+	}
+	return s.Home.Path("secrets", "age.key")
+}
+
+// SecretsKeyring is the path to the PGP secret keyring that decrypts
+// encrypted values files.
+func (s EnvSettings) SecretsKeyring() string {
+	if d, ok := os.LookupEnv("HELM_SECRETS_KEYRING"); ok {
+		return d
+	}
+	return filepath.Join(homedir.HomeDir(), ".gnupg", "secring.gpg")
+}
+
 // setFlagFromEnv looks up and sets a flag if the corresponding environment variable changed.
 // if the flag with the corresponding name was set during fs.Parse(), then the environment
 // variable is ignored.
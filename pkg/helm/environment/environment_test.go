@@ -18,6 +18,7 @@ package environment
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -35,8 +36,8 @@ func TestEnvSettings(t *testing.T) {
 		envars map[string]string
 
 		// expected values
-		home, host, ns, kcontext, kconfig, plugins string
-		debug, tlsverify                           bool
+		home, host, ns, kcontext, kconfig, plugins, storage, namespaces string
+		debug, tlsverify, tillerless                                    bool
 	}{
 		{
 			name:      "defaults",
@@ -45,6 +46,7 @@ func TestEnvSettings(t *testing.T) {
 			plugins:   helmpath.Home(DefaultHelmHome).Plugins(),
 			ns:        "kube-system",
 			tlsverify: false,
+			storage:   "secret",
 		},
 		{
 			name:      "with flags set",
@@ -56,6 +58,7 @@ func TestEnvSettings(t *testing.T) {
 			kconfig:   "/bar",
 			debug:     true,
 			tlsverify: false,
+			storage:   "secret",
 		},
 		{
 			name:      "with envvars set",
@@ -67,6 +70,7 @@ func TestEnvSettings(t *testing.T) {
 			ns:        "yourns",
 			debug:     true,
 			tlsverify: false,
+			storage:   "secret",
 		},
 		{
 			name:      "with TLS envvars set",
@@ -78,6 +82,7 @@ func TestEnvSettings(t *testing.T) {
 			ns:        "yourns",
 			debug:     true,
 			tlsverify: true,
+			storage:   "secret",
 		},
 		{
 			name:      "with flags and envvars set",
@@ -89,21 +94,36 @@ func TestEnvSettings(t *testing.T) {
 			ns:        "myns",
 			debug:     true,
 			tlsverify: false,
+			storage:   "secret",
+		},
+		{
+			name:       "with tillerless envvars set",
+			args:       []string{},
+			envars:     map[string]string{"HELM_TILLERLESS": "true", "HELM_TILLERLESS_STORAGE": "configmap", "HELM_TILLERLESS_NAMESPACES": "team-a,team-b", "TILLER_NAMESPACE": "yourns"},
+			home:       DefaultHelmHome,
+			plugins:    helmpath.Home(DefaultHelmHome).Plugins(),
+			ns:         "yourns",
+			tillerless: true,
+			storage:    "configmap",
+			namespaces: "team-a,team-b",
 		},
 	}
 
 	allEnvvars := map[string]string{
-		"HELM_DEBUG":        "",
-		"HELM_HOME":         "",
-		"HELM_HOST":         "",
-		"TILLER_NAMESPACE":  "",
-		"HELM_PLUGIN":       "",
-		"HELM_TLS_HOSTNAME": "",
-		"HELM_TLS_CA_CERT":  "",
-		"HELM_TLS_CERT":     "",
-		"HELM_TLS_KEY":      "",
-		"HELM_TLS_VERIFY":   "",
-		"HELM_TLS_ENABLE":   "",
+		"HELM_DEBUG":                 "",
+		"HELM_HOME":                  "",
+		"HELM_HOST":                  "",
+		"TILLER_NAMESPACE":           "",
+		"HELM_PLUGIN":                "",
+		"HELM_TLS_HOSTNAME":          "",
+		"HELM_TLS_CA_CERT":           "",
+		"HELM_TLS_CERT":              "",
+		"HELM_TLS_KEY":               "",
+		"HELM_TLS_VERIFY":            "",
+		"HELM_TLS_ENABLE":            "",
+		"HELM_TILLERLESS":            "",
+		"HELM_TILLERLESS_STORAGE":    "",
+		"HELM_TILLERLESS_NAMESPACES": "",
 	}
 
 	reset := resetEnv(allEnvvars)
@@ -146,6 +166,15 @@ func TestEnvSettings(t *testing.T) {
 			if settings.KubeConfig != tt.kconfig {
 				t.Errorf("expected kubeconfig %q, got %q", tt.kconfig, settings.KubeConfig)
 			}
+			if settings.Tillerless != tt.tillerless {
+				t.Errorf("expected tillerless %t, got %t", tt.tillerless, settings.Tillerless)
+			}
+			if settings.TillerlessStorage != tt.storage {
+				t.Errorf("expected tillerless-storage %q, got %q", tt.storage, settings.TillerlessStorage)
+			}
+			if settings.TillerlessNamespaces != tt.namespaces {
+				t.Errorf("expected tillerless-namespaces %q, got %q", tt.namespaces, settings.TillerlessNamespaces)
+			}
 			if settings.TLSVerify != tt.tlsverify {
 				t.Errorf("expected tls-verify %t, got %t", tt.tlsverify, settings.TLSVerify)
 			}
@@ -170,3 +199,25 @@ func resetEnv(envars map[string]string) func() {
 		}
 	}
 }
+
+func TestSecretsSettings(t *testing.T) {
+	reset := resetEnv(map[string]string{"HELM_SECRETS_AGE_KEY_FILE": "", "HELM_SECRETS_KEYRING": ""})
+	defer reset()
+
+	settings := EnvSettings{Home: helmpath.Home("/home/helm")}
+	if f := settings.SecretsAgeKeyFile(); f != filepath.Join("/home/helm", "secrets", "age.key") {
+		t.Errorf("unexpected default age key file %q", f)
+	}
+	if f := settings.SecretsKeyring(); filepath.Base(f) != "secring.gpg" {
+		t.Errorf("unexpected default keyring %q", f)
+	}
+
+	os.Setenv("HELM_SECRETS_AGE_KEY_FILE", "/tmp/age.key")
+	os.Setenv("HELM_SECRETS_KEYRING", "/tmp/secring.gpg")
+	if f := settings.SecretsAgeKeyFile(); f != "/tmp/age.key" {
+		t.Errorf("expected age key file /tmp/age.key, got %q", f)
+	}
+	if f := settings.SecretsKeyring(); f != "/tmp/secring.gpg" {
+		t.Errorf("expected keyring /tmp/secring.gpg, got %q", f)
+	}
+}
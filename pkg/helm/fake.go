@@ -19,13 +19,17 @@ package helm // import "k8s.io/helm/pkg/helm"
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"math/rand"
+	"sort"
 	"strings"
 	"sync"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"golang.org/x/net/context"
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/deprecation"
 	"k8s.io/helm/pkg/manifest"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
@@ -39,8 +43,11 @@ import (
 type FakeClient struct {
 	Rels            []*release.Release
 	Responses       map[string]release.TestRun_Status
+	TestRuns        []*release.TestRun
 	Opts            options
 	RenderManifests bool
+	// Warnings are returned with every upgraded release.
+	Warnings []string
 }
 
 // Option returns the fake release client
@@ -114,11 +121,12 @@ func (c *FakeClient) InstallReleaseFromChart(chart *chart.Chart, ns string, opts
 	}
 
 	mockOpts := &MockReleaseOptions{
-		Name:        releaseName,
-		Chart:       chart,
-		Config:      c.Opts.instReq.Values,
-		Namespace:   ns,
-		Description: releaseDescription,
+		Name:            releaseName,
+		Chart:           chart,
+		Config:          c.Opts.instReq.Values,
+		Namespace:       ns,
+		Description:     releaseDescription,
+		SensitiveValues: c.Opts.instReq.SensitiveValues,
 	}
 
 	release := ReleaseMock(mockOpts)
@@ -188,12 +196,13 @@ func (c *FakeClient) UpdateReleaseFromChart(rlsName string, newChart *chart.Char
 	}
 
 	mockOpts := &MockReleaseOptions{
-		Name:        rel.Release.Name,
-		Version:     rel.Release.Version + 1,
-		Chart:       newChart,
-		Config:      c.Opts.updateReq.Values,
-		Namespace:   rel.Release.Namespace,
-		Description: c.Opts.updateReq.Description,
+		Name:            rel.Release.Name,
+		Version:         rel.Release.Version + 1,
+		Chart:           newChart,
+		Config:          c.Opts.updateReq.Values,
+		Namespace:       rel.Release.Namespace,
+		Description:     c.Opts.updateReq.Description,
+		SensitiveValues: c.Opts.updateReq.SensitiveValues,
 	}
 
 	newRelease := ReleaseMock(mockOpts)
@@ -214,7 +223,7 @@ func (c *FakeClient) UpdateReleaseFromChart(rlsName string, newChart *chart.Char
 		*rel.Release = *newRelease
 	}
 
-	return &rls.UpdateReleaseResponse{Release: newRelease}, nil
+	return &rls.UpdateReleaseResponse{Release: newRelease, Warnings: c.Warnings}, nil
 }
 
 // RollbackRelease returns nil, nil
@@ -268,8 +277,149 @@ func (c *FakeClient) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls
 	return &resp, nil
 }
 
+// RewriteReleaseAPIs rewrites the deprecated apiVersions in the manifest of
+// the latest revision of a release in the fake client.
+func (c *FakeClient) RewriteReleaseAPIs(rlsName string, opts ...RewriteAPIsOption) (*rls.RewriteReleaseAPIsResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	var last *release.Release
+	for _, rel := range c.Rels {
+		if rel.Name == rlsName && (last == nil || rel.Version > last.Version) {
+			last = rel
+		}
+	}
+	if last == nil {
+		return nil, storageerrors.ErrReleaseNotFound(rlsName)
+	}
+
+	kubeVersion := reqOpts.rewriteReq.KubeVersion
+	manifest, rewritten, err := deprecation.Rewrite(last.Manifest, kubeVersion)
+	if err != nil {
+		return nil, err
+	}
+	rel := proto.Clone(last).(*release.Release)
+	rel.Manifest = manifest
+
+	resp := &rls.RewriteReleaseAPIsResponse{Release: rel, OriginalManifest: last.Manifest}
+	for i := range rewritten {
+		resp.Rewritten = append(resp.Rewritten, rewritten[i].Error())
+	}
+	remaining := deprecation.Check(manifest, kubeVersion)
+	for i := range remaining {
+		resp.Remaining = append(resp.Remaining, remaining[i].Error())
+	}
+	if !reqOpts.rewriteReq.DryRun {
+		last.Manifest = manifest
+	}
+	return resp, nil
+}
+
+// ExportRelease returns the latest revision of a release in the fake client,
+// or all of its revisions, oldest first.
+func (c *FakeClient) ExportRelease(rlsName string, opts ...ExportOption) ([]*release.Release, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	var rels []*release.Release
+	for _, rel := range c.Rels {
+		if rel.Name == rlsName {
+			rels = append(rels, rel)
+		}
+	}
+	if len(rels) == 0 {
+		return nil, storageerrors.ErrReleaseNotFound(rlsName)
+	}
+	sort.Slice(rels, func(i, j int) bool { return rels[i].Version < rels[j].Version })
+	if !reqOpts.exportReq.AllRevisions {
+		rels = rels[len(rels)-1:]
+	}
+	return rels, nil
+}
+
+// ImportRelease adds revisions of releases to the fake client.
+func (c *FakeClient) ImportRelease(rels []*release.Release, opts ...ImportOption) (*rls.ImportReleaseResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	req := reqOpts.importReq
+
+	resp := &rls.ImportReleaseResponse{}
+	var replace []int
+	for _, r := range rels {
+		rel := proto.Clone(r).(*release.Release)
+		if req.Namespace != "" {
+			rel.Namespace = req.Namespace
+		}
+		i := c.indexOf(rel.Name, rel.Version)
+		switch {
+		case i < 0:
+			replace = append(replace, -1)
+		case req.OnConflict == rls.ImportReleaseRequest_SKIP:
+			resp.Skipped = append(resp.Skipped, fmt.Sprintf("%s.v%d", rel.Name, rel.Version))
+			continue
+		case req.OnConflict == rls.ImportReleaseRequest_OVERWRITE:
+			replace = append(replace, i)
+		default:
+			return nil, fmt.Errorf("revision %d of release %s is already stored", rel.Version, rel.Name)
+		}
+		resp.Releases = append(resp.Releases, rel)
+	}
+	if req.DryRun {
+		return resp, nil
+	}
+	for n, rel := range resp.Releases {
+		if i := replace[n]; i >= 0 {
+			c.Rels[i] = rel
+			continue
+		}
+		c.Rels = append(c.Rels, rel)
+	}
+	return resp, nil
+}
+
+// UnlockRelease marks the pending latest revision of a release in the fake
+// client as failed.
+func (c *FakeClient) UnlockRelease(rlsName string) (*rls.UnlockReleaseResponse, error) {
+	var last *release.Release
+	for _, rel := range c.Rels {
+		if rel.Name == rlsName && (last == nil || rel.Version > last.Version) {
+			last = rel
+		}
+	}
+	if last == nil {
+		return nil, storageerrors.ErrReleaseNotFound(rlsName)
+	}
+	switch last.Info.Status.Code {
+	case release.Status_PENDING_INSTALL, release.Status_PENDING_UPGRADE, release.Status_PENDING_ROLLBACK:
+	default:
+		return nil, fmt.Errorf("release %s has no pending revision (revision %d is %s)", last.Name, last.Version, last.Info.Status.Code)
+	}
+	last.Info.Description = fmt.Sprintf("%s interrupted: unlocked on request", last.Info.Status.Code)
+	last.Info.Status.Code = release.Status_FAILED
+	return &rls.UnlockReleaseResponse{Release: last}, nil
+}
+
+func (c *FakeClient) indexOf(name string, version int32) int {
+	for i, rel := range c.Rels {
+		if rel.Name == name && rel.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
 // RunReleaseTest executes a pre-defined tests on a release
 func (c *FakeClient) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
 
 	results := make(chan *rls.TestReleaseResponse)
 	errc := make(chan error, 1)
@@ -286,6 +436,11 @@ func (c *FakeClient) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (
 		}
 
 		wg.Wait()
+		if reqOpts.testReq.Results {
+			for _, r := range c.TestRuns {
+				results <- &rls.TestReleaseResponse{Status: r.Status, Result: r}
+			}
+		}
 		close(results)
 		close(errc)
 	}()
@@ -315,13 +470,14 @@ metadata:
 
 // MockReleaseOptions allows for user-configurable options on mock release objects.
 type MockReleaseOptions struct {
-	Name        string
-	Version     int32
-	Chart       *chart.Chart
-	Config      *chart.Config
-	StatusCode  release.Status_Code
-	Namespace   string
-	Description string
+	Name            string
+	Version         int32
+	Chart           *chart.Chart
+	Config          *chart.Config
+	StatusCode      release.Status_Code
+	Namespace       string
+	Description     string
+	SensitiveValues []string
 }
 
 // ReleaseMock creates a mock release object based on options set by
@@ -395,7 +551,8 @@ func ReleaseMock(opts *MockReleaseOptions) *release.Release {
 				Events:   []release.Hook_Event{release.Hook_PRE_INSTALL},
 			},
 		},
-		Manifest: MockManifest,
+		Manifest:        MockManifest,
+		SensitiveValues: opts.SensitiveValues,
 	}
 }
 
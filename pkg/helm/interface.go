@@ -19,6 +19,7 @@ package helm
 import (
 	"golang.org/x/net/context"
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
 )
 
@@ -40,5 +41,9 @@ type Interface interface {
 	ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.GetHistoryResponse, error)
 	GetVersion(opts ...VersionOption) (*rls.GetVersionResponse, error)
 	RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error)
+	RewriteReleaseAPIs(rlsName string, opts ...RewriteAPIsOption) (*rls.RewriteReleaseAPIsResponse, error)
+	ExportRelease(rlsName string, opts ...ExportOption) ([]*release.Release, error)
+	ImportRelease(rels []*release.Release, opts ...ImportOption) (*rls.ImportReleaseResponse, error)
+	UnlockRelease(rlsName string) (*rls.UnlockReleaseResponse, error)
 	PingTiller() error
 }
@@ -18,15 +18,18 @@ package helm
 
 import (
 	"crypto/tls"
+	"net"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/metadata"
 
+	"k8s.io/helm/pkg/logging"
 	cpb "k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tracing"
 	"k8s.io/helm/pkg/version"
 )
 
@@ -75,6 +78,12 @@ type options struct {
 	before func(context.Context, proto.Message) error
 	// release history options are applied directly to the get release history request
 	histReq rls.GetHistoryRequest
+	// rewrite options are applied directly to the rewrite release APIs request
+	rewriteReq rls.RewriteReleaseAPIsRequest
+	// export options are applied directly to the export release request
+	exportReq rls.ExportReleaseRequest
+	// import options are applied directly to the import release request
+	importReq rls.ImportReleaseRequest
 	// resetValues instructs Tiller to reset values to their defaults.
 	resetValues bool
 	// reuseValues instructs Tiller to reuse the values from the last release.
@@ -83,6 +92,18 @@ type options struct {
 	testReq rls.TestReleaseRequest
 	// connectTimeout specifies the time duration Helm will wait to establish a connection to tiller
 	connectTimeout time.Duration
+	// dialer, if set, creates the connections to Tiller instead of dialing the host over TCP
+	dialer func(context.Context, string) (net.Conn, error)
+	// maxRetries is the number of times an idempotent call is retried after a transient failure
+	maxRetries int
+	// retryBackoff is the time to wait before the first retry, doubled for each further retry
+	retryBackoff time.Duration
+	// callTimeout, if set, is the deadline of each attempt of an idempotent call
+	callTimeout time.Duration
+	// traceParent is the trace context the calls to Tiller are part of
+	traceParent tracing.SpanContext
+	// requestID identifies the calls to Tiller in its logs
+	requestID string
 }
 
 // Host specifies the host address of the Tiller release server, (default = ":44134").
@@ -100,6 +121,55 @@ func WithTLS(cfg *tls.Config) Option {
 	}
 }
 
+// MaxRetries specifies how many times calls that only read releases (list,
+// status, content, history and version) are retried after a transient
+// failure, such as a lost connection (default = 3).
+func MaxRetries(retries int) Option {
+	return func(opts *options) {
+		opts.maxRetries = retries
+	}
+}
+
+// RetryBackoff specifies how long to wait before the first retry of a call.
+// The wait doubles for each further retry (default = 200ms).
+func RetryBackoff(backoff time.Duration) Option {
+	return func(opts *options) {
+		opts.retryBackoff = backoff
+	}
+}
+
+// CallTimeout specifies the deadline of each attempt of a retried call. An
+// attempt that runs out of time is retried. Zero means no deadline.
+func CallTimeout(timeout time.Duration) Option {
+	return func(opts *options) {
+		opts.callTimeout = timeout
+	}
+}
+
+// TraceParent specifies the trace context the calls to Tiller are part of.
+// Tiller traces its operations as children of it.
+func TraceParent(sc tracing.SpanContext) Option {
+	return func(opts *options) {
+		opts.traceParent = sc
+	}
+}
+
+// RequestID specifies the ID Tiller logs the calls of the client with, to join
+// its logs with those of the client.
+func RequestID(id string) Option {
+	return func(opts *options) {
+		opts.requestID = id
+	}
+}
+
+// WithDialer specifies the function used to open connections to Tiller, e.g.
+// to reach a release server running in the same process.
+func WithDialer(dialer func(context.Context, string) (net.Conn, error)) Option {
+	return func(opts *options) {
+		opts.dialer = dialer
+	}
+}
+
 // BeforeCall returns an option that allows intercepting a helm client rpc
 // before being sent OTA to tiller. The intercepting function should return
 // an error to indicate that the call should not proceed or nil otherwise.
@@ -248,6 +318,20 @@ func ReleaseTestLogs(logs bool) ReleaseTestOption {
 	}
 }
 
+// ReleaseTestResults is a boolean value representing whether to stream the structured result of each test
+func ReleaseTestResults(results bool) ReleaseTestOption {
+	return func(opts *options) {
+		opts.testReq.Results = results
+	}
+}
+
+// ReleaseTestFilters selects the tests to run using attribute=value pairs, or !attribute=value to exclude tests
+func ReleaseTestFilters(filters []string) ReleaseTestOption {
+	return func(opts *options) {
+		opts.testReq.Filters = filters
+	}
+}
+
 // RollbackTimeout specifies the number of seconds before kubernetes calls timeout
 func RollbackTimeout(timeout int64) RollbackOption {
 	return func(opts *options) {
@@ -466,6 +550,41 @@ func UpgradeForce(force bool) UpdateOption {
 	}
 }
 
+// InstallAdopt will (if true) take over chart resources that already exist in the cluster
+func InstallAdopt(adopt bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.Adopt = adopt
+	}
+}
+
+// InstallAdoptOnly will (if true) fail the install unless every chart resource already exists in the cluster
+func InstallAdoptOnly(adoptOnly bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.AdoptOnly = adoptOnly
+	}
+}
+
+// UpgradeAdopt will (if true) take over new chart resources that already exist in the cluster
+func UpgradeAdopt(adopt bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Adopt = adopt
+	}
+}
+
+// InstallSensitiveValues marks the values at paths as sensitive, masking them when the release is printed
+func InstallSensitiveValues(paths []string) InstallOption {
+	return func(opts *options) {
+		opts.instReq.SensitiveValues = paths
+	}
+}
+
+// UpgradeSensitiveValues marks the values at paths as sensitive, masking them when the release is printed
+func UpgradeSensitiveValues(paths []string) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.SensitiveValues = paths
+	}
+}
+
 // ContentOption allows setting optional attributes when
 // performing a GetReleaseContent tiller rpc.
 type ContentOption func(*options)
@@ -519,14 +638,76 @@ func WithMaxHistory(max int32) HistoryOption {
 	}
 }
 
+// RewriteAPIsOption allows configuring optional request data for
+// rewriting the deprecated apiVersions of a release.
+type RewriteAPIsOption func(*options)
+
+// RewriteAPIsDryRun will instruct Tiller to return the rewritten release
+// without storing it.
+func RewriteAPIsDryRun(dry bool) RewriteAPIsOption {
+	return func(opts *options) {
+		opts.rewriteReq.DryRun = dry
+	}
+}
+
+// RewriteAPIsKubeVersion sets the Kubernetes version whose deprecated
+// apiVersions are rewritten, instead of the version of the cluster.
+func RewriteAPIsKubeVersion(kubeVersion string) RewriteAPIsOption {
+	return func(opts *options) {
+		opts.rewriteReq.KubeVersion = kubeVersion
+	}
+}
+
+// ExportOption allows configuring optional request data for exporting a
+// release.
+type ExportOption func(*options)
+
+// ExportAllRevisions will instruct Tiller to export every stored revision of
+// the release instead of the latest one.
+func ExportAllRevisions(all bool) ExportOption {
+	return func(opts *options) {
+		opts.exportReq.AllRevisions = all
+	}
+}
+
+// ImportOption allows configuring optional request data for importing
+// releases.
+type ImportOption func(*options)
+
+// ImportNamespace moves the imported releases to the given namespace.
+func ImportNamespace(ns string) ImportOption {
+	return func(opts *options) {
+		opts.importReq.Namespace = ns
+	}
+}
+
+// ImportDryRun will instruct Tiller to check the import without storing
+// anything.
+func ImportDryRun(dry bool) ImportOption {
+	return func(opts *options) {
+		opts.importReq.DryRun = dry
+	}
+}
+
+// ImportOnConflict tells Tiller what to do with revisions that are already
+// stored.
+func ImportOnConflict(c rls.ImportReleaseRequest_Conflict) ImportOption {
+	return func(opts *options) {
+		opts.importReq.OnConflict = c
+	}
+}
+
 // NewContext creates a versioned context.
 func NewContext() context.Context {
 	return FromContext(context.TODO())
 }
 
-// FromContext returns a versioned context from a parent context
+// FromContext returns a versioned context from a parent context. The trace
+// context and request ID of the parent, if any, are passed on to Tiller.
 func FromContext(ctx context.Context) context.Context {
 	md := metadata.Pairs("x-helm-api-client", version.GetVersion())
+	tracing.Inject(ctx, md)
+	logging.InjectRequestID(ctx, md)
 	return metadata.NewOutgoingContext(ctx, md)
 }
 
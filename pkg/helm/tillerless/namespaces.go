@@ -0,0 +1,204 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tillerless
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s.io/helm/pkg/logging"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/storage/driver"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+var _ driver.Driver = (*namespacedDriver)(nil)
+
+// namespacedDriver stores the records of each release in the namespace the
+// release is installed into.
+//
+// Release names are unique across namespaces, so records are looked up in
+// each of the given namespaces in turn. Only these namespaces are accessed,
+// which needs no rights on Secrets or ConfigMaps in the rest of the cluster.
+type namespacedDriver struct {
+	name       string
+	clientset  kubernetes.Interface
+	storage    string
+	namespaces []string
+	log        logging.Logger
+
+	mu      sync.Mutex
+	drivers map[string]driver.Driver
+}
+
+func newNamespacedDriver(clientset kubernetes.Interface, storage string, namespaces []string, log logging.Logger) *namespacedDriver {
+	d := &namespacedDriver{
+		clientset:  clientset,
+		storage:    storage,
+		namespaces: namespaces,
+		log:        log,
+		drivers:    map[string]driver.Driver{},
+	}
+	d.name = d.driver(metav1.NamespaceDefault).Name()
+	return d
+}
+
+// Name returns the name of the driver of each namespace.
+func (d *namespacedDriver) Name() string {
+	return d.name
+}
+
+// Get fetches the release named by key from the namespace it is stored in.
+func (d *namespacedDriver) Get(key string) (*rspb.Release, error) {
+	_, rls, err := d.locate(key)
+	return rls, err
+}
+
+// List fetches the releases of all namespaces such that filter(release) == true.
+func (d *namespacedDriver) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
+	var results []*rspb.Release
+	for _, ns := range d.namespaces {
+		rels, err := d.driver(ns).List(filter)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rels...)
+	}
+	return results, nil
+}
+
+// Query fetches the releases of all namespaces that match the provided map of
+// labels.
+func (d *namespacedDriver) Query(labels map[string]string) ([]*rspb.Release, error) {
+	var results []*rspb.Release
+	for _, ns := range d.namespaces {
+		rels, err := d.driver(ns).Query(labels)
+		if err != nil && !storageerrors.IsReleaseNotFound(err) {
+			return nil, err
+		}
+		results = append(results, rels...)
+	}
+	if len(results) == 0 {
+		return nil, storageerrors.ErrReleaseNotFound(labels["NAME"])
+	}
+	return results, nil
+}
+
+// Create stores the release in its namespace.
+func (d *namespacedDriver) Create(key string, rls *rspb.Release) error {
+	ns, err := d.namespace(rls)
+	if err != nil {
+		return err
+	}
+	return d.driver(ns).Create(key, rls)
+}
+
+// Update replaces the release stored in its namespace.
+func (d *namespacedDriver) Update(key string, rls *rspb.Release) error {
+	ns, err := d.namespace(rls)
+	if err != nil {
+		return err
+	}
+	return d.driver(ns).Update(key, rls)
+}
+
+// Delete deletes the release named by key from the namespace it is stored in.
+func (d *namespacedDriver) Delete(key string) (*rspb.Release, error) {
+	ns, _, err := d.locate(key)
+	if err != nil {
+		return nil, err
+	}
+	return d.driver(ns).Delete(key)
+}
+
+// locate fetches the release named by key from the first namespace holding it.
+func (d *namespacedDriver) locate(key string) (string, *rspb.Release, error) {
+	for _, ns := range d.namespaces {
+		rls, err := d.driver(ns).Get(key)
+		if storageerrors.IsReleaseNotFound(err) {
+			continue
+		}
+		return ns, rls, err
+	}
+	return "", nil, storageerrors.ErrReleaseNotFound(key)
+}
+
+// namespace returns the namespace the release is stored in.
+func (d *namespacedDriver) namespace(rls *rspb.Release) (string, error) {
+	ns := rls.Namespace
+	if ns == "" {
+		ns = metav1.NamespaceDefault
+	}
+	return ns, checkNamespace(d.namespaces, rls.Name, ns)
+}
+
+// driver returns the driver storing the records of namespace ns.
+func (d *namespacedDriver) driver(ns string) driver.Driver {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if drv, ok := d.drivers[ns]; ok {
+		return drv
+	}
+	var drv driver.Driver
+	switch d.storage {
+	case StorageConfigMap:
+		cfgmaps := driver.NewConfigMaps(d.clientset.CoreV1().ConfigMaps(ns))
+		cfgmaps.Log = d.log
+		drv = cfgmaps
+	default:
+		secrets := driver.NewSecrets(d.clientset.CoreV1().Secrets(ns))
+		secrets.Log = d.log
+		drv = secrets
+	}
+	d.drivers[ns] = drv
+	return drv
+}
+
+// namespaceGuard refuses installing releases into namespaces other than the
+// ones release records are looked up in, as they could not be found again.
+type namespaceGuard struct {
+	services.ReleaseServiceServer
+	namespaces []string
+}
+
+// InstallRelease installs the release if its namespace is one of g.namespaces.
+func (g *namespaceGuard) InstallRelease(c context.Context, req *services.InstallReleaseRequest) (*services.InstallReleaseResponse, error) {
+	ns := req.Namespace
+	if ns == "" {
+		ns = metav1.NamespaceDefault
+	}
+	if err := checkNamespace(g.namespaces, req.Name, ns); err != nil {
+		return nil, err
+	}
+	return g.ReleaseServiceServer.InstallRelease(c, req)
+}
+
+// checkNamespace returns an error if ns is not one of namespaces.
+func checkNamespace(namespaces []string, name, ns string) error {
+	for _, n := range namespaces {
+		if n == ns {
+			return nil
+		}
+	}
+	return fmt.Errorf("release %q is in namespace %q, which is not one of the tillerless namespaces (%s)", name, ns, strings.Join(namespaces, ", "))
+}
@@ -0,0 +1,172 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tillerless runs the Tiller release engine inside the helm client.
+//
+// Instead of dialing a Tiller running in the cluster, the client serves the
+// release service from an in-process gRPC server backed by the user's own
+// Kubernetes credentials. Release records are stored as Secrets or ConfigMaps
+// in the namespace of each release, so no in-cluster deployment is needed.
+// Releases are limited to a configured list of namespaces, so only access to
+// those namespaces is needed.
+package tillerless // import "k8s.io/helm/pkg/helm/tillerless"
+
+import (
+	"fmt"
+	"net"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/logging"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/tiller"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+const (
+	// StorageSecret stores release records as Secrets.
+	StorageSecret = "secret"
+	// StorageConfigMap stores release records as ConfigMaps.
+	StorageConfigMap = "configmap"
+
+	// bufferSize is the size of the in-memory connection buffer.
+	bufferSize = 1024 * 1024
+)
+
+// Config describes how to set up an in-process release engine.
+type Config struct {
+	// Getter loads the Kubernetes configuration, usually from the user's kubeconfig.
+	Getter genericclioptions.RESTClientGetter
+	// Storage is the storage driver, either StorageSecret or StorageConfigMap.
+	// It defaults to StorageSecret.
+	Storage string
+	// Namespaces are the namespaces releases are installed into. Release
+	// records are stored in, and only looked up in, these namespaces. It
+	// defaults to the namespace of the current kubeconfig context.
+	Namespaces []string
+	// Instance identifies this client as the owner of pending revisions.
+	Instance string
+	// Log receives the log of the release engine. It defaults to
+	// logging.Discard.
+	Log logging.Logger
+}
+
+// Client is a helm.Interface that serves its requests with a Tiller release
+// server running in the same process.
+//
+// Requests go through the same gRPC service as with an in-cluster Tiller, so
+// every helm command behaves the same in both modes.
+type Client struct {
+	*helm.Client
+
+	// Server is the release server handling the requests.
+	Server *tiller.ReleaseServer
+
+	grpc *grpc.Server
+}
+
+// New returns a client running a release engine that talks to the cluster
+// with the given configuration.
+func New(cfg Config, opts ...helm.Option) (*Client, error) {
+	if cfg.Storage == "" {
+		cfg.Storage = StorageSecret
+	}
+	if cfg.Log == nil {
+		cfg.Log = logging.Discard
+	}
+	if cfg.Storage != StorageSecret && cfg.Storage != StorageConfigMap {
+		return nil, fmt.Errorf("unknown storage driver %q, must be one of %q or %q", cfg.Storage, StorageSecret, StorageConfigMap)
+	}
+
+	if len(cfg.Namespaces) == 0 {
+		ns, _, err := cfg.Getter.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return nil, fmt.Errorf("could not get the namespace of the kubeconfig context: %s", err)
+		}
+		cfg.Namespaces = []string{ns}
+	}
+
+	kubeClient := kube.New(cfg.Getter)
+	clientset, err := kubeClient.KubernetesClientSet()
+	if err != nil {
+		return nil, fmt.Errorf("could not get Kubernetes client: %s", err)
+	}
+
+	env := environment.New()
+	env.Releases = storage.Init(newNamespacedDriver(clientset, cfg.Storage, cfg.Namespaces, cfg.Log))
+	env.KubeClient = kubeClient
+	env.Releases.Log = cfg.Log
+	kubeClient.Log = cfg.Log
+
+	c := newClient(env, clientset, cfg.Namespaces, opts...)
+	c.Server.Instance = cfg.Instance
+	c.Server.Log = cfg.Log
+	return c, nil
+}
+
+// NewFromEnvironment returns a client running a release engine with the given
+// environment and clientset.
+func NewFromEnvironment(env *environment.Environment, clientset kubernetes.Interface, opts ...helm.Option) *Client {
+	return newClient(env, clientset, nil, opts...)
+}
+
+// newClient returns a client running a release engine with the given
+// environment and clientset. If namespaces is not empty, releases can only be
+// installed into these namespaces.
+func newClient(env *environment.Environment, clientset kubernetes.Interface, namespaces []string, opts ...helm.Option) *Client {
+	svc := tiller.NewReleaseServer(env, clientset, false)
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus("Tiller", healthpb.HealthCheckResponse_SERVING)
+
+	srv := tiller.NewServer()
+	if len(namespaces) > 0 {
+		services.RegisterReleaseServiceServer(srv, &namespaceGuard{ReleaseServiceServer: svc, namespaces: namespaces})
+	} else {
+		services.RegisterReleaseServiceServer(srv, svc)
+	}
+	healthpb.RegisterHealthServer(srv, healthSrv)
+
+	lis := bufconn.Listen(bufferSize)
+	go srv.Serve(lis)
+
+	dial := func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}
+	opts = append(opts, helm.Host("tillerless"), helm.WithDialer(dial))
+
+	return &Client{
+		Client: helm.NewClient(opts...),
+		Server: svc,
+		grpc:   srv,
+	}
+}
+
+// Close closes the connection to the release engine and stops it.
+func (c *Client) Close() error {
+	err := c.Client.Close()
+	c.grpc.Stop()
+	return err
+}
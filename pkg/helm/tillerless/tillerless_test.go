@@ -0,0 +1,171 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tillerless
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/logging"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+func newTestClient(opts ...helm.Option) *Client {
+	env := environment.New()
+	env.Releases = storage.Init(driver.NewMemory())
+	env.KubeClient = &environment.PrintingKubeClient{Out: ioutil.Discard}
+	return NewFromEnvironment(env, fake.NewSimpleClientset(), opts...)
+}
+
+func testChart(version string) *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{Name: "hello", Version: version},
+		Templates: []*chart.Template{
+			{Name: "templates/hello", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: hello\n")},
+		},
+	}
+}
+
+func TestClient(t *testing.T) {
+	c := newTestClient()
+	defer c.Close()
+
+	var _ helm.Interface = c
+
+	if err := c.PingTiller(); err != nil {
+		t.Fatalf("ping failed: %s", err)
+	}
+
+	inst, err := c.InstallReleaseFromChart(testChart("0.1.0"), "default", helm.ReleaseName("hi"))
+	if err != nil {
+		t.Fatalf("install failed: %s", err)
+	}
+	if inst.Release.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("expected release to be deployed, got %s", inst.Release.Info.Status.Code)
+	}
+
+	if _, err := c.UpdateReleaseFromChart("hi", testChart("0.2.0")); err != nil {
+		t.Fatalf("upgrade failed: %s", err)
+	}
+
+	list, err := c.ListReleases()
+	if err != nil {
+		t.Fatalf("list failed: %s", err)
+	}
+	if len(list.Releases) != 1 || list.Releases[0].Version != 2 {
+		t.Fatalf("expected revision 2 of one release, got %v", list.Releases)
+	}
+
+	hist, err := c.ReleaseHistory("hi", helm.WithMaxHistory(10))
+	if err != nil {
+		t.Fatalf("history failed: %s", err)
+	}
+	if len(hist.Releases) != 2 {
+		t.Errorf("expected 2 revisions, got %d", len(hist.Releases))
+	}
+
+	if _, err := c.DeleteRelease("hi", helm.DeletePurge(true)); err != nil {
+		t.Fatalf("delete failed: %s", err)
+	}
+	if _, err := c.ReleaseStatus("hi"); err == nil {
+		t.Error("expected purged release to be gone")
+	}
+}
+
+func TestClientClose(t *testing.T) {
+	c := newTestClient(helm.ConnectTimeout(1))
+	c.Close()
+
+	if err := c.PingTiller(); err == nil {
+		t.Error("expected ping to fail after close")
+	}
+}
+
+func TestNewUnknownStorage(t *testing.T) {
+	_, err := New(Config{Storage: "sql"})
+	if err == nil || !strings.Contains(err.Error(), `unknown storage driver "sql"`) {
+		t.Fatalf("expected an error for an unsupported storage driver, got %v", err)
+	}
+}
+
+func TestNamespacedDriver(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	env := environment.New()
+	namespaces := []string{"team-a", "team-b"}
+	env.Releases = storage.Init(newNamespacedDriver(clientset, StorageSecret, namespaces, logging.Discard))
+	env.KubeClient = &environment.PrintingKubeClient{Out: ioutil.Discard}
+	c := newClient(env, clientset, namespaces)
+	defer c.Close()
+
+	for _, r := range []struct{ name, namespace string }{{"a", "team-a"}, {"b", "team-b"}} {
+		if _, err := c.InstallReleaseFromChart(testChart("0.1.0"), r.namespace, helm.ReleaseName(r.name)); err != nil {
+			t.Fatalf("install of %s failed: %s", r.name, err)
+		}
+		secrets, err := clientset.CoreV1().Secrets(r.namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(secrets.Items) != 1 || secrets.Items[0].Name != r.name+".v1" {
+			t.Errorf("expected the record of %s in namespace %s, got %v", r.name, r.namespace, secrets.Items)
+		}
+	}
+
+	if _, err := c.UpdateReleaseFromChart("a", testChart("0.2.0")); err != nil {
+		t.Fatalf("upgrade failed: %s", err)
+	}
+	if _, err := clientset.CoreV1().Secrets("team-a").Get(context.TODO(), "a.v2", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the upgraded revision in namespace team-a: %s", err)
+	}
+
+	list, err := c.ListReleases()
+	if err != nil {
+		t.Fatalf("list failed: %s", err)
+	}
+	if len(list.Releases) != 2 {
+		t.Errorf("expected the releases of both namespaces, got %v", list.Releases)
+	}
+
+	if _, err := c.DeleteRelease("b", helm.DeletePurge(true)); err != nil {
+		t.Fatalf("delete failed: %s", err)
+	}
+	if secrets, _ := clientset.CoreV1().Secrets("team-b").List(context.TODO(), metav1.ListOptions{}); len(secrets.Items) != 0 {
+		t.Errorf("expected the records of b to be purged, got %v", secrets.Items)
+	}
+
+	if _, err := c.InstallReleaseFromChart(testChart("0.1.0"), "team-c", helm.ReleaseName("c")); err == nil || !strings.Contains(err.Error(), "not one of the tillerless namespaces") {
+		t.Errorf("expected an install into another namespace to fail, got %v", err)
+	}
+
+	for _, a := range clientset.Actions() {
+		if a.GetResource().Resource != "secrets" {
+			continue
+		}
+		if a.GetNamespace() != "team-a" && a.GetNamespace() != "team-b" {
+			t.Errorf("expected only secrets in the tillerless namespaces to be accessed, got %s %s in %q", a.GetVerb(), a.GetResource().Resource, a.GetNamespace())
+		}
+	}
+}
@@ -29,6 +29,8 @@ const (
 	HookDeleteAnno = "helm.sh/hook-delete-policy"
 	// HookDeleteTimeoutAnno is the label name for the timeout value for delete policies
 	HookDeleteTimeoutAnno = "helm.sh/hook-delete-timeout"
+	// TestRetriesAnno is the annotation name for the number of times a failed test is retried
+	TestRetriesAnno = "helm.sh/test-retries"
 )
 
 // Types of hooks
@@ -59,6 +59,9 @@ import (
 	"k8s.io/kubectl/pkg/validation"
 	"k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubectl/pkg/cmd/get"
+
+	"k8s.io/helm/pkg/logging"
+	"k8s.io/helm/pkg/tracing"
 )
 
 // MissingGetHeader is added to Get's output when a resource is not found.
@@ -72,7 +75,7 @@ var metadataAccessor = meta.NewAccessor()
 // Client represents a client capable of communicating with the Kubernetes API.
 type Client struct {
 	cmdutil.Factory
-	Log func(string, ...interface{})
+	Log logging.Logger
 }
 
 // New creates a new Client.
@@ -88,12 +91,10 @@ func New(getter genericclioptions.RESTClientGetter) *Client {
 
 	return &Client{
 		Factory: cmdutil.NewFactory(getter),
-		Log:     nopLogger,
+		Log:     logging.Discard,
 	}
 }
 
-var nopLogger = func(_ string, _ ...interface{}) {}
-
 // ResourceActorFunc performs an action on a single resource.
 type ResourceActorFunc func(*resource.Info) error
 
@@ -101,6 +102,34 @@ type ResourceActorFunc func(*resource.Info) error
 //
 // Namespace will set the namespace.
 func (c *Client) Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	return c.CreateWithOptions(namespace, reader, CreateOptions{
+		Timeout:    timeout,
+		ShouldWait: shouldWait,
+	})
+}
+
+// CreateOptions provides options to control create behavior
+type CreateOptions struct {
+	Timeout    int64
+	ShouldWait bool
+	// Adopt takes over resources that already exist instead of failing to create them
+	Adopt bool
+	// AdoptOnly requires every resource to already exist, nothing is created
+	AdoptOnly bool
+	// ReleaseName, if set, is recorded as the owner of every resource, and
+	// resources owned by another release are not adopted
+	ReleaseName string
+	// Context, if set, carries the span of the calling operation. Waiting for
+	// the resources is traced as its child.
+	Context context.Context
+}
+
+// CreateWithOptions creates Kubernetes resources from an io.reader.
+//
+// Namespace will set the namespace. CreateOptions provides additional parameters to control
+// create behavior. When adopting, resources that already exist are patched to the state
+// given in the reader instead of being created.
+func (c *Client) CreateWithOptions(namespace string, reader io.Reader, opts CreateOptions) error {
 	client, err := c.KubernetesClientSet()
 	if err != nil {
 		return err
@@ -108,21 +137,104 @@ func (c *Client) Create(namespace string, reader io.Reader, timeout int64, shoul
 	if err := ensureNamespace(client, namespace); err != nil {
 		return err
 	}
-	c.Log("building resources from manifest")
+	c.Log.Debugf("building resources from manifest")
 	infos, buildErr := c.BuildUnstructured(namespace, reader)
 	if buildErr != nil {
 		return buildErr
 	}
-	c.Log("creating %d resource(s)", len(infos))
-	if err := perform(infos, createResource); err != nil {
+	if opts.ReleaseName != "" {
+		for _, info := range infos {
+			if err := setOwner(info.Object, opts.ReleaseName); err != nil {
+				return fmt.Errorf("could not set owner of %q: %s", info.Name, err)
+			}
+		}
+	}
+
+	create := createResource
+	if opts.Adopt || opts.AdoptOnly {
+		// Look up every resource before changing any, so that a resource that
+		// cannot be adopted leaves the cluster untouched.
+		existing, err := c.lookupAdoptable(infos, opts)
+		if err != nil {
+			return err
+		}
+		c.Log.Infof("adopting %d and creating %d resource(s)", len(existing), len(infos)-len(existing))
+		create = func(info *resource.Info) error {
+			if current, ok := existing[info]; ok {
+				return c.adoptResource(info, current)
+			}
+			return createResource(info)
+		}
+	} else {
+		c.Log.Infof("creating %d resource(s)", len(infos))
+	}
+	if err := perform(infos, create); err != nil {
 		return err
 	}
-	if shouldWait {
-		return c.waitForResources(time.Duration(timeout)*time.Second, infos)
+	if opts.ShouldWait {
+		return c.tracedWait(opts.Context, opts.Timeout, infos)
 	}
 	return nil
 }
 
+// lookupAdoptable gets the resources of infos that already exist in the
+// cluster. It fails if one of them is owned by another release, or if one is
+// missing and opts only allow adoption.
+func (c *Client) lookupAdoptable(infos Result, opts CreateOptions) (map[*resource.Info]runtime.Object, error) {
+	var mu sync.Mutex
+	existing := map[*resource.Info]runtime.Object{}
+	err := perform(infos, func(info *resource.Info) error {
+		current, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("could not get information about the resource: %s", err)
+			}
+			if opts.AdoptOnly {
+				return fmt.Errorf("kind %s with the name %q does not exist in the cluster and cannot be adopted", info.Mapping.GroupVersionKind.Kind, info.Name)
+			}
+			return nil
+		}
+		if opts.ReleaseName != "" {
+			if err := checkOwner(current, opts.ReleaseName); err != nil {
+				return err
+			}
+		}
+		mu.Lock()
+		existing[info] = current
+		mu.Unlock()
+		return nil
+	})
+	return existing, err
+}
+
+// adoptResource patches an existing resource to the state of target, which
+// records the release that owns it.
+func (c *Client) adoptResource(target *resource.Info, current runtime.Object) error {
+	kind := target.Mapping.GroupVersionKind.Kind
+	c.Log.Infof("Adopting existing %s %q", kind, target.Name)
+
+	patch, err := json.Marshal(target.Object)
+	if err != nil {
+		return fmt.Errorf("serializing target configuration: %s", err)
+	}
+
+	// The rendered object is sent as the patch so that every field it sets is
+	// applied, while fields that only exist in the cluster are left alone.
+	patchType := types.StrategicMergePatchType
+	versionedObject, err := asVersioned(target)
+	_, isUnstructured := versionedObject.(runtime.Unstructured)
+	_, isCRD := versionedObject.(*apiextv1beta1.CustomResourceDefinition)
+	if err != nil || isUnstructured || isCRD {
+		patchType = types.MergePatchType
+	}
+
+	obj, err := resource.NewHelper(target.Client, target.Mapping).Patch(target.Namespace, target.Name, patchType, patch, nil)
+	if err != nil {
+		return fmt.Errorf("failed to adopt %s %q: %s", kind, target.Name, err)
+	}
+	return target.Refresh(obj, true)
+}
+
 func (c *Client) newBuilder(namespace string, reader io.Reader) *resource.Result {
 	return c.NewBuilder().
 		ContinueOnError().
@@ -138,7 +250,7 @@ func (c *Client) newBuilder(namespace string, reader io.Reader) *resource.Result
 func (c *Client) validator() validation.Schema {
 	schema, err := c.Validator(true)
 	if err != nil {
-		c.Log("warning: failed to load schema: %s", err)
+		c.Log.Warnf("failed to load schema: %s", err)
 	}
 	return schema
 }
@@ -189,7 +301,7 @@ func resourceInfoToObject(info *resource.Info, c *Client) runtime.Object {
 		// If the problem is just that the resource is not registered, don't print any
 		// error. This is normal for custom resources.
 		if !runtime.IsNotRegisteredError(err) {
-			c.Log("Warning: conversion to internal type failed: %v", err)
+			c.Log.Warnf("conversion to internal type failed: %v", err)
 		}
 		// Add the unstructured object in this situation. It will still get listed, just
 		// with less information.
@@ -230,9 +342,9 @@ func (c *Client) Get(namespace string, reader io.Reader) (string, error) {
 	err = perform(infos, func(info *resource.Info) error {
 		mux.Lock()
 		defer mux.Unlock()
-		c.Log("Doing get for %s: %q", info.Mapping.GroupVersionKind.Kind, info.Name)
+		c.Log.Debugf("Doing get for %s: %q", info.Mapping.GroupVersionKind.Kind, info.Name)
 		if err := info.Get(); err != nil {
-			c.Log("WARNING: Failed Get for resource %q: %s", info.Name, err)
+			c.Log.Warnf("Failed Get for resource %q: %s", info.Name, err)
 			missing = append(missing, fmt.Sprintf("%v\t\t%s", info.Mapping.Resource, info.Name))
 			return nil
 		}
@@ -254,7 +366,7 @@ func (c *Client) Get(namespace string, reader io.Reader) (string, error) {
 		//Get the relation pods
 		objPods, err = c.getSelectRelationPod(info, objPods)
 		if err != nil {
-			c.Log("Warning: get the relation pod is failed, err:%s", err.Error())
+			c.Log.Warnf("get the relation pod is failed, err:%s", err.Error())
 		}
 
 		return nil
@@ -303,7 +415,7 @@ func (c *Client) Get(namespace string, reader io.Reader) (string, error) {
 		vk := objs[t]
 		for _, resourceName := range sortedResources {
 			if err := typePrinter.PrintObj(vk[resourceName], buf); err != nil {
-				c.Log("failed to print object type %s, object: %q :\n %v", t, resourceName, err)
+				c.Log.Errorf("failed to print object type %s, object: %q :\n %v", t, resourceName, err)
 				return "", err
 			}
 		}
@@ -345,6 +457,14 @@ type UpdateOptions struct {
 	ShouldWait bool
 	// Allow deletion of new resources created in this update when update failed
 	CleanupOnFail bool
+	// Adopt takes over resources that already exist but were not part of the original manifest
+	Adopt bool
+	// ReleaseName, if set, is recorded as the owner of every resource, and
+	// resources owned by another release are not adopted
+	ReleaseName string
+	// Context, if set, carries the span of the calling operation. Waiting for
+	// the resources is traced as its child.
+	Context context.Context
 }
 
 // UpdateWithOptions reads the current configuration and a target configuration from io.reader
@@ -360,23 +480,32 @@ func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReade
 		return fmt.Errorf("failed decoding reader into objects: %s", err)
 	}
 
-	c.Log("building resources from updated manifest")
+	c.Log.Debugf("building resources from updated manifest")
 	target, err := c.BuildUnstructured(namespace, targetReader)
 	if err != nil {
 		return fmt.Errorf("failed decoding reader into objects: %s", err)
 	}
 
+	if opts.ReleaseName != "" {
+		for _, info := range target {
+			if err := setOwner(info.Object, opts.ReleaseName); err != nil {
+				return fmt.Errorf("could not set owner of %q: %s", info.Name, err)
+			}
+		}
+	}
+
 	newlyCreatedResources := []*resource.Info{}
 	updateErrors := []string{}
 
-	c.Log("checking %d resources for changes", len(target))
+	c.Log.Debugf("checking %d resources for changes", len(target))
 	err = target.Visit(func(info *resource.Info, err error) error {
 		if err != nil {
 			return err
 		}
 
 		helper := resource.NewHelper(info.Client, info.Mapping)
-		if _, err := helper.Get(info.Namespace, info.Name, info.Export); err != nil {
+		current, err := helper.Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
 			if !errors.IsNotFound(err) {
 				return fmt.Errorf("Could not get information about the resource: %s", err)
 			}
@@ -388,7 +517,7 @@ func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReade
 			newlyCreatedResources = append(newlyCreatedResources, info)
 
 			kind := info.Mapping.GroupVersionKind.Kind
-			c.Log("Created a new %s called %q\n", kind, info.Name)
+			c.Log.Infof("Created a new %s called %q\n", kind, info.Name)
 			return nil
 		}
 
@@ -400,15 +529,23 @@ func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReade
 		//
 		// See https://github.com/helm/helm/issues/1193 for more info.
 		if originalInfo == nil {
+			if opts.Adopt {
+				if opts.ReleaseName != "" {
+					if err := checkOwner(current, opts.ReleaseName); err != nil {
+						return err
+					}
+				}
+				return c.adoptResource(info, current)
+			}
 			return fmt.Errorf(
-				"kind %s with the name %q already exists in the cluster and wasn't defined in the previous release. Before upgrading, please either delete the resource from the cluster or remove it from the chart",
+				"kind %s with the name %q already exists in the cluster and wasn't defined in the previous release. Before upgrading, please either delete the resource from the cluster, remove it from the chart or adopt it with --adopt",
 				info.Mapping.GroupVersionKind.Kind,
 				info.Name,
 			)
 		}
 
 		if err := updateResource(c, info, originalInfo.Object, opts.Force, opts.Recreate); err != nil {
-			c.Log("error updating the resource %q:\n\t %v", info.Name, err)
+			c.Log.Errorf("error updating the resource %q:\n\t %v", info.Name, err)
 			updateErrors = append(updateErrors, err.Error())
 		}
 
@@ -418,7 +555,7 @@ func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReade
 	cleanupErrors := []string{}
 
 	if opts.CleanupOnFail && (err != nil || len(updateErrors) != 0) {
-		c.Log("Cleanup on fail enabled: cleaning up newly created resources due to update manifests failures")
+		c.Log.Infof("Cleanup on fail enabled: cleaning up newly created resources due to update manifests failures")
 		cleanupErrors = c.cleanup(newlyCreatedResources)
 	}
 
@@ -430,30 +567,30 @@ func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReade
 	}
 
 	for _, info := range original.Difference(target) {
-		c.Log("Deleting %q in %s...", info.Name, info.Namespace)
+		c.Log.Infof("Deleting %q in %s...", info.Name, info.Namespace)
 
 		if err := info.Get(); err != nil {
-			c.Log("Unable to get obj %q, err: %s", info.Name, err)
+			c.Log.Errorf("Unable to get obj %q, err: %s", info.Name, err)
 		}
 		annotations, err := metadataAccessor.Annotations(info.Object)
 		if err != nil {
-			c.Log("Unable to get annotations on %q, err: %s", info.Name, err)
+			c.Log.Errorf("Unable to get annotations on %q, err: %s", info.Name, err)
 		}
 		if ResourcePolicyIsKeep(annotations) {
 			policy := annotations[ResourcePolicyAnno]
-			c.Log("Skipping delete of %q due to annotation [%s=%s]", info.Name, ResourcePolicyAnno, policy)
+			c.Log.Infof("Skipping delete of %q due to annotation [%s=%s]", info.Name, ResourcePolicyAnno, policy)
 			continue
 		}
 
 		if err := deleteResource(info); err != nil {
-			c.Log("Failed to delete %q, err: %s", info.Name, err)
+			c.Log.Errorf("Failed to delete %q, err: %s", info.Name, err)
 		}
 	}
 	if opts.ShouldWait {
-		err := c.waitForResources(time.Duration(opts.Timeout)*time.Second, target)
+		err := c.tracedWait(opts.Context, opts.Timeout, target)
 
 		if opts.CleanupOnFail && err != nil {
-			c.Log("Cleanup on fail enabled: cleaning up newly created resources due to wait failure during update")
+			c.Log.Infof("Cleanup on fail enabled: cleaning up newly created resources due to wait failure during update")
 			cleanupErrors = c.cleanup(newlyCreatedResources)
 			return fmt.Errorf(strings.Join(append([]string{err.Error()}, cleanupErrors...), " && "))
 		}
@@ -463,12 +600,23 @@ func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReade
 	return nil
 }
 
+// tracedWait waits for resources to be ready, traced as a child of the span
+// in ctx.
+func (c *Client) tracedWait(ctx context.Context, timeout int64, infos Result) error {
+	_, span := tracing.Start(ctx, "kube.wait",
+		tracing.Int64("kube.resources", int64(len(infos))),
+		tracing.Int64("kube.timeout_seconds", timeout))
+	err := c.waitForResources(time.Duration(timeout)*time.Second, infos)
+	span.End(err)
+	return err
+}
+
 func (c *Client) cleanup(newlyCreatedResources []*resource.Info) (cleanupErrors []string) {
 	for _, info := range newlyCreatedResources {
 		kind := info.Mapping.GroupVersionKind.Kind
-		c.Log("Deleting newly created %s with the name %q in %s...", kind, info.Name, info.Namespace)
+		c.Log.Infof("Deleting newly created %s with the name %q in %s...", kind, info.Name, info.Namespace)
 		if err := deleteResource(info); err != nil {
-			c.Log("Error deleting newly created %s with the name %q in %s: %s", kind, info.Name, info.Namespace, err)
+			c.Log.Errorf("Error deleting newly created %s with the name %q in %s: %s", kind, info.Name, info.Namespace, err)
 			cleanupErrors = append(cleanupErrors, err.Error())
 		}
 	}
@@ -493,7 +641,7 @@ func (c *Client) DeleteWithTimeout(namespace string, reader io.Reader, timeout i
 		return err
 	}
 	err = perform(infos, func(info *resource.Info) error {
-		c.Log("Starting delete for %q %s", info.Name, info.Mapping.GroupVersionKind.Kind)
+		c.Log.Infof("Starting delete for %q %s", info.Name, info.Mapping.GroupVersionKind.Kind)
 		err := deleteResource(info)
 		return c.skipIfNotFound(err)
 	})
@@ -502,7 +650,7 @@ func (c *Client) DeleteWithTimeout(namespace string, reader io.Reader, timeout i
 	}
 
 	if shouldWait {
-		c.Log("Waiting for %d seconds for delete to be completed", timeout)
+		c.Log.Infof("Waiting for %d seconds for delete to be completed", timeout)
 		return waitUntilAllResourceDeleted(infos, time.Duration(timeout)*time.Second)
 	}
 
@@ -511,7 +659,7 @@ func (c *Client) DeleteWithTimeout(namespace string, reader io.Reader, timeout i
 
 func (c *Client) skipIfNotFound(err error) error {
 	if errors.IsNotFound(err) {
-		c.Log("%v", err)
+		c.Log.Debugf("%v", err)
 		return nil
 	}
 	return err
@@ -719,7 +867,7 @@ func updateResource(c *Client, target *resource.Info, currentObj runtime.Object,
 		return fmt.Errorf("failed to create patch: %s", err)
 	}
 	if patch == nil {
-		c.Log("Looks like there are no changes for %s %q", target.Mapping.GroupVersionKind.Kind, target.Name)
+		c.Log.Debugf("Looks like there are no changes for %s %q", target.Mapping.GroupVersionKind.Kind, target.Name)
 		// This needs to happen to make sure that tiller has the latest info from the API
 		// Otherwise there will be no labels and other functions that use labels will panic
 		if err := target.Get(); err != nil {
@@ -784,7 +932,7 @@ func updateResource(c *Client, target *resource.Info, currentObj runtime.Object,
 
 	// Restart pods
 	for _, pod := range pods.Items {
-		c.Log("Restarting pod: %v/%v", pod.Namespace, pod.Name)
+		c.Log.Infof("Restarting pod: %v/%v", pod.Namespace, pod.Name)
 
 		// Delete each pod for get them restarted with changed spec.
 		if err := client.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, *metav1.NewPreconditionDeleteOptions(string(pod.UID))); err != nil {
@@ -846,7 +994,7 @@ func (c *Client) watchUntilReady(timeout time.Duration, info *resource.Info) err
 	lw := cachetools.NewListWatchFromClient(info.Client, info.Mapping.Resource.Resource, info.Namespace, selector)
 
 	kind := info.Mapping.GroupVersionKind.Kind
-	c.Log("Watching for changes to %s %s with timeout of %v", kind, info.Name, timeout)
+	c.Log.Infof("Watching for changes to %s %s with timeout of %v", kind, info.Name, timeout)
 
 	// What we watch for depends on the Kind.
 	// - For a Job, we watch for completion.
@@ -863,17 +1011,17 @@ func (c *Client) watchUntilReady(timeout time.Duration, info *resource.Info) err
 			// we get. We care mostly about jobs, where what we want to see is
 			// the status go into a good state. For other types, like ReplicaSet
 			// we don't really do anything to support these as hooks.
-			c.Log("Add/Modify event for %s: %v", info.Name, e.Type)
+			c.Log.Debugf("Add/Modify event for %s: %v", info.Name, e.Type)
 			if kind == "Job" {
 				return c.waitForJob(e, info.Name)
 			}
 			return true, nil
 		case watch.Deleted:
-			c.Log("Deleted event for %s", info.Name)
+			c.Log.Debugf("Deleted event for %s", info.Name)
 			return true, nil
 		case watch.Error:
 			// Handle error and return with an error.
-			c.Log("Error event for %s", info.Name)
+			c.Log.Errorf("Error event for %s", info.Name)
 			return true, fmt.Errorf("Failed to deploy %s", info.Name)
 		default:
 			return false, nil
@@ -900,7 +1048,7 @@ func (c *Client) waitForJob(e watch.Event, name string) (bool, error) {
 		}
 	}
 
-	c.Log("%s: Jobs active: %d, jobs failed: %d, jobs succeeded: %d", name, job.Status.Active, job.Status.Failed, job.Status.Succeeded)
+	c.Log.Debugf("%s: Jobs active: %d, jobs failed: %d, jobs succeeded: %d", name, job.Status.Active, job.Status.Failed, job.Status.Succeeded)
 	return false, nil
 }
 
@@ -946,7 +1094,7 @@ func (c *Client) WaitAndGetCompletedPodPhase(namespace string, reader io.Reader,
 func (c *Client) watchPodUntilComplete(timeout time.Duration, info *resource.Info) error {
 	lw := cachetools.NewListWatchFromClient(info.Client, info.Mapping.Resource.Resource, info.Namespace, fields.ParseSelectorOrDie(fmt.Sprintf("metadata.name=%s", info.Name)))
 
-	c.Log("Watching pod %s for completion with timeout of %v", info.Name, timeout)
+	c.Log.Infof("Watching pod %s for completion with timeout of %v", info.Name, timeout)
 	ctx, cancel := watchtools.ContextWithOptionalTimeout(context.Background(), timeout)
 	defer cancel()
 	_, err := watchtools.ListWatchUntil(ctx, lw, func(e watch.Event) (bool, error) {
@@ -992,7 +1140,7 @@ func (c *Client) getSelectRelationPod(info *resource.Info, objPods map[string][]
 		return objPods, nil
 	}
 
-	c.Log("get relation pod of object: %s/%s/%s", info.Namespace, info.Mapping.GroupVersionKind.Kind, info.Name)
+	c.Log.Debugf("get relation pod of object: %s/%s/%s", info.Namespace, info.Mapping.GroupVersionKind.Kind, info.Name)
 
 	versioned := asVersionedOrUnstructured(info)
 	selector, ok := getSelectorFromObject(versioned)
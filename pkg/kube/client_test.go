@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -36,6 +37,8 @@ import (
 	"k8s.io/client-go/rest/fake"
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
 	kubectlscheme "k8s.io/kubectl/pkg/scheme"
+
+	"k8s.io/helm/pkg/logging"
 )
 
 func init() {
@@ -138,7 +141,7 @@ func newTestClient() *testClient {
 	tf := cmdtesting.NewTestFactory()
 	c := &Client{
 		Factory: tf,
-		Log:     nopLogger,
+		Log:     logging.Discard,
 	}
 	return &testClient{
 		Client:      c,
@@ -197,7 +200,7 @@ func TestUpdate(t *testing.T) {
 
 	c := &Client{
 		Factory: tf,
-		Log:     nopLogger,
+		Log:     logging.Discard,
 	}
 
 	if err := c.Update(v1.NamespaceDefault, objBody(&listA), objBody(&listB), false, false, 0, false); err != nil {
@@ -271,11 +274,11 @@ func TestUpdateNonManagedResourceError(t *testing.T) {
 
 	c := &Client{
 		Factory: tf,
-		Log:     nopLogger,
+		Log:     logging.Discard,
 	}
 
 	if err := c.Update(v1.NamespaceDefault, objBody(&current), objBody(&target), false, false, 0, false); err != nil {
-		if err.Error() != "kind Pod with the name \"starfish\" already exists in the cluster and wasn't defined in the previous release. Before upgrading, please either delete the resource from the cluster or remove it from the chart" {
+		if err.Error() != "kind Pod with the name \"starfish\" already exists in the cluster and wasn't defined in the previous release. Before upgrading, please either delete the resource from the cluster, remove it from the chart or adopt it with --adopt" {
 			t.Fatal(err)
 		}
 	} else {
@@ -283,6 +286,179 @@ func TestUpdateNonManagedResourceError(t *testing.T) {
 	}
 }
 
+func TestCreateWithAdopt(t *testing.T) {
+	existing := newPod("starfish")
+	target := newPodList("starfish", "otter")
+
+	var actions []string
+
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+
+	handler := func(req *http.Request) (*http.Response, error) {
+		p, m := req.URL.Path, req.Method
+		actions = append(actions, p+":"+m)
+		t.Logf("got request %s %s", p, m)
+		switch {
+		case p == "/api/v1/namespaces/default" && m == "GET":
+			return newResponse(200, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+		case p == "/namespaces/default/pods/starfish" && m == "GET":
+			return newResponse(200, &existing)
+		case p == "/namespaces/default/pods/starfish" && m == "PATCH":
+			data, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("could not dump request: %s", err)
+			}
+			req.Body.Close()
+			if !strings.Contains(string(data), `"helm.sh/release-name":"aeneas"`) {
+				t.Errorf("expected patch to record the owning release, got %s", data)
+			}
+			return newResponse(200, &target.Items[0])
+		case p == "/namespaces/default/pods/otter" && m == "GET":
+			return newResponse(404, notFoundBody())
+		case p == "/namespaces/default/pods" && m == "POST":
+			return newResponse(200, &target.Items[1])
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+			return nil, nil
+		}
+	}
+	tf.Client = &fake.RESTClient{NegotiatedSerializer: scheme.Codecs.WithoutConversion(), Client: fake.CreateHTTPClient(handler)}
+	tf.UnstructuredClient = &fake.RESTClient{NegotiatedSerializer: unstructuredSerializer, Client: fake.CreateHTTPClient(handler)}
+
+	c := &Client{
+		Factory: tf,
+		Log:     logging.Discard,
+	}
+	opts := CreateOptions{Adopt: true, ReleaseName: "aeneas"}
+	if err := c.CreateWithOptions(v1.NamespaceDefault, objBody(&target), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every resource is looked up before any is adopted or created; the
+	// requests of each step are concurrent.
+	expectedActions := []string{
+		"/api/v1/namespaces/default:GET",
+		"/namespaces/default/pods/otter:GET",
+		"/namespaces/default/pods/starfish:GET",
+		"/namespaces/default/pods/starfish:PATCH",
+		"/namespaces/default/pods:POST",
+	}
+	if len(expectedActions) != len(actions) {
+		t.Fatalf("unexpected number of requests, expected %d, got %d: %v", len(expectedActions), len(actions), actions)
+	}
+	sort.Strings(actions[1:3])
+	sort.Strings(actions[3:])
+	for k, v := range expectedActions {
+		if actions[k] != v {
+			t.Errorf("expected %s request got %s", v, actions[k])
+		}
+	}
+}
+
+func TestCreateWithAdoptErrors(t *testing.T) {
+	owned := newPod("starfish")
+	owned.Annotations = map[string]string{ReleaseNameAnno: "virgil"}
+
+	tests := []struct {
+		name     string
+		existing *v1.Pod
+		opts     CreateOptions
+		expected string
+	}{
+		{
+			name:     "owned by another release",
+			existing: &owned,
+			opts:     CreateOptions{Adopt: true, ReleaseName: "aeneas"},
+			expected: `Pod "starfish" is owned by release "virgil" and cannot be adopted by release "aeneas"`,
+		},
+		{
+			name:     "missing with adopt only",
+			opts:     CreateOptions{AdoptOnly: true, ReleaseName: "aeneas"},
+			expected: `kind Pod with the name "starfish" does not exist in the cluster and cannot be adopted`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory()
+			defer tf.Cleanup()
+
+			handler := func(req *http.Request) (*http.Response, error) {
+				p, m := req.URL.Path, req.Method
+				switch {
+				case p == "/api/v1/namespaces/default" && m == "GET":
+					return newResponse(200, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+				case p == "/namespaces/default/pods/starfish" && m == "GET":
+					if tt.existing == nil {
+						return newResponse(404, notFoundBody())
+					}
+					return newResponse(200, tt.existing)
+				default:
+					t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+					return nil, nil
+				}
+			}
+			tf.Client = &fake.RESTClient{NegotiatedSerializer: scheme.Codecs.WithoutConversion(), Client: fake.CreateHTTPClient(handler)}
+			tf.UnstructuredClient = &fake.RESTClient{NegotiatedSerializer: unstructuredSerializer, Client: fake.CreateHTTPClient(handler)}
+
+			c := &Client{
+				Factory: tf,
+				Log:     logging.Discard,
+			}
+			target := newPodList("starfish")
+			err := c.CreateWithOptions(v1.NamespaceDefault, objBody(&target), tt.opts)
+			if err == nil {
+				t.Fatal("error expected")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error containing %q, got %q", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestCreateWithAdoptOnlyMissing(t *testing.T) {
+	existing := newPod("starfish")
+	target := newPodList("starfish", "otter")
+
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+
+	var mu sync.Mutex
+	var changes []string
+	handler := func(req *http.Request) (*http.Response, error) {
+		p, m := req.URL.Path, req.Method
+		switch {
+		case p == "/api/v1/namespaces/default" && m == "GET":
+			return newResponse(200, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+		case p == "/namespaces/default/pods/starfish" && m == "GET":
+			return newResponse(200, &existing)
+		case p == "/namespaces/default/pods/otter" && m == "GET":
+			return newResponse(404, notFoundBody())
+		default:
+			mu.Lock()
+			changes = append(changes, p+":"+m)
+			mu.Unlock()
+			return newResponse(200, &target.Items[0])
+		}
+	}
+	tf.Client = &fake.RESTClient{NegotiatedSerializer: scheme.Codecs.WithoutConversion(), Client: fake.CreateHTTPClient(handler)}
+	tf.UnstructuredClient = &fake.RESTClient{NegotiatedSerializer: unstructuredSerializer, Client: fake.CreateHTTPClient(handler)}
+
+	c := &Client{
+		Factory: tf,
+		Log:     logging.Discard,
+	}
+	opts := CreateOptions{AdoptOnly: true, ReleaseName: "aeneas"}
+	if err := c.CreateWithOptions(v1.NamespaceDefault, objBody(&target), opts); err == nil {
+		t.Fatal("error expected")
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no resource to be changed, got %v", changes)
+	}
+}
+
 func TestDeleteWithTimeout(t *testing.T) {
 	testCases := map[string]struct {
 		deleteTimeout int64
@@ -0,0 +1,56 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ReleaseNameAnno is the annotation name recording the release that owns a resource
+const ReleaseNameAnno = "helm.sh/release-name"
+
+// setOwner records releaseName as the owner of obj.
+func setOwner(obj runtime.Object, releaseName string) error {
+	annotations, err := metadataAccessor.Annotations(obj)
+	if err != nil {
+		return err
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ReleaseNameAnno] = releaseName
+	return metadataAccessor.SetAnnotations(obj, annotations)
+}
+
+// checkOwner returns an error if obj is owned by a release other than releaseName.
+//
+// Resources without an owner may be claimed by any release.
+func checkOwner(obj runtime.Object, releaseName string) error {
+	annotations, err := metadataAccessor.Annotations(obj)
+	if err != nil {
+		return err
+	}
+	owner, ok := annotations[ReleaseNameAnno]
+	if ok && owner != "" && owner != releaseName {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		name, _ := metadataAccessor.Name(obj)
+		return fmt.Errorf("%s %q is owned by release %q and cannot be adopted by release %q", kind, name, owner, releaseName)
+	}
+	return nil
+}
@@ -19,18 +19,58 @@ package lint // import "k8s.io/helm/pkg/lint"
 import (
 	"path/filepath"
 
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm/environment"
 	"k8s.io/helm/pkg/lint/rules"
 	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/plugin"
 )
 
 // All runs all of the available linters on the given base directory.
 func All(basedir string, values []byte, namespace string, strict bool) support.Linter {
+	return AllWithCapabilities(basedir, values, namespace, strict, nil)
+}
+
+// AllWithCapabilities runs all of the available linters on the given base
+// directory, rendering the templates with the given capabilities. A nil caps
+// uses the default capabilities.
+func AllWithCapabilities(basedir string, values []byte, namespace string, strict bool, caps *chartutil.Capabilities) support.Linter {
+	return AllWithOptions(basedir, values, Options{
+		TemplateOptions: rules.TemplateOptions{Namespace: namespace, Strict: strict, Capabilities: caps},
+	})
+}
+
+// Options configures a linting run.
+type Options struct {
+	rules.TemplateOptions
+	// Plugins add the lint rules they supply to the run. Plugins without
+	// lint rules are ignored.
+	Plugins []*plugin.Plugin
+	// Settings are passed to plugins in their environment.
+	Settings environment.EnvSettings
+	// ValuesFiles are the values files the values of the run were read
+	// from. The ones holding secrets are checked to be encrypted.
+	ValuesFiles []string
+}
+
+// AllWithOptions runs all of the available linters on the given base
+// directory, and the lint rules of the given plugins.
+//
+// The severity of rules is configured in the .helmlint.yaml file of the
+// chart, and rules are suppressed in single files with comments, see
+// support.Suppressions.
+func AllWithOptions(basedir string, values []byte, opts Options) support.Linter {
 	// Using abs path to get directory context
 	chartDir, _ := filepath.Abs(basedir)
 
 	linter := support.Linter{ChartDir: chartDir}
+	rules.Config(&linter, opts.Plugins)
 	rules.Chartfile(&linter)
-	rules.Values(&linter)
-	rules.Templates(&linter, values, namespace, strict)
+	rules.ValuesWithFiles(&linter, opts.ValuesFiles)
+	rules.Files(&linter)
+	rules.TemplatesWithOptions(&linter, values, opts.TemplateOptions)
+	for _, p := range opts.Plugins {
+		rules.Plugin(&linter, p, opts.Settings, values, opts.TemplateOptions)
+	}
 	return linter
 }
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/plugin"
 
 	"testing"
 )
@@ -33,6 +34,7 @@ const (
 	badValuesFileDir = "rules/testdata/badvaluesfile"
 	badYamlFileDir   = "rules/testdata/albatross"
 	goodChartDir     = "rules/testdata/goodone"
+	configuredDir    = "rules/testdata/configured"
 )
 
 func TestBadChart(t *testing.T) {
@@ -101,3 +103,36 @@ func TestGoodChart(t *testing.T) {
 		t.Errorf("All failed but shouldn't have: %#v", m)
 	}
 }
+
+func TestConfiguredChart(t *testing.T) {
+	m := All(configuredDir, values, namespace, strict).Messages
+	expect := []string{
+		`[ERROR] .helmlint.yaml: unknown rule "no-such-rule"`,
+		`[ERROR] values.yaml: file does not exist`,
+		`[WARNING] templates/other.md: file extension '.md' not valid. Valid extensions are .yaml, .yml, .tpl, or .txt`,
+	}
+	if len(m) != len(expect) {
+		t.Fatalf("expected %d messages, got %v", len(expect), m)
+	}
+	for i, msg := range m {
+		if msg.Error() != expect[i] {
+			t.Errorf("expected %q, got %q", expect[i], msg.Error())
+		}
+	}
+}
+
+func TestPlugins(t *testing.T) {
+	plugins, err := plugin.LoadAll("rules/testdata/plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	linter := AllWithOptions(goodChartDir, values, Options{Plugins: plugins})
+
+	ids := map[string]bool{}
+	for _, msg := range linter.Messages {
+		ids[msg.RuleID()] = true
+	}
+	if len(linter.Messages) != 3 || !ids["lint-plugin"] || !ids["house-rules-team-label"] || !ids["values-echo"] {
+		t.Errorf("expected a message of each plugin, got %v", linter.Messages)
+	}
+}
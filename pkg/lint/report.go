@@ -0,0 +1,232 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/version"
+)
+
+const (
+	// ReportJSON writes the report as JSON.
+	ReportJSON = "json"
+	// ReportSARIF writes the report as SARIF 2.1.0, as read by code scanning
+	// tools.
+	ReportSARIF = "sarif"
+)
+
+// Report is a machine readable summary of linting charts.
+type Report struct {
+	Charts []*ReportChart `json:"charts"`
+
+	rules map[string]support.Rule
+}
+
+// ReportChart is the result of linting a chart.
+type ReportChart struct {
+	Path string `json:"path"`
+	// Error is set if the chart could not be linted.
+	Error           string           `json:"error,omitempty"`
+	HighestSeverity string           `json:"highestSeverity"`
+	Messages        []*ReportMessage `json:"messages"`
+}
+
+// ReportMessage is a problem found in a chart.
+type ReportMessage struct {
+	Rule     string `json:"rule,omitempty"`
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Message  string `json:"message"`
+}
+
+// NewReport creates an empty report.
+func NewReport() *Report {
+	return &Report{Charts: []*ReportChart{}, rules: map[string]support.Rule{}}
+}
+
+// Add adds the result of linting the chart at path to the report. A non-nil
+// err tells that the chart could not be linted.
+func (r *Report) Add(path string, linter support.Linter, err error) {
+	c := &ReportChart{
+		Path:            path,
+		HighestSeverity: support.SeverityName(linter.HighestSeverity),
+		Messages:        []*ReportMessage{},
+	}
+	if err != nil {
+		c.Error = err.Error()
+		c.HighestSeverity = support.SeverityName(support.ErrorSev)
+	}
+	for _, msg := range linter.Messages {
+		c.Messages = append(c.Messages, &ReportMessage{
+			Rule:     msg.RuleID(),
+			Severity: support.SeverityName(msg.Severity),
+			Path:     msg.Path,
+			Message:  msg.Err.Error(),
+		})
+	}
+	for id, rule := range linter.Rules {
+		r.rules[id] = rule
+	}
+	r.Charts = append(r.Charts, c)
+}
+
+// Write writes the report to w in the given format.
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case ReportJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case ReportSARIF:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r.sarif())
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifText          `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifText       `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+func (r *Report) sarif() *sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "helm lint",
+			Version:        version.GetVersion(),
+			InformationURI: "https://helm.sh",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	ids := make([]string, 0, len(r.rules))
+	for id := range r.rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		rule := r.rules[id]
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:                   id,
+			ShortDescription:     sarifText{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity)},
+		})
+	}
+
+	for _, c := range r.Charts {
+		if c.Error != "" {
+			run.Results = append(run.Results, sarifResult{
+				Level:     sarifLevel(support.ErrorSev),
+				Message:   sarifText{Text: c.Error},
+				Locations: sarifLocations(c.Path),
+			})
+		}
+		for _, msg := range c.Messages {
+			severity, _ := support.ParseSeverity(msg.Severity)
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    msg.Rule,
+				Level:     sarifLevel(severity),
+				Message:   sarifText{Text: msg.Message},
+				Locations: sarifLocations(filepath.Join(c.Path, msg.Path)),
+			})
+		}
+	}
+
+	return &sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	}
+}
+
+func sarifLocations(path string) []sarifLocation {
+	return []sarifLocation{{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(path)},
+		},
+	}}
+}
+
+func sarifLevel(severity int) string {
+	switch severity {
+	case support.ErrorSev:
+		return "error"
+	case support.WarningSev:
+		return "warning"
+	case support.InfoSev:
+		return "note"
+	}
+	return "none"
+}
@@ -36,28 +36,29 @@ func Chartfile(linter *support.Linter) {
 	chartFileName := "Chart.yaml"
 	chartPath := filepath.Join(linter.ChartDir, chartFileName)
 
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartYamlNotDirectory(chartPath))
+	linter.RunRule(chartYamlFile, chartFileName, validateChartYamlNotDirectory(chartPath))
 
 	chartFile, err := chartutil.LoadChartfile(chartPath)
-	validChartFile := linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartYamlFormat(err))
+	validChartFile := linter.RunRule(chartYamlFormat, chartFileName, validateChartYamlFormat(err))
 
 	// Guard clause. Following linter rules require a parseable ChartFile
 	if !validChartFile {
 		return
 	}
 
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartNamePresence(chartFile))
-	linter.RunLinterRule(support.WarningSev, chartFileName, validateChartNameFormat(chartFile))
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartNameDirMatch(linter.ChartDir, chartFile))
+	linter.RunRule(chartName, chartFileName, validateChartNamePresence(chartFile))
+	linter.RunRule(chartNameFormat, chartFileName, validateChartNameFormat(chartFile))
+	linter.RunRule(chartNameDir, chartFileName, validateChartNameDirMatch(linter.ChartDir, chartFile))
 
 	// Chart metadata
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartAPIVersion(chartFile))
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartVersion(chartFile))
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartEngine(chartFile))
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartMaintainer(chartFile))
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartSources(chartFile))
-	linter.RunLinterRule(support.InfoSev, chartFileName, validateChartIconPresence(chartFile))
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartIconURL(chartFile))
+	linter.RunRule(chartAPIVersion, chartFileName, validateChartAPIVersion(chartFile))
+	linter.RunRule(chartVersion, chartFileName, validateChartVersion(chartFile))
+	linter.RunRule(chartEngine, chartFileName, validateChartEngine(chartFile))
+	linter.RunRule(chartMaintainers, chartFileName, validateChartMaintainer(chartFile))
+	linter.RunRule(chartSources, chartFileName, validateChartSources(chartFile))
+	linter.RunRule(chartIcon, chartFileName, validateChartIconPresence(chartFile))
+	linter.RunRule(chartIconURL, chartFileName, validateChartIconURL(chartFile))
+	linter.RunRule(chartType, chartFileName, validateChartType(chartFile))
 }
 
 func validateChartYamlNotDirectory(chartPath string) error {
@@ -191,3 +192,11 @@ func validateChartIconURL(cf *chart.Metadata) error {
 	}
 	return nil
 }
+
+func validateChartType(cf *chart.Metadata) error {
+	switch cf.Type {
+	case "", chartutil.TypeApplication, chartutil.TypeLibrary:
+		return nil
+	}
+	return fmt.Errorf("type '%s' is not valid. The value must be \"%s\" or \"%s\"", cf.Type, chartutil.TypeApplication, chartutil.TypeLibrary)
+}
@@ -231,6 +231,18 @@ func TestValidateChartIconURL(t *testing.T) {
 	}
 }
 
+func TestValidateChartType(t *testing.T) {
+	for _, test := range []string{"", "application", "library"} {
+		if err := validateChartType(&chart.Metadata{Type: test}); err != nil {
+			t.Errorf("validateChartType(%q) to return no error, got %s", test, err)
+		}
+	}
+	err := validateChartType(&chart.Metadata{Type: "plugin"})
+	if err == nil || !strings.Contains(err.Error(), "type 'plugin' is not valid") {
+		t.Errorf("validateChartType(\"plugin\") to return \"type 'plugin' is not valid\", got %v", err)
+	}
+}
+
 func TestChartfile(t *testing.T) {
 	linter := support.Linter{ChartDir: badChartDir}
 	Chartfile(&linter)
@@ -0,0 +1,60 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/plugin"
+)
+
+// Config reads the .helmlint.yaml file and the suppression comments of the
+// chart into the Linter, so that the rules run afterwards obey them. Rule IDs
+// are checked against the built-in rules and those of plugins.
+func Config(linter *support.Linter, plugins []*plugin.Plugin) {
+	path := support.ConfigFileName
+
+	config, err := support.LoadConfig(linter.ChartDir)
+	if linter.RunRule(lintConfig, path, err) {
+		linter.Config = config
+
+		known := map[string]bool{}
+		for _, r := range Builtin {
+			known[r.ID] = true
+		}
+		// broken plugins are reported when they are run
+		pluginRules, _ := PluginRules(plugins)
+		for _, r := range pluginRules {
+			known[r.ID] = true
+		}
+
+		var unknown []string
+		for id := range config.Rules {
+			if !known[id] {
+				unknown = append(unknown, id)
+			}
+		}
+		sort.Strings(unknown)
+		for _, id := range unknown {
+			linter.RunRule(lintConfig, path, fmt.Errorf("unknown rule %q", id))
+		}
+	}
+
+	linter.RunRule(lintConfig, "templates/", linter.LoadSuppressions())
+}
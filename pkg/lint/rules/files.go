@@ -0,0 +1,80 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/lint/support"
+)
+
+// hotspots is the number of largest files named when the chart is close to
+// its total size limit.
+const hotspots = 3
+
+// Files warns about the files of a chart that are larger than half of the
+// chartutil.DefaultLimits, and about a chart close to its limits on the
+// number of files or their total size. Files in the archives of dependencies
+// are counted decompressed.
+func Files(linter *support.Linter) {
+	sizes, err := chartutil.DirFileSizes(linter.ChartDir)
+	if err != nil {
+		// reported by templates-load
+		return
+	}
+	limits := chartutil.DefaultLimits
+
+	var total int64
+	for _, f := range sizes {
+		total += f.Size
+		if limits.MaxFileSize > 0 && f.Size > limits.MaxFileSize/2 {
+			linter.RunRule(filesSize, f.Name, fmt.Errorf("file is %s, %s the limit of %s per file", formatSize(f.Size), closeTo(f.Size, limits.MaxFileSize), formatSize(limits.MaxFileSize)))
+		}
+	}
+
+	if limits.MaxFiles > 0 && len(sizes) > limits.MaxFiles/2 {
+		linter.RunRule(filesSize, ".", fmt.Errorf("chart has %d files, %s the limit of %d files", len(sizes), closeTo(int64(len(sizes)), int64(limits.MaxFiles)), limits.MaxFiles))
+	}
+	if limits.MaxTotalSize > 0 && total > limits.MaxTotalSize/2 {
+		var largest []string
+		for i := 0; i < len(sizes) && i < hotspots; i++ {
+			largest = append(largest, fmt.Sprintf("%s (%s)", sizes[i].Name, formatSize(sizes[i].Size)))
+		}
+		linter.RunRule(filesSize, ".", fmt.Errorf("chart is %s decompressed, %s the limit of %s, the largest files are %s",
+			formatSize(total), closeTo(total, limits.MaxTotalSize), formatSize(limits.MaxTotalSize), strings.Join(largest, ", ")))
+	}
+}
+
+func closeTo(n, limit int64) string {
+	if n > limit {
+		return "over"
+	}
+	return "close to"
+}
+
+// formatSize formats a number of bytes in KiB or MiB.
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/lint/support"
+)
+
+func TestFiles(t *testing.T) {
+	chartDir, err := ioutil.TempDir("", "helm-lint-files-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(chartDir)
+
+	files := map[string]string{
+		"Chart.yaml":           "name: files\nversion: 0.1.0\n",
+		"values.yaml":          "",
+		"files/large.json":     strings.Repeat("a", 3000),
+		"files/too-large.json": strings.Repeat("a", 5000),
+		"templates/small.yaml": "a: b\n",
+	}
+	for name, data := range files {
+		path := filepath.Join(chartDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer func(limits chartutil.Limits) { chartutil.DefaultLimits = limits }(chartutil.DefaultLimits)
+	chartutil.DefaultLimits = chartutil.Limits{MaxFiles: 8, MaxFileSize: 4096, MaxTotalSize: 10000}
+
+	linter := support.Linter{ChartDir: chartDir}
+	Files(&linter)
+
+	var messages []string
+	for _, m := range linter.Messages {
+		if m.RuleID() != filesSize.ID || m.Severity != support.WarningSev {
+			t.Errorf("unexpected message %v", m)
+		}
+		messages = append(messages, m.Path+": "+m.Err.Error())
+	}
+	expect := []string{
+		"files/too-large.json: file is 4.9KiB, over the limit of 4.0KiB per file",
+		"files/large.json: file is 2.9KiB, close to the limit of 4.0KiB per file",
+		".: chart has 5 files, close to the limit of 8 files",
+		".: chart is 7.8KiB decompressed, close to the limit of 9.8KiB, the largest files are files/too-large.json (4.9KiB), files/large.json (2.9KiB), Chart.yaml (27B)",
+	}
+	if !reflect.DeepEqual(messages, expect) {
+		t.Errorf("expected\n%s\ngot\n%s", strings.Join(expect, "\n"), strings.Join(messages, "\n"))
+	}
+}
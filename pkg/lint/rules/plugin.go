@@ -0,0 +1,120 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/helm/pkg/helm/environment"
+	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/plugin"
+)
+
+// PluginMessage is a problem a lint plugin found in a chart.
+type PluginMessage struct {
+	// Rule is the ID of the violated rule, one of the rules of the plugin.
+	Rule string `json:"rule"`
+	// Path is the path of the file with the problem, relative to the chart.
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// PluginRules returns the lint rules declared by plugins.
+func PluginRules(plugins []*plugin.Plugin) ([]support.Rule, error) {
+	var rules []support.Rule
+	for _, p := range plugins {
+		if p.Metadata.LintRules == nil {
+			continue
+		}
+		for _, r := range p.Metadata.LintRules.Rules {
+			severity, err := support.ParseSeverity(r.Severity)
+			if err != nil {
+				return nil, fmt.Errorf("plugin %q: rule %s: %s", p.Metadata.Name, r.ID, err)
+			}
+			rules = append(rules, support.Rule{ID: r.ID, Severity: severity, Description: r.Description})
+		}
+	}
+	return rules, nil
+}
+
+// Plugin lints a chart with the lint rules of a plugin.
+//
+// The plugin command is run with the chart directory as its last argument
+// and the values to lint with on stdin. It writes the problems it finds to
+// stdout as a JSON list of PluginMessages.
+func Plugin(linter *support.Linter, p *plugin.Plugin, settings environment.EnvSettings, values []byte, opts TemplateOptions) {
+	if p.Metadata.LintRules == nil {
+		return
+	}
+	path := filepath.Join("plugins", p.Metadata.Name)
+
+	rules, err := PluginRules([]*plugin.Plugin{p})
+	if !linter.RunRule(lintPlugin, path, err) {
+		return
+	}
+	declared := map[string]support.Rule{}
+	for _, r := range rules {
+		declared[r.ID] = r
+	}
+
+	msgs, err := runPlugin(linter.ChartDir, p, settings, values, opts)
+	if !linter.RunRule(lintPlugin, path, err) {
+		return
+	}
+	for _, m := range msgs {
+		rule, ok := declared[m.Rule]
+		if !ok {
+			linter.RunRule(lintPlugin, path, fmt.Errorf("plugin %q reported undeclared rule %q", p.Metadata.Name, m.Rule))
+			continue
+		}
+		linter.RunRule(rule, m.Path, fmt.Errorf("%s", m.Message))
+	}
+}
+
+func runPlugin(chartDir string, p *plugin.Plugin, settings environment.EnvSettings, values []byte, opts TemplateOptions) ([]PluginMessage, error) {
+	commands := strings.Split(p.Metadata.LintRules.Command, " ")
+	argv := append(commands[1:], chartDir)
+	prog := exec.Command(filepath.Join(p.Dir, commands[0]), argv...)
+	plugin.SetupPluginEnv(settings, p.Metadata.Name, p.Dir)
+	prog.Env = append(os.Environ(),
+		"HELM_LINT_NAMESPACE="+opts.Namespace,
+		"HELM_LINT_KUBE_VERSION="+opts.KubeVersion,
+	)
+	// The values may hold decrypted secrets, so they never touch the disk.
+	prog.Stdin = bytes.NewReader(values)
+	buf := bytes.NewBuffer(nil)
+	prog.Stdout = buf
+	prog.Stderr = os.Stderr
+	if err := prog.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("plugin %q exited with error", p.Metadata.Name)
+		}
+		return nil, err
+	}
+
+	var msgs []PluginMessage
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &msgs); err != nil && buf.Len() > 0 {
+		return nil, fmt.Errorf("plugin %q: invalid output: %s", p.Metadata.Name, err)
+	}
+	return msgs, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm/environment"
+	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/plugin"
+)
+
+func TestPlugin(t *testing.T) {
+	p, err := plugin.LoadDir("testdata/plugins/house-rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chartDir, _ := filepath.Abs(goodChartDir)
+	linter := support.Linter{ChartDir: chartDir}
+	Plugin(&linter, p, environment.EnvSettings{}, []byte{}, TemplateOptions{})
+
+	if len(linter.Messages) != 1 {
+		t.Fatalf("expected 1 message, got %v", linter.Messages)
+	}
+	m := linter.Messages[0]
+	if m.RuleID() != "house-rules-team-label" || m.Severity != support.WarningSev || m.Path != "templates/goodone.yaml" {
+		t.Errorf("unexpected message %v of rule %q", m, m.RuleID())
+	}
+}
+
+func TestPluginValues(t *testing.T) {
+	p, err := plugin.LoadDir("testdata/plugins/values")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chartDir, _ := filepath.Abs(goodChartDir)
+	linter := support.Linter{ChartDir: chartDir}
+	Plugin(&linter, p, environment.EnvSettings{}, []byte("password: hunter2\n"), TemplateOptions{})
+
+	if len(linter.Messages) != 1 {
+		t.Fatalf("expected 1 message, got %v", linter.Messages)
+	}
+	if m := linter.Messages[0]; m.Err.Error() != "password: hunter2" {
+		t.Errorf("expected the values on stdin, got %v", m)
+	}
+}
+
+func TestPluginFailure(t *testing.T) {
+	p, err := plugin.LoadDir("testdata/plugins/broken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chartDir, _ := filepath.Abs(goodChartDir)
+	linter := support.Linter{ChartDir: chartDir}
+	Plugin(&linter, p, environment.EnvSettings{}, []byte{}, TemplateOptions{})
+
+	if len(linter.Messages) != 1 {
+		t.Fatalf("expected 1 message, got %v", linter.Messages)
+	}
+	m := linter.Messages[0]
+	if m.RuleID() != lintPlugin.ID || !strings.Contains(m.Err.Error(), `plugin "broken" exited with error`) {
+		t.Errorf("unexpected message %v of rule %q", m, m.RuleID())
+	}
+}
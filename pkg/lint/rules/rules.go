@@ -0,0 +1,87 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import "k8s.io/helm/pkg/lint/support"
+
+// The rules checked by Chartfile.
+var (
+	chartYamlFile         = support.Rule{ID: "chart-yaml-file", Severity: support.ErrorSev, Description: "Chart.yaml is a file"}
+	chartYamlFormat       = support.Rule{ID: "chart-yaml-format", Severity: support.ErrorSev, Description: "Chart.yaml is valid YAML"}
+	chartName             = support.Rule{ID: "chart-name", Severity: support.ErrorSev, Description: "The chart has a name"}
+	chartNameFormat       = support.Rule{ID: "chart-name-format", Severity: support.WarningSev, Description: "The chart name has no dots"}
+	chartNameDir          = support.Rule{ID: "chart-name-dir", Severity: support.ErrorSev, Description: "The chart name matches the name of its directory"}
+	chartAPIVersion       = support.Rule{ID: "chart-api-version", Severity: support.ErrorSev, Description: "The chart apiVersion is v1"}
+	chartVersion          = support.Rule{ID: "chart-version", Severity: support.ErrorSev, Description: "The chart version is a SemVer 2 version"}
+	chartEngine           = support.Rule{ID: "chart-engine", Severity: support.ErrorSev, Description: "The chart engine is known"}
+	chartMaintainers      = support.Rule{ID: "chart-maintainers", Severity: support.ErrorSev, Description: "Maintainers have a name, and valid emails and URLs"}
+	chartSources          = support.Rule{ID: "chart-sources", Severity: support.ErrorSev, Description: "Sources are valid URLs"}
+	chartIcon             = support.Rule{ID: "chart-icon", Severity: support.InfoSev, Description: "The chart has an icon"}
+	chartIconURL          = support.Rule{ID: "chart-icon-url", Severity: support.ErrorSev, Description: "The chart icon is a valid URL"}
+	chartType             = support.Rule{ID: "chart-type", Severity: support.ErrorSev, Description: "The chart type is application or library"}
+	valuesFile            = support.Rule{ID: "values-file", Severity: support.InfoSev, Description: "The chart has a values.yaml file"}
+	valuesFormat          = support.Rule{ID: "values-format", Severity: support.ErrorSev, Description: "values.yaml is valid YAML"}
+	valuesSecrets         = support.Rule{ID: "values-secrets", Severity: support.WarningSev, Description: "Values files matching a secrets pattern are encrypted"}
+	filesSize             = support.Rule{ID: "files-size", Severity: support.WarningSev, Description: "Files are well below the size limits of the chart loader"}
+	templatesDir          = support.Rule{ID: "templates-dir", Severity: support.WarningSev, Description: "The chart has a templates/ directory"}
+	templatesLoad         = support.Rule{ID: "templates-load", Severity: support.ErrorSev, Description: "The chart can be loaded"}
+	templatesRender       = support.Rule{ID: "templates-render", Severity: support.ErrorSev, Description: "The templates render"}
+	templatesCRDs         = support.Rule{ID: "templates-crds", Severity: support.ErrorSev, Description: "The schemas of the chart's CustomResourceDefinitions are valid"}
+	templateExtension     = support.Rule{ID: "template-extension", Severity: support.WarningSev, Description: "Templates are .yaml, .yml, .tpl or .txt files"}
+	templateYaml          = support.Rule{ID: "template-yaml", Severity: support.ErrorSev, Description: "Rendered templates are valid YAML"}
+	templateSchema        = support.Rule{ID: "template-schema", Severity: support.ErrorSev, Description: "Rendered objects match the schema of their kind"}
+	templateUnknownKind   = support.Rule{ID: "template-unknown-kind", Severity: support.WarningSev, Description: "Rendered objects are of a kind with a known schema"}
+	templateAPIDeprecated = support.Rule{ID: "template-api-deprecated", Severity: support.WarningSev, Description: "Rendered objects use no deprecated apiVersions"}
+	templateAPIRemoved    = support.Rule{ID: "template-api-removed", Severity: support.ErrorSev, Description: "Rendered objects use no apiVersions removed from Kubernetes"}
+	libraryTemplate       = support.Rule{ID: "library-template", Severity: support.ErrorSev, Description: "Templates of library charts only define named templates"}
+	lintConfig            = support.Rule{ID: "lint-config", Severity: support.ErrorSev, Description: "The .helmlint.yaml file is valid"}
+	lintPlugin            = support.Rule{ID: "lint-plugin", Severity: support.ErrorSev, Description: "Lint plugins run successfully"}
+)
+
+// Builtin are the rules built into Helm.
+var Builtin = []support.Rule{
+	chartYamlFile,
+	chartYamlFormat,
+	chartName,
+	chartNameFormat,
+	chartNameDir,
+	chartAPIVersion,
+	chartVersion,
+	chartEngine,
+	chartMaintainers,
+	chartSources,
+	chartIcon,
+	chartIconURL,
+	chartType,
+	valuesFile,
+	valuesFormat,
+	valuesSecrets,
+	filesSize,
+	templatesDir,
+	templatesLoad,
+	templatesRender,
+	templatesCRDs,
+	templateExtension,
+	templateYaml,
+	templateSchema,
+	templateUnknownKind,
+	templateAPIDeprecated,
+	templateAPIRemoved,
+	libraryTemplate,
+	lintConfig,
+	lintPlugin,
+}
@@ -21,22 +21,54 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/deprecation"
 	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/lint/support"
 	cpb "k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/schema"
 	"k8s.io/helm/pkg/timeconv"
 	tversion "k8s.io/helm/pkg/version"
 )
 
 // Templates lints the templates in the Linter.
 func Templates(linter *support.Linter, values []byte, namespace string, strict bool) {
+	TemplatesWithCapabilities(linter, values, namespace, strict, nil)
+}
+
+// TemplatesWithCapabilities lints the templates in the Linter, rendering them
+// with the given capabilities. A nil caps uses the default capabilities.
+func TemplatesWithCapabilities(linter *support.Linter, values []byte, namespace string, strict bool, caps *chartutil.Capabilities) {
+	TemplatesWithOptions(linter, values, TemplateOptions{Namespace: namespace, Strict: strict, Capabilities: caps})
+}
+
+// TemplateOptions configures how templates are linted.
+type TemplateOptions struct {
+	Namespace string
+	Strict    bool
+	// Capabilities to render the templates with. If nil, the default
+	// capabilities are used.
+	Capabilities *chartutil.Capabilities
+	// Schemas to validate the rendered objects against. If nil, objects are
+	// not validated. The schemas of the chart's CustomResourceDefinitions are
+	// added to them.
+	Schemas *schema.Set
+	// KubeVersion to check the apiVersions of the rendered objects against.
+	// If empty, deprecated apiVersions are not reported.
+	KubeVersion string
+}
+
+// TemplatesWithOptions lints the templates in the Linter.
+func TemplatesWithOptions(linter *support.Linter, values []byte, opts TemplateOptions) {
+	namespace, strict, caps := opts.Namespace, opts.Strict, opts.Capabilities
 	path := "templates/"
 	templatesPath := filepath.Join(linter.ChartDir, path)
 
-	templatesDirExist := linter.RunLinterRule(support.WarningSev, path, validateTemplatesDir(templatesPath))
+	templatesDirExist := linter.RunRule(templatesDir, path, validateTemplatesDir(templatesPath))
 
 	// Templates directory is optional for now
 	if !templatesDirExist {
@@ -46,17 +78,28 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 	// Load chart and parse templates, based on tiller/release_server
 	chart, err := chartutil.Load(linter.ChartDir)
 
-	chartLoaded := linter.RunLinterRule(support.ErrorSev, path, err)
+	chartLoaded := linter.RunRule(templatesLoad, path, err)
 
 	if !chartLoaded {
 		return
 	}
 
+	// Library charts render nothing, so render them like applications to
+	// find templates with output
+	library := chartutil.IsLibraryChart(chart)
+	if library {
+		md := *chart.Metadata
+		md.Type = chartutil.TypeApplication
+		chart.Metadata = &md
+	}
+
 	options := chartutil.ReleaseOptions{Name: "testRelease", Time: timeconv.Now(), Namespace: namespace}
-	caps := &chartutil.Capabilities{
-		APIVersions:   chartutil.DefaultVersionSet,
-		KubeVersion:   chartutil.DefaultKubeVersion,
-		TillerVersion: tversion.GetVersionProto(),
+	if caps == nil {
+		caps = &chartutil.Capabilities{
+			APIVersions:   chartutil.DefaultVersionSet,
+			KubeVersion:   chartutil.DefaultKubeVersion,
+			TillerVersion: tversion.GetVersionProto(),
+		}
 	}
 	cvals, err := chartutil.CoalesceValues(chart, &cpb.Config{Raw: string(values)})
 	if err != nil {
@@ -82,12 +125,20 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 	}
 	renderedContentMap, err := e.Render(chart, valuesToRender)
 
-	renderOk := linter.RunLinterRule(support.ErrorSev, path, err)
+	renderOk := linter.RunRule(templatesRender, path, err)
 
 	if !renderOk {
 		return
 	}
 
+	var schemas *schema.Set
+	if opts.Schemas != nil {
+		schemas, err = opts.Schemas.WithCRDs(crds(chart, renderedContentMap)...)
+		if !linter.RunRule(templatesCRDs, path, err) {
+			return
+		}
+	}
+
 	/* Iterate over all the templates to check:
 	- It is a .yaml file
 	- All the values in the template file is defined
@@ -99,7 +150,13 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 		fileName, _ := template.Name, template.Data
 		path = fileName
 
-		linter.RunLinterRule(support.WarningSev, path, validateAllowedExtension(fileName))
+		linter.RunRule(templateExtension, path, validateAllowedExtension(fileName))
+
+		if library {
+			renderedContent := renderedContentMap[filepath.Join(chart.GetMetadata().Name, fileName)]
+			linter.RunRule(libraryTemplate, path, validateLibraryTemplate(fileName, renderedContent))
+			continue
+		}
 
 		// We only apply the following lint rules to yaml files
 		if filepath.Ext(fileName) != ".yaml" || filepath.Ext(fileName) == ".yml" {
@@ -112,11 +169,46 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 		// key will be raised as well
 		err := yaml.Unmarshal([]byte(renderedContent), &yamlStruct)
 
-		validYaml := linter.RunLinterRule(support.ErrorSev, path, validateYamlContent(err))
+		validYaml := linter.RunRule(templateYaml, path, validateYamlContent(err))
 
 		if !validYaml {
 			continue
 		}
+
+		if schemas != nil {
+			validateSchema(linter, path, schemas, renderedContent)
+		}
+		if opts.KubeVersion != "" {
+			validateAPIVersions(linter, path, opts.KubeVersion, renderedContent)
+		}
+	}
+}
+
+// validateAPIVersions reports objects using apiVersions that are removed in
+// kubeVersion as errors, and those that are only deprecated as warnings.
+func validateAPIVersions(linter *support.Linter, path, kubeVersion, renderedContent string) {
+	for _, f := range deprecation.Check(renderedContent, kubeVersion) {
+		f := f
+		rule := templateAPIDeprecated
+		if f.Removed {
+			rule = templateAPIRemoved
+		}
+		linter.RunRule(rule, path, &f)
+	}
+}
+
+// validateSchema validates every object in the rendered content of a
+// template. A kind without a schema is only a warning, as it may be defined
+// in the cluster.
+func validateSchema(linter *support.Linter, path string, schemas *schema.Set, renderedContent string) {
+	for _, doc := range releaseutil.SplitManifestDocs(renderedContent) {
+		for _, err := range schemas.Validate([]byte(doc)) {
+			rule := templateSchema
+			if _, ok := err.(*schema.UnknownKindError); ok {
+				rule = templateUnknownKind
+			}
+			linter.RunRule(rule, path, err)
+		}
 	}
 }
 
@@ -143,6 +235,13 @@ func validateAllowedExtension(fileName string) error {
 	return fmt.Errorf("file extension '%s' not valid. Valid extensions are .yaml, .yml, .tpl, or .txt", ext)
 }
 
+func validateLibraryTemplate(fileName, renderedContent string) error {
+	if strings.HasPrefix(filepath.Base(fileName), "_") || strings.TrimSpace(renderedContent) == "" {
+		return nil
+	}
+	return errors.New("library charts are not installed, so their templates may only define named templates")
+}
+
 func validateYamlContent(err error) error {
 	if err != nil {
 		return fmt.Errorf("unable to parse YAML\n\t%s", err)
@@ -157,3 +256,15 @@ type K8sYamlStruct struct {
 		Namespace string
 	}
 }
+
+// crds returns the documents that may define CustomResourceDefinitions: the
+// chart's crds/ files and everything it renders.
+func crds(chart *cpb.Chart, renderedContentMap map[string]string) [][]byte {
+	docs := schema.ChartCRDs(chart)
+	for _, content := range renderedContentMap {
+		for _, doc := range releaseutil.SplitManifestDocs(content) {
+			docs = append(docs, []byte(doc))
+		}
+	}
+	return docs
+}
@@ -19,10 +19,13 @@ package rules
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/schema"
 )
 
 const (
@@ -82,3 +85,94 @@ func TestTemplateIntegrationHappyPath(t *testing.T) {
 		t.Fatalf("Expected no error, got %d, %v", len(res), res)
 	}
 }
+
+func TestTemplatesWithCapabilities(t *testing.T) {
+	basedir := "./testdata/capabilities"
+
+	linter := support.Linter{ChartDir: basedir}
+	Templates(&linter, nil, namespace, strict)
+	if len(linter.Messages) != 1 || !strings.Contains(linter.Messages[0].Err.Error(), "Widgets are not supported") {
+		t.Fatalf("Expected the chart to fail with the default capabilities, got %v", linter.Messages)
+	}
+
+	caps := &chartutil.Capabilities{
+		APIVersions: chartutil.NewVersionSet("v1", "example.com/v1", "example.com/v1/Widget"),
+		KubeVersion: chartutil.DefaultKubeVersion,
+	}
+	linter = support.Linter{ChartDir: basedir}
+	TemplatesWithCapabilities(&linter, nil, namespace, strict, caps)
+	if len(linter.Messages) != 0 {
+		t.Fatalf("Expected no error, got %d, %v", len(linter.Messages), linter.Messages)
+	}
+}
+
+func TestTemplatesWithSchemas(t *testing.T) {
+	basedir := "./testdata/schemas"
+
+	linter := support.Linter{ChartDir: basedir}
+	Templates(&linter, nil, namespace, strict)
+	if len(linter.Messages) != 0 {
+		t.Fatalf("Expected no validation without schemas, got %v", linter.Messages)
+	}
+
+	linter = support.Linter{ChartDir: basedir}
+	TemplatesWithOptions(&linter, nil, TemplateOptions{Namespace: namespace, Schemas: schema.Bundled()})
+
+	expected := []string{
+		`[ERROR] templates/deployment.yaml: Deployment "testRelease": spec.replicas: expected integer, got string`,
+		`[ERROR] templates/deployment.yaml: Deployment "testRelease": spec.template.spec.containers[0].port: unknown field`,
+		`[ERROR] templates/widget.yaml: Widget "testRelease": spec.size: expected integer, got string`,
+		`[WARNING] templates/widget.yaml: no schema for kind "Gadget" in apiVersion "example.com/v1" in Kubernetes v1.18`,
+	}
+	var got []string
+	for _, m := range linter.Messages {
+		got = append(got, m.Error())
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected messages\n%q\ngot\n%q", expected, got)
+	}
+	if linter.HighestSeverity != support.ErrorSev {
+		t.Errorf("Expected highest severity ERROR, got %d", linter.HighestSeverity)
+	}
+}
+
+func TestTemplatesDeprecatedAPIs(t *testing.T) {
+	basedir := "./testdata/deprecated"
+
+	linter := support.Linter{ChartDir: basedir}
+	TemplatesWithOptions(&linter, nil, TemplateOptions{Namespace: namespace, KubeVersion: "v1.16.0"})
+
+	expected := []string{
+		`[ERROR] templates/deployment.yaml: Deployment "testRelease" uses extensions/v1beta1, which is removed in Kubernetes v1.16; use apps/v1 instead`,
+		`[WARNING] templates/ingress.yaml: Ingress "testRelease" uses extensions/v1beta1, which is deprecated since Kubernetes v1.14 and removed in v1.22; use networking.k8s.io/v1beta1 instead`,
+	}
+	var got []string
+	for _, m := range linter.Messages {
+		got = append(got, m.Error())
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected messages\n%q\ngot\n%q", expected, got)
+	}
+
+	linter = support.Linter{ChartDir: basedir}
+	TemplatesWithOptions(&linter, nil, TemplateOptions{Namespace: namespace, KubeVersion: "v1.8.0"})
+	if len(linter.Messages) != 0 {
+		t.Errorf("Expected no messages before the deprecation, got %v", linter.Messages)
+	}
+}
+
+func TestTemplatesLibrary(t *testing.T) {
+	linter := support.Linter{ChartDir: "./testdata/library"}
+	Templates(&linter, nil, namespace, strict)
+
+	expected := []string{
+		"[ERROR] templates/configmap.yaml: library charts are not installed, so their templates may only define named templates",
+	}
+	var got []string
+	for _, m := range linter.Messages {
+		got = append(got, m.Error())
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected messages\n%q\ngot\n%q", expected, got)
+	}
+}
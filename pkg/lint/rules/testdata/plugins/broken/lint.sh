@@ -0,0 +1,3 @@
+#!/bin/sh
+echo "cannot lint $1" >&2
+exit 1
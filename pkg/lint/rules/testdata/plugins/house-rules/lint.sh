@@ -0,0 +1,11 @@
+#!/bin/sh
+# Reports the templates of the chart in $1 without a team label.
+sep=""
+printf '['
+for f in "$1"/templates/*.yaml; do
+  if ! grep -q "team:" "$f"; then
+    printf '%s{"rule": "house-rules-team-label", "path": "templates/%s", "message": "objects need a team label"}' "$sep" "$(basename "$f")"
+    sep=","
+  fi
+done
+printf ']\n'
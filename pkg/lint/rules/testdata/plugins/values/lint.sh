@@ -0,0 +1,3 @@
+#!/bin/sh
+# Reports the values read from stdin.
+printf '[{"rule": "values-echo", "path": "values.yaml", "message": "%s"}]\n' "$(tr -d '\n')"
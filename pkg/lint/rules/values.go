@@ -17,25 +17,52 @@ limitations under the License.
 package rules
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/secrets"
 )
 
-// Values lints a chart's values.yaml file.
+// DefaultSecretsPatterns match the names of the values files that hold
+// secrets, unless the .helmlint.yaml file of the chart configures others.
+var DefaultSecretsPatterns = []string{"secrets.yaml", "secrets.*.yaml", "*.secrets.yaml", "*-secrets.yaml"}
+
+// Values lints a chart's values.yaml file, and warns about the values files
+// holding secrets that are not encrypted.
 func Values(linter *support.Linter) {
+	ValuesWithFiles(linter, nil)
+}
+
+// ValuesWithFiles lints like Values, and also warns about the values files
+// outside of the chart, e.g. the ones passed with --values, that hold secrets
+// but are not encrypted.
+func ValuesWithFiles(linter *support.Linter, valuesFiles []string) {
 	file := "values.yaml"
 	vf := filepath.Join(linter.ChartDir, file)
-	fileExists := linter.RunLinterRule(support.InfoSev, file, validateValuesFileExistence(linter, vf))
+	fileExists := linter.RunRule(valuesFile, file, validateValuesFileExistence(linter, vf))
 
-	if !fileExists {
-		return
+	if fileExists {
+		linter.RunRule(valuesFormat, file, validateValuesFile(linter, vf))
 	}
 
-	linter.RunLinterRule(support.ErrorSev, file, validateValuesFile(linter, vf))
+	files, err := secretsValuesFiles(linter)
+	if err != nil {
+		linter.RunRule(valuesSecrets, ".", err)
+		return
+	}
+	for _, f := range files {
+		linter.RunRule(valuesSecrets, f, validateSecretsValuesFile(filepath.Join(linter.ChartDir, f)))
+	}
+	for _, f := range valuesFiles {
+		if matchSecretsPattern(linter, filepath.Base(f)) {
+			linter.RunRule(valuesSecrets, f, validateSecretsValuesFile(f))
+		}
+	}
 }
 
 func validateValuesFileExistence(linter *support.Linter, valuesPath string) error {
@@ -53,3 +80,56 @@ func validateValuesFile(linter *support.Linter, valuesPath string) error {
 	}
 	return nil
 }
+
+// secretsValuesFiles returns the paths of the files of the chart, relative to
+// the chart, whose names match a secrets pattern. The templates and the
+// subcharts of the chart are skipped.
+func secretsValuesFiles(linter *support.Linter) ([]string, error) {
+	var files []string
+	err := filepath.Walk(linter.ChartDir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(linter.ChartDir, path)
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			if rel == "templates" || rel == "charts" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if matchSecretsPattern(linter, fi.Name()) {
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return files, err
+}
+
+// matchSecretsPattern returns whether the file name matches a secrets
+// pattern.
+func matchSecretsPattern(linter *support.Linter, name string) bool {
+	patterns := DefaultSecretsPatterns
+	if linter.Config != nil && len(linter.Config.SecretsPatterns) > 0 {
+		patterns = linter.Config.SecretsPatterns
+	}
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func validateSecretsValuesFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if !secrets.IsEncrypted(data) {
+		return errors.New("file holds secrets but is not encrypted, use 'helm secrets encrypt'")
+	}
+	return nil
+}
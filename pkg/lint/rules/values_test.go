@@ -0,0 +1,128 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"filippo.io/age"
+
+	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/secrets"
+)
+
+func TestValuesSecrets(t *testing.T) {
+	chartDir, err := ioutil.TempDir("", "helm-lint-secrets-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(chartDir)
+
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := secrets.Encrypt([]byte("password: hunter2\n"), secrets.Recipients{Age: []*age.X25519Recipient{id.Recipient()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{
+		"values.yaml":               []byte("name: value\n"),
+		"secrets.yaml":              []byte("password: hunter2\n"),
+		"secrets.prod.yaml":         encrypted,
+		"env/staging-secrets.yaml":  []byte("password: hunter2\n"),
+		"env/staging.secret.txt":    []byte("password: hunter2\n"),
+		"templates/secrets.yaml":    []byte("kind: Secret\n"),
+		"charts/db/secrets.yaml":    []byte("password: hunter2\n"),
+		"charts/db/values.yaml":     []byte("name: value\n"),
+		"env/production.values.yml": []byte("name: value\n"),
+	}
+	for name, data := range files {
+		path := filepath.Join(chartDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	valuesDir, err := ioutil.TempDir("", "helm-lint-secrets-values-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(valuesDir)
+	for name, data := range map[string][]byte{
+		"prod-secrets.yaml":    []byte("password: hunter2\n"),
+		"staging-secrets.yaml": encrypted,
+		"prod.yaml":            []byte("name: value\n"),
+	} {
+		if err := ioutil.WriteFile(filepath.Join(valuesDir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prodSecrets := filepath.Join(valuesDir, "prod-secrets.yaml")
+	valuesFiles := []string{prodSecrets, filepath.Join(valuesDir, "staging-secrets.yaml"), filepath.Join(valuesDir, "prod.yaml")}
+
+	tests := []struct {
+		name        string
+		patterns    []string
+		valuesFiles []string
+		expect      []string
+	}{
+		{
+			name:   "default patterns",
+			expect: []string{"env/staging-secrets.yaml", "secrets.yaml"},
+		},
+		{
+			name:     "configured patterns",
+			patterns: []string{"*.secret.txt", "secrets.*.yaml"},
+			expect:   []string{"env/staging.secret.txt"},
+		},
+		{
+			name:        "values files",
+			valuesFiles: valuesFiles,
+			expect:      []string{"env/staging-secrets.yaml", "secrets.yaml", prodSecrets},
+		},
+		{
+			name:        "values files with configured patterns",
+			patterns:    []string{"*.secret.txt", "prod.yaml"},
+			valuesFiles: valuesFiles,
+			expect:      []string{"env/staging.secret.txt", filepath.Join(valuesDir, "prod.yaml")},
+		},
+	}
+
+	for _, tt := range tests {
+		linter := support.Linter{ChartDir: chartDir, Config: &support.Config{SecretsPatterns: tt.patterns}}
+		ValuesWithFiles(&linter, tt.valuesFiles)
+
+		var paths []string
+		for _, m := range linter.Messages {
+			if m.RuleID() != valuesSecrets.ID || m.Severity != support.WarningSev {
+				t.Errorf("%s: unexpected message %v", tt.name, m)
+			}
+			paths = append(paths, m.Path)
+		}
+		if !reflect.DeepEqual(paths, tt.expect) {
+			t.Errorf("%s: expected warnings for %v, got %v", tt.name, tt.expect, linter.Messages)
+		}
+	}
+}
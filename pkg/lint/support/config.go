@@ -0,0 +1,160 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package support
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// ConfigFileName is the name of the file configuring the linting of a chart.
+const ConfigFileName = ".helmlint.yaml"
+
+// Off disables a rule in a Config.
+const Off = "off"
+
+// Rule describes a lint rule.
+type Rule struct {
+	// ID identifies the rule in configurations and suppressions, e.g.
+	// "chart-icon".
+	ID string
+	// Severity is the severity of the rule unless configured otherwise.
+	Severity int
+	// Description tells what the rule checks.
+	Description string
+}
+
+// Config configures the rules of a linting run.
+type Config struct {
+	// Rules maps the IDs of rules to their severity, one of "info",
+	// "warning", "error", or "off" to disable the rule.
+	Rules map[string]string `json:"rules"`
+	// SecretsPatterns are the glob patterns of the names of values files
+	// that hold secrets, and must be encrypted. If empty, the default
+	// patterns of the values-secrets rule are used.
+	SecretsPatterns []string `json:"secretsPatterns"`
+}
+
+// LoadConfig reads the .helmlint.yaml file of the chart in chartDir. A chart
+// without one has an empty configuration.
+func LoadConfig(chartDir string) (*Config, error) {
+	data, err := ioutil.ReadFile(filepath.Join(chartDir, ConfigFileName))
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Config{}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("unable to parse YAML\n\t%s", err)
+	}
+	for _, p := range c.SecretsPatterns {
+		if _, err := filepath.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("secrets pattern %q: %s", p, err)
+		}
+	}
+	for id, s := range c.Rules {
+		if s == Off {
+			continue
+		}
+		if _, err := ParseSeverity(s); err != nil {
+			return nil, fmt.Errorf("rule %s: %s", id, err)
+		}
+	}
+	return c, nil
+}
+
+// Severity returns the configured severity of rule, and whether the rule is
+// enabled.
+func (c *Config) Severity(rule Rule) (int, bool) {
+	if c == nil {
+		return rule.Severity, true
+	}
+	s, ok := c.Rules[rule.ID]
+	if !ok {
+		return rule.Severity, true
+	}
+	if s == Off {
+		return UnknownSev, false
+	}
+	severity, err := ParseSeverity(s)
+	if err != nil {
+		return rule.Severity, true
+	}
+	return severity, true
+}
+
+var suppression = regexp.MustCompile(`helm-lint:\s*disable=([\w.-]+(?:\s*,\s*[\w.-]+)*)`)
+
+// Suppressions returns the IDs of the rules that the content of a file
+// suppresses with comments like
+//
+//	# helm-lint: disable=chart-icon,values-file
+//
+// or, in templates,
+//
+//	{{/* helm-lint: disable=template-extension */}}
+func Suppressions(content []byte) []string {
+	var ids []string
+	for _, m := range suppression.FindAllSubmatch(content, -1) {
+		for _, id := range strings.Split(string(m[1]), ",") {
+			ids = append(ids, strings.TrimSpace(id))
+		}
+	}
+	return ids
+}
+
+// LoadSuppressions reads the suppression comments of the Chart.yaml,
+// values.yaml and template files of the chart in ChartDir.
+func (l *Linter) LoadSuppressions() error {
+	for _, name := range []string{"Chart.yaml", "values.yaml"} {
+		data, err := ioutil.ReadFile(filepath.Join(l.ChartDir, name))
+		if err != nil {
+			// reported by the rules checking the file
+			continue
+		}
+		l.Suppress(name, Suppressions(data)...)
+	}
+
+	templates := filepath.Join(l.ChartDir, "templates")
+	if _, err := os.Stat(templates); err != nil {
+		return nil
+	}
+	return filepath.Walk(templates, func(path string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(l.ChartDir, path)
+		if err != nil {
+			return err
+		}
+		l.Suppress(filepath.ToSlash(rel), Suppressions(data)...)
+		return nil
+	})
+}
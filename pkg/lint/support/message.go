@@ -16,7 +16,10 @@ limitations under the License.
 
 package support
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Severity indicates the severity of a Message.
 const (
@@ -39,6 +42,15 @@ type Linter struct {
 	// The highest severity of all the failing lint rules
 	HighestSeverity int
 	ChartDir        string
+	// Config changes the severity of rules. If nil, every rule has its
+	// default severity.
+	Config *Config
+	// Rules are the rules that produced Messages, by ID.
+	Rules map[string]Rule
+
+	// suppressed maps the paths of files to the IDs of the rules suppressed
+	// in them.
+	suppressed map[string]map[string]bool
 }
 
 // Message describes an error encountered while linting.
@@ -53,6 +65,24 @@ func (m Message) Error() string {
 	return fmt.Sprintf("[%s] %s: %s", sev[m.Severity], m.Path, m.Err.Error())
 }
 
+// RuleID returns the ID of the rule that produced the message, if any.
+func (m Message) RuleID() string {
+	if err, ok := m.Err.(*RuleError); ok {
+		return err.RuleID
+	}
+	return ""
+}
+
+// RuleError is the error of a message produced by a rule.
+type RuleError struct {
+	RuleID string
+	Err    error
+}
+
+func (e *RuleError) Error() string {
+	return e.Err.Error()
+}
+
 // NewMessage creates a new Message struct
 func NewMessage(severity int, path string, err error) Message {
 	return Message{Severity: severity, Path: path, Err: err}
@@ -74,3 +104,63 @@ func (l *Linter) RunLinterRule(severity int, path string, err error) bool {
 	}
 	return err == nil
 }
+
+// RunRule records err, unless it is nil, as a message of the given rule. The
+// severity of the message is the one configured for the rule. Errors of
+// disabled rules, and of rules suppressed in the file at path, are dropped.
+//
+// It returns true if the validation passed, so that rules depending on it
+// can be skipped either way.
+func (l *Linter) RunRule(rule Rule, path string, err error) bool {
+	if err == nil {
+		return true
+	}
+	severity, enabled := l.Config.Severity(rule)
+	if !enabled || l.suppressed[path][rule.ID] {
+		return false
+	}
+	if severity < 0 || severity >= len(sev) {
+		return false
+	}
+
+	l.Messages = append(l.Messages, NewMessage(severity, path, &RuleError{RuleID: rule.ID, Err: err}))
+	if severity > l.HighestSeverity {
+		l.HighestSeverity = severity
+	}
+	if l.Rules == nil {
+		l.Rules = map[string]Rule{}
+	}
+	l.Rules[rule.ID] = rule
+	return false
+}
+
+// Suppress drops the messages of the given rules for the file at path.
+func (l *Linter) Suppress(path string, ruleIDs ...string) {
+	if l.suppressed == nil {
+		l.suppressed = map[string]map[string]bool{}
+	}
+	if l.suppressed[path] == nil {
+		l.suppressed[path] = map[string]bool{}
+	}
+	for _, id := range ruleIDs {
+		l.suppressed[path][id] = true
+	}
+}
+
+// SeverityName returns the name of a severity, e.g. "WARNING".
+func SeverityName(severity int) string {
+	if severity < 0 || severity >= len(sev) {
+		return sev[UnknownSev]
+	}
+	return sev[severity]
+}
+
+// ParseSeverity parses the name of a severity, case insensitively.
+func ParseSeverity(name string) (int, error) {
+	for i, s := range sev {
+		if i != UnknownSev && strings.EqualFold(s, name) {
+			return i, nil
+		}
+	}
+	return UnknownSev, fmt.Errorf("unknown severity %q, allowed values: info, warning, error", name)
+}
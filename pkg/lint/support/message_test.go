@@ -77,3 +77,50 @@ func TestMessage(t *testing.T) {
 		t.Errorf("Unexpected output: %s", m.Error())
 	}
 }
+
+func TestRunRule(t *testing.T) {
+	rule := Rule{ID: "some-rule", Severity: WarningSev}
+	l := Linter{Config: &Config{Rules: map[string]string{"other-rule": "error", "disabled-rule": Off}}}
+	l.Suppress("templates/suppressed.yaml", "some-rule")
+
+	if !l.RunRule(rule, "Chart.yaml", nil) || len(l.Messages) != 0 {
+		t.Errorf("expected a passing rule to record nothing, got %v", l.Messages)
+	}
+	if l.RunRule(rule, "templates/suppressed.yaml", errLint) || len(l.Messages) != 0 {
+		t.Errorf("expected a suppressed rule to fail but record nothing, got %v", l.Messages)
+	}
+	if l.RunRule(Rule{ID: "disabled-rule", Severity: ErrorSev}, "Chart.yaml", errLint) || len(l.Messages) != 0 {
+		t.Errorf("expected a disabled rule to fail but record nothing, got %v", l.Messages)
+	}
+
+	l.RunRule(rule, "Chart.yaml", errLint)
+	l.RunRule(Rule{ID: "other-rule", Severity: InfoSev}, "Chart.yaml", errLint)
+	if len(l.Messages) != 2 {
+		t.Fatalf("expected 2 messages, got %v", l.Messages)
+	}
+	if m := l.Messages[0]; m.Severity != WarningSev || m.RuleID() != "some-rule" {
+		t.Errorf("expected a warning of some-rule, got %v of %q", m, m.RuleID())
+	}
+	if m := l.Messages[1]; m.Severity != ErrorSev || m.RuleID() != "other-rule" {
+		t.Errorf("expected the configured severity of other-rule, got %v of %q", m, m.RuleID())
+	}
+	if l.HighestSeverity != ErrorSev {
+		t.Errorf("expected the highest severity to be %d, got %d", ErrorSev, l.HighestSeverity)
+	}
+}
+
+func TestSuppressions(t *testing.T) {
+	content := []byte(`# helm-lint: disable=chart-icon, values-file
+{{/* helm-lint: disable=template-extension */}}
+# helm-lint: nothing`)
+	ids := Suppressions(content)
+	expect := []string{"chart-icon", "values-file", "template-extension"}
+	if len(ids) != len(expect) {
+		t.Fatalf("expected %v, got %v", expect, ids)
+	}
+	for i := range expect {
+		if ids[i] != expect[i] {
+			t.Errorf("expected %v, got %v", expect, ids)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging provides the structured, levelled logger of Tiller and
+// Rudder.
+//
+// Entries carry fields such as the release, revision, namespace, operation and
+// request ID they relate to, and are written as text or as JSON lines. The
+// request ID is passed from the helm client to Tiller, and from Tiller to
+// Rudder, in gRPC metadata, so the log entries of one request can be joined
+// across processes.
+package logging // import "k8s.io/helm/pkg/logging"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log entry.
+type Level int
+
+const (
+	// DebugLevel is for entries only useful when debugging.
+	DebugLevel Level = iota
+	// InfoLevel is for entries about normal operation.
+	InfoLevel
+	// WarnLevel is for entries about problems that did not fail an operation.
+	WarnLevel
+	// ErrorLevel is for entries about failed operations.
+	ErrorLevel
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+func (l Level) String() string {
+	if l < DebugLevel || l > ErrorLevel {
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLevel parses the name of a level, e.g. "info".
+func ParseLevel(s string) (Level, error) {
+	for i, name := range levelNames {
+		if strings.EqualFold(s, name) {
+			return Level(i), nil
+		}
+	}
+	return InfoLevel, fmt.Errorf("unknown log level %q, must be one of %s", s, strings.Join(levelNames, ", "))
+}
+
+// Format is the output format of a logger.
+type Format string
+
+const (
+	// TextFormat writes entries as lines of text, with the fields as
+	// key=value pairs after the message.
+	TextFormat Format = "text"
+	// JSONFormat writes entries as JSON objects, one per line.
+	JSONFormat Format = "json"
+)
+
+// ParseFormat parses the name of a format.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case TextFormat, JSONFormat:
+		return f, nil
+	}
+	return TextFormat, fmt.Errorf("unknown log format %q, must be %q or %q", s, TextFormat, JSONFormat)
+}
+
+// Keys of the fields set by Helm.
+const (
+	ComponentKey = "component"
+	OperationKey = "operation"
+	ReleaseKey   = "release"
+	RevisionKey  = "revision"
+	NamespaceKey = "namespace"
+	RequestIDKey = "request_id"
+	ErrorKey     = "error"
+)
+
+// Field is a key/value pair attached to log entries.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// F returns a field.
+func F(key string, value interface{}) Field { return Field{Key: key, Value: value} }
+
+// Component returns the field naming the component that logs an entry.
+func Component(name string) Field { return F(ComponentKey, name) }
+
+// Logger writes structured log entries.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+	// With returns a logger adding fields to every entry. Fields replace
+	// those with the same key.
+	With(fields ...Field) Logger
+}
+
+// New returns a logger writing the entries at or above level to out.
+func New(out io.Writer, format Format, level Level) Logger {
+	return &logger{out: &writer{w: out}, format: format, level: level}
+}
+
+// Discard is a logger that drops all entries.
+var Discard Logger = &logger{level: ErrorLevel + 1}
+
+// writer serializes the writes of loggers sharing an output.
+type writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+type logger struct {
+	out    *writer
+	format Format
+	level  Level
+	fields []Field
+}
+
+func (l *logger) Debugf(format string, args ...interface{}) { l.log(DebugLevel, format, args) }
+func (l *logger) Infof(format string, args ...interface{})  { l.log(InfoLevel, format, args) }
+func (l *logger) Warnf(format string, args ...interface{})  { l.log(WarnLevel, format, args) }
+func (l *logger) Errorf(format string, args ...interface{}) { l.log(ErrorLevel, format, args) }
+
+func (l *logger) With(fields ...Field) Logger {
+	c := *l
+	c.fields = make([]Field, len(l.fields), len(l.fields)+len(fields))
+	copy(c.fields, l.fields)
+next:
+	for _, f := range fields {
+		for i := range c.fields {
+			if c.fields[i].Key == f.Key {
+				c.fields[i] = f
+				continue next
+			}
+		}
+		c.fields = append(c.fields, f)
+	}
+	return &c
+}
+
+func (l *logger) log(level Level, format string, args []interface{}) {
+	if level < l.level || l.out == nil {
+		return
+	}
+	msg := strings.TrimSuffix(fmt.Sprintf(format, args...), "\n")
+	now := time.Now()
+
+	var b bytes.Buffer
+	if l.format == JSONFormat {
+		l.writeJSON(&b, now, level, msg)
+	} else {
+		l.writeText(&b, now, level, msg)
+	}
+	b.WriteByte('\n')
+
+	l.out.mu.Lock()
+	defer l.out.mu.Unlock()
+	l.out.w.Write(b.Bytes())
+}
+
+func (l *logger) writeText(b *bytes.Buffer, now time.Time, level Level, msg string) {
+	b.WriteString(now.Format("2006/01/02 15:04:05 "))
+	for _, f := range l.fields {
+		if f.Key == ComponentKey {
+			fmt.Fprintf(b, "[%v] ", f.Value)
+		}
+	}
+	if level != InfoLevel {
+		fmt.Fprintf(b, "%s: ", strings.ToUpper(level.String()))
+	}
+	b.WriteString(msg)
+	for _, f := range l.fields {
+		if f.Key != ComponentKey {
+			fmt.Fprintf(b, " %s=%s", f.Key, textValue(f.Value))
+		}
+	}
+}
+
+func textValue(v interface{}) string {
+	s := fmt.Sprint(v)
+	if err, ok := v.(error); ok {
+		s = err.Error()
+	}
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+func (l *logger) writeJSON(b *bytes.Buffer, now time.Time, level Level, msg string) {
+	fields := make([]Field, 0, len(l.fields)+3)
+	fields = append(fields,
+		F("time", now.UTC().Format(time.RFC3339Nano)),
+		F("level", level.String()),
+		F("msg", msg))
+	fields = append(fields, l.fields...)
+
+	b.WriteByte('{')
+	for i, f := range fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeJSONValue(b, f.Key)
+		b.WriteByte(':')
+		writeJSONValue(b, f.Value)
+	}
+	b.WriteByte('}')
+}
+
+func writeJSONValue(b *bytes.Buffer, v interface{}) {
+	if err, ok := v.(error); ok {
+		v = err.Error()
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(v))
+	}
+	b.Write(data)
+}
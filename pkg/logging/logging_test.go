@@ -0,0 +1,113 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTextFormat(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, TextFormat, InfoLevel).With(Component("tiller"), F(ReleaseKey, "web"))
+
+	l.Debugf("hidden")
+	l.Infof("installing %s", "web")
+	l.With(F(RevisionKey, 2), F(ReleaseKey, "db")).Warnf("hook %s failed", "pre-install")
+	l.Errorf("failed: %s", "boom")
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", lines)
+	}
+	expect := []string{
+		"[tiller] installing web release=web",
+		"[tiller] WARN: hook pre-install failed release=db revision=2",
+		"[tiller] ERROR: failed: boom release=web",
+	}
+	for i, line := range lines {
+		if !strings.HasSuffix(line, expect[i]) {
+			t.Errorf("expected line %d to end with %q, got %q", i, expect[i], line)
+		}
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	var b bytes.Buffer
+	l := New(&b, JSONFormat, DebugLevel).With(
+		Component("tiller"),
+		F(RevisionKey, int32(3)),
+		F(ErrorKey, errors.New("boom")),
+	)
+	l.Debugf("upgrade of %s\n", "web")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(b.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a JSON line, got %q: %s", b.String(), err)
+	}
+	expect := map[string]interface{}{
+		"level":     "debug",
+		"msg":       "upgrade of web",
+		"component": "tiller",
+		"revision":  float64(3),
+		"error":     "boom",
+	}
+	for k, v := range expect {
+		if entry[k] != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, entry[k])
+		}
+	}
+	if _, ok := entry["time"]; !ok {
+		t.Error("expected a time field")
+	}
+}
+
+func TestParse(t *testing.T) {
+	if l, err := ParseLevel("WARN"); err != nil || l != WarnLevel {
+		t.Errorf("expected warn level, got %v, %v", l, err)
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+	if f, err := ParseFormat("json"); err != nil || f != JSONFormat {
+		t.Errorf("expected json format, got %v, %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestRequestIDPropagation(t *testing.T) {
+	md := metadata.MD{}
+	InjectRequestID(WithRequestID(context.Background(), "abc123"), md)
+
+	ctx := ExtractRequestID(metadata.NewIncomingContext(context.Background(), md))
+	if id := RequestIDFromContext(ctx); id != "abc123" {
+		t.Errorf("expected request ID abc123, got %q", id)
+	}
+
+	ctx = ExtractRequestID(context.Background())
+	if id := RequestIDFromContext(ctx); len(id) != 16 {
+		t.Errorf("expected a new request ID, got %q", id)
+	}
+}
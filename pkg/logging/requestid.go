@@ -0,0 +1,70 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDMetadataKey is the gRPC metadata key carrying the request ID of
+// the caller.
+const RequestIDMetadataKey = "x-helm-request-id"
+
+type requestIDKey struct{}
+
+// NewRequestID returns a new random request ID.
+func NewRequestID() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// WithRequestID returns a context carrying the request ID id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID in ctx, or "".
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// InjectRequestID adds the request ID of ctx to the outgoing gRPC metadata md.
+func InjectRequestID(ctx context.Context, md metadata.MD) {
+	if id := RequestIDFromContext(ctx); id != "" {
+		md.Set(RequestIDMetadataKey, id)
+	}
+}
+
+// ExtractRequestID returns a context carrying the request ID found in the
+// incoming gRPC metadata of ctx, or else a new one.
+func ExtractRequestID(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(RequestIDMetadataKey); len(v) > 0 && v[0] != "" {
+			return WithRequestID(ctx, v[0])
+		}
+	}
+	return WithRequestID(ctx, NewRequestID())
+}
@@ -38,6 +38,26 @@ type Downloaders struct {
 	Command string `json:"command"`
 }
 
+// LintRules are the lint rules a plugin adds to 'helm lint'.
+type LintRules struct {
+	// Command is the executable path, relative to the plugin directory, with
+	// which the plugin lints a chart
+	Command string `json:"command"`
+	// Rules are the rules the command checks.
+	Rules []LintRule `json:"rules"`
+}
+
+// LintRule describes a lint rule of a plugin.
+type LintRule struct {
+	// ID identifies the rule, and should be prefixed with the plugin name.
+	ID string `json:"id"`
+	// Severity is the default severity of the rule, one of "info",
+	// "warning" or "error".
+	Severity string `json:"severity"`
+	// Description tells what the rule checks.
+	Description string `json:"description"`
+}
+
 // Metadata describes a plugin.
 //
 // This is the plugin equivalent of a chart.Metadata.
@@ -82,6 +102,9 @@ type Metadata struct {
 	// Downloaders field is used if the plugin supply downloader mechanism
 	// for special protocols.
 	Downloaders []Downloaders `json:"downloaders"`
+
+	// LintRules is set if the plugin supplies lint rules.
+	LintRules *LintRules `json:"lintRules"`
 }
 
 // Plugin represents a plugin.
@@ -197,4 +220,8 @@ func SetupPluginEnv(settings helm_env.EnvSettings,
 	if settings.Debug {
 		os.Setenv("HELM_DEBUG", "1")
 	}
+	if settings.Tillerless {
+		os.Setenv("HELM_TILLERLESS", "1")
+		os.Setenv("HELM_TILLERLESS_STORAGE", settings.TillerlessStorage)
+	}
 }
@@ -27,7 +27,13 @@ type Info struct {
 	// Deleted tracks when this object was deleted.
 	Deleted *timestamp.Timestamp `protobuf:"bytes,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
 	// Description is human-friendly "log entry" about this release.
-	Description          string   `protobuf:"bytes,5,opt,name=Description,proto3" json:"Description,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=Description,proto3" json:"Description,omitempty"`
+	// owner identifies the Tiller instance that performed the last operation
+	// on this revision.
+	Owner string `protobuf:"bytes,6,opt,name=owner,proto3" json:"owner,omitempty"`
+	// timeout is the timeout in seconds of the operation creating this
+	// revision. Revisions still pending after it are considered interrupted.
+	Timeout              int64    `protobuf:"varint,7,opt,name=timeout,proto3" json:"timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -37,7 +43,7 @@ func (m *Info) Reset()         { *m = Info{} }
 func (m *Info) String() string { return proto.CompactTextString(m) }
 func (*Info) ProtoMessage()    {}
 func (*Info) Descriptor() ([]byte, []int) {
-	return fileDescriptor_info_1c62b71ed76c67c1, []int{0}
+	return fileDescriptor_info_f779b5e4bb3729b2, []int{0}
 }
 func (m *Info) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Info.Unmarshal(m, b)
@@ -92,27 +98,43 @@ func (m *Info) GetDescription() string {
 	return ""
 }
 
+func (m *Info) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Info) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Info)(nil), "hapi.release.Info")
 }
 
-func init() { proto.RegisterFile("hapi/release/info.proto", fileDescriptor_info_1c62b71ed76c67c1) }
-
-var fileDescriptor_info_1c62b71ed76c67c1 = []byte{
-	// 235 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x8f, 0x31, 0x4f, 0xc3, 0x30,
-	0x10, 0x85, 0x95, 0x52, 0x5a, 0xd5, 0x6d, 0x19, 0x2c, 0x24, 0x42, 0x16, 0x22, 0xa6, 0x0e, 0xc8,
-	0x91, 0x80, 0x1d, 0x81, 0xba, 0xb0, 0x06, 0x26, 0x16, 0xe4, 0xe2, 0x73, 0xb1, 0xe4, 0xe6, 0x2c,
-	0xfb, 0x3a, 0xf0, 0x2f, 0xf8, 0xc9, 0xa8, 0xb6, 0x83, 0xd2, 0xa9, 0xab, 0xbf, 0xf7, 0x3e, 0xbf,
-	0x63, 0x57, 0xdf, 0xd2, 0x99, 0xc6, 0x83, 0x05, 0x19, 0xa0, 0x31, 0x9d, 0x46, 0xe1, 0x3c, 0x12,
-	0xf2, 0xc5, 0x01, 0x88, 0x0c, 0xaa, 0x9b, 0x2d, 0xe2, 0xd6, 0x42, 0x13, 0xd9, 0x66, 0xaf, 0x1b,
-	0x32, 0x3b, 0x08, 0x24, 0x77, 0x2e, 0xc5, 0xab, 0xeb, 0x23, 0x4f, 0x20, 0x49, 0xfb, 0x90, 0xd0,
-	0xed, 0xef, 0x88, 0x8d, 0x5f, 0x3b, 0x8d, 0xfc, 0x8e, 0x4d, 0x12, 0x28, 0x8b, 0xba, 0x58, 0xcd,
-	0xef, 0x2f, 0xc5, 0xf0, 0x0f, 0xf1, 0x16, 0x59, 0x9b, 0x33, 0xfc, 0x99, 0x5d, 0x68, 0xe3, 0x03,
-	0x7d, 0x2a, 0x70, 0x16, 0x7f, 0x40, 0x95, 0xa3, 0xd8, 0xaa, 0x44, 0xda, 0x22, 0xfa, 0x2d, 0xe2,
-	0xbd, 0xdf, 0xd2, 0x2e, 0x63, 0x63, 0x9d, 0x0b, 0xfc, 0x89, 0x2d, 0xad, 0x1c, 0x1a, 0xce, 0x4e,
-	0x1a, 0x16, 0x87, 0xc2, 0xbf, 0xe0, 0x91, 0x4d, 0x15, 0x58, 0x20, 0x50, 0xe5, 0xf8, 0x64, 0xb5,
-	0x8f, 0xf2, 0x9a, 0xcd, 0xd7, 0x10, 0xbe, 0xbc, 0x71, 0x64, 0xb0, 0x2b, 0xcf, 0xeb, 0x62, 0x35,
-	0x6b, 0x87, 0x4f, 0x2f, 0xb3, 0x8f, 0x69, 0xbe, 0x7a, 0x33, 0x89, 0xa6, 0x87, 0xbf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x1a, 0x52, 0x8f, 0x9c, 0x89, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("hapi/release/info.proto", fileDescriptor_info_f779b5e4bb3729b2) }
+
+var fileDescriptor_info_f779b5e4bb3729b2 = []byte{
+	// 257 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0x3f, 0x4f, 0xc3, 0x30,
+	0x10, 0xc5, 0x95, 0xfe, 0x8b, 0xea, 0xb6, 0x0c, 0x56, 0x25, 0x4c, 0x16, 0x22, 0xa6, 0x0c, 0xc8,
+	0x91, 0x80, 0x1d, 0x81, 0xba, 0xb0, 0x06, 0x26, 0x16, 0xe4, 0x92, 0x4b, 0xb1, 0xe4, 0xe6, 0x2c,
+	0xfb, 0x22, 0xc4, 0xb7, 0xe3, 0xa3, 0xa1, 0xda, 0x89, 0x14, 0xa6, 0x8e, 0x77, 0xbf, 0xf7, 0xde,
+	0x3d, 0x1d, 0xbb, 0xfc, 0x52, 0x56, 0x97, 0x0e, 0x0c, 0x28, 0x0f, 0xa5, 0x6e, 0x1b, 0x94, 0xd6,
+	0x21, 0x21, 0x5f, 0x9f, 0x80, 0xec, 0x41, 0x76, 0x7d, 0x40, 0x3c, 0x18, 0x28, 0x03, 0xdb, 0x77,
+	0x4d, 0x49, 0xfa, 0x08, 0x9e, 0xd4, 0xd1, 0x46, 0x79, 0x76, 0xf5, 0x2f, 0xc7, 0x93, 0xa2, 0xce,
+	0x47, 0x74, 0xf3, 0x3b, 0x61, 0xb3, 0x97, 0xb6, 0x41, 0x7e, 0xcb, 0x16, 0x11, 0x88, 0x24, 0x4f,
+	0x8a, 0xd5, 0xdd, 0x56, 0x8e, 0x6f, 0xc8, 0xd7, 0xc0, 0xaa, 0x5e, 0xc3, 0x9f, 0xd8, 0x45, 0xa3,
+	0x9d, 0xa7, 0x8f, 0x1a, 0xac, 0xc1, 0x1f, 0xa8, 0xc5, 0x24, 0xb8, 0x32, 0x19, 0xbb, 0xc8, 0xa1,
+	0x8b, 0x7c, 0x1b, 0xba, 0x54, 0x9b, 0xe0, 0xd8, 0xf5, 0x06, 0xfe, 0xc8, 0x36, 0x46, 0x8d, 0x13,
+	0xa6, 0x67, 0x13, 0xd6, 0x46, 0x8d, 0x02, 0x1e, 0x58, 0x5a, 0x83, 0x01, 0x82, 0x5a, 0xcc, 0xce,
+	0x5a, 0x07, 0x29, 0xcf, 0xd9, 0x6a, 0x07, 0xfe, 0xd3, 0x69, 0x4b, 0x1a, 0x5b, 0x31, 0xcf, 0x93,
+	0x62, 0x59, 0x8d, 0x57, 0x7c, 0xcb, 0xe6, 0xf8, 0xdd, 0x82, 0x13, 0x8b, 0xc0, 0xe2, 0xc0, 0x05,
+	0x4b, 0x4f, 0x6f, 0xc5, 0x8e, 0x44, 0x9a, 0x27, 0xc5, 0xb4, 0x1a, 0xc6, 0xe7, 0xe5, 0x7b, 0xda,
+	0x7f, 0x69, 0xbf, 0x08, 0x97, 0xef, 0xff, 0x06, 0x00, 0xb3, 0xee, 0x74, 0x12, 0xb9, 0x01, 0x00,
+	0x00,
 }
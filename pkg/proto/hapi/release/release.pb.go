@@ -38,7 +38,10 @@ type Release struct {
 	// Version is an int32 which represents the version of the release.
 	Version int32 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
 	// Namespace is the kubernetes namespace of the release.
-	Namespace            string   `protobuf:"bytes,8,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Namespace string `protobuf:"bytes,8,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// SensitiveValues are the paths of the values marked as sensitive by the
+	// chart or the user. They are masked when the release is printed.
+	SensitiveValues      []string `protobuf:"bytes,9,rep,name=sensitive_values,json=sensitiveValues,proto3" json:"sensitive_values,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -48,7 +51,7 @@ func (m *Release) Reset()         { *m = Release{} }
 func (m *Release) String() string { return proto.CompactTextString(m) }
 func (*Release) ProtoMessage()    {}
 func (*Release) Descriptor() ([]byte, []int) {
-	return fileDescriptor_release_4bea5d16ba219619, []int{0}
+	return fileDescriptor_release_12224fceada8bd6e, []int{0}
 }
 func (m *Release) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Release.Unmarshal(m, b)
@@ -124,28 +127,39 @@ func (m *Release) GetNamespace() string {
 	return ""
 }
 
+func (m *Release) GetSensitiveValues() []string {
+	if m != nil {
+		return m.SensitiveValues
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Release)(nil), "hapi.release.Release")
 }
 
-func init() { proto.RegisterFile("hapi/release/release.proto", fileDescriptor_release_4bea5d16ba219619) }
-
-var fileDescriptor_release_4bea5d16ba219619 = []byte{
-	// 256 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0xbf, 0x4e, 0xc3, 0x40,
-	0x0c, 0xc6, 0x95, 0x36, 0x7f, 0x1a, 0xc3, 0x82, 0x07, 0xb0, 0x22, 0x86, 0x88, 0x01, 0x22, 0x86,
-	0x54, 0x82, 0x37, 0x80, 0x05, 0xd6, 0x1b, 0xd9, 0x8e, 0xe8, 0x42, 0x4e, 0xa5, 0xe7, 0x28, 0x17,
-	0xf1, 0x2c, 0x3c, 0x2e, 0xba, 0x3f, 0x85, 0x94, 0x2e, 0x4e, 0xec, 0xdf, 0xa7, 0xcf, 0xdf, 0x19,
-	0xaa, 0x41, 0x8e, 0x7a, 0x3b, 0xa9, 0x4f, 0x25, 0xad, 0x3a, 0x7c, 0xdb, 0x71, 0xe2, 0x99, 0xf1,
-	0xdc, 0xb1, 0x36, 0xce, 0xaa, 0xab, 0x23, 0xe5, 0xc0, 0xbc, 0x0b, 0xb2, 0x7f, 0x40, 0x9b, 0x9e,
-	0x8f, 0x40, 0x37, 0xc8, 0x69, 0xde, 0x76, 0x6c, 0x7a, 0xfd, 0x11, 0xc1, 0xe5, 0x12, 0xb8, 0x1a,
-	0xe6, 0x37, 0xdf, 0x2b, 0x28, 0x44, 0xf0, 0x41, 0x84, 0xd4, 0xc8, 0xbd, 0xa2, 0xa4, 0x4e, 0x9a,
-	0x52, 0xf8, 0x7f, 0xbc, 0x85, 0xd4, 0xd9, 0xd3, 0xaa, 0x4e, 0x9a, 0xb3, 0x07, 0x6c, 0x97, 0xf9,
-	0xda, 0x57, 0xd3, 0xb3, 0xf0, 0x1c, 0xef, 0x20, 0xf3, 0xb6, 0xb4, 0xf6, 0xc2, 0x8b, 0x20, 0x0c,
-	0x9b, 0x9e, 0x5d, 0x15, 0x81, 0xe3, 0x3d, 0xe4, 0x21, 0x18, 0xa5, 0x4b, 0xcb, 0xa8, 0xf4, 0x44,
-	0x44, 0x05, 0x56, 0xb0, 0xd9, 0x4b, 0xa3, 0x7b, 0x65, 0x67, 0xca, 0x7c, 0xa8, 0xdf, 0x1e, 0x1b,
-	0xc8, 0xdc, 0x41, 0x2c, 0xe5, 0xf5, 0xfa, 0x34, 0xd9, 0x0b, 0xf3, 0x4e, 0x04, 0x01, 0x12, 0x14,
-	0x5f, 0x6a, 0xb2, 0x9a, 0x0d, 0x15, 0x75, 0xd2, 0x64, 0xe2, 0xd0, 0xe2, 0x35, 0x94, 0xee, 0x91,
-	0x76, 0x94, 0x9d, 0xa2, 0x8d, 0x5f, 0xf0, 0x37, 0x78, 0x2a, 0xdf, 0x8a, 0x68, 0xf7, 0x9e, 0xfb,
-	0x63, 0x3d, 0xfe, 0x04, 0x00, 0x00, 0xff, 0xff, 0xc8, 0x8f, 0xec, 0x97, 0xbb, 0x01, 0x00, 0x00,
+func init() {
+	proto.RegisterFile("hapi/release/release.proto", fileDescriptor_release_12224fceada8bd6e)
+}
+
+var fileDescriptor_release_12224fceada8bd6e = []byte{
+	// 280 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0x3f, 0x4f, 0xc3, 0x30,
+	0x10, 0xc5, 0x95, 0xe6, 0x5f, 0x73, 0x20, 0x01, 0x37, 0x80, 0x15, 0x31, 0x44, 0x0c, 0x10, 0x18,
+	0x52, 0x09, 0xbe, 0x01, 0x2c, 0xb0, 0x7a, 0x60, 0x60, 0x41, 0x26, 0x72, 0x88, 0xd5, 0xd6, 0x17,
+	0xc5, 0x21, 0x5f, 0x90, 0x2f, 0x86, 0x6c, 0xa7, 0x25, 0x85, 0xc5, 0x89, 0xdf, 0xef, 0xe9, 0xdd,
+	0xf3, 0x41, 0xde, 0x8a, 0x4e, 0xad, 0x7a, 0xb9, 0x91, 0xc2, 0xc8, 0xdd, 0xb7, 0xea, 0x7a, 0x1a,
+	0x08, 0x8f, 0x2d, 0xab, 0x26, 0x2d, 0xbf, 0x38, 0x70, 0xb6, 0x44, 0x6b, 0x6f, 0xfb, 0x03, 0x94,
+	0x6e, 0xe8, 0x00, 0xd4, 0xad, 0xe8, 0x87, 0x55, 0x4d, 0xba, 0x51, 0x9f, 0x13, 0x38, 0x9f, 0x03,
+	0x7b, 0x7a, 0xfd, 0xea, 0x7b, 0x01, 0x29, 0xf7, 0x39, 0x88, 0x10, 0x69, 0xb1, 0x95, 0x2c, 0x28,
+	0x82, 0x32, 0xe3, 0xee, 0x1f, 0xaf, 0x21, 0xb2, 0xf1, 0x6c, 0x51, 0x04, 0xe5, 0xd1, 0x3d, 0x56,
+	0xf3, 0x7e, 0xd5, 0x8b, 0x6e, 0x88, 0x3b, 0x8e, 0x37, 0x10, 0xbb, 0x58, 0x16, 0x3a, 0xe3, 0x99,
+	0x37, 0xfa, 0x49, 0x4f, 0xf6, 0xe4, 0x9e, 0xe3, 0x1d, 0x24, 0xbe, 0x18, 0x8b, 0xe6, 0x91, 0x93,
+	0xd3, 0x11, 0x3e, 0x39, 0x30, 0x87, 0xe5, 0x56, 0x68, 0xd5, 0x48, 0x33, 0xb0, 0xd8, 0x95, 0xda,
+	0xdf, 0xb1, 0x84, 0xd8, 0x2e, 0xc4, 0xb0, 0xa4, 0x08, 0xff, 0x37, 0x7b, 0x26, 0x5a, 0x73, 0x6f,
+	0x40, 0x06, 0xe9, 0x28, 0x7b, 0xa3, 0x48, 0xb3, 0xb4, 0x08, 0xca, 0x98, 0xef, 0xae, 0x78, 0x09,
+	0x99, 0x7d, 0xa4, 0xe9, 0x44, 0x2d, 0xd9, 0xd2, 0x0d, 0xf8, 0x15, 0xf0, 0x16, 0x4e, 0x8d, 0xd4,
+	0x46, 0x0d, 0x6a, 0x94, 0xef, 0xa3, 0xd8, 0x7c, 0x49, 0xc3, 0xb2, 0x22, 0x2c, 0x33, 0x7e, 0xb2,
+	0xd7, 0x5f, 0x9d, 0xfc, 0x98, 0xbd, 0xa5, 0xd3, 0xe4, 0x8f, 0xc4, 0xed, 0xf5, 0xe1, 0x67, 0x00,
+	0x78, 0x34, 0xa4, 0xd6, 0xe6, 0x01, 0x00, 0x00,
 }
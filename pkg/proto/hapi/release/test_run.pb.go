@@ -45,25 +45,30 @@ func (x TestRun_Status) String() string {
 	return proto.EnumName(TestRun_Status_name, int32(x))
 }
 func (TestRun_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_test_run_31b133e40c63664e, []int{0, 0}
+	return fileDescriptor_test_run_333a4829402366c0, []int{0, 0}
 }
 
 type TestRun struct {
-	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Status               TestRun_Status       `protobuf:"varint,2,opt,name=status,proto3,enum=hapi.release.TestRun_Status" json:"status,omitempty"`
-	Info                 string               `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
-	StartedAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	CompletedAt          *timestamp.Timestamp `protobuf:"bytes,5,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Name        string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status      TestRun_Status       `protobuf:"varint,2,opt,name=status,proto3,enum=hapi.release.TestRun_Status" json:"status,omitempty"`
+	Info        string               `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+	StartedAt   *timestamp.Timestamp `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// logs are the logs captured from the test pod. They are always captured
+	// for tests that did not succeed, and for every test if they were requested.
+	Logs string `protobuf:"bytes,6,opt,name=logs,proto3" json:"logs,omitempty"`
+	// attempts is the number of times the test pod was run
+	Attempts             int32    `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TestRun) Reset()         { *m = TestRun{} }
 func (m *TestRun) String() string { return proto.CompactTextString(m) }
 func (*TestRun) ProtoMessage()    {}
 func (*TestRun) Descriptor() ([]byte, []int) {
-	return fileDescriptor_test_run_31b133e40c63664e, []int{0}
+	return fileDescriptor_test_run_333a4829402366c0, []int{0}
 }
 func (m *TestRun) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TestRun.Unmarshal(m, b)
@@ -118,33 +123,48 @@ func (m *TestRun) GetCompletedAt() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *TestRun) GetLogs() string {
+	if m != nil {
+		return m.Logs
+	}
+	return ""
+}
+
+func (m *TestRun) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*TestRun)(nil), "hapi.release.TestRun")
 	proto.RegisterEnum("hapi.release.TestRun_Status", TestRun_Status_name, TestRun_Status_value)
 }
 
 func init() {
-	proto.RegisterFile("hapi/release/test_run.proto", fileDescriptor_test_run_31b133e40c63664e)
-}
-
-var fileDescriptor_test_run_31b133e40c63664e = []byte{
-	// 274 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x8f, 0xc1, 0x4b, 0xfb, 0x30,
-	0x1c, 0xc5, 0x7f, 0xe9, 0xf6, 0x6b, 0x69, 0x3a, 0xa4, 0xe4, 0x54, 0xa6, 0x60, 0xd9, 0xa9, 0xa7,
-	0x14, 0xa6, 0x17, 0x41, 0x0f, 0x75, 0x4c, 0x19, 0x4a, 0x84, 0x74, 0x45, 0xf0, 0x32, 0x32, 0xcd,
-	0x66, 0xa1, 0x6d, 0x4a, 0xf3, 0xed, 0xdf, 0xe3, 0xbf, 0x2a, 0x69, 0x33, 0xf1, 0xe6, 0xed, 0xfb,
-	0x78, 0x9f, 0xf7, 0xf2, 0x82, 0xcf, 0x3f, 0x45, 0x5b, 0xa6, 0x9d, 0xac, 0xa4, 0xd0, 0x32, 0x05,
-	0xa9, 0x61, 0xd7, 0xf5, 0x0d, 0x6d, 0x3b, 0x05, 0x8a, 0xcc, 0x8c, 0x49, 0xad, 0x39, 0xbf, 0x3c,
-	0x2a, 0x75, 0xac, 0x64, 0x3a, 0x78, 0xfb, 0xfe, 0x90, 0x42, 0x59, 0x4b, 0x0d, 0xa2, 0x6e, 0x47,
-	0x7c, 0xf1, 0xe5, 0x60, 0x6f, 0x2b, 0x35, 0xf0, 0xbe, 0x21, 0x04, 0x4f, 0x1b, 0x51, 0xcb, 0x08,
-	0xc5, 0x28, 0xf1, 0xf9, 0x70, 0x93, 0x6b, 0xec, 0x6a, 0x10, 0xd0, 0xeb, 0xc8, 0x89, 0x51, 0x72,
-	0xb6, 0xbc, 0xa0, 0xbf, 0xfb, 0xa9, 0x8d, 0xd2, 0x7c, 0x60, 0xb8, 0x65, 0x4d, 0x53, 0xd9, 0x1c,
-	0x54, 0x34, 0x19, 0x9b, 0xcc, 0x4d, 0x6e, 0x30, 0xd6, 0x20, 0x3a, 0x90, 0x1f, 0x3b, 0x01, 0xd1,
-	0x34, 0x46, 0x49, 0xb0, 0x9c, 0xd3, 0x71, 0x1f, 0x3d, 0xed, 0xa3, 0xdb, 0xd3, 0x3e, 0xee, 0x5b,
-	0x3a, 0x03, 0x72, 0x87, 0x67, 0xef, 0xaa, 0x6e, 0x2b, 0x69, 0xc3, 0xff, 0xff, 0x0c, 0x07, 0x3f,
-	0x7c, 0x06, 0x8b, 0x5b, 0xec, 0x8e, 0xfb, 0x48, 0x80, 0xbd, 0x82, 0x3d, 0xb1, 0x97, 0x57, 0x16,
-	0xfe, 0x33, 0x22, 0x2f, 0x56, 0xab, 0x75, 0x9e, 0x87, 0xc8, 0x88, 0x87, 0x6c, 0xf3, 0x5c, 0xf0,
-	0x75, 0xe8, 0x18, 0xc1, 0x0b, 0xc6, 0x36, 0xec, 0x31, 0x9c, 0xdc, 0xfb, 0x6f, 0x9e, 0xfd, 0xed,
-	0xde, 0x1d, 0x5e, 0xba, 0xfa, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x31, 0x86, 0x46, 0xdb, 0x81, 0x01,
-	0x00, 0x00,
+	proto.RegisterFile("hapi/release/test_run.proto", fileDescriptor_test_run_333a4829402366c0)
+}
+
+var fileDescriptor_test_run_333a4829402366c0 = []byte{
+	// 294 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0x4d, 0x4b, 0xc3, 0x30,
+	0x18, 0xc7, 0xcd, 0x5e, 0x5a, 0x97, 0x0d, 0x29, 0x39, 0x85, 0x29, 0x58, 0x76, 0xea, 0x29, 0x85,
+	0xe9, 0x45, 0xd0, 0xc3, 0x1c, 0x53, 0x86, 0x12, 0x21, 0x5d, 0x11, 0xbc, 0x8c, 0x4c, 0xb3, 0x59,
+	0x68, 0x9b, 0xd2, 0x3c, 0xfd, 0x9c, 0x7e, 0x25, 0x49, 0x9b, 0x0d, 0x6f, 0xde, 0x9e, 0x3f, 0xff,
+	0x17, 0x7e, 0x09, 0xbe, 0xfc, 0x96, 0x55, 0x16, 0xd7, 0x2a, 0x57, 0xd2, 0xa8, 0x18, 0x94, 0x81,
+	0x6d, 0xdd, 0x94, 0xac, 0xaa, 0x35, 0x68, 0x32, 0xb1, 0x26, 0x73, 0xe6, 0xf4, 0xfa, 0xa0, 0xf5,
+	0x21, 0x57, 0x71, 0xeb, 0xed, 0x9a, 0x7d, 0x0c, 0x59, 0xa1, 0x0c, 0xc8, 0xa2, 0xea, 0xe2, 0xb3,
+	0x9f, 0x1e, 0xf6, 0x37, 0xca, 0x80, 0x68, 0x4a, 0x42, 0xf0, 0xa0, 0x94, 0x85, 0xa2, 0x28, 0x44,
+	0xd1, 0x48, 0xb4, 0x37, 0xb9, 0xc5, 0x9e, 0x01, 0x09, 0x8d, 0xa1, 0xbd, 0x10, 0x45, 0x17, 0xf3,
+	0x2b, 0xf6, 0x77, 0x9f, 0xb9, 0x2a, 0x4b, 0xda, 0x8c, 0x70, 0x59, 0xbb, 0x94, 0x95, 0x7b, 0x4d,
+	0xfb, 0xdd, 0x92, 0xbd, 0xc9, 0x1d, 0xc6, 0x06, 0x64, 0x0d, 0xea, 0x6b, 0x2b, 0x81, 0x0e, 0x42,
+	0x14, 0x8d, 0xe7, 0x53, 0xd6, 0xf1, 0xb1, 0x23, 0x1f, 0xdb, 0x1c, 0xf9, 0xc4, 0xc8, 0xa5, 0x17,
+	0x40, 0x1e, 0xf0, 0xe4, 0x53, 0x17, 0x55, 0xae, 0x5c, 0x79, 0xf8, 0x6f, 0x79, 0x7c, 0xca, 0x2f,
+	0xc0, 0xd2, 0xe4, 0xfa, 0x60, 0xa8, 0xd7, 0xd1, 0xd8, 0x9b, 0x4c, 0xf1, 0xb9, 0x04, 0x50, 0x45,
+	0x05, 0x86, 0xfa, 0x21, 0x8a, 0x86, 0xe2, 0xa4, 0x67, 0xf7, 0xd8, 0xeb, 0xde, 0x43, 0xc6, 0xd8,
+	0x4f, 0xf9, 0x0b, 0x7f, 0x7b, 0xe7, 0xc1, 0x99, 0x15, 0x49, 0xba, 0x5c, 0xae, 0x92, 0x24, 0x40,
+	0x56, 0x3c, 0x2d, 0xd6, 0xaf, 0xa9, 0x58, 0x05, 0x3d, 0x2b, 0x44, 0xca, 0xf9, 0x9a, 0x3f, 0x07,
+	0xfd, 0xc7, 0xd1, 0x87, 0xef, 0x7e, 0x67, 0xe7, 0xb5, 0x64, 0x37, 0xbf, 0x03, 0x00, 0xb1, 0xb0,
+	0x1c, 0xa3, 0xb1, 0x01, 0x00, 0x00,
 }
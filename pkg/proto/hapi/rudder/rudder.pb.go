@@ -54,7 +54,7 @@ func (x Result_Status) String() string {
 	return proto.EnumName(Result_Status_name, int32(x))
 }
 func (Result_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{0, 0}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{0, 0}
 }
 
 type Result struct {
@@ -69,7 +69,7 @@ func (m *Result) Reset()         { *m = Result{} }
 func (m *Result) String() string { return proto.CompactTextString(m) }
 func (*Result) ProtoMessage()    {}
 func (*Result) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{0}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{0}
 }
 func (m *Result) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Result.Unmarshal(m, b)
@@ -113,7 +113,7 @@ func (m *VersionReleaseRequest) Reset()         { *m = VersionReleaseRequest{} }
 func (m *VersionReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*VersionReleaseRequest) ProtoMessage()    {}
 func (*VersionReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{1}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{1}
 }
 func (m *VersionReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VersionReleaseRequest.Unmarshal(m, b)
@@ -145,7 +145,7 @@ func (m *VersionReleaseResponse) Reset()         { *m = VersionReleaseResponse{}
 func (m *VersionReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*VersionReleaseResponse) ProtoMessage()    {}
 func (*VersionReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{2}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{2}
 }
 func (m *VersionReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VersionReleaseResponse.Unmarshal(m, b)
@@ -181,6 +181,8 @@ func (m *VersionReleaseResponse) GetVersion() string {
 
 type InstallReleaseRequest struct {
 	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	Adopt                bool             `protobuf:"varint,2,opt,name=Adopt,proto3" json:"Adopt,omitempty"`
+	AdoptOnly            bool             `protobuf:"varint,3,opt,name=AdoptOnly,proto3" json:"AdoptOnly,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -190,7 +192,7 @@ func (m *InstallReleaseRequest) Reset()         { *m = InstallReleaseRequest{} }
 func (m *InstallReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*InstallReleaseRequest) ProtoMessage()    {}
 func (*InstallReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{3}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{3}
 }
 func (m *InstallReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallReleaseRequest.Unmarshal(m, b)
@@ -217,6 +219,20 @@ func (m *InstallReleaseRequest) GetRelease() *release.Release {
 	return nil
 }
 
+func (m *InstallReleaseRequest) GetAdopt() bool {
+	if m != nil {
+		return m.Adopt
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetAdoptOnly() bool {
+	if m != nil {
+		return m.AdoptOnly
+	}
+	return false
+}
+
 type InstallReleaseResponse struct {
 	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
 	Result               *Result          `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
@@ -229,7 +245,7 @@ func (m *InstallReleaseResponse) Reset()         { *m = InstallReleaseResponse{}
 func (m *InstallReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*InstallReleaseResponse) ProtoMessage()    {}
 func (*InstallReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{4}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{4}
 }
 func (m *InstallReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallReleaseResponse.Unmarshal(m, b)
@@ -274,7 +290,7 @@ func (m *DeleteReleaseRequest) Reset()         { *m = DeleteReleaseRequest{} }
 func (m *DeleteReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteReleaseRequest) ProtoMessage()    {}
 func (*DeleteReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{5}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{5}
 }
 func (m *DeleteReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteReleaseRequest.Unmarshal(m, b)
@@ -313,7 +329,7 @@ func (m *DeleteReleaseResponse) Reset()         { *m = DeleteReleaseResponse{} }
 func (m *DeleteReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteReleaseResponse) ProtoMessage()    {}
 func (*DeleteReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{6}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{6}
 }
 func (m *DeleteReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteReleaseResponse.Unmarshal(m, b)
@@ -355,6 +371,7 @@ type UpgradeReleaseRequest struct {
 	Recreate             bool             `protobuf:"varint,5,opt,name=Recreate,proto3" json:"Recreate,omitempty"`
 	Force                bool             `protobuf:"varint,6,opt,name=Force,proto3" json:"Force,omitempty"`
 	CleanupOnFail        bool             `protobuf:"varint,7,opt,name=CleanupOnFail,proto3" json:"CleanupOnFail,omitempty"`
+	Adopt                bool             `protobuf:"varint,8,opt,name=Adopt,proto3" json:"Adopt,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -364,7 +381,7 @@ func (m *UpgradeReleaseRequest) Reset()         { *m = UpgradeReleaseRequest{} }
 func (m *UpgradeReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*UpgradeReleaseRequest) ProtoMessage()    {}
 func (*UpgradeReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{7}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{7}
 }
 func (m *UpgradeReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpgradeReleaseRequest.Unmarshal(m, b)
@@ -433,6 +450,13 @@ func (m *UpgradeReleaseRequest) GetCleanupOnFail() bool {
 	return false
 }
 
+func (m *UpgradeReleaseRequest) GetAdopt() bool {
+	if m != nil {
+		return m.Adopt
+	}
+	return false
+}
+
 type UpgradeReleaseResponse struct {
 	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
 	Result               *Result          `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
@@ -445,7 +469,7 @@ func (m *UpgradeReleaseResponse) Reset()         { *m = UpgradeReleaseResponse{}
 func (m *UpgradeReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*UpgradeReleaseResponse) ProtoMessage()    {}
 func (*UpgradeReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{8}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{8}
 }
 func (m *UpgradeReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpgradeReleaseResponse.Unmarshal(m, b)
@@ -496,7 +520,7 @@ func (m *RollbackReleaseRequest) Reset()         { *m = RollbackReleaseRequest{}
 func (m *RollbackReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*RollbackReleaseRequest) ProtoMessage()    {}
 func (*RollbackReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{9}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{9}
 }
 func (m *RollbackReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RollbackReleaseRequest.Unmarshal(m, b)
@@ -577,7 +601,7 @@ func (m *RollbackReleaseResponse) Reset()         { *m = RollbackReleaseResponse
 func (m *RollbackReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*RollbackReleaseResponse) ProtoMessage()    {}
 func (*RollbackReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{10}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{10}
 }
 func (m *RollbackReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RollbackReleaseResponse.Unmarshal(m, b)
@@ -622,7 +646,7 @@ func (m *ReleaseStatusRequest) Reset()         { *m = ReleaseStatusRequest{} }
 func (m *ReleaseStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*ReleaseStatusRequest) ProtoMessage()    {}
 func (*ReleaseStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{11}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{11}
 }
 func (m *ReleaseStatusRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReleaseStatusRequest.Unmarshal(m, b)
@@ -661,7 +685,7 @@ func (m *ReleaseStatusResponse) Reset()         { *m = ReleaseStatusResponse{} }
 func (m *ReleaseStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*ReleaseStatusResponse) ProtoMessage()    {}
 func (*ReleaseStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rudder_dd8cdbe38a210d28, []int{12}
+	return fileDescriptor_rudder_8e42ebe63e3db442, []int{12}
 }
 func (m *ReleaseStatusResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReleaseStatusResponse.Unmarshal(m, b)
@@ -959,47 +983,49 @@ var _ReleaseModuleService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "hapi/rudder/rudder.proto",
 }
 
-func init() { proto.RegisterFile("hapi/rudder/rudder.proto", fileDescriptor_rudder_dd8cdbe38a210d28) }
-
-var fileDescriptor_rudder_dd8cdbe38a210d28 = []byte{
-	// 615 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x56, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x8d, 0x9b, 0xc6, 0x69, 0xa6, 0x2a, 0x44, 0xab, 0xba, 0xb5, 0x2c, 0x0e, 0x91, 0x85, 0x50,
-	0x44, 0xa9, 0x2b, 0x15, 0x8e, 0x5c, 0x20, 0xfd, 0x14, 0x22, 0x95, 0x36, 0x84, 0x4a, 0xdc, 0xb6,
-	0xc9, 0x24, 0x18, 0x36, 0xb6, 0x59, 0xaf, 0x7b, 0x04, 0x7e, 0x0d, 0xff, 0x12, 0x84, 0xec, 0xb5,
-	0x23, 0x6c, 0xd6, 0x22, 0x14, 0x29, 0x17, 0x4e, 0xde, 0xd9, 0x79, 0x9d, 0x9d, 0xf7, 0x76, 0xf6,
-	0x35, 0x60, 0xbf, 0x67, 0x91, 0x7f, 0x24, 0x92, 0xe9, 0x14, 0x45, 0xfe, 0xf1, 0x22, 0x11, 0xca,
-	0x90, 0xec, 0xa6, 0x19, 0x2f, 0x46, 0x71, 0xeb, 0x4f, 0x30, 0xf6, 0x54, 0xce, 0xd9, 0x57, 0x78,
-	0xe4, 0xc8, 0x62, 0x3c, 0xf2, 0x83, 0x59, 0xa8, 0xe0, 0x8e, 0x53, 0x4a, 0xe4, 0x5f, 0x95, 0x73,
-	0x39, 0x98, 0x14, 0xe3, 0x84, 0x4b, 0x42, 0x60, 0x33, 0xfd, 0x1b, 0xdb, 0xe8, 0x19, 0xfd, 0x0e,
-	0xcd, 0xd6, 0xa4, 0x0b, 0x4d, 0x1e, 0xce, 0xed, 0x8d, 0x5e, 0xb3, 0xdf, 0xa1, 0xe9, 0xd2, 0x7d,
-	0x0e, 0xe6, 0x48, 0x32, 0x99, 0xc4, 0x64, 0x1b, 0xda, 0xe3, 0xe1, 0xab, 0xe1, 0xd5, 0xf5, 0xb0,
-	0xdb, 0x48, 0x83, 0xd1, 0x78, 0x30, 0x38, 0x1d, 0x8d, 0xba, 0x06, 0xd9, 0x81, 0xce, 0x78, 0x38,
-	0xb8, 0x78, 0x31, 0x3c, 0x3f, 0x3d, 0xe9, 0x6e, 0x90, 0x0e, 0xb4, 0x4e, 0x29, 0xbd, 0xa2, 0xdd,
-	0xa6, 0xbb, 0x0f, 0xd6, 0x5b, 0x14, 0xb1, 0x1f, 0x06, 0x54, 0x75, 0x41, 0xf1, 0x53, 0x82, 0xb1,
-	0x74, 0xcf, 0x60, 0xaf, 0x9a, 0x88, 0xa3, 0x30, 0x88, 0x31, 0x6d, 0x2b, 0x60, 0x0b, 0x2c, 0xda,
-	0x4a, 0xd7, 0xc4, 0x86, 0xf6, 0xad, 0x42, 0xdb, 0x1b, 0xd9, 0x76, 0x11, 0xba, 0x17, 0x60, 0x5d,
-	0x06, 0xb1, 0x64, 0x9c, 0x97, 0x0f, 0x20, 0x47, 0xd0, 0xce, 0x89, 0x67, 0x95, 0xb6, 0x8f, 0x2d,
-	0x2f, 0x13, 0xb1, 0x50, 0xa3, 0x80, 0x17, 0x28, 0xf7, 0x0b, 0xec, 0x55, 0x2b, 0xe5, 0x1d, 0xfd,
-	0x6d, 0x29, 0xf2, 0x0c, 0x4c, 0x91, 0x69, 0x9c, 0x75, 0xbb, 0x7d, 0xfc, 0xc0, 0xd3, 0xdd, 0x9f,
-	0xa7, 0xee, 0x81, 0xe6, 0x58, 0xf7, 0x1c, 0x76, 0x4f, 0x90, 0xa3, 0xc4, 0x7f, 0x65, 0xf2, 0x19,
-	0xac, 0x4a, 0xa1, 0xf5, 0x12, 0xf9, 0x6e, 0x80, 0x35, 0x8e, 0xe6, 0x82, 0x4d, 0x35, 0x54, 0x26,
-	0x89, 0x10, 0x18, 0xc8, 0x3f, 0x34, 0x90, 0xa3, 0xc8, 0x21, 0x98, 0x92, 0x89, 0x39, 0x16, 0x0d,
-	0xd4, 0xe0, 0x73, 0x50, 0x3a, 0x27, 0x6f, 0xfc, 0x05, 0x86, 0x89, 0xb4, 0x9b, 0x3d, 0xa3, 0xdf,
-	0xa4, 0x45, 0x98, 0x4e, 0xd5, 0x35, 0xf3, 0xa5, 0xbd, 0xd9, 0x33, 0xfa, 0x5b, 0x34, 0x5b, 0x13,
-	0x07, 0xb6, 0x28, 0x4e, 0x04, 0x32, 0x89, 0x76, 0x2b, 0xdb, 0x5f, 0xc6, 0x64, 0x17, 0x5a, 0x67,
-	0xa1, 0x98, 0xa0, 0x6d, 0x66, 0x09, 0x15, 0x90, 0x87, 0xb0, 0x33, 0xe0, 0xc8, 0x82, 0x24, 0xba,
-	0x0a, 0xce, 0x98, 0xcf, 0xed, 0x76, 0x96, 0x2d, 0x6f, 0xa6, 0x93, 0x54, 0xa5, 0xbf, 0xde, 0x0b,
-	0xf8, 0x61, 0xc0, 0x1e, 0x0d, 0x39, 0xbf, 0x61, 0x93, 0x8f, 0xff, 0xe5, 0x0d, 0x7c, 0x35, 0x60,
-	0xff, 0x37, 0x01, 0xd6, 0xfe, 0x9a, 0xf3, 0x4a, 0xca, 0x3e, 0xef, 0xfc, 0x9a, 0x23, 0xb0, 0x2a,
-	0x85, 0xee, 0x4a, 0xe4, 0x51, 0x6e, 0xf8, 0x8a, 0x06, 0x29, 0xa3, 0x2f, 0x83, 0x59, 0xa8, 0xfe,
-	0x09, 0x1c, 0x7f, 0x6b, 0x2d, 0x7b, 0x7f, 0x1d, 0x4e, 0x13, 0x8e, 0x23, 0x45, 0x95, 0xcc, 0xa0,
-	0x9d, 0x9b, 0x36, 0x39, 0xd0, 0x8b, 0xa0, 0x35, 0x7b, 0xe7, 0xc9, 0x6a, 0x60, 0xc5, 0xcb, 0x6d,
-	0x90, 0x05, 0xdc, 0x2b, 0x5b, 0x71, 0xdd, 0x71, 0x5a, 0xeb, 0xaf, 0x3b, 0x4e, 0xef, 0xee, 0x6e,
-	0x83, 0x7c, 0x80, 0x9d, 0x92, 0x5f, 0x92, 0xc7, 0xfa, 0x02, 0x3a, 0x77, 0x76, 0x0e, 0x56, 0xc2,
-	0x2e, 0xcf, 0x8a, 0xe0, 0x7e, 0x65, 0x30, 0x49, 0x4d, 0xbb, 0xfa, 0x07, 0xec, 0x1c, 0xae, 0x88,
-	0xfe, 0x55, 0xcc, 0xb2, 0x1b, 0xd5, 0x89, 0xa9, 0xb5, 0xec, 0x3a, 0x31, 0xf5, 0x06, 0xa7, 0xc4,
-	0x2c, 0x8d, 0x6b, 0x9d, 0x98, 0xba, 0xc7, 0x51, 0x27, 0xa6, 0x76, 0xfe, 0xdd, 0xc6, 0xcb, 0xad,
-	0x77, 0xa6, 0x42, 0xdc, 0x98, 0xd9, 0x8f, 0x9b, 0xa7, 0x3f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xe2,
-	0x9e, 0x21, 0x0d, 0x43, 0x09, 0x00, 0x00,
+func init() { proto.RegisterFile("hapi/rudder/rudder.proto", fileDescriptor_rudder_8e42ebe63e3db442) }
+
+var fileDescriptor_rudder_8e42ebe63e3db442 = []byte{
+	// 643 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x56, 0x4d, 0x6f, 0xd3, 0x4c,
+	0x10, 0x6e, 0x92, 0xc6, 0x49, 0xa6, 0xea, 0xfb, 0x46, 0xab, 0xa6, 0xb5, 0xac, 0x1e, 0x2a, 0x0b,
+	0xa1, 0x8a, 0xb6, 0xae, 0x54, 0x38, 0x72, 0x29, 0xe9, 0x07, 0x15, 0x22, 0x91, 0x36, 0x84, 0x4a,
+	0xdc, 0xb6, 0xc9, 0xb4, 0x18, 0xb6, 0x5e, 0xb3, 0x5e, 0x57, 0x42, 0x42, 0xc0, 0x7f, 0xe0, 0x3f,
+	0xf0, 0x33, 0x11, 0xf2, 0xae, 0x9d, 0xd6, 0xc6, 0x11, 0xa6, 0x48, 0xb9, 0x70, 0xf2, 0xcc, 0xce,
+	0x93, 0xf9, 0x7c, 0x76, 0x36, 0x60, 0xbf, 0x65, 0xa1, 0xbf, 0x2f, 0xe3, 0xe9, 0x14, 0x65, 0xfa,
+	0xf1, 0x42, 0x29, 0x94, 0x20, 0x6b, 0x89, 0xc5, 0x8b, 0x50, 0xde, 0xf8, 0x13, 0x8c, 0x3c, 0x63,
+	0x73, 0x36, 0x0c, 0x1e, 0x39, 0xb2, 0x08, 0xf7, 0xfd, 0xe0, 0x52, 0x18, 0xb8, 0xe3, 0xe4, 0x0c,
+	0xe9, 0xd7, 0xd8, 0x5c, 0x0e, 0x16, 0xc5, 0x28, 0xe6, 0x8a, 0x10, 0x58, 0x4e, 0x7e, 0x63, 0xd7,
+	0xb6, 0x6a, 0xdb, 0x1d, 0xaa, 0x65, 0xd2, 0x85, 0x06, 0x17, 0x57, 0x76, 0x7d, 0xab, 0xb1, 0xdd,
+	0xa1, 0x89, 0xe8, 0x3e, 0x05, 0x6b, 0xa4, 0x98, 0x8a, 0x23, 0xb2, 0x02, 0xad, 0xf1, 0xe0, 0xc5,
+	0x60, 0x78, 0x3e, 0xe8, 0x2e, 0x25, 0xca, 0x68, 0xdc, 0xef, 0x1f, 0x8f, 0x46, 0xdd, 0x1a, 0x59,
+	0x85, 0xce, 0x78, 0xd0, 0x7f, 0x7e, 0x38, 0x38, 0x3d, 0x3e, 0xea, 0xd6, 0x49, 0x07, 0x9a, 0xc7,
+	0x94, 0x0e, 0x69, 0xb7, 0xe1, 0x6e, 0x40, 0xef, 0x35, 0xca, 0xc8, 0x17, 0x01, 0x35, 0x59, 0x50,
+	0xfc, 0x10, 0x63, 0xa4, 0xdc, 0x13, 0x58, 0x2f, 0x1a, 0xa2, 0x50, 0x04, 0x11, 0x26, 0x69, 0x05,
+	0xec, 0x1a, 0xb3, 0xb4, 0x12, 0x99, 0xd8, 0xd0, 0xba, 0x31, 0x68, 0xbb, 0xae, 0x8f, 0x33, 0xd5,
+	0xfd, 0x04, 0xbd, 0xb3, 0x20, 0x52, 0x8c, 0xf3, 0x7c, 0x00, 0xb2, 0x0f, 0xad, 0xb4, 0x70, 0xed,
+	0x69, 0xe5, 0xa0, 0xe7, 0xe9, 0x26, 0x66, 0xdd, 0xc8, 0xe0, 0x19, 0x8a, 0xac, 0x41, 0xf3, 0x70,
+	0x2a, 0x42, 0xa5, 0x23, 0xb4, 0xa9, 0x51, 0xc8, 0x26, 0x74, 0xb4, 0x30, 0x0c, 0xf8, 0x47, 0xbb,
+	0xa1, 0x2d, 0xb7, 0x07, 0xee, 0x17, 0x58, 0x2f, 0x46, 0x4f, 0xab, 0xf8, 0xe3, 0xf0, 0x4f, 0xc0,
+	0x92, 0x7a, 0x2e, 0x3a, 0xfe, 0xca, 0xc1, 0xa6, 0x57, 0x36, 0x73, 0xcf, 0xcc, 0x8e, 0xa6, 0x58,
+	0xf7, 0x14, 0xd6, 0x8e, 0x90, 0xa3, 0xc2, 0xbf, 0xac, 0xde, 0xfd, 0x0c, 0xbd, 0x82, 0xa3, 0xc5,
+	0x16, 0xf2, 0xad, 0x0e, 0xbd, 0x71, 0x78, 0x25, 0xd9, 0xb4, 0xa4, 0x94, 0x49, 0x2c, 0x25, 0x06,
+	0xea, 0x37, 0x09, 0xa4, 0x28, 0xb2, 0x07, 0x96, 0x62, 0xf2, 0x0a, 0xb3, 0x04, 0xe6, 0xe0, 0x53,
+	0x50, 0xc2, 0xad, 0x57, 0xfe, 0x35, 0x8a, 0x58, 0xe9, 0xf9, 0x36, 0x68, 0xa6, 0x26, 0x4c, 0x3c,
+	0x67, 0xbe, 0xb2, 0x97, 0xf5, 0xd8, 0xb5, 0x4c, 0x1c, 0x68, 0x53, 0x9c, 0x48, 0x64, 0x0a, 0xed,
+	0xa6, 0x3e, 0x9f, 0xe9, 0x09, 0x83, 0x4e, 0x84, 0x9c, 0xa0, 0x6d, 0x19, 0x06, 0x69, 0x85, 0x3c,
+	0x80, 0xd5, 0x3e, 0x47, 0x16, 0xc4, 0xe1, 0x30, 0x38, 0x61, 0x3e, 0xb7, 0x5b, 0xda, 0x9a, 0x3f,
+	0xbc, 0x65, 0x5f, 0xfb, 0x0e, 0xfb, 0x12, 0x7e, 0x15, 0x9b, 0xb2, 0xd8, 0xb1, 0xfc, 0xa8, 0xc1,
+	0x3a, 0x15, 0x9c, 0x5f, 0xb0, 0xc9, 0xfb, 0x7f, 0x71, 0x2e, 0xee, 0xd7, 0x1a, 0x6c, 0xfc, 0xd2,
+	0x80, 0x85, 0xdf, 0xf1, 0xd4, 0x93, 0x59, 0xc4, 0xf7, 0xbe, 0xe3, 0x21, 0xf4, 0x0a, 0x8e, 0xee,
+	0x5b, 0xc8, 0xc3, 0xf4, 0xe9, 0x30, 0x65, 0x90, 0x3c, 0xfa, 0x2c, 0xb8, 0x14, 0xe6, 0x39, 0x39,
+	0xf8, 0xde, 0x9c, 0xe5, 0xfe, 0x52, 0x4c, 0x63, 0x8e, 0x23, 0x53, 0x2a, 0xb9, 0x84, 0x56, 0xba,
+	0xfe, 0xc9, 0x4e, 0x79, 0x13, 0x4a, 0x9f, 0x0d, 0x67, 0xb7, 0x1a, 0xd8, 0xd4, 0xe5, 0x2e, 0x91,
+	0x6b, 0xf8, 0x2f, 0xbf, 0xa0, 0xe7, 0x85, 0x2b, 0x7d, 0x44, 0x9c, 0xdd, 0x6a, 0xe0, 0x59, 0xb8,
+	0x77, 0xb0, 0x9a, 0xdb, 0xa2, 0xe4, 0x51, 0xb9, 0x83, 0xb2, 0x9d, 0xed, 0xec, 0x54, 0xc2, 0xce,
+	0x62, 0x85, 0xf0, 0x7f, 0x81, 0x98, 0x64, 0x4e, 0xba, 0xe5, 0x17, 0xd8, 0xd9, 0xab, 0x88, 0xbe,
+	0xdb, 0xcc, 0xfc, 0x36, 0x9a, 0xd7, 0xcc, 0xd2, 0x45, 0xee, 0xec, 0x56, 0x03, 0xdf, 0x6d, 0x66,
+	0x8e, 0xae, 0xf3, 0x9a, 0x59, 0x76, 0x39, 0x9c, 0x9d, 0x4a, 0xd8, 0x2c, 0xd6, 0xb3, 0xf6, 0x1b,
+	0xcb, 0x20, 0x2e, 0x2c, 0xfd, 0x37, 0xe9, 0xf1, 0xcf, 0x01, 0x00, 0xa6, 0x29, 0xde, 0xd9, 0x8d,
+	0x09, 0x00, 0x00,
 }
@@ -53,7 +53,7 @@ func (x ListSort_SortBy) String() string {
 	return proto.EnumName(ListSort_SortBy_name, int32(x))
 }
 func (ListSort_SortBy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{1, 0}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{1, 0}
 }
 
 // SortOrder defines sort orders to augment sorting operations.
@@ -77,7 +77,37 @@ func (x ListSort_SortOrder) String() string {
 	return proto.EnumName(ListSort_SortOrder_name, int32(x))
 }
 func (ListSort_SortOrder) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{1, 1}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{1, 1}
+}
+
+// Conflict tells what to do with revisions that are already stored.
+type ImportReleaseRequest_Conflict int32
+
+const (
+	// FAIL fails the import before storing anything.
+	ImportReleaseRequest_FAIL ImportReleaseRequest_Conflict = 0
+	// SKIP keeps the stored revisions.
+	ImportReleaseRequest_SKIP ImportReleaseRequest_Conflict = 1
+	// OVERWRITE replaces the stored revisions.
+	ImportReleaseRequest_OVERWRITE ImportReleaseRequest_Conflict = 2
+)
+
+var ImportReleaseRequest_Conflict_name = map[int32]string{
+	0: "FAIL",
+	1: "SKIP",
+	2: "OVERWRITE",
+}
+var ImportReleaseRequest_Conflict_value = map[string]int32{
+	"FAIL":      0,
+	"SKIP":      1,
+	"OVERWRITE": 2,
+}
+
+func (x ImportReleaseRequest_Conflict) String() string {
+	return proto.EnumName(ImportReleaseRequest_Conflict_name, int32(x))
+}
+func (ImportReleaseRequest_Conflict) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{25, 0}
 }
 
 // ListReleasesRequest requests a list of releases.
@@ -114,7 +144,7 @@ func (m *ListReleasesRequest) Reset()         { *m = ListReleasesRequest{} }
 func (m *ListReleasesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListReleasesRequest) ProtoMessage()    {}
 func (*ListReleasesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{0}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{0}
 }
 func (m *ListReleasesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListReleasesRequest.Unmarshal(m, b)
@@ -194,7 +224,7 @@ func (m *ListSort) Reset()         { *m = ListSort{} }
 func (m *ListSort) String() string { return proto.CompactTextString(m) }
 func (*ListSort) ProtoMessage()    {}
 func (*ListSort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{1}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{1}
 }
 func (m *ListSort) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListSort.Unmarshal(m, b)
@@ -234,7 +264,7 @@ func (m *ListReleasesResponse) Reset()         { *m = ListReleasesResponse{} }
 func (m *ListReleasesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListReleasesResponse) ProtoMessage()    {}
 func (*ListReleasesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{2}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{2}
 }
 func (m *ListReleasesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListReleasesResponse.Unmarshal(m, b)
@@ -297,7 +327,7 @@ func (m *GetReleaseStatusRequest) Reset()         { *m = GetReleaseStatusRequest
 func (m *GetReleaseStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*GetReleaseStatusRequest) ProtoMessage()    {}
 func (*GetReleaseStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{3}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{3}
 }
 func (m *GetReleaseStatusRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReleaseStatusRequest.Unmarshal(m, b)
@@ -348,7 +378,7 @@ func (m *GetReleaseStatusResponse) Reset()         { *m = GetReleaseStatusRespon
 func (m *GetReleaseStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*GetReleaseStatusResponse) ProtoMessage()    {}
 func (*GetReleaseStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{4}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{4}
 }
 func (m *GetReleaseStatusResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReleaseStatusResponse.Unmarshal(m, b)
@@ -404,7 +434,7 @@ func (m *GetReleaseContentRequest) Reset()         { *m = GetReleaseContentReque
 func (m *GetReleaseContentRequest) String() string { return proto.CompactTextString(m) }
 func (*GetReleaseContentRequest) ProtoMessage()    {}
 func (*GetReleaseContentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{5}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{5}
 }
 func (m *GetReleaseContentRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReleaseContentRequest.Unmarshal(m, b)
@@ -451,7 +481,7 @@ func (m *GetReleaseContentResponse) Reset()         { *m = GetReleaseContentResp
 func (m *GetReleaseContentResponse) String() string { return proto.CompactTextString(m) }
 func (*GetReleaseContentResponse) ProtoMessage()    {}
 func (*GetReleaseContentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{6}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{6}
 }
 func (m *GetReleaseContentResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReleaseContentResponse.Unmarshal(m, b)
@@ -509,7 +539,12 @@ type UpdateReleaseRequest struct {
 	// Render subchart notes if enabled
 	SubNotes bool `protobuf:"varint,13,opt,name=subNotes,proto3" json:"subNotes,omitempty"`
 	// Allow deletion of new resources created in this update when update failed
-	CleanupOnFail        bool     `protobuf:"varint,14,opt,name=cleanup_on_fail,json=cleanupOnFail,proto3" json:"cleanup_on_fail,omitempty"`
+	CleanupOnFail bool `protobuf:"varint,14,opt,name=cleanup_on_fail,json=cleanupOnFail,proto3" json:"cleanup_on_fail,omitempty"`
+	// Adopt takes over resources that already exist in the cluster but were not
+	// part of the previous release.
+	Adopt bool `protobuf:"varint,15,opt,name=adopt,proto3" json:"adopt,omitempty"`
+	// SensitiveValues are the paths of values the user marks as sensitive.
+	SensitiveValues      []string `protobuf:"bytes,16,rep,name=sensitive_values,json=sensitiveValues,proto3" json:"sensitive_values,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -519,7 +554,7 @@ func (m *UpdateReleaseRequest) Reset()         { *m = UpdateReleaseRequest{} }
 func (m *UpdateReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateReleaseRequest) ProtoMessage()    {}
 func (*UpdateReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{7}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{7}
 }
 func (m *UpdateReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateReleaseRequest.Unmarshal(m, b)
@@ -637,19 +672,36 @@ func (m *UpdateReleaseRequest) GetCleanupOnFail() bool {
 	return false
 }
 
+func (m *UpdateReleaseRequest) GetAdopt() bool {
+	if m != nil {
+		return m.Adopt
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetSensitiveValues() []string {
+	if m != nil {
+		return m.SensitiveValues
+	}
+	return nil
+}
+
 // UpdateReleaseResponse is the response to an update request.
 type UpdateReleaseResponse struct {
-	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Release *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	// warnings are problems the update did not fail on, like objects using
+	// deprecated apiVersions.
+	Warnings             []string `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *UpdateReleaseResponse) Reset()         { *m = UpdateReleaseResponse{} }
 func (m *UpdateReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateReleaseResponse) ProtoMessage()    {}
 func (*UpdateReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{8}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{8}
 }
 func (m *UpdateReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateReleaseResponse.Unmarshal(m, b)
@@ -676,6 +728,13 @@ func (m *UpdateReleaseResponse) GetRelease() *release.Release {
 	return nil
 }
 
+func (m *UpdateReleaseResponse) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
 type RollbackReleaseRequest struct {
 	// The name of the release
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -707,7 +766,7 @@ func (m *RollbackReleaseRequest) Reset()         { *m = RollbackReleaseRequest{}
 func (m *RollbackReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*RollbackReleaseRequest) ProtoMessage()    {}
 func (*RollbackReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{9}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{9}
 }
 func (m *RollbackReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RollbackReleaseRequest.Unmarshal(m, b)
@@ -809,7 +868,7 @@ func (m *RollbackReleaseResponse) Reset()         { *m = RollbackReleaseResponse
 func (m *RollbackReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*RollbackReleaseResponse) ProtoMessage()    {}
 func (*RollbackReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{10}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{10}
 }
 func (m *RollbackReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RollbackReleaseResponse.Unmarshal(m, b)
@@ -863,8 +922,16 @@ type InstallReleaseRequest struct {
 	Wait           bool `protobuf:"varint,9,opt,name=wait,proto3" json:"wait,omitempty"`
 	DisableCrdHook bool `protobuf:"varint,10,opt,name=disable_crd_hook,json=disableCrdHook,proto3" json:"disable_crd_hook,omitempty"`
 	// Description, if set, will set the description for the installed release
-	Description          string   `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
-	SubNotes             bool     `protobuf:"varint,12,opt,name=subNotes,proto3" json:"subNotes,omitempty"`
+	Description string `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
+	SubNotes    bool   `protobuf:"varint,12,opt,name=subNotes,proto3" json:"subNotes,omitempty"`
+	// Adopt takes over resources of the chart that already exist in the cluster
+	// instead of failing because they already exist.
+	Adopt bool `protobuf:"varint,13,opt,name=adopt,proto3" json:"adopt,omitempty"`
+	// AdoptOnly requires every resource of the chart to already exist in the
+	// cluster. No resource is created.
+	AdoptOnly bool `protobuf:"varint,14,opt,name=adopt_only,json=adoptOnly,proto3" json:"adopt_only,omitempty"`
+	// SensitiveValues are the paths of values the user marks as sensitive.
+	SensitiveValues      []string `protobuf:"bytes,15,rep,name=sensitive_values,json=sensitiveValues,proto3" json:"sensitive_values,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -874,7 +941,7 @@ func (m *InstallReleaseRequest) Reset()         { *m = InstallReleaseRequest{} }
 func (m *InstallReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*InstallReleaseRequest) ProtoMessage()    {}
 func (*InstallReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{11}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{11}
 }
 func (m *InstallReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallReleaseRequest.Unmarshal(m, b)
@@ -978,6 +1045,27 @@ func (m *InstallReleaseRequest) GetSubNotes() bool {
 	return false
 }
 
+func (m *InstallReleaseRequest) GetAdopt() bool {
+	if m != nil {
+		return m.Adopt
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetAdoptOnly() bool {
+	if m != nil {
+		return m.AdoptOnly
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetSensitiveValues() []string {
+	if m != nil {
+		return m.SensitiveValues
+	}
+	return nil
+}
+
 // InstallReleaseResponse is the response from a release installation.
 type InstallReleaseResponse struct {
 	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
@@ -990,7 +1078,7 @@ func (m *InstallReleaseResponse) Reset()         { *m = InstallReleaseResponse{}
 func (m *InstallReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*InstallReleaseResponse) ProtoMessage()    {}
 func (*InstallReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{12}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{12}
 }
 func (m *InstallReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallReleaseResponse.Unmarshal(m, b)
@@ -1038,7 +1126,7 @@ func (m *UninstallReleaseRequest) Reset()         { *m = UninstallReleaseRequest
 func (m *UninstallReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*UninstallReleaseRequest) ProtoMessage()    {}
 func (*UninstallReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{13}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{13}
 }
 func (m *UninstallReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UninstallReleaseRequest.Unmarshal(m, b)
@@ -1108,7 +1196,7 @@ func (m *UninstallReleaseResponse) Reset()         { *m = UninstallReleaseRespon
 func (m *UninstallReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*UninstallReleaseResponse) ProtoMessage()    {}
 func (*UninstallReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{14}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{14}
 }
 func (m *UninstallReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UninstallReleaseResponse.Unmarshal(m, b)
@@ -1153,7 +1241,7 @@ func (m *GetVersionRequest) Reset()         { *m = GetVersionRequest{} }
 func (m *GetVersionRequest) String() string { return proto.CompactTextString(m) }
 func (*GetVersionRequest) ProtoMessage()    {}
 func (*GetVersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{15}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{15}
 }
 func (m *GetVersionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetVersionRequest.Unmarshal(m, b)
@@ -1184,7 +1272,7 @@ func (m *GetVersionResponse) Reset()         { *m = GetVersionResponse{} }
 func (m *GetVersionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetVersionResponse) ProtoMessage()    {}
 func (*GetVersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{16}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{16}
 }
 func (m *GetVersionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetVersionResponse.Unmarshal(m, b)
@@ -1226,7 +1314,7 @@ func (m *GetHistoryRequest) Reset()         { *m = GetHistoryRequest{} }
 func (m *GetHistoryRequest) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryRequest) ProtoMessage()    {}
 func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{17}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{17}
 }
 func (m *GetHistoryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryRequest.Unmarshal(m, b)
@@ -1272,7 +1360,7 @@ func (m *GetHistoryResponse) Reset()         { *m = GetHistoryResponse{} }
 func (m *GetHistoryResponse) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryResponse) ProtoMessage()    {}
 func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{18}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{18}
 }
 func (m *GetHistoryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryResponse.Unmarshal(m, b)
@@ -1312,7 +1400,14 @@ type TestReleaseRequest struct {
 	// maximum number of test pods to run in parallel
 	MaxParallel uint32 `protobuf:"varint,5,opt,name=max_parallel,json=maxParallel,proto3" json:"max_parallel,omitempty"`
 	// logs specifies whether or not to dump the logs from the test pods
-	Logs                 bool     `protobuf:"varint,6,opt,name=logs,proto3" json:"logs,omitempty"`
+	Logs bool `protobuf:"varint,6,opt,name=logs,proto3" json:"logs,omitempty"`
+	// results specifies whether or not to stream the structured result of each test
+	// once the test suite has completed
+	Results bool `protobuf:"varint,7,opt,name=results,proto3" json:"results,omitempty"`
+	// filters select the tests to run using attribute=value pairs. A filter
+	// prefixed with '!' excludes the matching tests. Only the "name" attribute
+	// is currently supported.
+	Filters              []string `protobuf:"bytes,8,rep,name=filters,proto3" json:"filters,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1322,7 +1417,7 @@ func (m *TestReleaseRequest) Reset()         { *m = TestReleaseRequest{} }
 func (m *TestReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*TestReleaseRequest) ProtoMessage()    {}
 func (*TestReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{19}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{19}
 }
 func (m *TestReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TestReleaseRequest.Unmarshal(m, b)
@@ -1384,20 +1479,37 @@ func (m *TestReleaseRequest) GetLogs() bool {
 	return false
 }
 
+func (m *TestReleaseRequest) GetResults() bool {
+	if m != nil {
+		return m.Results
+	}
+	return false
+}
+
+func (m *TestReleaseRequest) GetFilters() []string {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
 // TestReleaseResponse represents a message from executing a test
 type TestReleaseResponse struct {
-	Msg                  string                 `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
-	Status               release.TestRun_Status `protobuf:"varint,2,opt,name=status,proto3,enum=hapi.release.TestRun_Status" json:"status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Msg    string                 `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	Status release.TestRun_Status `protobuf:"varint,2,opt,name=status,proto3,enum=hapi.release.TestRun_Status" json:"status,omitempty"`
+	// result is the structured result of a single test. It is only set when
+	// results were requested.
+	Result               *release.TestRun `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *TestReleaseResponse) Reset()         { *m = TestReleaseResponse{} }
 func (m *TestReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*TestReleaseResponse) ProtoMessage()    {}
 func (*TestReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_96f0f3bb9e4ff674, []int{20}
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{20}
 }
 func (m *TestReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TestReleaseResponse.Unmarshal(m, b)
@@ -1431,6 +1543,430 @@ func (m *TestReleaseResponse) GetStatus() release.TestRun_Status {
 	return release.TestRun_UNKNOWN
 }
 
+func (m *TestReleaseResponse) GetResult() *release.TestRun {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+// RewriteReleaseAPIsRequest is a request to move the objects of a release
+// from deprecated apiVersions to supported ones.
+type RewriteReleaseAPIsRequest struct {
+	// Name is the name of the release
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// dry_run, if true, returns the rewritten release without storing it.
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// kube_version is the Kubernetes version whose deprecated apiVersions are
+	// rewritten. It defaults to the version of the cluster.
+	KubeVersion          string   `protobuf:"bytes,3,opt,name=kube_version,json=kubeVersion,proto3" json:"kube_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RewriteReleaseAPIsRequest) Reset()         { *m = RewriteReleaseAPIsRequest{} }
+func (m *RewriteReleaseAPIsRequest) String() string { return proto.CompactTextString(m) }
+func (*RewriteReleaseAPIsRequest) ProtoMessage()    {}
+func (*RewriteReleaseAPIsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{21}
+}
+func (m *RewriteReleaseAPIsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RewriteReleaseAPIsRequest.Unmarshal(m, b)
+}
+func (m *RewriteReleaseAPIsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RewriteReleaseAPIsRequest.Marshal(b, m, deterministic)
+}
+func (dst *RewriteReleaseAPIsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RewriteReleaseAPIsRequest.Merge(dst, src)
+}
+func (m *RewriteReleaseAPIsRequest) XXX_Size() int {
+	return xxx_messageInfo_RewriteReleaseAPIsRequest.Size(m)
+}
+func (m *RewriteReleaseAPIsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RewriteReleaseAPIsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RewriteReleaseAPIsRequest proto.InternalMessageInfo
+
+func (m *RewriteReleaseAPIsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RewriteReleaseAPIsRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *RewriteReleaseAPIsRequest) GetKubeVersion() string {
+	if m != nil {
+		return m.KubeVersion
+	}
+	return ""
+}
+
+// RewriteReleaseAPIsResponse is the response to a RewriteReleaseAPIs request.
+type RewriteReleaseAPIsResponse struct {
+	// release is the rewritten latest revision of the release.
+	Release *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	// original_manifest is the manifest of the release before the rewrite.
+	OriginalManifest string `protobuf:"bytes,2,opt,name=original_manifest,json=originalManifest,proto3" json:"original_manifest,omitempty"`
+	// rewritten describes every object moved to another apiVersion.
+	Rewritten []string `protobuf:"bytes,3,rep,name=rewritten,proto3" json:"rewritten,omitempty"`
+	// remaining describes every object still using a deprecated apiVersion,
+	// which has to be changed in the chart.
+	Remaining            []string `protobuf:"bytes,4,rep,name=remaining,proto3" json:"remaining,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RewriteReleaseAPIsResponse) Reset()         { *m = RewriteReleaseAPIsResponse{} }
+func (m *RewriteReleaseAPIsResponse) String() string { return proto.CompactTextString(m) }
+func (*RewriteReleaseAPIsResponse) ProtoMessage()    {}
+func (*RewriteReleaseAPIsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{22}
+}
+func (m *RewriteReleaseAPIsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RewriteReleaseAPIsResponse.Unmarshal(m, b)
+}
+func (m *RewriteReleaseAPIsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RewriteReleaseAPIsResponse.Marshal(b, m, deterministic)
+}
+func (dst *RewriteReleaseAPIsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RewriteReleaseAPIsResponse.Merge(dst, src)
+}
+func (m *RewriteReleaseAPIsResponse) XXX_Size() int {
+	return xxx_messageInfo_RewriteReleaseAPIsResponse.Size(m)
+}
+func (m *RewriteReleaseAPIsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RewriteReleaseAPIsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RewriteReleaseAPIsResponse proto.InternalMessageInfo
+
+func (m *RewriteReleaseAPIsResponse) GetRelease() *release.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+func (m *RewriteReleaseAPIsResponse) GetOriginalManifest() string {
+	if m != nil {
+		return m.OriginalManifest
+	}
+	return ""
+}
+
+func (m *RewriteReleaseAPIsResponse) GetRewritten() []string {
+	if m != nil {
+		return m.Rewritten
+	}
+	return nil
+}
+
+func (m *RewriteReleaseAPIsResponse) GetRemaining() []string {
+	if m != nil {
+		return m.Remaining
+	}
+	return nil
+}
+
+// ExportReleaseRequest is a request to export the stored revisions of a
+// release.
+type ExportReleaseRequest struct {
+	// Name is the name of the release
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// all_revisions, if true, exports every stored revision instead of the
+	// latest one.
+	AllRevisions         bool     `protobuf:"varint,2,opt,name=all_revisions,json=allRevisions,proto3" json:"all_revisions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportReleaseRequest) Reset()         { *m = ExportReleaseRequest{} }
+func (m *ExportReleaseRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportReleaseRequest) ProtoMessage()    {}
+func (*ExportReleaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{23}
+}
+func (m *ExportReleaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportReleaseRequest.Unmarshal(m, b)
+}
+func (m *ExportReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportReleaseRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExportReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportReleaseRequest.Merge(dst, src)
+}
+func (m *ExportReleaseRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportReleaseRequest.Size(m)
+}
+func (m *ExportReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportReleaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportReleaseRequest proto.InternalMessageInfo
+
+func (m *ExportReleaseRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ExportReleaseRequest) GetAllRevisions() bool {
+	if m != nil {
+		return m.AllRevisions
+	}
+	return false
+}
+
+// ExportReleaseResponse is a revision of an exported release. One is sent for
+// every revision, oldest first.
+type ExportReleaseResponse struct {
+	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ExportReleaseResponse) Reset()         { *m = ExportReleaseResponse{} }
+func (m *ExportReleaseResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportReleaseResponse) ProtoMessage()    {}
+func (*ExportReleaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{24}
+}
+func (m *ExportReleaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportReleaseResponse.Unmarshal(m, b)
+}
+func (m *ExportReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportReleaseResponse.Marshal(b, m, deterministic)
+}
+func (dst *ExportReleaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportReleaseResponse.Merge(dst, src)
+}
+func (m *ExportReleaseResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportReleaseResponse.Size(m)
+}
+func (m *ExportReleaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportReleaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportReleaseResponse proto.InternalMessageInfo
+
+func (m *ExportReleaseResponse) GetRelease() *release.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+// ImportReleaseRequest is a request to store revisions of releases exported
+// from another Tiller.
+type ImportReleaseRequest struct {
+	// releases are the revisions to import.
+	Releases []*release.Release `protobuf:"bytes,1,rep,name=releases,proto3" json:"releases,omitempty"`
+	// namespace, if set, moves the imported releases to this namespace.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// dry_run, if true, checks the import without storing anything.
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// on_conflict tells what to do with revisions that are already stored.
+	OnConflict           ImportReleaseRequest_Conflict `protobuf:"varint,4,opt,name=on_conflict,json=onConflict,proto3,enum=hapi.services.tiller.ImportReleaseRequest_Conflict" json:"on_conflict,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *ImportReleaseRequest) Reset()         { *m = ImportReleaseRequest{} }
+func (m *ImportReleaseRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportReleaseRequest) ProtoMessage()    {}
+func (*ImportReleaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{25}
+}
+func (m *ImportReleaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportReleaseRequest.Unmarshal(m, b)
+}
+func (m *ImportReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportReleaseRequest.Marshal(b, m, deterministic)
+}
+func (dst *ImportReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportReleaseRequest.Merge(dst, src)
+}
+func (m *ImportReleaseRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportReleaseRequest.Size(m)
+}
+func (m *ImportReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportReleaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportReleaseRequest proto.InternalMessageInfo
+
+func (m *ImportReleaseRequest) GetReleases() []*release.Release {
+	if m != nil {
+		return m.Releases
+	}
+	return nil
+}
+
+func (m *ImportReleaseRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ImportReleaseRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *ImportReleaseRequest) GetOnConflict() ImportReleaseRequest_Conflict {
+	if m != nil {
+		return m.OnConflict
+	}
+	return ImportReleaseRequest_FAIL
+}
+
+// ImportReleaseResponse is the response to an ImportRelease request.
+type ImportReleaseResponse struct {
+	// releases are the imported revisions.
+	Releases []*release.Release `protobuf:"bytes,1,rep,name=releases,proto3" json:"releases,omitempty"`
+	// skipped describes the revisions that were already stored and kept.
+	Skipped              []string `protobuf:"bytes,2,rep,name=skipped,proto3" json:"skipped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportReleaseResponse) Reset()         { *m = ImportReleaseResponse{} }
+func (m *ImportReleaseResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportReleaseResponse) ProtoMessage()    {}
+func (*ImportReleaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{26}
+}
+func (m *ImportReleaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportReleaseResponse.Unmarshal(m, b)
+}
+func (m *ImportReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportReleaseResponse.Marshal(b, m, deterministic)
+}
+func (dst *ImportReleaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportReleaseResponse.Merge(dst, src)
+}
+func (m *ImportReleaseResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportReleaseResponse.Size(m)
+}
+func (m *ImportReleaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportReleaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportReleaseResponse proto.InternalMessageInfo
+
+func (m *ImportReleaseResponse) GetReleases() []*release.Release {
+	if m != nil {
+		return m.Releases
+	}
+	return nil
+}
+
+func (m *ImportReleaseResponse) GetSkipped() []string {
+	if m != nil {
+		return m.Skipped
+	}
+	return nil
+}
+
+// UnlockReleaseRequest is a request to mark the pending revision of a
+// release as failed, so that it can be upgraded or rolled back again.
+type UnlockReleaseRequest struct {
+	// Name is the name of the release
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlockReleaseRequest) Reset()         { *m = UnlockReleaseRequest{} }
+func (m *UnlockReleaseRequest) String() string { return proto.CompactTextString(m) }
+func (*UnlockReleaseRequest) ProtoMessage()    {}
+func (*UnlockReleaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{27}
+}
+func (m *UnlockReleaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockReleaseRequest.Unmarshal(m, b)
+}
+func (m *UnlockReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockReleaseRequest.Marshal(b, m, deterministic)
+}
+func (dst *UnlockReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockReleaseRequest.Merge(dst, src)
+}
+func (m *UnlockReleaseRequest) XXX_Size() int {
+	return xxx_messageInfo_UnlockReleaseRequest.Size(m)
+}
+func (m *UnlockReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockReleaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockReleaseRequest proto.InternalMessageInfo
+
+func (m *UnlockReleaseRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// UnlockReleaseResponse is the response to an UnlockRelease request.
+type UnlockReleaseResponse struct {
+	// release is the revision marked as failed.
+	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *UnlockReleaseResponse) Reset()         { *m = UnlockReleaseResponse{} }
+func (m *UnlockReleaseResponse) String() string { return proto.CompactTextString(m) }
+func (*UnlockReleaseResponse) ProtoMessage()    {}
+func (*UnlockReleaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_2c2ce12a128a278b, []int{28}
+}
+func (m *UnlockReleaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockReleaseResponse.Unmarshal(m, b)
+}
+func (m *UnlockReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockReleaseResponse.Marshal(b, m, deterministic)
+}
+func (dst *UnlockReleaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockReleaseResponse.Merge(dst, src)
+}
+func (m *UnlockReleaseResponse) XXX_Size() int {
+	return xxx_messageInfo_UnlockReleaseResponse.Size(m)
+}
+func (m *UnlockReleaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockReleaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockReleaseResponse proto.InternalMessageInfo
+
+func (m *UnlockReleaseResponse) GetRelease() *release.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ListReleasesRequest)(nil), "hapi.services.tiller.ListReleasesRequest")
 	proto.RegisterType((*ListSort)(nil), "hapi.services.tiller.ListSort")
@@ -1453,8 +1989,17 @@ func init() {
 	proto.RegisterType((*GetHistoryResponse)(nil), "hapi.services.tiller.GetHistoryResponse")
 	proto.RegisterType((*TestReleaseRequest)(nil), "hapi.services.tiller.TestReleaseRequest")
 	proto.RegisterType((*TestReleaseResponse)(nil), "hapi.services.tiller.TestReleaseResponse")
+	proto.RegisterType((*RewriteReleaseAPIsRequest)(nil), "hapi.services.tiller.RewriteReleaseAPIsRequest")
+	proto.RegisterType((*RewriteReleaseAPIsResponse)(nil), "hapi.services.tiller.RewriteReleaseAPIsResponse")
+	proto.RegisterType((*ExportReleaseRequest)(nil), "hapi.services.tiller.ExportReleaseRequest")
+	proto.RegisterType((*ExportReleaseResponse)(nil), "hapi.services.tiller.ExportReleaseResponse")
+	proto.RegisterType((*ImportReleaseRequest)(nil), "hapi.services.tiller.ImportReleaseRequest")
+	proto.RegisterType((*ImportReleaseResponse)(nil), "hapi.services.tiller.ImportReleaseResponse")
+	proto.RegisterType((*UnlockReleaseRequest)(nil), "hapi.services.tiller.UnlockReleaseRequest")
+	proto.RegisterType((*UnlockReleaseResponse)(nil), "hapi.services.tiller.UnlockReleaseResponse")
 	proto.RegisterEnum("hapi.services.tiller.ListSort_SortBy", ListSort_SortBy_name, ListSort_SortBy_value)
 	proto.RegisterEnum("hapi.services.tiller.ListSort_SortOrder", ListSort_SortOrder_name, ListSort_SortOrder_value)
+	proto.RegisterEnum("hapi.services.tiller.ImportReleaseRequest_Conflict", ImportReleaseRequest_Conflict_name, ImportReleaseRequest_Conflict_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1492,6 +2037,15 @@ type ReleaseServiceClient interface {
 	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
 	// RunReleaseTest executes the tests defined of a named release
 	RunReleaseTest(ctx context.Context, in *TestReleaseRequest, opts ...grpc.CallOption) (ReleaseService_RunReleaseTestClient, error)
+	// RewriteReleaseAPIs moves the objects of the latest revision of a release
+	// from deprecated apiVersions to supported ones.
+	RewriteReleaseAPIs(ctx context.Context, in *RewriteReleaseAPIsRequest, opts ...grpc.CallOption) (*RewriteReleaseAPIsResponse, error)
+	// ExportRelease streams the stored revisions of a release.
+	ExportRelease(ctx context.Context, in *ExportReleaseRequest, opts ...grpc.CallOption) (ReleaseService_ExportReleaseClient, error)
+	// ImportRelease stores revisions of releases exported from another Tiller.
+	ImportRelease(ctx context.Context, in *ImportReleaseRequest, opts ...grpc.CallOption) (*ImportReleaseResponse, error)
+	// UnlockRelease marks the pending revision of a release as failed.
+	UnlockRelease(ctx context.Context, in *UnlockReleaseRequest, opts ...grpc.CallOption) (*UnlockReleaseResponse, error)
 }
 
 type releaseServiceClient struct {
@@ -1638,6 +2192,65 @@ func (x *releaseServiceRunReleaseTestClient) Recv() (*TestReleaseResponse, error
 	return m, nil
 }
 
+func (c *releaseServiceClient) RewriteReleaseAPIs(ctx context.Context, in *RewriteReleaseAPIsRequest, opts ...grpc.CallOption) (*RewriteReleaseAPIsResponse, error) {
+	out := new(RewriteReleaseAPIsResponse)
+	err := c.cc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/RewriteReleaseAPIs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) ExportRelease(ctx context.Context, in *ExportReleaseRequest, opts ...grpc.CallOption) (ReleaseService_ExportReleaseClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ReleaseService_serviceDesc.Streams[2], "/hapi.services.tiller.ReleaseService/ExportRelease", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &releaseServiceExportReleaseClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReleaseService_ExportReleaseClient interface {
+	Recv() (*ExportReleaseResponse, error)
+	grpc.ClientStream
+}
+
+type releaseServiceExportReleaseClient struct {
+	grpc.ClientStream
+}
+
+func (x *releaseServiceExportReleaseClient) Recv() (*ExportReleaseResponse, error) {
+	m := new(ExportReleaseResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *releaseServiceClient) ImportRelease(ctx context.Context, in *ImportReleaseRequest, opts ...grpc.CallOption) (*ImportReleaseResponse, error) {
+	out := new(ImportReleaseResponse)
+	err := c.cc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/ImportRelease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) UnlockRelease(ctx context.Context, in *UnlockReleaseRequest, opts ...grpc.CallOption) (*UnlockReleaseResponse, error) {
+	out := new(UnlockReleaseResponse)
+	err := c.cc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/UnlockRelease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReleaseServiceServer is the server API for ReleaseService service.
 type ReleaseServiceServer interface {
 	// ListReleases retrieves release history.
@@ -1663,6 +2276,15 @@ type ReleaseServiceServer interface {
 	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
 	// RunReleaseTest executes the tests defined of a named release
 	RunReleaseTest(*TestReleaseRequest, ReleaseService_RunReleaseTestServer) error
+	// RewriteReleaseAPIs moves the objects of the latest revision of a release
+	// from deprecated apiVersions to supported ones.
+	RewriteReleaseAPIs(context.Context, *RewriteReleaseAPIsRequest) (*RewriteReleaseAPIsResponse, error)
+	// ExportRelease streams the stored revisions of a release.
+	ExportRelease(*ExportReleaseRequest, ReleaseService_ExportReleaseServer) error
+	// ImportRelease stores revisions of releases exported from another Tiller.
+	ImportRelease(context.Context, *ImportReleaseRequest) (*ImportReleaseResponse, error)
+	// UnlockRelease marks the pending revision of a release as failed.
+	UnlockRelease(context.Context, *UnlockReleaseRequest) (*UnlockReleaseResponse, error)
 }
 
 func RegisterReleaseServiceServer(s *grpc.Server, srv ReleaseServiceServer) {
@@ -1855,6 +2477,81 @@ func (x *releaseServiceRunReleaseTestServer) Send(m *TestReleaseResponse) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ReleaseService_RewriteReleaseAPIs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RewriteReleaseAPIsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).RewriteReleaseAPIs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/RewriteReleaseAPIs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).RewriteReleaseAPIs(ctx, req.(*RewriteReleaseAPIsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_ExportRelease_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportReleaseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReleaseServiceServer).ExportRelease(m, &releaseServiceExportReleaseServer{stream})
+}
+
+type ReleaseService_ExportReleaseServer interface {
+	Send(*ExportReleaseResponse) error
+	grpc.ServerStream
+}
+
+type releaseServiceExportReleaseServer struct {
+	grpc.ServerStream
+}
+
+func (x *releaseServiceExportReleaseServer) Send(m *ExportReleaseResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ReleaseService_ImportRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).ImportRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/ImportRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).ImportRelease(ctx, req.(*ImportReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_UnlockRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).UnlockRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/UnlockRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).UnlockRelease(ctx, req.(*UnlockReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hapi.services.tiller.ReleaseService",
 	HandlerType: (*ReleaseServiceServer)(nil),
@@ -1891,6 +2588,18 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetHistory",
 			Handler:    _ReleaseService_GetHistory_Handler,
 		},
+		{
+			MethodName: "RewriteReleaseAPIs",
+			Handler:    _ReleaseService_RewriteReleaseAPIs_Handler,
+		},
+		{
+			MethodName: "ImportRelease",
+			Handler:    _ReleaseService_ImportRelease_Handler,
+		},
+		{
+			MethodName: "UnlockRelease",
+			Handler:    _ReleaseService_UnlockRelease_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1903,98 +2612,129 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _ReleaseService_RunReleaseTest_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportRelease",
+			Handler:       _ReleaseService_ExportRelease_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "hapi/services/tiller.proto",
 }
 
-func init() { proto.RegisterFile("hapi/services/tiller.proto", fileDescriptor_tiller_96f0f3bb9e4ff674) }
-
-var fileDescriptor_tiller_96f0f3bb9e4ff674 = []byte{
-	// 1369 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xdd, 0x72, 0x1b, 0xc5,
-	0x12, 0xb6, 0xb4, 0xfa, 0x6d, 0xd9, 0x8a, 0x32, 0x71, 0xec, 0x8d, 0x4e, 0xce, 0x29, 0x9f, 0xa5,
-	0x48, 0x94, 0x40, 0x64, 0x30, 0xdc, 0x50, 0x45, 0x51, 0xe5, 0x28, 0xc6, 0x0e, 0x18, 0x87, 0x5a,
-	0x27, 0xa1, 0x8a, 0x2a, 0x4a, 0x35, 0x96, 0x46, 0xce, 0x92, 0xd5, 0x8e, 0xd8, 0x99, 0x35, 0xf6,
-	0x23, 0x70, 0xc9, 0x3b, 0x70, 0x0d, 0xaf, 0xc0, 0x2d, 0xcf, 0xc0, 0xcb, 0x50, 0xf3, 0xb7, 0xd6,
-	0xac, 0x76, 0x15, 0xe1, 0x1b, 0xed, 0xcc, 0x74, 0x4f, 0x77, 0xcf, 0xf7, 0x4d, 0xf7, 0xb4, 0x0d,
-	0xdd, 0x37, 0x78, 0x16, 0xec, 0x32, 0x12, 0x5f, 0x04, 0x23, 0xc2, 0x76, 0x79, 0x10, 0x86, 0x24,
-	0xee, 0xcf, 0x62, 0xca, 0x29, 0xda, 0x14, 0xb2, 0xbe, 0x91, 0xf5, 0x95, 0xac, 0xbb, 0x25, 0x77,
-	0x8c, 0xde, 0xe0, 0x98, 0xab, 0x5f, 0xa5, 0xdd, 0xdd, 0x9e, 0x5f, 0xa7, 0xd1, 0x24, 0x38, 0xd7,
-	0x02, 0xe5, 0x22, 0x26, 0x21, 0xc1, 0x8c, 0x98, 0xaf, 0xb5, 0xc9, 0xc8, 0x82, 0x68, 0x42, 0xb5,
-	0xe0, 0x3f, 0x96, 0x80, 0x13, 0xc6, 0x87, 0x71, 0x12, 0x69, 0xe1, 0x3d, 0x4b, 0xc8, 0x38, 0xe6,
-	0x09, 0xb3, 0x9c, 0x5d, 0x90, 0x98, 0x05, 0x34, 0x32, 0x5f, 0x25, 0xf3, 0xfe, 0x2c, 0xc3, 0x9d,
-	0xe3, 0x80, 0x71, 0x5f, 0x6d, 0x64, 0x3e, 0xf9, 0x29, 0x21, 0x8c, 0xa3, 0x4d, 0xa8, 0x86, 0xc1,
-	0x34, 0xe0, 0x6e, 0x69, 0xa7, 0xd4, 0x73, 0x7c, 0x35, 0x41, 0x5b, 0x50, 0xa3, 0x93, 0x09, 0x23,
-	0xdc, 0x2d, 0xef, 0x94, 0x7a, 0x4d, 0x5f, 0xcf, 0xd0, 0x17, 0x50, 0x67, 0x34, 0xe6, 0xc3, 0xb3,
-	0x2b, 0xd7, 0xd9, 0x29, 0xf5, 0xda, 0x7b, 0xef, 0xf7, 0xf3, 0x70, 0xea, 0x0b, 0x4f, 0xa7, 0x34,
-	0xe6, 0x7d, 0xf1, 0xf3, 0xf4, 0xca, 0xaf, 0x31, 0xf9, 0x15, 0x76, 0x27, 0x41, 0xc8, 0x49, 0xec,
-	0x56, 0x94, 0x5d, 0x35, 0x43, 0x87, 0x00, 0xd2, 0x2e, 0x8d, 0xc7, 0x24, 0x76, 0xab, 0xd2, 0x74,
-	0x6f, 0x05, 0xd3, 0x2f, 0x84, 0xbe, 0xdf, 0x64, 0x66, 0x88, 0x3e, 0x87, 0x75, 0x05, 0xc9, 0x70,
-	0x44, 0xc7, 0x84, 0xb9, 0xb5, 0x1d, 0xa7, 0xd7, 0xde, 0xbb, 0xa7, 0x4c, 0x19, 0xf8, 0x4f, 0x15,
-	0x68, 0x03, 0x3a, 0x26, 0x7e, 0x4b, 0xa9, 0x8b, 0x31, 0x43, 0xf7, 0xa1, 0x19, 0xe1, 0x29, 0x61,
-	0x33, 0x3c, 0x22, 0x6e, 0x5d, 0x46, 0x78, 0xbd, 0xe0, 0x45, 0xd0, 0x30, 0xce, 0xbd, 0xa7, 0x50,
-	0x53, 0x47, 0x43, 0x2d, 0xa8, 0xbf, 0x3a, 0xf9, 0xfa, 0xe4, 0xc5, 0x77, 0x27, 0x9d, 0x35, 0xd4,
-	0x80, 0xca, 0xc9, 0xfe, 0x37, 0x07, 0x9d, 0x12, 0xba, 0x0d, 0x1b, 0xc7, 0xfb, 0xa7, 0x2f, 0x87,
-	0xfe, 0xc1, 0xf1, 0xc1, 0xfe, 0xe9, 0xc1, 0xb3, 0x4e, 0x19, 0xb5, 0x01, 0x06, 0x47, 0xfb, 0xfe,
-	0xcb, 0xa1, 0x54, 0x71, 0xbc, 0xff, 0x41, 0x33, 0x3d, 0x03, 0xaa, 0x83, 0xb3, 0x7f, 0x3a, 0x50,
-	0x26, 0x9e, 0x1d, 0x9c, 0x0e, 0x3a, 0x25, 0xef, 0x97, 0x12, 0x6c, 0xda, 0x94, 0xb1, 0x19, 0x8d,
-	0x18, 0x11, 0x9c, 0x8d, 0x68, 0x12, 0xa5, 0x9c, 0xc9, 0x09, 0x42, 0x50, 0x89, 0xc8, 0xa5, 0x61,
-	0x4c, 0x8e, 0x85, 0x26, 0xa7, 0x1c, 0x87, 0x92, 0x2d, 0xc7, 0x57, 0x13, 0xf4, 0x31, 0x34, 0x34,
-	0x14, 0xcc, 0xad, 0xec, 0x38, 0xbd, 0xd6, 0xde, 0x5d, 0x1b, 0x20, 0xed, 0xd1, 0x4f, 0xd5, 0xbc,
-	0x43, 0xd8, 0x3e, 0x24, 0x26, 0x12, 0x85, 0x9f, 0xb9, 0x41, 0xc2, 0x2f, 0x9e, 0x12, 0x19, 0x8c,
-	0xf0, 0x8b, 0xa7, 0x04, 0xb9, 0x50, 0xd7, 0xd7, 0x4f, 0x86, 0x53, 0xf5, 0xcd, 0xd4, 0xe3, 0xe0,
-	0x2e, 0x1a, 0xd2, 0xe7, 0xca, 0xb3, 0xf4, 0x00, 0x2a, 0x22, 0x33, 0xa4, 0x99, 0xd6, 0x1e, 0xb2,
-	0xe3, 0x7c, 0x1e, 0x4d, 0xa8, 0x2f, 0xe5, 0x36, 0x75, 0x4e, 0x96, 0xba, 0xa3, 0x79, 0xaf, 0x03,
-	0x1a, 0x71, 0x12, 0xf1, 0x9b, 0xc5, 0x7f, 0x0c, 0xf7, 0x72, 0x2c, 0xe9, 0x03, 0xec, 0x42, 0x5d,
-	0x87, 0x26, 0xad, 0x15, 0xe2, 0x6a, 0xb4, 0xbc, 0xbf, 0x1c, 0xd8, 0x7c, 0x35, 0x1b, 0x63, 0x4e,
-	0x8c, 0x68, 0x49, 0x50, 0x0f, 0xa1, 0x2a, 0x2b, 0x8c, 0xc6, 0xe2, 0xb6, 0xb2, 0xad, 0xca, 0xd0,
-	0x40, 0xfc, 0xfa, 0x4a, 0x8e, 0x1e, 0x43, 0xed, 0x02, 0x87, 0x09, 0x61, 0x12, 0x88, 0x14, 0x35,
-	0xad, 0x29, 0xcb, 0x93, 0xaf, 0x35, 0xd0, 0x36, 0xd4, 0xc7, 0xf1, 0x95, 0xa8, 0x2f, 0x32, 0x25,
-	0x1b, 0x7e, 0x6d, 0x1c, 0x5f, 0xf9, 0x49, 0x84, 0xde, 0x83, 0x8d, 0x71, 0xc0, 0xf0, 0x59, 0x48,
-	0x86, 0x6f, 0x28, 0x7d, 0xcb, 0x64, 0x56, 0x36, 0xfc, 0x75, 0xbd, 0x78, 0x24, 0xd6, 0x50, 0x57,
-	0xdc, 0xa4, 0x51, 0x4c, 0x30, 0x27, 0x6e, 0x4d, 0xca, 0xd3, 0xb9, 0xc0, 0x90, 0x07, 0x53, 0x42,
-	0x13, 0x2e, 0x53, 0xc9, 0xf1, 0xcd, 0x14, 0xfd, 0x1f, 0xd6, 0x63, 0xc2, 0x08, 0x1f, 0xea, 0x28,
-	0x1b, 0x72, 0x67, 0x4b, 0xae, 0xbd, 0x56, 0x61, 0x21, 0xa8, 0xfc, 0x8c, 0x03, 0xee, 0x36, 0xa5,
-	0x48, 0x8e, 0xd5, 0xb6, 0x84, 0x11, 0xb3, 0x0d, 0xcc, 0xb6, 0x84, 0x11, 0xbd, 0x6d, 0x13, 0xaa,
-	0x13, 0x1a, 0x8f, 0x88, 0xdb, 0x92, 0x32, 0x35, 0x41, 0x3b, 0xd0, 0x1a, 0x13, 0x36, 0x8a, 0x83,
-	0x19, 0x17, 0x8c, 0xae, 0x4b, 0x4c, 0xe7, 0x97, 0xc4, 0x39, 0x58, 0x72, 0x76, 0x42, 0x39, 0x61,
-	0xee, 0x86, 0x3a, 0x87, 0x99, 0xa3, 0x07, 0x70, 0x6b, 0x14, 0x12, 0x1c, 0x25, 0xb3, 0x21, 0x8d,
-	0x86, 0x13, 0x1c, 0x84, 0x6e, 0x5b, 0xaa, 0x6c, 0xe8, 0xe5, 0x17, 0xd1, 0x97, 0x38, 0x08, 0xbd,
-	0x23, 0xb8, 0x9b, 0xa1, 0xf2, 0xa6, 0xb7, 0xe2, 0xf7, 0x32, 0x6c, 0xf9, 0x34, 0x0c, 0xcf, 0xf0,
-	0xe8, 0xed, 0x0a, 0xf7, 0x62, 0x8e, 0xc2, 0xf2, 0x72, 0x0a, 0x9d, 0x1c, 0x0a, 0xe7, 0xae, 0x7a,
-	0xc5, 0xba, 0xea, 0x16, 0xb9, 0xd5, 0x62, 0x72, 0x6b, 0x36, 0xb9, 0x86, 0xb9, 0xfa, 0x1c, 0x73,
-	0x29, 0x2d, 0x8d, 0x25, 0xb4, 0x34, 0x17, 0x69, 0xc9, 0x81, 0x1e, 0xf2, 0xa0, 0xff, 0x0a, 0xb6,
-	0x17, 0xf0, 0xba, 0x29, 0xf8, 0xbf, 0x3a, 0x70, 0xf7, 0x79, 0xc4, 0x38, 0x0e, 0xc3, 0x0c, 0xf6,
-	0x69, 0xfe, 0x95, 0x56, 0xce, 0xbf, 0xf2, 0xbf, 0xc9, 0x3f, 0xc7, 0x22, 0xcf, 0x30, 0x5d, 0x99,
-	0x63, 0x7a, 0xa5, 0x9c, 0xb4, 0x2a, 0x61, 0x2d, 0x53, 0x09, 0xd1, 0x7f, 0x01, 0x54, 0x12, 0x49,
-	0xe3, 0x8a, 0xa4, 0xa6, 0x5c, 0x39, 0xd1, 0x85, 0xcf, 0xf0, 0xda, 0xc8, 0xe7, 0x75, 0x3e, 0x23,
-	0x7b, 0xd0, 0x31, 0xf1, 0x8c, 0xe2, 0xb1, 0x8c, 0x49, 0x13, 0xd4, 0xd6, 0xeb, 0x83, 0x78, 0x2c,
-	0xa2, 0xca, 0x72, 0xdd, 0x5a, 0x9e, 0x82, 0xeb, 0x76, 0x0a, 0x7a, 0xcf, 0x61, 0x2b, 0x4b, 0xc9,
-	0x4d, 0xe9, 0xfd, 0xad, 0x04, 0xdb, 0xaf, 0xa2, 0x20, 0x97, 0xe0, 0xbc, 0xe4, 0x5a, 0x80, 0xbc,
-	0x9c, 0x03, 0xf9, 0x26, 0x54, 0x67, 0x49, 0x7c, 0x4e, 0x34, 0x85, 0x6a, 0x32, 0x8f, 0x65, 0xc5,
-	0xc6, 0x32, 0x83, 0x46, 0x75, 0x01, 0x0d, 0x6f, 0x08, 0xee, 0x62, 0x94, 0x37, 0x3c, 0xb3, 0x38,
-	0x57, 0xfa, 0x86, 0x36, 0xd5, 0x7b, 0xe9, 0xdd, 0x81, 0xdb, 0x87, 0x84, 0xbf, 0x56, 0xa9, 0xae,
-	0x01, 0xf0, 0x0e, 0x00, 0xcd, 0x2f, 0x5e, 0xfb, 0xd3, 0x4b, 0xb6, 0x3f, 0xd3, 0x60, 0x1a, 0x7d,
-	0xa3, 0xe5, 0x7d, 0x26, 0x6d, 0x1f, 0x05, 0x8c, 0xd3, 0xf8, 0x6a, 0x19, 0xb8, 0x1d, 0x70, 0xa6,
-	0xf8, 0x52, 0x3f, 0xb1, 0x62, 0xe8, 0x1d, 0xca, 0x08, 0xd2, 0xad, 0x3a, 0x82, 0xf9, 0x86, 0xa5,
-	0xb4, 0x5a, 0xc3, 0xf2, 0x47, 0x09, 0xd0, 0x4b, 0x92, 0x36, 0x4f, 0xef, 0x78, 0xec, 0x0d, 0x4f,
-	0x65, 0x9b, 0x27, 0x17, 0xea, 0xba, 0xd0, 0x68, 0x66, 0xcd, 0x54, 0xdc, 0xd6, 0x19, 0x8e, 0x71,
-	0x18, 0x92, 0x50, 0xbf, 0x9b, 0xe9, 0x5c, 0xbc, 0x53, 0x53, 0x7c, 0x39, 0x4c, 0xe5, 0x82, 0xde,
-	0x0d, 0xbf, 0x35, 0xc5, 0x97, 0xdf, 0x1a, 0x15, 0x04, 0x95, 0x90, 0x9e, 0x33, 0xfd, 0x66, 0xca,
-	0xb1, 0xf7, 0x03, 0xdc, 0xb1, 0x02, 0xd6, 0x67, 0x17, 0x18, 0xb1, 0x73, 0x1d, 0xb0, 0x18, 0xa2,
-	0x4f, 0xa1, 0xa6, 0x9a, 0x56, 0x19, 0x6e, 0x7b, 0xef, 0xbe, 0x8d, 0x85, 0x34, 0x92, 0x44, 0xba,
-	0xcb, 0xf5, 0xb5, 0xee, 0xde, 0xdf, 0x0d, 0x68, 0x9b, 0xb6, 0x4b, 0xb5, 0xd4, 0x28, 0x80, 0xf5,
-	0xf9, 0xfe, 0x12, 0x3d, 0x2a, 0xee, 0xb8, 0x33, 0x7f, 0x36, 0x74, 0x1f, 0xaf, 0xa2, 0xaa, 0x4e,
-	0xe0, 0xad, 0x7d, 0x54, 0x42, 0x0c, 0x3a, 0xd9, 0xb6, 0x0f, 0x3d, 0xc9, 0xb7, 0x51, 0xd0, 0x67,
-	0x76, 0xfb, 0xab, 0xaa, 0x1b, 0xb7, 0xe8, 0x42, 0xde, 0x43, 0xbb, 0x57, 0x43, 0xef, 0x34, 0x63,
-	0xb7, 0x87, 0xdd, 0xdd, 0x95, 0xf5, 0x53, 0xbf, 0x3f, 0xc2, 0x86, 0xd5, 0x09, 0xa0, 0x02, 0xb4,
-	0xf2, 0x3a, 0xbf, 0xee, 0x07, 0x2b, 0xe9, 0xa6, 0xbe, 0xa6, 0xd0, 0xb6, 0x4b, 0x23, 0x2a, 0x30,
-	0x90, 0xfb, 0xa6, 0x75, 0x3f, 0x5c, 0x4d, 0x39, 0x75, 0xc7, 0xa0, 0x93, 0xad, 0x4b, 0x45, 0x3c,
-	0x16, 0x54, 0xd9, 0x22, 0x1e, 0x8b, 0xca, 0x9d, 0xb7, 0x86, 0x30, 0xc0, 0x75, 0x59, 0x42, 0x0f,
-	0x0b, 0x09, 0xb1, 0xab, 0x59, 0xb7, 0xf7, 0x6e, 0xc5, 0xd4, 0xc5, 0x0c, 0x6e, 0x65, 0x3a, 0x08,
-	0x54, 0x00, 0x4d, 0x7e, 0x63, 0xd6, 0x7d, 0xb2, 0xa2, 0x76, 0xe6, 0x50, 0xba, 0xd2, 0x2d, 0x39,
-	0x94, 0x5d, 0x46, 0x97, 0x1c, 0x2a, 0x53, 0x34, 0xbd, 0x35, 0x14, 0x40, 0xdb, 0x4f, 0x22, 0xed,
-	0x5a, 0x94, 0x05, 0x54, 0xb0, 0x7b, 0xb1, 0x50, 0x76, 0x1f, 0xad, 0xa0, 0x79, 0x9d, 0xdf, 0x4f,
-	0xe1, 0xfb, 0x86, 0x51, 0x3d, 0xab, 0xc9, 0xff, 0x38, 0x7c, 0xf2, 0x4f, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x8a, 0x23, 0x76, 0x0d, 0x5f, 0x11, 0x00, 0x00,
+func init() { proto.RegisterFile("hapi/services/tiller.proto", fileDescriptor_tiller_2c2ce12a128a278b) }
+
+var fileDescriptor_tiller_2c2ce12a128a278b = []byte{
+	// 1779 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x18, 0xcb, 0x72, 0xe3, 0xc6,
+	0xd1, 0x20, 0x29, 0x3e, 0x9a, 0x22, 0x45, 0xcd, 0x52, 0x2b, 0x2c, 0x62, 0xa7, 0x64, 0xa4, 0x62,
+	0x73, 0x77, 0xb3, 0x5c, 0x47, 0xce, 0x25, 0x55, 0xa9, 0x54, 0x69, 0x65, 0x5a, 0x62, 0x2c, 0x4b,
+	0x5b, 0x90, 0x76, 0x5d, 0x95, 0x0b, 0x0a, 0x22, 0x87, 0x5a, 0x58, 0x20, 0x86, 0xc1, 0x0c, 0xb5,
+	0xd2, 0x07, 0xe4, 0x90, 0x54, 0x3e, 0x21, 0xc7, 0x9c, 0x73, 0xcc, 0x39, 0xff, 0x92, 0x2f, 0xc8,
+	0x1f, 0xb8, 0xe6, 0x05, 0x01, 0x20, 0x20, 0xc1, 0xbc, 0x90, 0x33, 0xdd, 0x3d, 0xdd, 0x3d, 0xfd,
+	0x1e, 0x80, 0xf5, 0xc1, 0x5b, 0xf8, 0xaf, 0x29, 0x8e, 0x6e, 0xfc, 0x09, 0xa6, 0xaf, 0x99, 0x1f,
+	0x04, 0x38, 0x1a, 0x2e, 0x22, 0xc2, 0x08, 0xea, 0x73, 0xdc, 0x50, 0xe3, 0x86, 0x12, 0x67, 0x3d,
+	0x15, 0x27, 0x26, 0x1f, 0xbc, 0x88, 0xc9, 0x5f, 0x49, 0x6d, 0xed, 0x26, 0xe1, 0x24, 0x9c, 0xf9,
+	0x57, 0x0a, 0x21, 0x45, 0x44, 0x38, 0xc0, 0x1e, 0xc5, 0xfa, 0x3f, 0x75, 0x48, 0xe3, 0xfc, 0x70,
+	0x46, 0x14, 0xe2, 0x17, 0x29, 0x04, 0xc3, 0x94, 0xb9, 0xd1, 0x32, 0x54, 0xc8, 0x67, 0x29, 0x24,
+	0x65, 0x1e, 0x5b, 0xd2, 0x94, 0xb0, 0x1b, 0x1c, 0x51, 0x9f, 0x84, 0xfa, 0x5f, 0xe2, 0xec, 0xff,
+	0x56, 0xe0, 0xc9, 0x89, 0x4f, 0x99, 0x23, 0x0f, 0x52, 0x07, 0xff, 0x65, 0x89, 0x29, 0x43, 0x7d,
+	0xd8, 0x08, 0xfc, 0xb9, 0xcf, 0x4c, 0x63, 0xcf, 0x18, 0x54, 0x1d, 0xb9, 0x41, 0x4f, 0xa1, 0x4e,
+	0x66, 0x33, 0x8a, 0x99, 0x59, 0xd9, 0x33, 0x06, 0x2d, 0x47, 0xed, 0xd0, 0x1f, 0xa1, 0x41, 0x49,
+	0xc4, 0xdc, 0xcb, 0x3b, 0xb3, 0xba, 0x67, 0x0c, 0xba, 0xfb, 0xbf, 0x1e, 0xe6, 0xd9, 0x69, 0xc8,
+	0x25, 0x9d, 0x93, 0x88, 0x0d, 0xf9, 0xcf, 0x9b, 0x3b, 0xa7, 0x4e, 0xc5, 0x3f, 0xe7, 0x3b, 0xf3,
+	0x03, 0x86, 0x23, 0xb3, 0x26, 0xf9, 0xca, 0x1d, 0x3a, 0x02, 0x10, 0x7c, 0x49, 0x34, 0xc5, 0x91,
+	0xb9, 0x21, 0x58, 0x0f, 0x4a, 0xb0, 0x3e, 0xe3, 0xf4, 0x4e, 0x8b, 0xea, 0x25, 0xfa, 0x03, 0x6c,
+	0x4a, 0x93, 0xb8, 0x13, 0x32, 0xc5, 0xd4, 0xac, 0xef, 0x55, 0x07, 0xdd, 0xfd, 0x67, 0x92, 0x95,
+	0x36, 0xff, 0xb9, 0x34, 0xda, 0x21, 0x99, 0x62, 0xa7, 0x2d, 0xc9, 0xf9, 0x9a, 0xa2, 0x4f, 0xa1,
+	0x15, 0x7a, 0x73, 0x4c, 0x17, 0xde, 0x04, 0x9b, 0x0d, 0xa1, 0xe1, 0x3d, 0xc0, 0x0e, 0xa1, 0xa9,
+	0x85, 0xdb, 0x6f, 0xa0, 0x2e, 0xaf, 0x86, 0xda, 0xd0, 0x78, 0x77, 0xfa, 0xdd, 0xe9, 0xd9, 0x0f,
+	0xa7, 0xbd, 0x4f, 0x50, 0x13, 0x6a, 0xa7, 0x07, 0xdf, 0x8f, 0x7a, 0x06, 0xda, 0x86, 0xce, 0xc9,
+	0xc1, 0xf9, 0x85, 0xeb, 0x8c, 0x4e, 0x46, 0x07, 0xe7, 0xa3, 0x6f, 0x7a, 0x15, 0xd4, 0x05, 0x38,
+	0x3c, 0x3e, 0x70, 0x2e, 0x5c, 0x41, 0x52, 0xb5, 0x7f, 0x09, 0xad, 0xf8, 0x0e, 0xa8, 0x01, 0xd5,
+	0x83, 0xf3, 0x43, 0xc9, 0xe2, 0x9b, 0xd1, 0xf9, 0x61, 0xcf, 0xb0, 0xff, 0x66, 0x40, 0x3f, 0xed,
+	0x32, 0xba, 0x20, 0x21, 0xc5, 0xdc, 0x67, 0x13, 0xb2, 0x0c, 0x63, 0x9f, 0x89, 0x0d, 0x42, 0x50,
+	0x0b, 0xf1, 0xad, 0xf6, 0x98, 0x58, 0x73, 0x4a, 0x46, 0x98, 0x17, 0x08, 0x6f, 0x55, 0x1d, 0xb9,
+	0x41, 0xbf, 0x85, 0xa6, 0x32, 0x05, 0x35, 0x6b, 0x7b, 0xd5, 0x41, 0x7b, 0x7f, 0x27, 0x6d, 0x20,
+	0x25, 0xd1, 0x89, 0xc9, 0xec, 0x23, 0xd8, 0x3d, 0xc2, 0x5a, 0x13, 0x69, 0x3f, 0x1d, 0x41, 0x5c,
+	0xae, 0x37, 0xc7, 0xa6, 0xa1, 0xe4, 0x7a, 0x73, 0x8c, 0x4c, 0x68, 0xa8, 0xf0, 0x13, 0xea, 0x6c,
+	0x38, 0x7a, 0x6b, 0x33, 0x30, 0x57, 0x19, 0xa9, 0x7b, 0xe5, 0x71, 0xfa, 0x02, 0x6a, 0x3c, 0x33,
+	0x04, 0x9b, 0xf6, 0x3e, 0x4a, 0xeb, 0x39, 0x0e, 0x67, 0xc4, 0x11, 0xf8, 0xb4, 0xeb, 0xaa, 0x59,
+	0xd7, 0x1d, 0x27, 0xa5, 0x1e, 0x92, 0x90, 0xe1, 0x90, 0xad, 0xa7, 0xff, 0x09, 0x3c, 0xcb, 0xe1,
+	0xa4, 0x2e, 0xf0, 0x1a, 0x1a, 0x4a, 0x35, 0xc1, 0xad, 0xd0, 0xae, 0x9a, 0xca, 0xfe, 0x7b, 0x0d,
+	0xfa, 0xef, 0x16, 0x53, 0x8f, 0x61, 0x8d, 0x7a, 0x40, 0xa9, 0x2f, 0x61, 0x43, 0x54, 0x18, 0x65,
+	0x8b, 0x6d, 0xc9, 0x5b, 0x80, 0x86, 0x87, 0xfc, 0xd7, 0x91, 0x78, 0xf4, 0x02, 0xea, 0x37, 0x5e,
+	0xb0, 0xc4, 0xd4, 0xac, 0x26, 0xad, 0xa6, 0x28, 0x45, 0x79, 0x72, 0x14, 0x05, 0xda, 0x85, 0xc6,
+	0x34, 0xba, 0xe3, 0xf5, 0x45, 0xa4, 0x64, 0xd3, 0xa9, 0x4f, 0xa3, 0x3b, 0x67, 0x19, 0xa2, 0x5f,
+	0x41, 0x67, 0xea, 0x53, 0xef, 0x32, 0xc0, 0xee, 0x07, 0x42, 0xae, 0xa9, 0xc8, 0xca, 0xa6, 0xb3,
+	0xa9, 0x80, 0xc7, 0x1c, 0x86, 0x2c, 0x1e, 0x49, 0x93, 0x08, 0x7b, 0x0c, 0x9b, 0x75, 0x81, 0x8f,
+	0xf7, 0xdc, 0x86, 0xcc, 0x9f, 0x63, 0xb2, 0x64, 0x22, 0x95, 0xaa, 0x8e, 0xde, 0xa2, 0xcf, 0x61,
+	0x33, 0xc2, 0x14, 0x33, 0x57, 0x69, 0xd9, 0x14, 0x27, 0xdb, 0x02, 0xf6, 0x5e, 0xaa, 0x85, 0xa0,
+	0xf6, 0xd1, 0xf3, 0x99, 0xd9, 0x12, 0x28, 0xb1, 0x96, 0xc7, 0x96, 0x14, 0xeb, 0x63, 0xa0, 0x8f,
+	0x2d, 0x29, 0x56, 0xc7, 0xfa, 0xb0, 0x31, 0x23, 0xd1, 0x04, 0x9b, 0x6d, 0x81, 0x93, 0x1b, 0xb4,
+	0x07, 0xed, 0x29, 0xa6, 0x93, 0xc8, 0x5f, 0x30, 0xee, 0xd1, 0x4d, 0x61, 0xd3, 0x24, 0x88, 0xdf,
+	0x83, 0x2e, 0x2f, 0x4f, 0x09, 0xc3, 0xd4, 0xec, 0xc8, 0x7b, 0xe8, 0x3d, 0xfa, 0x02, 0xb6, 0x26,
+	0x01, 0xf6, 0xc2, 0xe5, 0xc2, 0x25, 0xa1, 0x3b, 0xf3, 0xfc, 0xc0, 0xec, 0x0a, 0x92, 0x8e, 0x02,
+	0x9f, 0x85, 0xdf, 0x7a, 0x7e, 0xc0, 0x65, 0x7b, 0x53, 0xb2, 0x60, 0xe6, 0x96, 0x94, 0x2d, 0x36,
+	0xe8, 0x39, 0xf4, 0x28, 0x0e, 0xa9, 0xcf, 0xfc, 0x9b, 0x58, 0xf1, 0xde, 0x5e, 0x75, 0xd0, 0x72,
+	0xb6, 0x62, 0xb8, 0x54, 0xde, 0x9e, 0xc2, 0x4e, 0x26, 0x16, 0xd6, 0x0c, 0x2b, 0x7e, 0x9d, 0x8f,
+	0x5e, 0x14, 0xfa, 0xe1, 0x15, 0x35, 0x2b, 0x42, 0x58, 0xbc, 0xb7, 0xff, 0x5d, 0x81, 0xa7, 0x0e,
+	0x09, 0x82, 0x4b, 0x6f, 0x72, 0x5d, 0x22, 0xe8, 0x12, 0xf1, 0x51, 0x79, 0x38, 0x3e, 0xaa, 0x39,
+	0xf1, 0x91, 0xc8, 0xa3, 0x5a, 0x2a, 0x8f, 0x52, 0x91, 0xb3, 0x51, 0x1c, 0x39, 0xf5, 0x74, 0xe4,
+	0xe8, 0xb0, 0x68, 0x24, 0xc2, 0x22, 0xf6, 0x79, 0xf3, 0x01, 0x9f, 0xb7, 0x56, 0x7d, 0x9e, 0xe3,
+	0x57, 0xc8, 0xf1, 0xab, 0xfd, 0x27, 0xd8, 0x5d, 0xb1, 0xd7, 0xba, 0xf9, 0xfe, 0xff, 0x2a, 0xec,
+	0x8c, 0x43, 0xca, 0xbc, 0x20, 0xc8, 0xd8, 0x3e, 0x4e, 0x6e, 0xa3, 0x74, 0x72, 0x57, 0x7e, 0x4e,
+	0x72, 0x57, 0x53, 0xce, 0xd3, 0x9e, 0xae, 0x25, 0x3c, 0x5d, 0x2a, 0xe1, 0x53, 0x65, 0xb6, 0x9e,
+	0x29, 0xb3, 0xe8, 0x33, 0x00, 0x99, 0xa1, 0x82, 0xb9, 0x74, 0x52, 0x4b, 0x40, 0x4e, 0x55, 0x55,
+	0xd5, 0x7e, 0x6d, 0xe6, 0xfb, 0x35, 0x99, 0xee, 0x03, 0xe8, 0x69, 0x7d, 0x26, 0xd1, 0x54, 0xe8,
+	0xa4, 0x1c, 0xd4, 0x55, 0xf0, 0xc3, 0x68, 0xca, 0xb5, 0xca, 0xfa, 0xba, 0xfd, 0x70, 0x7e, 0x6f,
+	0x66, 0xf2, 0x3b, 0xce, 0xdb, 0x4e, 0x32, 0x6f, 0x3f, 0x03, 0x10, 0x0b, 0x97, 0x84, 0xc1, 0x9d,
+	0x4a, 0xf8, 0x96, 0x80, 0x9c, 0x85, 0xc1, 0x5d, 0x6e, 0x5a, 0x6f, 0xe5, 0xa7, 0xf5, 0x18, 0x9e,
+	0x66, 0x5d, 0xbe, 0x6e, 0xf8, 0xfc, 0xcb, 0x80, 0xdd, 0x77, 0xa1, 0x9f, 0x1b, 0x40, 0x79, 0xc9,
+	0xbb, 0xe2, 0xd2, 0x4a, 0x8e, 0x4b, 0xfb, 0xb0, 0xb1, 0x58, 0x46, 0x57, 0x58, 0x85, 0x88, 0xdc,
+	0x24, 0x7d, 0x55, 0x4b, 0xfb, 0x2a, 0x63, 0xed, 0x8d, 0x15, 0x6b, 0xdb, 0x2e, 0x98, 0xab, 0x5a,
+	0xae, 0x5b, 0xcb, 0x50, 0x62, 0x00, 0x68, 0xc9, 0x66, 0x6f, 0x3f, 0x81, 0xed, 0x23, 0xcc, 0xde,
+	0xcb, 0x52, 0xa2, 0x0c, 0x60, 0x8f, 0x00, 0x25, 0x81, 0xf7, 0xf2, 0x14, 0x28, 0x2d, 0x4f, 0x4f,
+	0xc7, 0x9a, 0x5e, 0x53, 0xd9, 0xbf, 0x17, 0xbc, 0x8f, 0x7d, 0xca, 0x48, 0x74, 0xf7, 0x90, 0x71,
+	0x7b, 0x50, 0x9d, 0x7b, 0xb7, 0x6a, 0x3e, 0xe0, 0x4b, 0xfb, 0x08, 0x50, 0xf2, 0xa8, 0xd2, 0x20,
+	0x39, 0x6d, 0x19, 0xe5, 0xa6, 0xad, 0xff, 0x19, 0x80, 0x2e, 0x70, 0x3c, 0xf9, 0x3d, 0x32, 0xa9,
+	0x68, 0x3f, 0x55, 0xd2, 0x7e, 0x32, 0xa1, 0xa1, 0x0a, 0x99, 0xf2, 0xac, 0xde, 0xf2, 0x6c, 0x58,
+	0x78, 0x91, 0x17, 0x04, 0x38, 0x50, 0x4d, 0x3f, 0xde, 0xf3, 0x26, 0x3b, 0xf7, 0x6e, 0xdd, 0x18,
+	0xcf, 0xdd, 0xdb, 0x71, 0xda, 0x73, 0xef, 0xf6, 0xad, 0x26, 0x41, 0x50, 0x0b, 0xc8, 0x15, 0x55,
+	0x0d, 0x5f, 0xac, 0xb9, 0xb0, 0x08, 0xd3, 0x65, 0xc0, 0xa8, 0x4a, 0x7b, 0xbd, 0xe5, 0x18, 0x39,
+	0xe4, 0xf3, 0x3e, 0xcf, 0x13, 0x44, 0x6f, 0xed, 0x7f, 0x18, 0xf0, 0x24, 0x75, 0x4b, 0x65, 0x30,
+	0x6e, 0x58, 0x7a, 0xa5, 0x6e, 0xc9, 0x97, 0xe8, 0x77, 0x50, 0x97, 0x63, 0xba, 0xb8, 0x63, 0x77,
+	0xff, 0xd3, 0xb4, 0x01, 0x05, 0x93, 0x65, 0xa8, 0xe6, 0x7a, 0x47, 0xd1, 0xa2, 0x57, 0x50, 0x97,
+	0x4a, 0xa8, 0x31, 0x68, 0x27, 0xf7, 0x94, 0xa3, 0x88, 0xec, 0x6b, 0x78, 0xe6, 0xe0, 0x8f, 0x91,
+	0x1f, 0xf7, 0xdf, 0x83, 0xb7, 0x63, 0xba, 0x56, 0x6b, 0xfc, 0x1c, 0x36, 0xaf, 0x97, 0x97, 0xd8,
+	0xd5, 0xad, 0x4f, 0x8e, 0xa3, 0x6d, 0x0e, 0xd3, 0x51, 0xf6, 0x1f, 0x03, 0xac, 0x3c, 0x69, 0xeb,
+	0x66, 0xc9, 0x4b, 0xd8, 0x26, 0x91, 0x7f, 0xe5, 0x87, 0x5e, 0xe0, 0xce, 0xbd, 0xd0, 0x9f, 0x61,
+	0xaa, 0x5f, 0x02, 0x3d, 0x8d, 0xf8, 0x5e, 0xc1, 0x79, 0x11, 0x8f, 0x84, 0x6c, 0x86, 0xb9, 0x72,
+	0xdc, 0x29, 0xf7, 0x00, 0x89, 0x9d, 0x7b, 0x3e, 0x1f, 0x17, 0xcc, 0x9a, 0xc6, 0x2a, 0x80, 0x7d,
+	0x06, 0xfd, 0xd1, 0xed, 0x82, 0x44, 0xac, 0x5c, 0xf9, 0xf1, 0x82, 0xc0, 0x8d, 0xf0, 0x8d, 0xcf,
+	0x2f, 0x1d, 0x97, 0x1f, 0x51, 0x16, 0x14, 0xcc, 0x3e, 0x86, 0x9d, 0x0c, 0xc3, 0x75, 0xab, 0xe3,
+	0x5f, 0x2b, 0xd0, 0x1f, 0xcf, 0x73, 0x74, 0xfb, 0xf9, 0x19, 0x98, 0xee, 0x73, 0x95, 0x6c, 0x9f,
+	0x2b, 0xec, 0xab, 0x17, 0xd0, 0x26, 0xa1, 0xcb, 0xbf, 0x00, 0x04, 0xfe, 0x44, 0x56, 0xce, 0xee,
+	0xfe, 0xd7, 0xf9, 0x0f, 0xd9, 0x3c, 0x55, 0x87, 0x87, 0xea, 0xa8, 0x03, 0x24, 0xd4, 0x6b, 0xfb,
+	0x15, 0x34, 0xf5, 0x9a, 0x3f, 0x0f, 0xbf, 0x3d, 0x18, 0x9f, 0xc8, 0x87, 0xe2, 0xf9, 0x77, 0xe3,
+	0xb7, 0x3d, 0x03, 0x75, 0xa0, 0x75, 0xf6, 0x7e, 0xe4, 0xfc, 0xe0, 0x8c, 0x2f, 0x46, 0xbd, 0x0a,
+	0x9f, 0x23, 0xc7, 0xf3, 0x3c, 0x8b, 0xae, 0x61, 0x07, 0x13, 0x1a, 0xf4, 0xda, 0x5f, 0x2c, 0xf0,
+	0x54, 0x0d, 0x92, 0x7a, 0x6b, 0xbf, 0x80, 0xfe, 0xbb, 0x30, 0x20, 0x65, 0x86, 0x48, 0xee, 0xe3,
+	0x0c, 0xed, 0x9a, 0x3e, 0xde, 0xff, 0xe7, 0x26, 0x74, 0xf5, 0xe3, 0x51, 0xda, 0x13, 0xf9, 0xb0,
+	0x99, 0x7c, 0x25, 0xa3, 0xe7, 0xc5, 0xdf, 0x0d, 0x32, 0x1f, 0x3f, 0xac, 0x17, 0x65, 0x48, 0xa5,
+	0xaa, 0xf6, 0x27, 0x5f, 0x19, 0x88, 0x42, 0x2f, 0xfb, 0x78, 0x45, 0xaf, 0xf2, 0x79, 0x14, 0xbc,
+	0x96, 0xad, 0x61, 0x59, 0x72, 0x2d, 0x16, 0xdd, 0xc0, 0xf6, 0x3d, 0x56, 0xbd, 0x38, 0xd1, 0xa3,
+	0x6c, 0xd2, 0x8f, 0x5c, 0xeb, 0x75, 0x69, 0xfa, 0x58, 0xee, 0x8f, 0xd0, 0x49, 0x3d, 0x47, 0x50,
+	0x81, 0xb5, 0xf2, 0xde, 0xaf, 0xd6, 0xcb, 0x52, 0xb4, 0xb1, 0xac, 0x39, 0x74, 0xd3, 0x33, 0x12,
+	0x2a, 0x60, 0x90, 0x3b, 0x3c, 0x5b, 0xbf, 0x29, 0x47, 0x1c, 0x8b, 0xa3, 0xd0, 0xcb, 0x0e, 0x28,
+	0x45, 0x7e, 0x2c, 0x18, 0xb7, 0xac, 0x61, 0x59, 0xf2, 0x58, 0xa8, 0x07, 0x70, 0x3f, 0x9f, 0xa0,
+	0x2f, 0x0b, 0x1d, 0x92, 0x1e, 0x6b, 0xac, 0xc1, 0xe3, 0x84, 0xb1, 0x88, 0x05, 0x6c, 0x65, 0x9e,
+	0x2a, 0xa8, 0xc0, 0x34, 0xf9, 0x2f, 0x40, 0xeb, 0x55, 0x49, 0xea, 0xcc, 0xa5, 0xd4, 0xc8, 0xf3,
+	0xc0, 0xa5, 0xd2, 0xf3, 0x94, 0x35, 0x78, 0x9c, 0x30, 0x16, 0xe1, 0x43, 0x97, 0xb7, 0x69, 0x29,
+	0x9a, 0x37, 0x6d, 0x54, 0x70, 0x7a, 0x75, 0x62, 0xb2, 0x9e, 0x97, 0xa0, 0x4c, 0xe4, 0xf7, 0x1d,
+	0xa0, 0xd5, 0xa6, 0x8c, 0x0a, 0x72, 0xa7, 0x70, 0x58, 0xb0, 0xbe, 0x2a, 0x7f, 0x20, 0xbe, 0x65,
+	0x00, 0x9d, 0x54, 0x1b, 0x2c, 0xca, 0xb6, 0xbc, 0xe6, 0x6b, 0xbd, 0x2c, 0x45, 0x9b, 0xb8, 0xe8,
+	0x8f, 0xd0, 0x19, 0xcf, 0x4b, 0x48, 0x1b, 0xcf, 0xcb, 0x4b, 0xcb, 0xed, 0x39, 0xaa, 0x8e, 0x24,
+	0x8b, 0x7f, 0x61, 0x1d, 0xc9, 0xe9, 0x26, 0xd6, 0xcb, 0x52, 0xb4, 0x5a, 0xd6, 0x1b, 0xf8, 0x73,
+	0x53, 0x93, 0x5e, 0xd6, 0xc5, 0x87, 0xef, 0xaf, 0x7f, 0x1a, 0x00, 0xf6, 0x63, 0x43, 0xa3, 0xe6,
+	0x17, 0x00, 0x00,
 }
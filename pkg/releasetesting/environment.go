@@ -35,6 +35,11 @@ import (
 // logEscaper is necessary for escaping control characters found in the log stream.
 var logEscaper = strings.NewReplacer("%", "%%")
 
+// maxStoredLogSize is the maximum number of bytes of a test pod's logs kept
+// on its result. Longer logs are truncated from the start, as the end of the
+// output usually holds the reason for a failure.
+const maxStoredLogSize = 16 * 1024
+
 // Environment encapsulates information about where test suite executes and returns results
 type Environment struct {
 	Namespace   string
@@ -71,6 +76,49 @@ func (env *Environment) getTestPodStatus(test *test) (v1.PodPhase, error) {
 	return status, err
 }
 
+func (env *Environment) deleteTestPod(test *test) error {
+	b := bytes.NewBufferString(test.manifest)
+	return env.KubeClient.DeleteWithTimeout(env.Namespace, b, env.Timeout, true)
+}
+
+// captureLogs records the logs of the test pod on the test result.
+func (env *Environment) captureLogs(test *test) {
+	_, logs, err := env.getPodLogs(test.manifest)
+	if err != nil {
+		log.Printf("Error getting logs for pod %s: %s", test.result.Name, err)
+		return
+	}
+	test.result.Logs = truncateLogs(logs)
+}
+
+func (env *Environment) getPodLogs(testManifest string) (string, string, error) {
+	infos, err := env.KubeClient.Build(env.Namespace, bytes.NewBufferString(testManifest))
+	if err != nil {
+		return "", "", err
+	}
+	if len(infos) == 0 {
+		return "", "", fmt.Errorf("Pod manifest is invalid. Unable to obtain the logs")
+	}
+	podName := infos[0].Object.(*v1.Pod).Name
+	lr, err := env.KubeClient.GetPodLogs(podName, env.Namespace)
+	if err != nil {
+		return podName, "", err
+	}
+	defer lr.Close()
+	logs, err := ioutil.ReadAll(lr)
+	if err != nil {
+		return podName, "", err
+	}
+	return podName, string(logs), nil
+}
+
+func truncateLogs(logs string) string {
+	if len(logs) <= maxStoredLogSize {
+		return logs
+	}
+	return "[truncated]\n" + logs[len(logs)-maxStoredLogSize:]
+}
+
 func (env *Environment) streamResult(r *release.TestRun) error {
 	switch r.Status {
 	case release.TestRun_SUCCESS:
@@ -95,6 +143,11 @@ func (env *Environment) streamRunning(name string) error {
 	return env.streamMessage(msg, release.TestRun_RUNNING)
 }
 
+func (env *Environment) streamRetry(name string, attempt int32, retries int) error {
+	msg := fmt.Sprintf("RETRYING: %s (attempt %d of %d failed)", name, attempt, retries+1)
+	return env.streamMessage(msg, release.TestRun_RUNNING)
+}
+
 func (env *Environment) streamError(info string) error {
 	msg := "ERROR: " + info
 	return env.streamMessage(msg, release.TestRun_FAILURE)
@@ -132,30 +185,51 @@ func (env *Environment) DeleteTestPods(testManifests []string) {
 	}
 }
 
-// GetLogs collects the logs from the pods created in testManifests
-func (env *Environment) GetLogs(testManifests []string) {
-	for _, testManifest := range testManifests {
-		infos, err := env.KubeClient.Build(env.Namespace, bytes.NewBufferString(testManifest))
+// StreamResults sends the structured result of each test to the client
+func (env *Environment) StreamResults(results []*release.TestRun) error {
+	for _, r := range results {
+		resp := &services.TestReleaseResponse{Status: r.Status, Result: r}
+		env.streamLock.Lock()
+		err := env.Stream.Send(resp)
+		env.streamLock.Unlock()
 		if err != nil {
-			env.streamError(err.Error())
-			continue
-		}
-		if len(infos) == 0 {
-			env.streamError(fmt.Sprint("Pod manifest is invalid. Unable to obtain the logs"))
-			continue
+			return err
 		}
-		podName := infos[0].Object.(*v1.Pod).Name
-		lr, err := env.KubeClient.GetPodLogs(podName, env.Namespace)
+	}
+	return nil
+}
+
+// GetLogs collects the logs from the pods created in testManifests. The logs
+// are also recorded on the entry of results that belongs to each pod.
+func (env *Environment) GetLogs(testManifests []string, results []*release.TestRun) {
+	env.collectLogs(testManifests, results, true)
+}
+
+// RecordLogs records the logs from the pods created in testManifests on the
+// entry of results that belongs to each pod, without streaming them.
+func (env *Environment) RecordLogs(testManifests []string, results []*release.TestRun) {
+	env.collectLogs(testManifests, results, false)
+}
+
+func (env *Environment) collectLogs(testManifests []string, results []*release.TestRun, stream bool) {
+	for _, testManifest := range testManifests {
+		podName, logs, err := env.getPodLogs(testManifest)
 		if err != nil {
-			env.streamError(err.Error())
+			if stream {
+				env.streamError(err.Error())
+			} else {
+				log.Printf("Error getting logs for pod %s: %s", podName, err)
+			}
 			continue
 		}
-		logs, err := ioutil.ReadAll(lr)
-		if err != nil {
-			env.streamError(err.Error())
-			continue
+		for _, r := range results {
+			if r.Name == podName {
+				r.Logs = truncateLogs(logs)
+			}
+		}
+		if stream {
+			msg := fmt.Sprintf("\nPOD LOGS: %s\n%s", podName, logEscaper.Replace(logs))
+			env.streamMessage(msg, release.TestRun_RUNNING)
 		}
-		msg := fmt.Sprintf("\nPOD LOGS: %s\n%s", podName, logEscaper.Replace(string(logs)))
-		env.streamMessage(msg, release.TestRun_RUNNING)
 	}
 }
@@ -94,7 +94,7 @@ func TestGetTestPodLogs(t *testing.T) {
 	mockTestEnv := newMockTestingEnvironment()
 	mockTestEnv.KubeClient = newGetLogKubeClient()
 
-	mockTestEnv.GetLogs(mockTestSuite.TestManifests)
+	mockTestEnv.GetLogs(mockTestSuite.TestManifests, nil)
 
 	expectedMessage := "ERROR: Pod manifest is invalid. Unable to obtain the logs"
 
@@ -107,6 +107,44 @@ func TestGetTestPodLogs(t *testing.T) {
 	}
 }
 
+func TestRecordTestPodLogs(t *testing.T) {
+	mockTestSuite := testSuiteFixture([]string{manifestWithTestSuccessHook})
+	mockTestEnv := newMockTestingEnvironment()
+	mockTestEnv.KubeClient = newGetLogKubeClient()
+
+	mockTestEnv.RecordLogs(mockTestSuite.TestManifests, nil)
+
+	stream := mockTestEnv.Stream.(*mockStream)
+	if len(stream.messages) != 0 {
+		t.Errorf("Expected no messages, got: %v", stream.messages)
+	}
+}
+
+func TestStreamResults(t *testing.T) {
+	mockTestEnv := newMockTestingEnvironment()
+
+	results := []*release.TestRun{
+		{Name: "finding-nemo", Status: release.TestRun_SUCCESS},
+		{Name: "gold-rush", Status: release.TestRun_FAILURE},
+	}
+	if err := mockTestEnv.StreamResults(results); err != nil {
+		t.Fatalf("Expected no errors, got 1: %s", err)
+	}
+
+	stream := mockTestEnv.Stream.(*mockStream)
+	if len(stream.messages) != 2 {
+		t.Fatalf("Expected 2 messages, got: %v", len(stream.messages))
+	}
+	for i, m := range stream.messages {
+		if m.Result != results[i] {
+			t.Errorf("Expected result %v, got: %v", results[i], m.Result)
+		}
+		if m.Status != results[i].Status {
+			t.Errorf("Expected status %v, got: %v", results[i].Status, m.Status)
+		}
+	}
+}
+
 func TestStreamMessage(t *testing.T) {
 	mockTestEnv := newMockTestingEnvironment()
 
@@ -0,0 +1,187 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasetesting
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+const (
+	// ReportJUnit writes the report as JUnit XML.
+	ReportJUnit = "junit"
+	// ReportJSON writes the report as JSON.
+	ReportJSON = "json"
+)
+
+// Report is a machine readable summary of a test suite run.
+type Report struct {
+	Release     string        `json:"release"`
+	StartedAt   *time.Time    `json:"startedAt,omitempty"`
+	CompletedAt *time.Time    `json:"completedAt,omitempty"`
+	Tests       []*ReportTest `json:"tests"`
+}
+
+// ReportTest is the result of a single test pod.
+type ReportTest struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Info        string     `json:"info,omitempty"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	Duration    float64    `json:"durationSeconds"`
+	Logs        string     `json:"logs,omitempty"`
+}
+
+// NewReport builds a report for the named release from the structured test results.
+func NewReport(name string, results []*release.TestRun) *Report {
+	r := &Report{Release: name, Tests: []*ReportTest{}}
+	for _, res := range results {
+		t := &ReportTest{
+			Name:        res.Name,
+			Status:      res.Status.String(),
+			Info:        res.Info,
+			StartedAt:   toTime(res.StartedAt),
+			CompletedAt: toTime(res.CompletedAt),
+			Logs:        res.Logs,
+		}
+		if t.StartedAt != nil && t.CompletedAt != nil {
+			t.Duration = t.CompletedAt.Sub(*t.StartedAt).Seconds()
+		}
+		if t.StartedAt != nil && (r.StartedAt == nil || t.StartedAt.Before(*r.StartedAt)) {
+			r.StartedAt = t.StartedAt
+		}
+		if t.CompletedAt != nil && (r.CompletedAt == nil || t.CompletedAt.After(*r.CompletedAt)) {
+			r.CompletedAt = t.CompletedAt
+		}
+		r.Tests = append(r.Tests, t)
+	}
+	return r
+}
+
+// Write writes the report to w in the given format.
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case ReportJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case ReportJUnit:
+		return r.writeJUnit(w)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+func (r *Report) writeJUnit(w io.Writer) error {
+	suite := junitTestSuite{Name: r.Release, Tests: len(r.Tests)}
+	if r.StartedAt != nil {
+		suite.Timestamp = r.StartedAt.UTC().Format(time.RFC3339)
+		if r.CompletedAt != nil {
+			suite.Time = formatSeconds(r.CompletedAt.Sub(*r.StartedAt).Seconds())
+		}
+	}
+	if suite.Time == "" {
+		suite.Time = formatSeconds(0)
+	}
+
+	for _, t := range r.Tests {
+		tc := junitTestCase{
+			Name:      t.Name,
+			Classname: r.Release,
+			Time:      formatSeconds(t.Duration),
+			SystemOut: t.Logs,
+		}
+		switch t.Status {
+		case release.TestRun_SUCCESS.String():
+		case release.TestRun_FAILURE.String():
+			suite.Failures++
+			tc.Failure = &junitMessage{Message: failureMessage(t), Type: t.Status, Body: t.Info}
+		default:
+			suite.Errors++
+			tc.Error = &junitMessage{Message: failureMessage(t), Type: t.Status, Body: t.Info}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func failureMessage(t *ReportTest) string {
+	if t.Info != "" {
+		return t.Info
+	}
+	return fmt.Sprintf("test %s finished with status %s", t.Name, t.Status)
+}
+
+func formatSeconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
+
+func toTime(ts *timestamp.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := timeconv.Time(ts)
+	return &t
+}
@@ -0,0 +1,128 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasetesting
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+func reportResultsFixture() []*release.TestRun {
+	start := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	return []*release.TestRun{
+		{
+			Name:        "finding-nemo",
+			Status:      release.TestRun_SUCCESS,
+			StartedAt:   timeconv.Timestamp(start),
+			CompletedAt: timeconv.Timestamp(start.Add(2 * time.Second)),
+			Logs:        "found him",
+		},
+		{
+			Name:        "gold-rush",
+			Status:      release.TestRun_FAILURE,
+			StartedAt:   timeconv.Timestamp(start.Add(time.Second)),
+			CompletedAt: timeconv.Timestamp(start.Add(5 * time.Second)),
+		},
+		{
+			Name:   "lost-at-sea",
+			Status: release.TestRun_UNKNOWN,
+			Info:   "timed out waiting for the condition",
+		},
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	r := NewReport("nemo", reportResultsFixture())
+
+	if r.Release != "nemo" {
+		t.Errorf("Expected release nemo, got %s", r.Release)
+	}
+	if len(r.Tests) != 3 {
+		t.Fatalf("Expected 3 tests, got %d", len(r.Tests))
+	}
+	if r.Tests[0].Duration != 2 {
+		t.Errorf("Expected a duration of 2s, got %v", r.Tests[0].Duration)
+	}
+	if r.Tests[2].Duration != 0 {
+		t.Errorf("Expected no duration without timestamps, got %v", r.Tests[2].Duration)
+	}
+	if d := r.CompletedAt.Sub(*r.StartedAt); d != 5*time.Second {
+		t.Errorf("Expected the suite to span 5s, got %v", d)
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewReport("nemo", reportResultsFixture()).Write(&buf, ReportJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	var r Report
+	if err := json.Unmarshal(buf.Bytes(), &r); err != nil {
+		t.Fatalf("Expected valid JSON, got %s", err)
+	}
+	if r.Tests[0].Logs != "found him" {
+		t.Errorf("Expected logs to be reported, got %q", r.Tests[0].Logs)
+	}
+	if r.Tests[1].Status != "FAILURE" {
+		t.Errorf("Expected status FAILURE, got %s", r.Tests[1].Status)
+	}
+}
+
+func TestReportJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewReport("nemo", reportResultsFixture()).Write(&buf, ReportJUnit); err != nil {
+		t.Fatal(err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("Expected valid XML, got %s", err)
+	}
+	if len(suites.Suites) != 1 {
+		t.Fatalf("Expected 1 test suite, got %d", len(suites.Suites))
+	}
+
+	s := suites.Suites[0]
+	if s.Tests != 3 || s.Failures != 1 || s.Errors != 1 {
+		t.Errorf("Expected 3 tests, 1 failure and 1 error, got %d, %d and %d", s.Tests, s.Failures, s.Errors)
+	}
+	if s.Time != "5.000" {
+		t.Errorf("Expected suite time 5.000, got %s", s.Time)
+	}
+	if s.Cases[0].SystemOut != "found him" {
+		t.Errorf("Expected logs in system-out, got %q", s.Cases[0].SystemOut)
+	}
+	if s.Cases[1].Failure == nil {
+		t.Errorf("Expected a failure for %s", s.Cases[1].Name)
+	}
+	if s.Cases[2].Error == nil || s.Cases[2].Error.Message != "timed out waiting for the condition" {
+		t.Errorf("Expected an error for %s, got %v", s.Cases[2].Name, s.Cases[2].Error)
+	}
+}
+
+func TestReportUnknownFormat(t *testing.T) {
+	if err := NewReport("nemo", nil).Write(&bytes.Buffer{}, "tap"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
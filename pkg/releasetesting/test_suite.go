@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"golang.org/x/sync/semaphore"
+	"strconv"
 	"strings"
 
 	"github.com/ghodss/yaml"
@@ -43,6 +44,7 @@ type TestSuite struct {
 type test struct {
 	manifest        string
 	expectedSuccess bool
+	retries         int
 	result          *release.TestRun
 }
 
@@ -62,7 +64,8 @@ func NewTestSuite(rel *release.Release) (*TestSuite, error) {
 	}, nil
 }
 
-// Run executes tests in a test suite and stores a result within a given environment
+// Run executes tests in a test suite and stores a result within a given environment.
+// The results of the tests that ran are kept even if the suite is aborted early.
 func (ts *TestSuite) Run(env *Environment) error {
 	ts.StartedAt = timeconv.Now()
 
@@ -82,6 +85,7 @@ func (ts *TestSuite) Run(env *Environment) error {
 		tests = append(tests, test)
 	}
 
+	var runErr error
 	if env.Parallel {
 		c := make(chan error, len(tests))
 		// Use a semaphore to restrict the number of tests running in parallel.
@@ -96,39 +100,137 @@ func (ts *TestSuite) Run(env *Environment) error {
 		}
 
 		for range tests {
-			if err := <-c; err != nil {
-				return err
+			if err := <-c; err != nil && runErr == nil {
+				runErr = err
 			}
 		}
 
 	} else {
 		for _, t := range tests {
-			if err := t.run(env); err != nil {
-				return err
+			if runErr = t.run(env); runErr != nil {
+				break
 			}
 		}
 	}
 
 	for _, t := range tests {
-		ts.Results = append(ts.Results, t.result)
+		if t.result.StartedAt != nil {
+			ts.Results = append(ts.Results, t.result)
+		}
 	}
 
 	ts.CompletedAt = timeconv.Now()
+	return runErr
+}
+
+// Filter reduces the tests of the suite to the ones selected by filters.
+//
+// Each filter has the form attribute=value, or !attribute=value to exclude the
+// matching tests. When no inclusive filter is given, every test that is not
+// excluded is kept. It is an error for a filter to match no test of the suite,
+// or for the filters to select no test at all.
+func (ts *TestSuite) Filter(filters []string) error {
+	if len(filters) == 0 {
+		return nil
+	}
+
+	include := map[string]bool{}
+	exclude := map[string]bool{}
+	for _, f := range filters {
+		parts := strings.SplitN(f, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return fmt.Errorf("invalid test filter %q, expected attribute=value", f)
+		}
+		attr, negate := strings.TrimPrefix(parts[0], "!"), strings.HasPrefix(parts[0], "!")
+		if attr != "name" {
+			return fmt.Errorf("unknown test filter attribute %q, only \"name\" is supported", attr)
+		}
+		if negate {
+			exclude[parts[1]] = true
+		} else {
+			include[parts[1]] = true
+		}
+	}
+
+	var manifests []string
+	names := map[string]bool{}
+	for _, m := range ts.TestManifests {
+		t, err := newTest(m)
+		if err != nil {
+			return err
+		}
+		name := t.result.Name
+		names[name] = true
+		if exclude[name] || (len(include) > 0 && !include[name]) {
+			continue
+		}
+		manifests = append(manifests, m)
+	}
+
+	var unmatched []string
+	for _, f := range filters {
+		if !names[strings.SplitN(f, "=", 2)[1]] {
+			unmatched = append(unmatched, f)
+		}
+	}
+	if len(unmatched) > 0 {
+		return fmt.Errorf("test filters matched no test: %s", strings.Join(unmatched, ", "))
+	}
+	if len(manifests) == 0 && len(ts.TestManifests) > 0 {
+		return fmt.Errorf("test filters %s select no test", strings.Join(filters, ", "))
+	}
+	ts.TestManifests = manifests
 	return nil
 }
 
 func (t *test) run(env *Environment) error {
 	t.result.StartedAt = timeconv.Now()
+	for {
+		t.result.Attempts++
+		created, err := t.runOnce(env)
+		if err != nil {
+			return err
+		}
+		if t.result.Status == release.TestRun_SUCCESS || int(t.result.Attempts) > t.retries {
+			if t.result.Status != release.TestRun_SUCCESS && created {
+				env.captureLogs(t)
+			}
+			break
+		}
+
+		if err := env.streamRetry(t.result.Name, t.result.Attempts, t.retries); err != nil {
+			return err
+		}
+		if created {
+			if err := env.deleteTestPod(t); err != nil {
+				// the test is not run again, so report the logs of the
+				// attempt that failed
+				env.captureLogs(t)
+				if streamErr := env.streamError(err.Error()); streamErr != nil {
+					return streamErr
+				}
+				break
+			}
+		}
+	}
+
+	t.result.CompletedAt = timeconv.Now()
+	return nil
+}
+
+// runOnce runs a single attempt of the test and reports whether the test pod was created.
+func (t *test) runOnce(env *Environment) (bool, error) {
 	if err := env.streamRunning(t.result.Name); err != nil {
-		return err
+		return false, err
 	}
 	t.result.Status = release.TestRun_RUNNING
+	t.result.Info = ""
 
 	resourceCreated := true
 	if err := env.createTestPod(t); err != nil {
 		resourceCreated = false
 		if streamErr := env.streamError(t.result.Info); streamErr != nil {
-			return err
+			return false, err
 		}
 	}
 
@@ -140,23 +242,22 @@ func (t *test) run(env *Environment) error {
 		if err != nil {
 			resourceCleanExit = false
 			if streamErr := env.streamError(t.result.Info); streamErr != nil {
-				return streamErr
+				return resourceCreated, streamErr
 			}
 		}
 	}
 
 	if resourceCreated && resourceCleanExit {
 		if err := t.assignTestResult(status); err != nil {
-			return err
+			return resourceCreated, err
 		}
 
 		if err := env.streamResult(t.result); err != nil {
-			return err
+			return resourceCreated, err
 		}
 	}
 
-	t.result.CompletedAt = timeconv.Now()
-	return nil
+	return resourceCreated, nil
 }
 
 func (t *test) assignTestResult(podStatus v1.PodPhase) error {
@@ -222,10 +323,19 @@ func newTest(testManifest string) (*test, error) {
 		return nil, err
 	}
 
+	retries := 0
+	if r, ok := sh.Metadata.Annotations[hooks.TestRetriesAnno]; ok {
+		retries, err = strconv.Atoi(r)
+		if err != nil || retries < 0 {
+			return nil, fmt.Errorf("invalid %s annotation %q on %s", hooks.TestRetriesAnno, r, sh.Metadata.Name)
+		}
+	}
+
 	name := strings.TrimSuffix(sh.Metadata.Name, ",")
 	return &test{
 		manifest:        testManifest,
 		expectedSuccess: expected,
+		retries:         retries,
 		result: &release.TestRun{
 			Name: name,
 		},
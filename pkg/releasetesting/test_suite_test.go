@@ -17,8 +17,10 @@ limitations under the License.
 package releasetesting
 
 import (
+	"errors"
 	"io"
 	"io/ioutil"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,8 +29,11 @@ import (
 	grpc "google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/resource"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -64,6 +69,20 @@ spec:
   - name: gold-finding-test
     image: fake-gold-finding-image
     cmd: fake-gold-finding-command
+`
+	manifestWithTestRetries = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: flaky-fin,
+  annotations:
+    "helm.sh/hook": test-success
+    "helm.sh/test-retries": "2"
+spec:
+  containers:
+  - name: flaky-fin-test
+    image: fake-image
+    cmd: fake-command
 `
 	manifestWithInstallHooks = `apiVersion: v1
 kind: ConfigMap
@@ -210,6 +229,135 @@ func TestRunSuccessWithTestFailureHook(t *testing.T) {
 	}
 }
 
+func TestRunRetries(t *testing.T) {
+	ts := testSuiteFixture([]string{manifestWithTestRetries})
+	env := testEnvFixture()
+	kc := newFlakyKubeClient(2)
+	env.KubeClient = kc
+	if err := ts.Run(env); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	result := ts.Results[0]
+	if result.Status != release.TestRun_SUCCESS {
+		t.Errorf("Expected test result to be successful, got: %v", result.Status)
+	}
+	if result.Attempts != 3 {
+		t.Errorf("Expected 3 attempts, got: %d", result.Attempts)
+	}
+	if kc.deleted != 2 {
+		t.Errorf("Expected the test pod to be deleted before each retry, got %d deletions", kc.deleted)
+	}
+}
+
+func TestRunRetriesExhausted(t *testing.T) {
+	ts := testSuiteFixture([]string{manifestWithTestRetries})
+	env := testEnvFixture()
+	env.KubeClient = newFlakyKubeClient(5)
+	if err := ts.Run(env); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	result := ts.Results[0]
+	if result.Status != release.TestRun_FAILURE {
+		t.Errorf("Expected test result to be failure, got: %v", result.Status)
+	}
+	if result.Attempts != 3 {
+		t.Errorf("Expected 3 attempts, got: %d", result.Attempts)
+	}
+}
+
+func TestRunRetriesDeleteError(t *testing.T) {
+	ts := testSuiteFixture([]string{manifestWithTestRetries})
+	env := testEnvFixture()
+	kc := newFlakyKubeClient(5)
+	kc.deleteErr = errors.New("pod is stuck terminating")
+	env.KubeClient = kc
+	if err := ts.Run(env); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	result := ts.Results[0]
+	if result.Status != release.TestRun_FAILURE {
+		t.Errorf("Expected test result to be failure, got: %v", result.Status)
+	}
+	if result.Attempts != 1 {
+		t.Errorf("Expected 1 attempt, got: %d", result.Attempts)
+	}
+	if result.Logs != "attempt failed" {
+		t.Errorf("Expected the logs of the failed attempt, got %q", result.Logs)
+	}
+}
+
+func TestRunKeepsResultsOnError(t *testing.T) {
+	ts := testSuiteFixture([]string{manifestWithTestSuccessHook, manifestWithTestFailureHook})
+	env := testEnvFixture()
+	// the stream breaks once the first test has reported its result
+	env.Stream = &mockStream{failAfter: 2}
+	if err := ts.Run(env); err == nil {
+		t.Fatal("Expected an error from the broken stream")
+	}
+
+	if len(ts.Results) != 2 {
+		t.Fatalf("Expected 2 test results, got %v", len(ts.Results))
+	}
+	if ts.Results[0].Status != release.TestRun_SUCCESS {
+		t.Errorf("Expected the first result to be kept, got: %v", ts.Results[0].Status)
+	}
+	if ts.CompletedAt == nil {
+		t.Errorf("Expected CompletedAt to not be nil. Got: %v", ts.CompletedAt)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		filters  []string
+		expected []string
+		err      bool
+	}{
+		{nil, []string{"finding-nemo", "gold-rush", "flaky-fin"}, false},
+		{[]string{"name=gold-rush"}, []string{"gold-rush"}, false},
+		{[]string{"name=gold-rush", "name=flaky-fin"}, []string{"gold-rush", "flaky-fin"}, false},
+		{[]string{"!name=gold-rush"}, []string{"finding-nemo", "flaky-fin"}, false},
+		{[]string{"name=finding-nemo", "!name=finding-nemo"}, nil, true},
+		{[]string{"name=gold-rush", "name=dory"}, nil, true},
+		{[]string{"!name=dory"}, nil, true},
+		{[]string{"kind=Pod"}, nil, true},
+		{[]string{"gold-rush"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		ts := testSuiteFixture([]string{manifestWithTestSuccessHook, manifestWithTestFailureHook, manifestWithTestRetries})
+		err := ts.Filter(tt.filters)
+		if (err != nil) != tt.err {
+			t.Errorf("%v: expected error %t, got %v", tt.filters, tt.err, err)
+			continue
+		}
+		if tt.err {
+			continue
+		}
+
+		var names []string
+		for _, m := range ts.TestManifests {
+			test, err := newTest(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, test.result.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("%v: expected tests %v, got %v", tt.filters, tt.expected, names)
+		}
+	}
+}
+
+func TestNewTestInvalidRetries(t *testing.T) {
+	m := strings.Replace(manifestWithTestRetries, `"2"`, `"many"`, 1)
+	if _, err := newTest(m); err == nil {
+		t.Error("Expected an error for an invalid retries annotation")
+	}
+}
+
 func TestExtractTestManifestsFromHooks(t *testing.T) {
 	rel := releaseStub()
 	testManifests, err := extractTestManifestsFromHooks(rel.Hooks)
@@ -401,11 +549,15 @@ func mockTillerEnvironment() *tillerEnv.Environment {
 }
 
 type mockStream struct {
-	stream   grpc.ServerStream
-	messages []*services.TestReleaseResponse
+	stream    grpc.ServerStream
+	messages  []*services.TestReleaseResponse
+	failAfter int
 }
 
 func (rs *mockStream) Send(m *services.TestReleaseResponse) error {
+	if rs.failAfter > 0 && len(rs.messages) >= rs.failAfter {
+		return errors.New("transport is closing")
+	}
 	rs.messages = append(rs.messages, m)
 	return nil
 }
@@ -466,3 +618,40 @@ func newPodFailedKubeClient() *podFailedKubeClient {
 func (p *podFailedKubeClient) WaitAndGetCompletedPodPhase(ns string, r io.Reader, timeout time.Duration) (v1.PodPhase, error) {
 	return v1.PodFailed, nil
 }
+
+// flakyKubeClient reports a failed test pod for the first failures runs.
+type flakyKubeClient struct {
+	tillerEnv.PrintingKubeClient
+	failures  int
+	runs      int
+	deleted   int
+	deleteErr error
+}
+
+func newFlakyKubeClient(failures int) *flakyKubeClient {
+	return &flakyKubeClient{
+		PrintingKubeClient: tillerEnv.PrintingKubeClient{Out: ioutil.Discard},
+		failures:           failures,
+	}
+}
+
+func (p *flakyKubeClient) WaitAndGetCompletedPodPhase(ns string, r io.Reader, timeout time.Duration) (v1.PodPhase, error) {
+	p.runs++
+	if p.runs <= p.failures {
+		return v1.PodFailed, nil
+	}
+	return v1.PodSucceeded, nil
+}
+
+func (p *flakyKubeClient) DeleteWithTimeout(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	p.deleted++
+	return p.deleteErr
+}
+
+func (p *flakyKubeClient) Build(ns string, r io.Reader) (kube.Result, error) {
+	return []*resource.Info{{Object: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "finding-nemo"}}}}, nil
+}
+
+func (p *flakyKubeClient) GetPodLogs(name, ns string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader("attempt failed")), nil
+}
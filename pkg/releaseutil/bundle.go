@@ -0,0 +1,171 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/proto"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+const (
+	// BundleAPIVersion is the version of the bundle format written by
+	// WriteBundle.
+	BundleAPIVersion = "v1"
+	// BundleIndexName is the name of the index of a bundle.
+	BundleIndexName = "bundle.yaml"
+)
+
+// Bundle is the index of a release bundle: a gzipped tar archive holding
+// revisions of releases, each stored as a release.Release protobuf in the
+// file named by its entry.
+type Bundle struct {
+	APIVersion string         `json:"apiVersion"`
+	Created    time.Time      `json:"created"`
+	Releases   []*BundleEntry `json:"releases"`
+}
+
+// BundleEntry is a revision of a release in a bundle.
+type BundleEntry struct {
+	Name    string `json:"name"`
+	Version int32  `json:"version"`
+	File    string `json:"file"`
+}
+
+// WriteBundle writes the given revisions of releases to w as a bundle.
+func WriteBundle(w io.Writer, rels []*rspb.Release) error {
+	zipper := gzip.NewWriter(w)
+	twriter := tar.NewWriter(zipper)
+
+	b := &Bundle{APIVersion: BundleAPIVersion, Created: time.Now()}
+	files := map[string][]byte{}
+	for _, rel := range rels {
+		data, err := proto.Marshal(rel)
+		if err != nil {
+			return err
+		}
+		e := &BundleEntry{
+			Name:    rel.Name,
+			Version: rel.Version,
+			File:    fmt.Sprintf("releases/%s.v%d", rel.Name, rel.Version),
+		}
+		b.Releases = append(b.Releases, e)
+		files[e.File] = data
+	}
+
+	index, err := yaml.Marshal(b)
+	if err != nil {
+		return err
+	}
+	if err := writeToTar(twriter, BundleIndexName, index); err != nil {
+		return err
+	}
+	for _, e := range b.Releases {
+		if err := writeToTar(twriter, e.File, files[e.File]); err != nil {
+			return err
+		}
+	}
+	if err := twriter.Close(); err != nil {
+		return err
+	}
+	return zipper.Close()
+}
+
+// ReadBundle reads the revisions of releases from a bundle, in the order
+// they were written.
+func ReadBundle(r io.Reader) ([]*rspb.Release, error) {
+	unzipped, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a release bundle: %s", err)
+	}
+	defer unzipped.Close()
+
+	var index []byte
+	files := map[string][]byte{}
+	tr := tar.NewReader(unzipped)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		if h.Name == BundleIndexName {
+			index = data
+			continue
+		}
+		files[h.Name] = data
+	}
+	if index == nil {
+		return nil, fmt.Errorf("not a release bundle: no %s", BundleIndexName)
+	}
+
+	var b Bundle
+	if err := yaml.Unmarshal(index, &b); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %s", BundleIndexName, err)
+	}
+	if b.APIVersion != BundleAPIVersion {
+		return nil, fmt.Errorf("unsupported release bundle version %q, expected %q", b.APIVersion, BundleAPIVersion)
+	}
+
+	rels := make([]*rspb.Release, 0, len(b.Releases))
+	for _, e := range b.Releases {
+		data, ok := files[e.File]
+		if !ok {
+			return nil, fmt.Errorf("%s.v%d: missing %s", e.Name, e.Version, e.File)
+		}
+		rel := &rspb.Release{}
+		if err := proto.Unmarshal(data, rel); err != nil {
+			return nil, fmt.Errorf("%s.v%d: %s", e.Name, e.Version, err)
+		}
+		if rel.Name != e.Name || rel.Version != e.Version {
+			return nil, fmt.Errorf("%s holds %s.v%d, expected %s.v%d", e.File, rel.Name, rel.Version, e.Name, e.Version)
+		}
+		rels = append(rels, rel)
+	}
+	return rels, nil
+}
+
+func writeToTar(out *tar.Writer, name string, body []byte) error {
+	h := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(body)),
+		ModTime: time.Now(),
+	}
+	if err := out.WriteHeader(h); err != nil {
+		return err
+	}
+	_, err := out.Write(body)
+	return err
+}
@@ -0,0 +1,70 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestBundle(t *testing.T) {
+	rels := []*rspb.Release{
+		{Name: "angry-bird", Version: 1, Namespace: "default", Manifest: "kind: ConfigMap"},
+		{Name: "angry-bird", Version: 2, Namespace: "default", Manifest: "kind: Secret"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBundle(&buf, rels); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadBundle(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) != 2 {
+		t.Fatalf("expected 2 releases, got %d", len(read))
+	}
+	for i, rel := range read {
+		if rel.Name != rels[i].Name || rel.Version != rels[i].Version || rel.Manifest != rels[i].Manifest {
+			t.Errorf("expected %v, got %v", rels[i], rel)
+		}
+	}
+}
+
+func TestReadBundleVersion(t *testing.T) {
+	var buf bytes.Buffer
+	zipper := gzip.NewWriter(&buf)
+	twriter := tar.NewWriter(zipper)
+	if err := writeToTar(twriter, BundleIndexName, []byte("apiVersion: v2\n")); err != nil {
+		t.Fatal(err)
+	}
+	twriter.Close()
+	zipper.Close()
+
+	if _, err := ReadBundle(&buf); err == nil || !strings.Contains(err.Error(), `unsupported release bundle version "v2"`) {
+		t.Errorf("expected an unsupported version error, got %v", err)
+	}
+
+	if _, err := ReadBundle(strings.NewReader("not a bundle")); err == nil {
+		t.Error("expected an error reading garbage")
+	}
+}
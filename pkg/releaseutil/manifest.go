@@ -41,19 +41,26 @@ func SplitManifests(bigFile string) map[string]string {
 	// a place holder, and doesn't have any further meaning.
 	tpl := "manifest-%d"
 	res := map[string]string{}
+	for i, d := range SplitManifestDocs(bigFile) {
+		res[fmt.Sprintf(tpl, i)] = d
+	}
+	return res
+}
+
+// SplitManifestDocs takes a string of manifest and returns the individual
+// manifests in the order they appear.
+func SplitManifestDocs(bigFile string) []string {
 	// Making sure that any extra whitespace in YAML stream doesn't interfere in splitting documents correctly.
 	bigFileTmp := strings.TrimSpace(bigFile)
 	docs := sep.Split(bigFileTmp, -1)
-	var count int
+	var res []string
 	for _, d := range docs {
 
 		if d == "" {
 			continue
 		}
 
-		d = strings.TrimSpace(d)
-		res[fmt.Sprintf(tpl, count)] = d
-		count = count + 1
+		res = append(res, strings.TrimSpace(d))
 	}
 	return res
 }
@@ -59,3 +59,11 @@ func TestSplitManifest(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, manifests)
 	}
 }
+
+func TestSplitManifestDocs(t *testing.T) {
+	docs := SplitManifestDocs(manifestFile + "\n---\nkind: Second\n---\nkind: Third\n")
+	expected := []string{expectedManifest, "kind: Second", "kind: Third"}
+	if !reflect.DeepEqual(docs, expected) {
+		t.Errorf("Expected %q, got %q", expected, docs)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"k8s.io/helm/pkg/chartutil"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// Redact returns a copy of rel whose sensitive values are masked with
+// chartutil.RedactedValue in the values supplied by the user, the manifest,
+// the hooks and the notes. rel is returned as is if it has no sensitive
+// values.
+//
+// The values of the chart are left unchanged; values computed from them are
+// masked with chartutil.RedactValues.
+func Redact(rel *rspb.Release) (*rspb.Release, error) {
+	if len(rel.SensitiveValues) == 0 {
+		return rel, nil
+	}
+	computed, err := chartutil.CoalesceValues(rel.Chart, rel.Config)
+	if err != nil {
+		return nil, err
+	}
+	sensitive := chartutil.SensitiveStrings(computed, rel.SensitiveValues)
+
+	r := proto.Clone(rel).(*rspb.Release)
+	if r.Config != nil && r.Config.Raw != "" {
+		vals, err := chartutil.ReadValues([]byte(r.Config.Raw))
+		if err != nil {
+			return nil, err
+		}
+		// Keep the values as they were written unless they hold sensitive
+		// values.
+		if len(chartutil.SensitiveStrings(vals, r.SensitiveValues)) > 0 {
+			if r.Config.Raw, err = chartutil.RedactValues(vals, r.SensitiveValues).YAML(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	r.Manifest = chartutil.RedactText(r.Manifest, sensitive)
+	for _, h := range r.Hooks {
+		h.Manifest = chartutil.RedactText(h.Manifest, sensitive)
+	}
+	if r.Info != nil && r.Info.Status != nil {
+		r.Info.Status.Notes = chartutil.RedactText(r.Info.Status.Notes, sensitive)
+	}
+	return r, nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil
+
+import (
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestRedact(t *testing.T) {
+	rel := &rspb.Release{
+		Name: "sensitive",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "app"},
+			Values:   &chart.Config{Raw: "auth:\n  password: changeme\n"},
+		},
+		Config:   &chart.Config{Raw: "auth:\n  user: admin\ntoken: s3cr3t-token\n"},
+		Manifest: "password: changeme\ntoken: czNjcjN0LXRva2Vu\nuser: admin\n",
+		Hooks:    []*rspb.Hook{{Name: "hook", Manifest: "token: s3cr3t-token\n"}},
+		Info:     &rspb.Info{Status: &rspb.Status{Notes: "Log in with admin/changeme"}},
+	}
+
+	if r, err := Redact(rel); err != nil || r != rel {
+		t.Errorf("expected a release without sensitive values to be unchanged, got %v, %v", r, err)
+	}
+
+	rel.SensitiveValues = []string{"auth.password", "token"}
+	r, err := Redact(rel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "auth:\n  user: admin\ntoken: REDACTED\n"; r.Config.Raw != expect {
+		t.Errorf("expected values\n%s\ngot\n%s", expect, r.Config.Raw)
+	}
+	if expect := "password: REDACTED\ntoken: REDACTED\nuser: admin\n"; r.Manifest != expect {
+		t.Errorf("expected manifest\n%s\ngot\n%s", expect, r.Manifest)
+	}
+	if expect := "token: REDACTED\n"; r.Hooks[0].Manifest != expect {
+		t.Errorf("expected hook\n%s\ngot\n%s", expect, r.Hooks[0].Manifest)
+	}
+	if expect := "Log in with admin/REDACTED"; r.Info.Status.Notes != expect {
+		t.Errorf("expected notes %q, got %q", expect, r.Info.Status.Notes)
+	}
+	if rel.Config.Raw != "auth:\n  user: admin\ntoken: s3cr3t-token\n" || rel.Hooks[0].Manifest != "token: s3cr3t-token\n" {
+		t.Error("expected the release to be left unchanged")
+	}
+}
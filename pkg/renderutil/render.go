@@ -32,6 +32,10 @@ type Options struct {
 	ReleaseOptions chartutil.ReleaseOptions
 	KubeVersion    string
 	APIVersions    []string
+	// Capabilities replaces the default capabilities, e.g. with those of a
+	// cluster read by chartutil.ReadCapabilitiesFile. KubeVersion overrides
+	// its Kubernetes version and APIVersions are added to its API versions.
+	Capabilities *chartutil.Capabilities
 }
 
 // Render chart templates locally and display the output.
@@ -69,19 +73,34 @@ func Render(c *chart.Chart, config *chart.Config, opts Options) (map[string]stri
 		KubeVersion:   chartutil.DefaultKubeVersion,
 		TillerVersion: tversion.GetVersionProto(),
 	}
+	if opts.Capabilities != nil {
+		c := *opts.Capabilities
+		caps = &c
+	}
 
 	if opts.KubeVersion != "" {
 		kv, verErr := semver.NewVersion(opts.KubeVersion)
 		if verErr != nil {
 			return nil, fmt.Errorf("could not parse a kubernetes version: %v", verErr)
 		}
-		caps.KubeVersion.Major = fmt.Sprint(kv.Major())
-		caps.KubeVersion.Minor = fmt.Sprint(kv.Minor())
-		caps.KubeVersion.GitVersion = fmt.Sprintf("v%d.%d.0", kv.Major(), kv.Minor())
+		// copy, so that neither the defaults nor opts.Capabilities are modified
+		info := *caps.KubeVersion
+		info.Major = fmt.Sprint(kv.Major())
+		info.Minor = fmt.Sprint(kv.Minor())
+		info.GitVersion = fmt.Sprintf("v%d.%d.0", kv.Major(), kv.Minor())
+		caps.KubeVersion = &info
 	}
 
 	if len(opts.APIVersions) > 0 {
-		caps.APIVersions = chartutil.NewVersionSet(append(opts.APIVersions, "v1")...)
+		if opts.Capabilities != nil {
+			vs := chartutil.NewVersionSet(opts.APIVersions...)
+			for v := range caps.APIVersions {
+				vs[v] = struct{}{}
+			}
+			caps.APIVersions = vs
+		} else {
+			caps.APIVersions = chartutil.NewVersionSet(append(opts.APIVersions, "v1")...)
+		}
 	}
 
 	vals, err := chartutil.ToRenderValuesCaps(c, config, opts.ReleaseOptions, caps)
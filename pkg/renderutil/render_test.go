@@ -151,3 +151,62 @@ data:
 		})
 	}
 }
+
+const capsTemplate = `kube: {{ .Capabilities.KubeVersion.GitVersion }}
+{{- if .Capabilities.APIVersions.Has "example.com/v1/Widget" }}
+widget: true
+{{- end }}
+{{- if .Capabilities.APIVersions.Has "extra/v1" }}
+extra: true
+{{- end }}
+`
+
+func TestRenderCapabilities(t *testing.T) {
+	testChart := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "hello"},
+		Templates: []*chart.Template{
+			{Name: "templates/caps.yaml", Data: []byte(capsTemplate)},
+		},
+	}
+	caps, err := chartutil.ReadCapabilities([]byte(`kubeVersion:
+  major: "1"
+  minor: "16"
+  gitVersion: v1.16.2
+apiVersions:
+- v1
+- example.com/v1
+- example.com/v1/Widget
+`), nil)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		opts Options
+		want string
+	}{
+		"Defaults": {
+			opts: Options{},
+			want: "kube: v1.14.0\n",
+		},
+		"Capabilities": {
+			opts: Options{Capabilities: caps},
+			want: "kube: v1.16.2\nwidget: true\n",
+		},
+		"CapabilitiesWithOverrides": {
+			opts: Options{Capabilities: caps, KubeVersion: "1.17", APIVersions: []string{"extra/v1"}},
+			want: "kube: v1.17.0\nwidget: true\nextra: true\n",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			got, err := Render(testChart, &chart.Config{Raw: "{}"}, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got["hello/templates/caps.yaml"])
+		})
+	}
+
+	// overrides must not leak into the capabilities or the defaults
+	require.Equal(t, "v1.16.2", caps.KubeVersion.GitVersion)
+	require.False(t, caps.APIVersions.Has("extra/v1"))
+	require.Equal(t, "v1.14.0", chartutil.DefaultKubeVersion.GitVersion)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// Node is a chart in the dependency graph of a chart.
+type Node struct {
+	// Name is the name of the chart.
+	Name string
+	// Version is the version of the chart in the charts/ directory, or the
+	// locked version if the chart has not been downloaded.
+	Version string
+	// Repository is the repository the chart is required from.
+	Repository string
+	// Digest is the digest the chart is locked with.
+	Digest string
+	// Constraint is the version constraint the parent requires the chart
+	// with. It is empty for charts that were added to charts/ by hand.
+	Constraint string
+	// Missing is set when the chart is required, but not in charts/.
+	Missing bool
+	// Dependencies are the charts this chart depends on.
+	Dependencies []*Node
+}
+
+// Graph returns the dependency graph of a loaded chart, following the
+// requirements of every chart in its charts/ directory.
+func Graph(c *chart.Chart) (*Node, error) {
+	n := &Node{Name: c.Metadata.Name, Version: c.Metadata.Version}
+	if err := graph(n, c); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func graph(n *Node, c *chart.Chart) error {
+	subcharts := map[string]*chart.Chart{}
+	for _, sc := range c.Dependencies {
+		subcharts[sc.Metadata.Name] = sc
+	}
+
+	reqs, err := chartutil.LoadRequirements(c)
+	if err != nil && err != chartutil.ErrRequirementsNotFound {
+		return fmt.Errorf("%s: %s", n.Name, err)
+	}
+	locked := map[string]*chartutil.Dependency{}
+	if lock, err := chartutil.LoadRequirementsLock(c); err == nil {
+		for _, d := range lock.Dependencies {
+			locked[d.Name] = d
+		}
+	}
+
+	required := map[string]bool{}
+	if reqs != nil {
+		for _, r := range reqs.Dependencies {
+			required[r.Name] = true
+			child := &Node{Name: r.Name, Repository: r.Repository, Constraint: r.Version}
+			if l, ok := locked[r.Name]; ok {
+				child.Version = l.Version
+				child.Digest = l.Digest
+			}
+			sc, ok := subcharts[r.Name]
+			if !ok {
+				child.Missing = true
+				n.Dependencies = append(n.Dependencies, child)
+				continue
+			}
+			child.Version = sc.Metadata.Version
+			if err := graph(child, sc); err != nil {
+				return err
+			}
+			n.Dependencies = append(n.Dependencies, child)
+		}
+	}
+
+	// charts vendored without a requirement
+	for _, sc := range c.Dependencies {
+		if required[sc.Metadata.Name] {
+			continue
+		}
+		child := &Node{Name: sc.Metadata.Name, Version: sc.Metadata.Version}
+		if err := graph(child, sc); err != nil {
+			return err
+		}
+		n.Dependencies = append(n.Dependencies, child)
+	}
+	return nil
+}
+
+// requirement is a chart in the graph, along with the path of the chart
+// requiring it.
+type requirement struct {
+	parent string
+	*Node
+}
+
+func (r requirement) String() string {
+	if r.Constraint == "" {
+		return fmt.Sprintf("%s vendors %s", r.parent, r.Version)
+	}
+	return fmt.Sprintf("%s requires %s (%s)", r.parent, r.Constraint, r.Version)
+}
+
+// Conflicts returns the charts that appear more than once in the graph at
+// versions that do not satisfy the constraints of every chart requiring
+// them. Each conflict lists all the places requiring the chart, e.g.
+//
+//	common: mychart requires ~1.2 (1.2.3), mychart/redis requires ^2.0 (2.1.0)
+func (n *Node) Conflicts() []string {
+	byName := map[string][]requirement{}
+	var walk func(path string, n *Node)
+	walk = func(path string, n *Node) {
+		for _, d := range n.Dependencies {
+			if !d.Missing {
+				byName[d.Name] = append(byName[d.Name], requirement{path, d})
+			}
+			walk(path+"/"+d.Name, d)
+		}
+	}
+	walk(n.Name, n)
+
+	var conflicts []string
+	for name, reqs := range byName {
+		if !conflicting(reqs) {
+			continue
+		}
+		s := make([]string, len(reqs))
+		for i, r := range reqs {
+			s[i] = r.String()
+		}
+		conflicts = append(conflicts, fmt.Sprintf("%s: %s", name, strings.Join(s, ", ")))
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// conflicting reports whether one of reqs is at a version another rejects.
+func conflicting(reqs []requirement) bool {
+	for _, a := range reqs {
+		if a.Constraint == "" {
+			continue
+		}
+		c, err := semver.NewConstraint(a.Constraint)
+		if err != nil {
+			continue
+		}
+		for _, b := range reqs {
+			if a.Version == b.Version {
+				continue
+			}
+			v, err := semver.NewVersion(b.Version)
+			if err != nil || !c.Check(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+func TestGraph(t *testing.T) {
+	c, err := chartutil.Load("testdata/graph")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := Graph(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Name != "mychart" || g.Version != "0.1.0" {
+		t.Errorf("unexpected root %s %s", g.Name, g.Version)
+	}
+	if len(g.Dependencies) != 4 {
+		t.Fatalf("expected 4 dependencies, got %d", len(g.Dependencies))
+	}
+
+	common := g.Dependencies[0]
+	if common.Name != "common" || common.Version != "1.2.3" || common.Constraint != "~1.2.0" {
+		t.Errorf("unexpected common dependency %+v", common)
+	}
+	if common.Digest != "sha256:e70e41f8922e19558a8bf62f591a8b70c8e4622e3c03e5415f09aba881f13885" {
+		t.Errorf("expected the locked digest, got %q", common.Digest)
+	}
+
+	redis := g.Dependencies[1]
+	if len(redis.Dependencies) != 1 || redis.Dependencies[0].Version != "2.0.0" {
+		t.Errorf("expected redis to depend on common 2.0.0, got %+v", redis.Dependencies)
+	}
+
+	if postgresql := g.Dependencies[2]; !postgresql.Missing || postgresql.Version != "1.0.0" {
+		t.Errorf("expected postgresql to be missing at the locked version, got %+v", postgresql)
+	}
+	if vendored := g.Dependencies[3]; vendored.Name != "vendored" || vendored.Constraint != "" {
+		t.Errorf("expected a vendored chart without a constraint, got %+v", vendored)
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	c, err := chartutil.Load("testdata/graph")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := Graph(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conflicts := g.Conflicts()
+	expect := "common: mychart requires ~1.2.0 (1.2.3), mychart/redis requires ^2.0.0 (2.0.0)"
+	if len(conflicts) != 1 || conflicts[0] != expect {
+		t.Errorf("expected %q, got %q", expect, conflicts)
+	}
+
+	// the same chart at compatible versions is no conflict
+	g.Dependencies[0].Constraint = ">=1.2.0"
+	g.Dependencies[1].Dependencies[0].Constraint = ">=1.0.0"
+	if conflicts := g.Conflicts(); len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %q", conflicts)
+	}
+}
@@ -120,6 +120,7 @@ func (r *Resolver) Resolve(reqs *chartutil.Requirements, repoNames map[string]st
 			if constraint.Check(v) {
 				found = true
 				locked[i].Version = v.Original()
+				locked[i].Digest = lockDigest(ver.Digest)
 				break
 			}
 		}
@@ -138,6 +139,15 @@ func (r *Resolver) Resolve(reqs *chartutil.Requirements, repoNames map[string]st
 	}, nil
 }
 
+// lockDigest returns the digest of an index entry in the form used by lock
+// files. Indexes generated by Helm store the bare SHA256 sum.
+func lockDigest(d string) string {
+	if d == "" || strings.HasPrefix(d, "sha256:") {
+		return d
+	}
+	return "sha256:" + d
+}
+
 // HashReq generates a hash of the requirements.
 //
 // This should be used only to compare against another hash generated by this
@@ -64,7 +64,7 @@ func TestResolve(t *testing.T) {
 			},
 			expect: &chartutil.RequirementsLock{
 				Dependencies: []*chartutil.Dependency{
-					{Name: "alpine", Repository: "http://example.com", Version: "0.2.0"},
+					{Name: "alpine", Repository: "http://example.com", Version: "0.2.0", Digest: "sha256:515c58e5f79d8b2913a10cb400ebb6fa9c77fe813287afbacf1a0b897cd78727"},
 				},
 			},
 		},
@@ -160,6 +160,9 @@ func TestResolve(t *testing.T) {
 		if d0.Version != e0.Version {
 			t.Errorf("%s: expected version %s, got %s", tt.name, e0.Version, d0.Version)
 		}
+		if d0.Digest != e0.Digest {
+			t.Errorf("%s: expected digest %q, got %q", tt.name, e0.Digest, d0.Digest)
+		}
 	}
 }
 
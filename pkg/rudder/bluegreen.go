@@ -0,0 +1,234 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rudder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+const (
+	// StrategyBlueGreen names the BlueGreen strategy.
+	StrategyBlueGreen = "blue-green"
+	// TrackPreview is the TrackLabel of the pods of the new revision run by
+	// the BlueGreen strategy.
+	TrackPreview = "preview"
+)
+
+// BlueGreen runs all pods of the new revision of each Deployment of a
+// release in preview Deployments next to the old revision. Once all previews
+// are ready, the selectors of the Services of their pods are switched to the
+// previews at once. The new revision is then applied while the Services stay
+// on the previews, and they are switched back to the updated Deployments
+// when these are ready. If a preview does not become ready in time, the
+// previews are deleted and the release is left as it was; if applying the
+// new revision fails, the Services are switched back to their selectors
+// from before the switch.
+type BlueGreen struct{}
+
+// switchedService is a Service whose selector was switched to previews.
+type switchedService struct {
+	service *v1.Service
+	// live is the selector of the Service before the switch.
+	live map[string]string
+	// target is the selector of the Service in the new revision.
+	target map[string]string
+}
+
+// Rollout rolls out target with a switch of Service selectors.
+func (BlueGreen) Rollout(ctx context.Context, c *Cluster, current, target *release.Release, opts kube.UpdateOptions) error {
+	deployments, services, err := rolledDeployments(current, target)
+	if err != nil {
+		return err
+	}
+
+	var previews []*appsv1.Deployment
+	for _, d := range deployments {
+		previews = append(previews, trackDeployment(d, TrackPreview, replicas(d)))
+	}
+	err = createDeployments(ctx, c, previews)
+	if err == nil {
+		err = waitReady(ctx, c, previews, opts.Timeout)
+	}
+	if err != nil {
+		deleteDeployments(ctx, c, previews)
+		return fmt.Errorf("blue/green rollout of release %s aborted: %s", target.Name, err)
+	}
+
+	switched, err := switchServices(ctx, c, target.Namespace, services, deployments)
+	if err != nil {
+		restoreServices(ctx, c, switched, liveSelector)
+		deleteDeployments(ctx, c, previews)
+		return fmt.Errorf("blue/green rollout of release %s aborted: %s", target.Name, err)
+	}
+
+	// Apply the Services of the manifest with the switched selectors, so
+	// that no traffic goes to the updated Deployments before they are
+	// ready.
+	manifest, err := switchedManifest(target.Manifest, switched)
+	if err == nil {
+		opts.ShouldWait = true
+		err = c.apply(current, target, manifest, opts)
+	}
+	if err != nil {
+		// The updated Deployments may not be ready, back to the old revision
+		// as far as it is left.
+		restoreServices(ctx, c, switched, liveSelector)
+		deleteDeployments(ctx, c, previews)
+		return err
+	}
+
+	c.log("switching services of release %s back from the previews", target.Name)
+	err = restoreServices(ctx, c, switched, targetSelector)
+	if derr := deleteDeployments(ctx, c, previews); err == nil {
+		err = derr
+	}
+	return err
+}
+
+// switchServices switches each Service of the manifest that selects the pods
+// of one of the Deployments to the previews, with the selector of the new
+// revision and the TrackLabel of previews.
+func switchServices(ctx context.Context, c *Cluster, namespace string, services map[string]*v1.Service, deployments []*appsv1.Deployment) ([]switchedService, error) {
+	var names []string
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var switched []switchedService
+	for _, name := range names {
+		s := services[name]
+		if !selectsAny(s.Spec.Selector, deployments) {
+			continue
+		}
+		ns := s.Namespace
+		if ns == "" {
+			ns = namespace
+		}
+		live, err := c.Clientset.CoreV1().Services(ns).Get(ctx, s.Name, metav1.GetOptions{})
+		if err != nil {
+			return switched, err
+		}
+		selector := live.Spec.Selector
+		live.Spec.Selector = withTrack(s.Spec.Selector, TrackPreview)
+		c.log("switching service %s to the previews", s.Name)
+		updated, err := c.Clientset.CoreV1().Services(ns).Update(ctx, live, metav1.UpdateOptions{})
+		if err != nil {
+			return switched, err
+		}
+		switched = append(switched, switchedService{service: updated, live: selector, target: s.Spec.Selector})
+	}
+	return switched, nil
+}
+
+func liveSelector(s switchedService) map[string]string   { return s.live }
+func targetSelector(s switchedService) map[string]string { return s.target }
+
+// restoreServices switches the switched Services back from the previews to
+// the selector chosen by selector: that of the live Service before the
+// switch when the rollout is aborted, or that of the new revision once it is
+// applied. It returns the first error.
+func restoreServices(ctx context.Context, c *Cluster, switched []switchedService, selector func(switchedService) map[string]string) error {
+	var first error
+	for _, s := range switched {
+		client := c.Clientset.CoreV1().Services(s.service.Namespace)
+		live, err := client.Get(ctx, s.service.Name, metav1.GetOptions{})
+		if err == nil {
+			live.Spec.Selector = selector(s)
+			_, err = client.Update(ctx, live, metav1.UpdateOptions{})
+		}
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// selectsAny returns whether the selector selects the pods of one of the
+// Deployments.
+func selectsAny(selector map[string]string, deployments []*appsv1.Deployment) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	for _, d := range deployments {
+		matches := true
+		for k, v := range selector {
+			if d.Spec.Template.Labels[k] != v {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// switchedManifest returns the manifest with the selectors of the switched
+// Services replaced by their switched selectors.
+func switchedManifest(manifest string, switched []switchedService) (string, error) {
+	if len(switched) == 0 {
+		return manifest, nil
+	}
+	byName := map[string]switchedService{}
+	for _, s := range switched {
+		byName[s.service.Name] = s
+	}
+
+	var docs []string
+	for _, m := range releaseutil.SplitManifestDocs(manifest) {
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(m), &head); err != nil {
+			return "", err
+		}
+		if head.Version != "v1" || head.Kind != "Service" || head.Metadata == nil {
+			docs = append(docs, m)
+			continue
+		}
+		s, ok := byName[head.Metadata.Name]
+		if !ok {
+			docs = append(docs, m)
+			continue
+		}
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(m), &obj); err != nil {
+			return "", err
+		}
+		if spec, ok := obj["spec"].(map[string]interface{}); ok {
+			spec["selector"] = s.service.Spec.Selector
+		}
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", err
+		}
+		docs = append(docs, string(out))
+	}
+	return strings.Join(docs, "\n---\n"), nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rudder
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+const (
+	// StrategyCanary names the Canary strategy.
+	StrategyCanary = "canary"
+	// CanaryWeightAnnotation is the annotation of a chart that sets the
+	// percentage of the replicas of a Deployment run as canaries, 10 unless
+	// set.
+	CanaryWeightAnnotation = "helm.sh/canary-weight"
+	// TrackCanary is the TrackLabel of canary pods.
+	TrackCanary = "canary"
+)
+
+// Canary runs the pods of the new revision of each Deployment of a release
+// next to those of the old revision first. The canary Deployments have the
+// weight of the replicas of the new revision, and the pods of the same
+// labels apart from the TrackLabel, so that Services send them a share of
+// the traffic by replica ratio. Once all canaries are ready, the new revision
+// is applied and the canaries are deleted. If a canary does not become ready
+// in time, the canaries are deleted and the release is left as it was.
+type Canary struct{}
+
+// Rollout rolls out target with canaries.
+func (Canary) Rollout(ctx context.Context, c *Cluster, current, target *release.Release, opts kube.UpdateOptions) error {
+	weight, err := canaryWeight(target)
+	if err != nil {
+		return err
+	}
+	deployments, _, err := rolledDeployments(current, target)
+	if err != nil {
+		return err
+	}
+
+	var canaries []*appsv1.Deployment
+	for _, d := range deployments {
+		// Round up so that every Deployment has a canary.
+		n := (replicas(d)*weight + 99) / 100
+		if n < 1 {
+			n = 1
+		}
+		canaries = append(canaries, trackDeployment(d, TrackCanary, n))
+	}
+
+	err = createDeployments(ctx, c, canaries)
+	if err == nil {
+		err = waitReady(ctx, c, canaries, opts.Timeout)
+	}
+	if err != nil {
+		deleteDeployments(ctx, c, canaries)
+		return fmt.Errorf("canary of release %s aborted: %s", target.Name, err)
+	}
+
+	c.log("promoting canaries of release %s", target.Name)
+	err = c.apply(current, target, target.Manifest, opts)
+	if derr := deleteDeployments(ctx, c, canaries); err == nil {
+		err = derr
+	}
+	return err
+}
+
+func canaryWeight(rel *release.Release) (int32, error) {
+	w, ok := rel.GetChart().GetMetadata().GetAnnotations()[CanaryWeightAnnotation]
+	if !ok {
+		return 10, nil
+	}
+	weight, err := strconv.Atoi(w)
+	if err != nil || weight < 1 || weight > 100 {
+		return 0, fmt.Errorf("annotation %s must be a percentage from 1 to 100, got %q", CanaryWeightAnnotation, w)
+	}
+	return int32(weight), nil
+}
@@ -17,12 +17,17 @@ limitations under the License.
 package rudder // import "k8s.io/helm/pkg/rudder"
 
 import (
+	"crypto/tls"
 	"fmt"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 
+	"k8s.io/helm/pkg/logging"
 	rudderAPI "k8s.io/helm/pkg/proto/hapi/rudder"
+	"k8s.io/helm/pkg/tracing"
 )
 
 // GrpcPort specifies port on which rudder will spawn a server
@@ -32,60 +37,80 @@ const (
 
 var grpcAddr = fmt.Sprintf("127.0.0.1:%d", GrpcPort)
 
+// TLSConfig is the client TLS configuration of the calls to Rudder. Calls are
+// made without TLS if it is nil.
+var TLSConfig *tls.Config
+
+// dial connects to Rudder.
+func dial() (*grpc.ClientConn, error) {
+	if TLSConfig != nil {
+		return grpc.Dial(grpcAddr, grpc.WithTransportCredentials(credentials.NewTLS(TLSConfig)))
+	}
+	return grpc.Dial(grpcAddr, grpc.WithInsecure())
+}
+
+// outgoing returns the context of a call to Rudder, passing on the trace
+// context and request ID of ctx.
+func outgoing(ctx context.Context) context.Context {
+	md := metadata.MD{}
+	tracing.Inject(ctx, md)
+	logging.InjectRequestID(ctx, md)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
 // InstallRelease calls Rudder InstallRelease method which should create provided release
-func InstallRelease(rel *rudderAPI.InstallReleaseRequest) (*rudderAPI.InstallReleaseResponse, error) {
-	//TODO(mkwiek): parametrize this
-	conn, err := grpc.Dial(grpcAddr, grpc.WithInsecure())
+func InstallRelease(ctx context.Context, rel *rudderAPI.InstallReleaseRequest) (*rudderAPI.InstallReleaseResponse, error) {
+	conn, err := dial()
 	if err != nil {
 		return nil, err
 	}
 
 	defer conn.Close()
 	client := rudderAPI.NewReleaseModuleServiceClient(conn)
-	return client.InstallRelease(context.Background(), rel)
+	return client.InstallRelease(outgoing(ctx), rel)
 }
 
 // UpgradeRelease calls Rudder UpgradeRelease method which should perform update
-func UpgradeRelease(req *rudderAPI.UpgradeReleaseRequest) (*rudderAPI.UpgradeReleaseResponse, error) {
-	conn, err := grpc.Dial(grpcAddr, grpc.WithInsecure())
+func UpgradeRelease(ctx context.Context, req *rudderAPI.UpgradeReleaseRequest) (*rudderAPI.UpgradeReleaseResponse, error) {
+	conn, err := dial()
 	if err != nil {
 		return nil, err
 	}
 	defer conn.Close()
 	client := rudderAPI.NewReleaseModuleServiceClient(conn)
-	return client.UpgradeRelease(context.Background(), req)
+	return client.UpgradeRelease(outgoing(ctx), req)
 }
 
 // RollbackRelease calls Rudder RollbackRelease method which should perform update
-func RollbackRelease(req *rudderAPI.RollbackReleaseRequest) (*rudderAPI.RollbackReleaseResponse, error) {
-	conn, err := grpc.Dial(grpcAddr, grpc.WithInsecure())
+func RollbackRelease(ctx context.Context, req *rudderAPI.RollbackReleaseRequest) (*rudderAPI.RollbackReleaseResponse, error) {
+	conn, err := dial()
 	if err != nil {
 		return nil, err
 	}
 	defer conn.Close()
 	client := rudderAPI.NewReleaseModuleServiceClient(conn)
-	return client.RollbackRelease(context.Background(), req)
+	return client.RollbackRelease(outgoing(ctx), req)
 }
 
 // ReleaseStatus calls Rudder ReleaseStatus method which should perform update
-func ReleaseStatus(req *rudderAPI.ReleaseStatusRequest) (*rudderAPI.ReleaseStatusResponse, error) {
-	conn, err := grpc.Dial(grpcAddr, grpc.WithInsecure())
+func ReleaseStatus(ctx context.Context, req *rudderAPI.ReleaseStatusRequest) (*rudderAPI.ReleaseStatusResponse, error) {
+	conn, err := dial()
 	if err != nil {
 		return nil, err
 	}
 	defer conn.Close()
 	client := rudderAPI.NewReleaseModuleServiceClient(conn)
-	return client.ReleaseStatus(context.Background(), req)
+	return client.ReleaseStatus(outgoing(ctx), req)
 }
 
 // DeleteRelease calls Rudder DeleteRelease method which should uninstall provided release
-func DeleteRelease(rel *rudderAPI.DeleteReleaseRequest) (*rudderAPI.DeleteReleaseResponse, error) {
-	conn, err := grpc.Dial(grpcAddr, grpc.WithInsecure())
+func DeleteRelease(ctx context.Context, rel *rudderAPI.DeleteReleaseRequest) (*rudderAPI.DeleteReleaseResponse, error) {
+	conn, err := dial()
 	if err != nil {
 		return nil, err
 	}
 
 	defer conn.Close()
 	client := rudderAPI.NewReleaseModuleServiceClient(conn)
-	return client.DeleteRelease(context.Background(), rel)
+	return client.DeleteRelease(outgoing(ctx), rel)
 }
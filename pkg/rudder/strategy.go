@@ -0,0 +1,309 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rudder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+const (
+	// StrategyAnnotation is the annotation of a chart that names the
+	// strategy Rudder rolls out upgrades and rollbacks of its releases with.
+	StrategyAnnotation = "helm.sh/rollout-strategy"
+	// TrackLabel is added to the selector and the pods of the Deployments
+	// that strategies run next to those of the release.
+	TrackLabel = "helm.sh/rollout-track"
+
+	// StrategyDefault applies the manifest of the new revision, like Tiller.
+	StrategyDefault = "default"
+
+	// defaultTimeout is the time in seconds Deployments are given to become
+	// ready if the operation has no timeout.
+	defaultTimeout = 300
+)
+
+// Applier applies manifests to the cluster. *kube.Client is an Applier.
+type Applier interface {
+	UpdateWithOptions(namespace string, originalReader, modifiedReader io.Reader, opts kube.UpdateOptions) error
+}
+
+// Cluster is what strategies operate on.
+type Cluster struct {
+	// Clientset reads and writes the Deployments and Services of releases.
+	Clientset kubernetes.Interface
+	// Applier applies the manifests of releases.
+	Applier Applier
+	// PollInterval is how often the readiness of Deployments is checked.
+	// Defaults to two seconds.
+	PollInterval time.Duration
+	Log          func(string, ...interface{})
+}
+
+func (c *Cluster) log(format string, v ...interface{}) {
+	if c.Log != nil {
+		c.Log(format, v...)
+	}
+}
+
+// apply applies the manifest of target over that of current.
+func (c *Cluster) apply(current, target *release.Release, manifest string, opts kube.UpdateOptions) error {
+	return c.Applier.UpdateWithOptions(target.Namespace, bytes.NewBufferString(current.Manifest), bytes.NewBufferString(manifest), opts)
+}
+
+// Strategy rolls out the revision of a release on upgrades and rollbacks.
+type Strategy interface {
+	// Rollout moves the resources of the release from the manifest of
+	// current to that of target. The resources are left as in current if
+	// the rollout is aborted.
+	Rollout(ctx context.Context, c *Cluster, current, target *release.Release, opts kube.UpdateOptions) error
+}
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]Strategy{
+		StrategyDefault:   defaultStrategy{},
+		StrategyCanary:    Canary{},
+		StrategyBlueGreen: BlueGreen{},
+	}
+)
+
+// RegisterStrategy makes a strategy available to charts under name,
+// replacing any strategy of that name.
+func RegisterStrategy(name string, s Strategy) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	strategies[name] = s
+}
+
+// StrategyFor returns the strategy named by the StrategyAnnotation of the
+// chart, or the default strategy if it has none.
+func StrategyFor(ch *chart.Chart) (Strategy, error) {
+	name := ch.GetMetadata().GetAnnotations()[StrategyAnnotation]
+	if name == "" {
+		name = StrategyDefault
+	}
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	s, ok := strategies[name]
+	if !ok {
+		var names []string
+		for n := range strategies {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown rollout strategy %q, one of %s", name, strings.Join(names, ", "))
+	}
+	return s, nil
+}
+
+// defaultStrategy applies the manifest of the target revision.
+type defaultStrategy struct{}
+
+func (defaultStrategy) Rollout(ctx context.Context, c *Cluster, current, target *release.Release, opts kube.UpdateOptions) error {
+	return c.apply(current, target, target.Manifest, opts)
+}
+
+// manifestObjects returns the Deployments of apps/v1 and the Services of the
+// manifest, keyed by name.
+func manifestObjects(manifest string) (map[string]*appsv1.Deployment, map[string]*v1.Service, error) {
+	deployments := map[string]*appsv1.Deployment{}
+	services := map[string]*v1.Service{}
+	for _, m := range releaseutil.SplitManifestDocs(manifest) {
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(m), &head); err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case head.Version == "apps/v1" && head.Kind == "Deployment":
+			d := &appsv1.Deployment{}
+			if err := yaml.Unmarshal([]byte(m), d); err != nil {
+				return nil, nil, err
+			}
+			deployments[d.Name] = d
+		case head.Version == "v1" && head.Kind == "Service":
+			s := &v1.Service{}
+			if err := yaml.Unmarshal([]byte(m), s); err != nil {
+				return nil, nil, err
+			}
+			services[s.Name] = s
+		}
+	}
+	return deployments, services, nil
+}
+
+// rolledDeployments returns the Deployments of target that are also in
+// current, sorted by name. Deployments new in target have no pods to roll
+// out next to.
+func rolledDeployments(current, target *release.Release) ([]*appsv1.Deployment, map[string]*v1.Service, error) {
+	old, _, err := manifestObjects(current.Manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	deployments, services, err := manifestObjects(target.Manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rolled []*appsv1.Deployment
+	for name, d := range deployments {
+		if _, ok := old[name]; ok {
+			if d.Namespace == "" {
+				d.Namespace = target.Namespace
+			}
+			rolled = append(rolled, d)
+		}
+	}
+	sort.Slice(rolled, func(i, j int) bool { return rolled[i].Name < rolled[j].Name })
+	return rolled, services, nil
+}
+
+func replicas(d *appsv1.Deployment) int32 {
+	if d.Spec.Replicas == nil {
+		return 1
+	}
+	return *d.Spec.Replicas
+}
+
+// trackDeployment returns a copy of d with the given number of replicas,
+// named after d and the track, whose selector and pods have the TrackLabel
+// set to track so that they are not taken for those of d.
+func trackDeployment(d *appsv1.Deployment, track string, n int32) *appsv1.Deployment {
+	t := d.DeepCopy()
+	t.ObjectMeta = metav1.ObjectMeta{
+		Name:        d.Name + "-" + track,
+		Namespace:   d.Namespace,
+		Labels:      withTrack(d.Labels, track),
+		Annotations: d.Annotations,
+	}
+	t.Spec.Replicas = &n
+	if t.Spec.Selector == nil {
+		t.Spec.Selector = &metav1.LabelSelector{}
+	}
+	t.Spec.Selector.MatchLabels = withTrack(t.Spec.Selector.MatchLabels, track)
+	t.Spec.Template.Labels = withTrack(t.Spec.Template.Labels, track)
+	return t
+}
+
+func withTrack(labels map[string]string, track string) map[string]string {
+	l := map[string]string{}
+	for k, v := range labels {
+		l[k] = v
+	}
+	l[TrackLabel] = track
+	return l
+}
+
+// createDeployments creates the Deployments, replacing any left over by an
+// earlier rollout.
+func createDeployments(ctx context.Context, c *Cluster, deployments []*appsv1.Deployment) error {
+	for _, d := range deployments {
+		client := c.Clientset.AppsV1().Deployments(d.Namespace)
+		if err := client.Delete(ctx, d.Name, deleteOptions()); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		c.log("creating deployment %s", d.Name)
+		if _, err := client.Create(ctx, d, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteDeployments deletes the Deployments with their pods, and returns the
+// first error.
+func deleteDeployments(ctx context.Context, c *Cluster, deployments []*appsv1.Deployment) error {
+	var first error
+	for _, d := range deployments {
+		c.log("deleting deployment %s", d.Name)
+		err := c.Clientset.AppsV1().Deployments(d.Namespace).Delete(ctx, d.Name, deleteOptions())
+		if err != nil && !apierrors.IsNotFound(err) && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+func deleteOptions() metav1.DeleteOptions {
+	background := metav1.DeletePropagationBackground
+	return metav1.DeleteOptions{PropagationPolicy: &background}
+}
+
+// waitReady waits until all pods of the Deployments are ready. It fails if
+// a Deployment exceeds its progress deadline, or the timeout in seconds
+// passes.
+func waitReady(ctx context.Context, c *Cluster, deployments []*appsv1.Deployment, timeout int64) error {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+
+	for _, d := range deployments {
+		for {
+			ready, err := deploymentReady(ctx, c, d)
+			if err != nil {
+				return err
+			}
+			if ready {
+				break
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("deployment %s not ready after %ds", d.Name, timeout)
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+	}
+	return nil
+}
+
+func deploymentReady(ctx context.Context, c *Cluster, d *appsv1.Deployment) (bool, error) {
+	live, err := c.Clientset.AppsV1().Deployments(d.Namespace).Get(ctx, d.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, cond := range live.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Status == v1.ConditionFalse {
+			return false, fmt.Errorf("deployment %s failed: %s", d.Name, cond.Message)
+		}
+	}
+	return live.Status.ObservedGeneration >= live.Generation && live.Status.ReadyReplicas >= replicas(live), nil
+}
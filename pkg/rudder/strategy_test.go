@@ -0,0 +1,320 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rudder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+const manifestTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 4
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: web:IMAGE
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  selector:
+    app: web
+  ports:
+  - port: 80
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web
+data:
+  image: IMAGE
+`
+
+// fakeApplier records the manifests applied, and the selector of the web
+// Service when they are.
+type fakeApplier struct {
+	clientset *fake.Clientset
+	err       error
+	manifests []string
+	selectors []map[string]string
+}
+
+func (a *fakeApplier) UpdateWithOptions(namespace string, originalReader, modifiedReader io.Reader, opts kube.UpdateOptions) error {
+	b, err := ioutil.ReadAll(modifiedReader)
+	if err != nil {
+		return err
+	}
+	a.manifests = append(a.manifests, string(b))
+	if s, err := a.clientset.CoreV1().Services(namespace).Get(context.TODO(), "web", metav1.GetOptions{}); err == nil {
+		a.selectors = append(a.selectors, s.Spec.Selector)
+	}
+	return a.err
+}
+
+func rolloutRelease(version int32, image string, annotations map[string]string) *release.Release {
+	return &release.Release{
+		Name:      "web",
+		Namespace: "default",
+		Version:   version,
+		Chart:     &chart.Chart{Metadata: &chart.Metadata{Name: "web", Annotations: annotations}},
+		Manifest:  strings.Replace(manifestTemplate, "IMAGE", image, -1),
+	}
+}
+
+// rolloutCluster returns a cluster with the Deployment and Service of the
+// first revision, whose Deployments become ready when created unless
+// failure is set.
+func rolloutCluster(failure string) (*Cluster, *fake.Clientset, *fakeApplier) {
+	four := int32(4)
+	cs := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &four},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 4},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "web"}},
+		},
+	)
+	cs.PrependReactor("create", "deployments", func(action ktesting.Action) (bool, runtime.Object, error) {
+		d := action.(ktesting.CreateAction).GetObject().(*appsv1.Deployment)
+		if failure != "" {
+			d.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Message: failure}}
+		} else {
+			d.Status.ReadyReplicas = *d.Spec.Replicas
+		}
+		return false, nil, nil
+	})
+	applier := &fakeApplier{clientset: cs}
+	return &Cluster{Clientset: cs, Applier: applier, PollInterval: time.Millisecond}, cs, applier
+}
+
+// createdDeployments returns the Deployments created in the cluster.
+func createdDeployments(cs *fake.Clientset) []*appsv1.Deployment {
+	var created []*appsv1.Deployment
+	for _, a := range cs.Actions() {
+		if c, ok := a.(ktesting.CreateAction); ok && a.GetResource().Resource == "deployments" {
+			created = append(created, c.GetObject().(*appsv1.Deployment))
+		}
+	}
+	return created
+}
+
+func TestStrategyFor(t *testing.T) {
+	for _, tt := range []struct {
+		annotations map[string]string
+		expect      Strategy
+		err         string
+	}{
+		{nil, defaultStrategy{}, ""},
+		{map[string]string{StrategyAnnotation: "canary"}, Canary{}, ""},
+		{map[string]string{StrategyAnnotation: "blue-green"}, BlueGreen{}, ""},
+		{map[string]string{StrategyAnnotation: "shadow"}, nil, `unknown rollout strategy "shadow", one of blue-green, canary, default`},
+	} {
+		s, err := StrategyFor(&chart.Chart{Metadata: &chart.Metadata{Annotations: tt.annotations}})
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("expected error %q, got %v", tt.err, err)
+			}
+			continue
+		}
+		if err != nil || s != tt.expect {
+			t.Errorf("expected %T for %v, got %T, %v", tt.expect, tt.annotations, s, err)
+		}
+	}
+}
+
+func TestCanary(t *testing.T) {
+	c, cs, applier := rolloutCluster("")
+	current := rolloutRelease(1, "1.0", nil)
+	target := rolloutRelease(2, "2.0", map[string]string{StrategyAnnotation: StrategyCanary, CanaryWeightAnnotation: "25"})
+
+	if err := (Canary{}).Rollout(context.TODO(), c, current, target, kube.UpdateOptions{Timeout: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	created := createdDeployments(cs)
+	if len(created) != 1 {
+		t.Fatalf("expected a canary deployment, got %v", created)
+	}
+	canary := created[0]
+	if canary.Name != "web-canary" || *canary.Spec.Replicas != 1 {
+		t.Errorf("expected 1 replica of web-canary, got %d of %s", *canary.Spec.Replicas, canary.Name)
+	}
+	expectLabels := map[string]string{"app": "web", TrackLabel: TrackCanary}
+	if !reflect.DeepEqual(canary.Spec.Selector.MatchLabels, expectLabels) || !reflect.DeepEqual(canary.Spec.Template.Labels, expectLabels) {
+		t.Errorf("expected the canary to select %v, got %v", expectLabels, canary.Spec.Selector.MatchLabels)
+	}
+	if image := canary.Spec.Template.Spec.Containers[0].Image; image != "web:2.0" {
+		t.Errorf("expected the canary to run the new image, got %s", image)
+	}
+
+	if len(applier.manifests) != 1 || applier.manifests[0] != target.Manifest {
+		t.Errorf("expected the new revision to be applied, got %v", applier.manifests)
+	}
+	if _, err := cs.AppsV1().Deployments("default").Get(context.TODO(), "web-canary", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the canary to be deleted, got %v", err)
+	}
+}
+
+func TestCanary_Abort(t *testing.T) {
+	c, cs, applier := rolloutCluster("ImagePullBackOff")
+	current := rolloutRelease(1, "1.0", nil)
+	target := rolloutRelease(2, "2.0", map[string]string{StrategyAnnotation: StrategyCanary})
+
+	err := (Canary{}).Rollout(context.TODO(), c, current, target, kube.UpdateOptions{Timeout: 10})
+	if err == nil || err.Error() != "canary of release web aborted: deployment web-canary failed: ImagePullBackOff" {
+		t.Errorf("expected the canary to be aborted, got %v", err)
+	}
+	if len(applier.manifests) != 0 {
+		t.Errorf("expected nothing to be applied, got %v", applier.manifests)
+	}
+	if _, err := cs.AppsV1().Deployments("default").Get(context.TODO(), "web-canary", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the canary to be deleted, got %v", err)
+	}
+
+	target.Chart.Metadata.Annotations[CanaryWeightAnnotation] = "0"
+	if err := (Canary{}).Rollout(context.TODO(), c, current, target, kube.UpdateOptions{}); err == nil {
+		t.Error("expected an error for an invalid weight")
+	}
+}
+
+func TestBlueGreen(t *testing.T) {
+	c, cs, applier := rolloutCluster("")
+	current := rolloutRelease(1, "1.0", nil)
+	target := rolloutRelease(2, "2.0", map[string]string{StrategyAnnotation: StrategyBlueGreen})
+
+	if err := (BlueGreen{}).Rollout(context.TODO(), c, current, target, kube.UpdateOptions{Timeout: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	created := createdDeployments(cs)
+	if len(created) != 1 || created[0].Name != "web-preview" || *created[0].Spec.Replicas != 4 {
+		t.Fatalf("expected 4 replicas of web-preview, got %v", created)
+	}
+
+	// The Service selects the previews while the new revision is applied.
+	preview := map[string]string{"app": "web", TrackLabel: TrackPreview}
+	if len(applier.selectors) != 1 || !reflect.DeepEqual(applier.selectors[0], preview) {
+		t.Errorf("expected the service to select %v when applying, got %v", preview, applier.selectors)
+	}
+	if len(applier.manifests) != 1 || !strings.Contains(applier.manifests[0], TrackLabel+": "+TrackPreview) || !strings.Contains(applier.manifests[0], "image: web:2.0") {
+		t.Errorf("expected the new revision to be applied with the switched selector, got %v", applier.manifests)
+	}
+
+	s, err := cs.CoreV1().Services("default").Get(context.TODO(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := map[string]string{"app": "web"}; !reflect.DeepEqual(s.Spec.Selector, expect) {
+		t.Errorf("expected the service to be switched back to %v, got %v", expect, s.Spec.Selector)
+	}
+	if _, err := cs.AppsV1().Deployments("default").Get(context.TODO(), "web-preview", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the preview to be deleted, got %v", err)
+	}
+}
+
+func TestBlueGreen_FailedApply(t *testing.T) {
+	c, cs, applier := rolloutCluster("")
+	applier.err = errors.New("timed out waiting for the condition")
+	current := rolloutRelease(1, "1.0", nil)
+	target := rolloutRelease(2, "2.0", map[string]string{StrategyAnnotation: StrategyBlueGreen})
+
+	if err := (BlueGreen{}).Rollout(context.TODO(), c, current, target, kube.UpdateOptions{Timeout: 10}); err != applier.err {
+		t.Errorf("expected the error of the apply, got %v", err)
+	}
+	s, err := cs.CoreV1().Services("default").Get(context.TODO(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := map[string]string{"app": "web"}; !reflect.DeepEqual(s.Spec.Selector, expect) {
+		t.Errorf("expected the service to be switched back to %v, got %v", expect, s.Spec.Selector)
+	}
+}
+
+func TestBlueGreen_ChangedSelector(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		applyErr error
+		expect   map[string]string
+	}{
+		{"applied", nil, map[string]string{"app": "web"}},
+		{"failed apply", errors.New("timed out waiting for the condition"), map[string]string{"app": "web", "generation": "1"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c, cs, applier := rolloutCluster("")
+			applier.err = tt.applyErr
+			// The live Service selects pods the new revision does not have.
+			live, _ := cs.CoreV1().Services("default").Get(context.TODO(), "web", metav1.GetOptions{})
+			live.Spec.Selector = map[string]string{"app": "web", "generation": "1"}
+			if _, err := cs.CoreV1().Services("default").Update(context.TODO(), live, metav1.UpdateOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			current := rolloutRelease(1, "1.0", nil)
+			target := rolloutRelease(2, "2.0", map[string]string{StrategyAnnotation: StrategyBlueGreen})
+
+			if err := (BlueGreen{}).Rollout(context.TODO(), c, current, target, kube.UpdateOptions{Timeout: 10}); err != tt.applyErr {
+				t.Fatalf("expected error %v, got %v", tt.applyErr, err)
+			}
+
+			// The previews are selected by the selector of the new revision.
+			preview := map[string]string{"app": "web", TrackLabel: TrackPreview}
+			if len(applier.selectors) != 1 || !reflect.DeepEqual(applier.selectors[0], preview) {
+				t.Errorf("expected the service to select %v when applying, got %v", preview, applier.selectors)
+			}
+			s, err := cs.CoreV1().Services("default").Get(context.TODO(), "web", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(s.Spec.Selector, tt.expect) {
+				t.Errorf("expected the service to be switched back to %v, got %v", tt.expect, s.Spec.Selector)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// BundledKubeVersion is the Kubernetes version of the bundled schemas.
+const BundledKubeVersion = "v1.18"
+
+var (
+	bundledOnce sync.Once
+	bundled     *Set
+)
+
+// Bundled returns the schemas of the kinds built into Kubernetes
+// BundledKubeVersion, as well as CustomResourceDefinitions.
+//
+// The schemas are derived from the Go types of the Kubernetes client, so they
+// are available without access to a cluster.
+func Bundled() *Set {
+	bundledOnce.Do(func() {
+		s := runtime.NewScheme()
+		for _, add := range []func(*runtime.Scheme) error{scheme.AddToScheme, apiextv1.AddToScheme, apiextv1beta1.AddToScheme} {
+			if err := add(s); err != nil {
+				panic(err)
+			}
+		}
+
+		bundled = NewSet(BundledKubeVersion)
+		r := reflector{}
+		for gvk, t := range s.AllKnownTypes() {
+			if gvk.Version == runtime.APIVersionInternal {
+				continue
+			}
+			bundled.Add(gvk.GroupVersion().String(), gvk.Kind, r.schemaOf(t))
+		}
+	})
+	return bundled
+}
+
+// metadataSchema is the schema of the metadata of every object.
+func metadataSchema() *Schema {
+	return reflector{}.schemaOf(reflect.TypeOf(metav1.ObjectMeta{}))
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+	// specialTypes are marshaled differently than their Go type suggests.
+	specialTypes = map[reflect.Type]*Schema{
+		reflect.TypeOf(metav1.Time{}):        {Type: "string"},
+		reflect.TypeOf(metav1.MicroTime{}):   {Type: "string"},
+		reflect.TypeOf(metav1.Duration{}):    {Type: "string"},
+		reflect.TypeOf(resource.Quantity{}):  {Format: FormatQuantity},
+		reflect.TypeOf(intstr.IntOrString{}): {Format: FormatIntOrString},
+	}
+)
+
+// reflector derives schemas from Go types, following their json tags.
+type reflector map[reflect.Type]*Schema
+
+func (r reflector) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if s, ok := specialTypes[t]; ok {
+		return s
+	}
+	if s, ok := r[t]; ok {
+		return s
+	}
+	// anything else with its own encoding, e.g. runtime.RawExtension, can
+	// hold any value
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return &Schema{}
+	}
+
+	s := &Schema{}
+	// cache before descending so recursive types terminate
+	r[t] = s
+	switch t.Kind() {
+	case reflect.String:
+		s.Type = "string"
+	case reflect.Bool:
+		s.Type = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s.Type = "integer"
+	case reflect.Float32, reflect.Float64:
+		s.Type = "number"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is base64 encoded
+			s.Type = "string"
+			break
+		}
+		s.Type = "array"
+		s.Items = r.schemaOf(t.Elem())
+	case reflect.Map:
+		s.Type = "object"
+		s.AdditionalProperties = r.schemaOf(t.Elem())
+	case reflect.Struct:
+		s.Type = "object"
+		s.Properties = map[string]*Schema{}
+		r.addFields(s, t)
+	}
+	return s
+}
+
+func (r reflector) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" && (f.Anonymous || strings.Contains(tag, ",inline")) {
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				r.addFields(s, ft)
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s.Properties[name] = r.schemaOf(f.Type)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+// crdVersion is a version of a CustomResourceDefinition in either
+// apiextensions.k8s.io/v1 or v1beta1.
+type crdVersion struct {
+	Name   string `json:"name"`
+	Served bool   `json:"served"`
+	Schema *struct {
+		OpenAPIV3Schema *openAPISchema `json:"openAPIV3Schema"`
+	} `json:"schema"`
+}
+
+type crd struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind string `json:"kind"`
+		} `json:"names"`
+		Versions []crdVersion `json:"versions"`
+
+		// v1beta1 only
+		Version    string `json:"version"`
+		Validation *struct {
+			OpenAPIV3Schema *openAPISchema `json:"openAPIV3Schema"`
+		} `json:"validation"`
+		PreserveUnknownFields *bool `json:"preserveUnknownFields"`
+	} `json:"spec"`
+}
+
+// WithCRDs returns a copy of s with the schemas of the custom resources
+// defined by docs. Documents that are not CustomResourceDefinitions are
+// ignored, so all manifests of a chart can be passed.
+func (s *Set) WithCRDs(docs ...[]byte) (*Set, error) {
+	c := s.copy()
+	for _, doc := range docs {
+		var d crd
+		if err := yaml.Unmarshal(doc, &d); err != nil {
+			// not for us to complain about
+			continue
+		}
+		if d.Kind != "CustomResourceDefinition" {
+			continue
+		}
+		if err := c.addCRD(&d); err != nil {
+			return nil, fmt.Errorf("CustomResourceDefinition %q: %s", d.Metadata.Name, err)
+		}
+	}
+	return c, nil
+}
+
+// ChartCRDs returns the documents in the crds/ files of a chart.
+func ChartCRDs(c *chart.Chart) [][]byte {
+	var docs [][]byte
+	for _, f := range c.GetFiles() {
+		if !strings.HasPrefix(f.TypeUrl, "crds/") {
+			continue
+		}
+		for _, doc := range releaseutil.SplitManifestDocs(string(f.Value)) {
+			docs = append(docs, []byte(doc))
+		}
+	}
+	return docs
+}
+
+func (s *Set) addCRD(d *crd) error {
+	var preserve bool
+	switch d.APIVersion {
+	case "apiextensions.k8s.io/v1":
+	case "apiextensions.k8s.io/v1beta1":
+		// v1beta1 keeps unknown fields unless told otherwise
+		preserve = d.Spec.PreserveUnknownFields == nil || *d.Spec.PreserveUnknownFields
+	default:
+		return nil
+	}
+
+	var common *openAPISchema
+	if d.Spec.Validation != nil {
+		common = d.Spec.Validation.OpenAPIV3Schema
+	}
+	versions := d.Spec.Versions
+	if len(versions) == 0 && d.Spec.Version != "" {
+		versions = []crdVersion{{Name: d.Spec.Version, Served: true}}
+	}
+
+	for _, v := range versions {
+		if !v.Served {
+			continue
+		}
+		o := common
+		if v.Schema != nil && v.Schema.OpenAPIV3Schema != nil {
+			o = v.Schema.OpenAPIV3Schema
+		}
+		schema, err := crdSchema(o, preserve)
+		if err != nil {
+			return err
+		}
+		s.Add(d.Spec.Group+"/"+v.Name, d.Spec.Names.Kind, schema)
+	}
+	return nil
+}
+
+// crdSchema converts the schema of a custom resource, adding the fields
+// every object has.
+func crdSchema(o *openAPISchema, preserve bool) (*Schema, error) {
+	if o == nil {
+		return &Schema{Type: "object"}, nil
+	}
+	c := &converter{done: map[string]*Schema{}}
+	s, err := c.convert(o)
+	if err != nil {
+		return nil, err
+	}
+	if preserve {
+		preserveUnknownFields(s)
+	}
+
+	s.Type = "object"
+	if s.Properties == nil {
+		s.Properties = map[string]*Schema{}
+	}
+	s.Properties["apiVersion"] = &Schema{Type: "string"}
+	s.Properties["kind"] = &Schema{Type: "string"}
+	s.Properties["metadata"] = metadataSchema()
+	return s, nil
+}
+
+func preserveUnknownFields(s *Schema) {
+	if s == nil {
+		return
+	}
+	s.PreserveUnknownFields = true
+	for _, p := range s.Properties {
+		preserveUnknownFields(p)
+	}
+	preserveUnknownFields(s.AdditionalProperties)
+	preserveUnknownFields(s.Items)
+}
@@ -0,0 +1,245 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// quantityDefinition is the OpenAPI definition of resource.Quantity, which
+// the document describes as a plain string.
+const quantityDefinition = "io.k8s.apimachinery.pkg.api.resource.Quantity"
+
+// openAPISchema is the part of an OpenAPI schema used for validation. It
+// reads both the v2 definitions served by the API server and the v3 schemas
+// of CustomResourceDefinitions.
+type openAPISchema struct {
+	Type                  string                    `json:"type"`
+	Format                string                    `json:"format"`
+	Ref                   string                    `json:"$ref"`
+	Properties            map[string]*openAPISchema `json:"properties"`
+	AdditionalProperties  json.RawMessage           `json:"additionalProperties"`
+	Items                 json.RawMessage           `json:"items"`
+	Required              []string                  `json:"required"`
+	PreserveUnknownFields bool                      `json:"x-kubernetes-preserve-unknown-fields"`
+	IntOrString           bool                      `json:"x-kubernetes-int-or-string"`
+	GroupVersionKind      []struct {
+		Group   string `json:"group"`
+		Version string `json:"version"`
+		Kind    string `json:"kind"`
+	} `json:"x-kubernetes-group-version-kind"`
+}
+
+// converter turns OpenAPI schemas into Schemas, resolving references to
+// definitions.
+type converter struct {
+	definitions map[string]*openAPISchema
+	done        map[string]*Schema
+}
+
+func (c *converter) convert(o *openAPISchema) (*Schema, error) {
+	if o == nil {
+		return &Schema{}, nil
+	}
+	if o.Ref != "" {
+		return c.resolve(o.Ref)
+	}
+
+	s := &Schema{
+		Type:                  o.Type,
+		Format:                o.Format,
+		Required:              o.Required,
+		PreserveUnknownFields: o.PreserveUnknownFields,
+	}
+	if o.IntOrString {
+		s.Format = FormatIntOrString
+	}
+	if s.Format != FormatIntOrString {
+		// other formats, e.g. date-time, are not validated
+		s.Format = ""
+	}
+	if o.Properties != nil {
+		s.Properties = make(map[string]*Schema, len(o.Properties))
+		for name, p := range o.Properties {
+			ps, err := c.convert(p)
+			if err != nil {
+				return nil, err
+			}
+			s.Properties[name] = ps
+		}
+	}
+
+	var err error
+	if s.AdditionalProperties, err = c.convertRaw(o.AdditionalProperties); err != nil {
+		return nil, err
+	}
+	if s.Items, err = c.convertRaw(o.Items); err != nil {
+		return nil, err
+	}
+
+	// schemas of CustomResourceDefinitions often leave the type implicit
+	if s.Type == "" {
+		switch {
+		case s.Properties != nil || s.AdditionalProperties != nil:
+			s.Type = "object"
+		case s.Items != nil:
+			s.Type = "array"
+		}
+	}
+	return s, nil
+}
+
+// convertRaw converts a field that is either a schema or a boolean. true
+// allows anything, false and a missing field leave the schema unset.
+func (c *converter) convertRaw(raw json.RawMessage) (*Schema, error) {
+	switch strings.TrimSpace(string(raw)) {
+	case "", "null", "false":
+		return nil, nil
+	case "true":
+		return &Schema{}, nil
+	}
+	// a list of schemas for the items of a tuple is not validated
+	if strings.HasPrefix(strings.TrimSpace(string(raw)), "[") {
+		return &Schema{}, nil
+	}
+	var o openAPISchema
+	if err := json.Unmarshal(raw, &o); err != nil {
+		return nil, err
+	}
+	return c.convert(&o)
+}
+
+func (c *converter) resolve(ref string) (*Schema, error) {
+	name := strings.TrimPrefix(ref, "#/definitions/")
+	if s, ok := c.done[name]; ok {
+		return s, nil
+	}
+	def, ok := c.definitions[name]
+	if !ok {
+		return nil, fmt.Errorf("unresolved reference %q", ref)
+	}
+	if name == quantityDefinition {
+		s := &Schema{Format: FormatQuantity}
+		c.done[name] = s
+		return s, nil
+	}
+
+	// register before converting so recursive definitions terminate
+	s := &Schema{}
+	c.done[name] = s
+	converted, err := c.convert(def)
+	if err != nil {
+		return nil, err
+	}
+	*s = *converted
+	return s, nil
+}
+
+// LoadOpenAPI reads the schemas of all kinds in an OpenAPI v2 document, as
+// served by the Kubernetes API server at /openapi/v2.
+func LoadOpenAPI(data []byte, kubeVersion string) (*Set, error) {
+	var doc struct {
+		Definitions map[string]*openAPISchema `json:"definitions"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("cannot parse OpenAPI document: %s", err)
+	}
+	if len(doc.Definitions) == 0 {
+		return nil, fmt.Errorf("OpenAPI document has no definitions")
+	}
+
+	c := &converter{definitions: doc.Definitions, done: map[string]*Schema{}}
+	set := NewSet(kubeVersion)
+	for name, def := range doc.Definitions {
+		if len(def.GroupVersionKind) == 0 {
+			continue
+		}
+		s, err := c.resolve("#/definitions/" + name)
+		if err != nil {
+			return nil, fmt.Errorf("definition %s: %s", name, err)
+		}
+		for _, gvk := range def.GroupVersionKind {
+			apiVersion := gvk.Version
+			if gvk.Group != "" {
+				apiVersion = gvk.Group + "/" + gvk.Version
+			}
+			set.Add(apiVersion, gvk.Kind, s)
+		}
+	}
+	return set, nil
+}
+
+// Load returns the schemas for kubeVersion from location.
+//
+// An empty location returns the bundled schemas, which are only those of
+// BundledKubeVersion; asking for another version fails. Otherwise location is
+// either an OpenAPI document, or a directory of documents named after the
+// Kubernetes version they describe, e.g. "v1.18.json".
+func Load(location, kubeVersion string) (*Set, error) {
+	if location == "" {
+		if kubeVersion != "" {
+			v, err := majorMinor(kubeVersion)
+			if err != nil {
+				return nil, err
+			}
+			if v != BundledKubeVersion {
+				return nil, fmt.Errorf("no bundled schemas for Kubernetes %s, only for %s: give the location of the schemas for %s", v, BundledKubeVersion, v)
+			}
+		}
+		return Bundled(), nil
+	}
+
+	fi, err := os.Stat(location)
+	if err != nil {
+		return nil, err
+	}
+	file := location
+	if fi.IsDir() {
+		v, err := majorMinor(kubeVersion)
+		if err != nil {
+			return nil, err
+		}
+		file = filepath.Join(location, v+".json")
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return nil, fmt.Errorf("no schemas for Kubernetes %s in %s", v, location)
+		}
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	set, err := LoadOpenAPI(data, kubeVersion)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", file, err)
+	}
+	return set, nil
+}
+
+// majorMinor turns a Kubernetes version like "1.18.3" into "v1.18".
+func majorMinor(kubeVersion string) (string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(kubeVersion, "v"), ".", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid Kubernetes version %q", kubeVersion)
+	}
+	return "v" + parts[0] + "." + parts[1], nil
+}
@@ -0,0 +1,316 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schema validates rendered Kubernetes manifests offline.
+//
+// Objects are checked against the schemas of the kinds a Kubernetes version
+// serves. Schemas are bundled with Helm, loaded from the OpenAPI document of a
+// cluster, or read from the CustomResourceDefinitions of a chart.
+package schema // import "k8s.io/helm/pkg/schema"
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// Formats with a special meaning for validation.
+const (
+	// FormatIntOrString allows both integers and strings.
+	FormatIntOrString = "int-or-string"
+	// FormatQuantity allows both numbers and strings, like resource.Quantity.
+	FormatQuantity = "quantity"
+)
+
+// Schema describes the allowed values of a field, following a subset of
+// OpenAPI.
+type Schema struct {
+	// Type is one of "object", "array", "string", "integer", "number" or
+	// "boolean". An empty Type allows any value.
+	Type string
+	// Format refines Type. See FormatIntOrString and FormatQuantity.
+	Format string
+	// Properties are the fields of an object. An object with neither
+	// Properties nor AdditionalProperties allows any fields.
+	Properties map[string]*Schema
+	// AdditionalProperties is the schema of the values of a map.
+	AdditionalProperties *Schema
+	// PreserveUnknownFields allows fields that are not in Properties.
+	PreserveUnknownFields bool
+	// Items is the schema of the elements of an array.
+	Items *Schema
+	// Required lists the fields an object must have.
+	Required []string
+}
+
+// FieldError is a problem with a field of an object.
+type FieldError struct {
+	// Kind and Name identify the object.
+	Kind string
+	Name string
+	// Path is the field, e.g. "spec.template.spec.containers[0].image".
+	Path    string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Path, e.Message)
+	switch {
+	case e.Kind != "" && e.Name != "":
+		return fmt.Sprintf("%s %q: %s", e.Kind, e.Name, msg)
+	case e.Kind != "":
+		return e.Kind + ": " + msg
+	}
+	return msg
+}
+
+// UnknownKindError is returned for objects of a kind without a schema.
+type UnknownKindError struct {
+	APIVersion string
+	Kind       string
+	// KubeVersion is the Kubernetes version of the schemas.
+	KubeVersion string
+}
+
+func (e *UnknownKindError) Error() string {
+	msg := fmt.Sprintf("no schema for kind %q in apiVersion %q", e.Kind, e.APIVersion)
+	if e.KubeVersion != "" {
+		msg += " in Kubernetes " + e.KubeVersion
+	}
+	return msg
+}
+
+// Set holds the schemas of the kinds served by a Kubernetes version.
+type Set struct {
+	// KubeVersion is the Kubernetes version of the schemas, e.g. "v1.18".
+	KubeVersion string
+
+	kinds map[string]*Schema
+}
+
+// NewSet returns an empty Set for the given Kubernetes version.
+func NewSet(kubeVersion string) *Set {
+	return &Set{KubeVersion: kubeVersion, kinds: map[string]*Schema{}}
+}
+
+// Add sets the schema of kind in apiVersion.
+func (s *Set) Add(apiVersion, kind string, schema *Schema) {
+	s.kinds[kindKey(apiVersion, kind)] = schema
+}
+
+// Lookup returns the schema of kind in apiVersion, or nil if there is none.
+func (s *Set) Lookup(apiVersion, kind string) *Schema {
+	return s.kinds[kindKey(apiVersion, kind)]
+}
+
+// copy returns a Set that can be added to without modifying s.
+func (s *Set) copy() *Set {
+	c := NewSet(s.KubeVersion)
+	for k, v := range s.kinds {
+		c.kinds[k] = v
+	}
+	return c
+}
+
+func kindKey(apiVersion, kind string) string {
+	return apiVersion + "/" + kind
+}
+
+// Validate checks a YAML document holding a single object. Objects of the
+// kind List are validated item by item.
+//
+// It returns a *FieldError for every problem found, or a single
+// *UnknownKindError if there is no schema for the object.
+func (s *Set) Validate(doc []byte) []error {
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal(doc, &obj); err != nil {
+		return []error{err}
+	}
+	if len(obj) == 0 {
+		return nil
+	}
+	return s.validateObject(obj, "")
+}
+
+func (s *Set) validateObject(obj map[string]interface{}, prefix string) []error {
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+	name := ""
+	if meta, ok := obj["metadata"].(map[string]interface{}); ok {
+		name, _ = meta["name"].(string)
+	}
+
+	v := &validator{kind: kind, name: name}
+	if apiVersion == "" {
+		v.fail(prefix+"apiVersion", "required field is missing")
+	}
+	if kind == "" {
+		v.fail(prefix+"kind", "required field is missing")
+	}
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+
+	if kind == "List" && apiVersion == "v1" {
+		items, _ := obj["items"].([]interface{})
+		var errs []error
+		for i, item := range items {
+			if m, ok := item.(map[string]interface{}); ok {
+				errs = append(errs, s.validateObject(m, fmt.Sprintf("%sitems[%d].", prefix, i))...)
+			}
+		}
+		return errs
+	}
+
+	schema := s.Lookup(apiVersion, kind)
+	if schema == nil {
+		return []error{&UnknownKindError{APIVersion: apiVersion, Kind: kind, KubeVersion: s.KubeVersion}}
+	}
+	v.validate(schema, strings.TrimSuffix(prefix, "."), obj)
+	return v.errs
+}
+
+type validator struct {
+	kind, name string
+	errs       []error
+}
+
+func (v *validator) fail(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, &FieldError{Kind: v.kind, Name: v.name, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validate(s *Schema, path string, value interface{}) {
+	// null is the same as an unset field
+	if value == nil {
+		return
+	}
+
+	switch s.Format {
+	case FormatIntOrString:
+		if _, ok := value.(string); !ok && !isInteger(value) {
+			v.fail(path, "expected integer or string, got %s", typeName(value))
+		}
+		return
+	case FormatQuantity:
+		switch value.(type) {
+		case string, float64:
+		default:
+			v.fail(path, "expected quantity, got %s", typeName(value))
+		}
+		return
+	}
+
+	switch s.Type {
+	case "":
+		return
+	case "string":
+		if _, ok := value.(string); !ok {
+			v.fail(path, "expected string, got %s", typeName(value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.fail(path, "expected boolean, got %s", typeName(value))
+		}
+	case "integer":
+		if !isInteger(value) {
+			v.fail(path, "expected integer, got %s", typeName(value))
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			v.fail(path, "expected number, got %s", typeName(value))
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			v.fail(path, "expected array, got %s", typeName(value))
+			return
+		}
+		if s.Items != nil {
+			for i, item := range items {
+				v.validate(s.Items, fmt.Sprintf("%s[%d]", path, i), item)
+			}
+		}
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			v.fail(path, "expected object, got %s", typeName(value))
+			return
+		}
+		v.validateFields(s, path, obj)
+	}
+}
+
+func (v *validator) validateFields(s *Schema, path string, obj map[string]interface{}) {
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			v.fail(join(path, name), "required field is missing")
+		}
+	}
+
+	// sort for a stable order of errors
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if prop, ok := s.Properties[name]; ok {
+			v.validate(prop, join(path, name), obj[name])
+			continue
+		}
+		switch {
+		case s.AdditionalProperties != nil:
+			v.validate(s.AdditionalProperties, fmt.Sprintf("%s[%s]", path, name), obj[name])
+		case s.Properties != nil && !s.PreserveUnknownFields:
+			v.fail(join(path, name), "unknown field")
+		}
+	}
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func isInteger(value interface{}) bool {
+	f, ok := value.(float64)
+	return ok && f == math.Trunc(f)
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if isInteger(value) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,321 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func errorStrings(errs []error) []string {
+	var s []string
+	for _, err := range errs {
+		s = append(s, err.Error())
+	}
+	return s
+}
+
+func TestBundledValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		doc    string
+		expect []string
+	}{
+		{
+			name: "valid deployment",
+			doc: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      creationTimestamp: null
+    spec:
+      containers:
+      - name: web
+        image: nginx
+        ports:
+        - containerPort: 80
+        readinessProbe:
+          httpGet:
+            port: http
+        resources:
+          limits:
+            cpu: 500m
+            memory: 1
+`,
+		},
+		{
+			name: "invalid deployment",
+			doc: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: 1
+spec:
+  replicas: "2"
+  template:
+    spec:
+      containers:
+      - name: web
+        imagePullPolicy: [Always]
+        ports:
+        - containerPort: 80
+          protocl: TCP
+        livenessProbe:
+          httpGet:
+            port: true
+`,
+			expect: []string{
+				`Deployment "web": metadata.labels[app]: expected string, got integer`,
+				`Deployment "web": spec.replicas: expected integer, got string`,
+				`Deployment "web": spec.template.spec.containers[0].imagePullPolicy: expected string, got array`,
+				`Deployment "web": spec.template.spec.containers[0].livenessProbe.httpGet.port: expected integer or string, got boolean`,
+				`Deployment "web": spec.template.spec.containers[0].ports[0].protocl: unknown field`,
+			},
+		},
+		{
+			name: "list",
+			doc: `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: a
+  data:
+    key: value
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: b
+  date: {}
+`,
+			expect: []string{`ConfigMap "b": items[1].date: unknown field`},
+		},
+		{
+			name:   "missing kind",
+			doc:    "apiVersion: v1\n",
+			expect: []string{`kind: required field is missing`},
+		},
+		{
+			name:   "unknown kind",
+			doc:    "apiVersion: example.com/v1\nkind: Widget\n",
+			expect: []string{`no schema for kind "Widget" in apiVersion "example.com/v1" in Kubernetes v1.18`},
+		},
+		{
+			name: "empty document",
+			doc:  "# nothing to see here\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errorStrings(Bundled().Validate([]byte(tt.doc)))
+			if !reflect.DeepEqual(got, tt.expect) {
+				t.Errorf("expected errors\n%q\ngot\n%q", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestUnknownKindError(t *testing.T) {
+	errs := Bundled().Validate([]byte("apiVersion: example.com/v1\nkind: Widget\n"))
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if _, ok := errs[0].(*UnknownKindError); !ok {
+		t.Errorf("expected *UnknownKindError, got %T", errs[0])
+	}
+}
+
+func TestLoad(t *testing.T) {
+	set, err := Load("testdata/schemas", "1.18.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.KubeVersion != "1.18.3" {
+		t.Errorf("expected kube version 1.18.3, got %s", set.KubeVersion)
+	}
+
+	doc := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+  annotations: {}
+`
+	expect := []string{
+		`Pod "app": spec: required field is missing`,
+		`Pod "app": metadata.annotations: unknown field`,
+	}
+	if got := errorStrings(set.Validate([]byte(doc))); !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected errors\n%q\ngot\n%q", expect, got)
+	}
+
+	doc = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+spec:
+  priority: 1.5
+  resources:
+    limits:
+      cpu: 0.5
+      memory: [1Gi]
+`
+	expect = []string{
+		`Pod "app": spec.priority: expected integer, got number`,
+		`Pod "app": spec.resources.limits[memory]: expected quantity, got array`,
+	}
+	if got := errorStrings(set.Validate([]byte(doc))); !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected errors\n%q\ngot\n%q", expect, got)
+	}
+
+	if set.Lookup("apps/v1", "Deployment") != nil {
+		t.Error("expected only the kinds of the document")
+	}
+}
+
+func TestLoadMissingVersion(t *testing.T) {
+	if _, err := Load("testdata/schemas", "1.9"); err == nil {
+		t.Error("expected an error for a version without schemas")
+	}
+	if _, err := Load("testdata/schemas", "latest"); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+	set, err := Load("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set != Bundled() {
+		t.Error("expected the bundled schemas for an empty location")
+	}
+	if set, err := Load("", "1.18.3"); err != nil || set != Bundled() {
+		t.Errorf("expected the bundled schemas for their version, got %v", err)
+	}
+	if _, err := Load("", "1.16"); err == nil {
+		t.Error("expected an error for a version other than the bundled one")
+	}
+}
+
+func TestWithCRDs(t *testing.T) {
+	crd, err := ioutil.ReadFile("testdata/crd.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, err := Bundled().WithCRDs(crd, []byte("apiVersion: v1\nkind: ConfigMap\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Bundled().Lookup("example.com/v1", "Widget") != nil {
+		t.Error("expected the bundled schemas to be left alone")
+	}
+	if set.Lookup("example.com/v1alpha1", "Widget") != nil {
+		t.Error("expected no schema for a version that is not served")
+	}
+
+	doc := `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+  labels:
+    size: large
+spec:
+  size: 3
+  port: http
+  extra:
+    anything: goes
+`
+	if errs := set.Validate([]byte(doc)); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+
+	doc = `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+  label: {}
+spec:
+  port: 1.5
+  colour: blue
+`
+	expect := []string{
+		`Widget "gadget": metadata.label: unknown field`,
+		`Widget "gadget": spec.size: required field is missing`,
+		`Widget "gadget": spec.colour: unknown field`,
+		`Widget "gadget": spec.port: expected integer or string, got number`,
+	}
+	if got := errorStrings(set.Validate([]byte(doc))); !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected errors\n%q\ngot\n%q", expect, got)
+	}
+}
+
+func TestWithCRDsV1beta1(t *testing.T) {
+	crd := `
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: gadgets.example.com
+spec:
+  group: example.com
+  version: v1
+  names:
+    kind: Gadget
+  validation:
+    openAPIV3Schema:
+      properties:
+        spec:
+          properties:
+            size:
+              type: integer
+`
+	set, err := Bundled().WithCRDs([]byte(crd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// unknown fields are preserved by default in v1beta1
+	doc := `
+apiVersion: example.com/v1
+kind: Gadget
+metadata:
+  name: gizmo
+spec:
+  size: small
+  colour: blue
+`
+	expect := []string{`Gadget "gizmo": spec.size: expected integer, got string`}
+	if got := errorStrings(set.Validate([]byte(doc))); !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected errors\n%q\ngot\n%q", expect, got)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package secrets encrypts and decrypts values files.
+
+The leaves of an encrypted values file are encrypted one by one, so that the
+structure of the file stays readable and diffs show which values changed:
+
+	db:
+	  password: ENC[AES256_GCM,data:OXX5tQ==,iv:...,tag:...]
+	  user: ENC[AES256_GCM,data:qsGTkA==,iv:...,tag:...]
+	helm-secrets:
+	  version: 1
+	  age:
+	  - recipient: age1...
+	    enc: |
+	      -----BEGIN AGE ENCRYPTED FILE-----
+	      ...
+	  pgp:
+	  - fingerprint: 4B6F...
+	    enc: |
+	      -----BEGIN PGP MESSAGE-----
+	      ...
+	  mac: 6c1f...
+
+The leaves are encrypted with a random data key, which is itself encrypted
+for each recipient of the file, with age X25519 keys or PGP keys. Any one of
+the recipients can decrypt the file. The MAC authenticates the whole
+document, including null values and empty maps and lists, so that values
+cannot be added, removed or moved between keys.
+
+Decryption only happens in memory; nothing in this package writes to disk.
+*/
+package secrets // import "k8s.io/helm/pkg/secrets"
@@ -0,0 +1,591 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ghodss/yaml"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/openpgp"
+	pgparmor "golang.org/x/crypto/openpgp/armor"
+
+	"k8s.io/helm/pkg/provenance"
+)
+
+// MetadataKey is the top-level key of the encryption metadata in an
+// encrypted values file.
+const MetadataKey = "helm-secrets"
+
+const (
+	formatVersion = 1
+	dataKeySize   = 32
+	pgpMessage    = "PGP MESSAGE"
+)
+
+var (
+	// ErrEncrypted is returned when encrypting a values file that is already
+	// encrypted.
+	ErrEncrypted = errors.New("values file is already encrypted")
+	// ErrNotEncrypted is returned when decrypting a values file that is not
+	// encrypted.
+	ErrNotEncrypted = errors.New("values file is not encrypted")
+	// ErrNoIdentity is returned when none of the identities can decrypt the
+	// data key of a values file.
+	ErrNoIdentity = errors.New("none of the identities can decrypt the values file")
+	// ErrMAC is returned when the values of a file do not match its MAC.
+	ErrMAC = errors.New("values file was tampered with: MAC mismatch")
+)
+
+var encryptedLeaf = regexp.MustCompile(`^ENC\[AES256_GCM,data:([^,]*),iv:([^,]+),tag:([^,\]]+)\]$`)
+
+// Recipients are the keys a values file is encrypted for.
+type Recipients struct {
+	// Age are age X25519 public keys.
+	Age []*age.X25519Recipient
+	// PGP are PGP public keys, e.g. from a keyring read with
+	// provenance.NewFromKeyring.
+	PGP openpgp.EntityList
+}
+
+// Identities are the keys a values file is decrypted with.
+type Identities struct {
+	// Age are age X25519 secret keys.
+	Age []*age.X25519Identity
+	// PGP is a keyring with PGP secret keys.
+	PGP openpgp.EntityList
+	// Passphrase unlocks the PGP secret keys that are encrypted. If nil,
+	// only unencrypted keys are used.
+	Passphrase provenance.PassphraseFetcher
+}
+
+// metadata describes how a values file is encrypted.
+type metadata struct {
+	Version int      `json:"version"`
+	Age     []ageKey `json:"age,omitempty"`
+	PGP     []pgpKey `json:"pgp,omitempty"`
+	MAC     string   `json:"mac"`
+}
+
+// ageKey is the data key encrypted for an age recipient.
+type ageKey struct {
+	Recipient string `json:"recipient"`
+	Enc       string `json:"enc"`
+}
+
+// pgpKey is the data key encrypted for a PGP key.
+type pgpKey struct {
+	Fingerprint string `json:"fingerprint"`
+	Enc         string `json:"enc"`
+}
+
+// IsEncrypted returns whether data is an encrypted values file.
+func IsEncrypted(data []byte) bool {
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return false
+	}
+	_, ok := values[MetadataKey]
+	return ok
+}
+
+// Encrypt encrypts the leaves of the values file data for the recipients.
+func Encrypt(data []byte, r Recipients) ([]byte, error) {
+	if len(r.Age) == 0 && len(r.PGP) == 0 {
+		return nil, errors.New("no recipients to encrypt the values file for")
+	}
+	values, meta, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if meta != nil {
+		return nil, ErrEncrypted
+	}
+
+	key := make([]byte, dataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	meta = &metadata{Version: formatVersion}
+	for _, ar := range r.Age {
+		enc, err := ageEncrypt(key, ar)
+		if err != nil {
+			return nil, err
+		}
+		meta.Age = append(meta.Age, ageKey{Recipient: ar.String(), Enc: string(enc)})
+	}
+	for _, e := range r.PGP {
+		enc, err := pgpEncrypt(key, e)
+		if err != nil {
+			return nil, err
+		}
+		meta.PGP = append(meta.PGP, pgpKey{Fingerprint: fingerprint(e), Enc: string(enc)})
+	}
+	return seal(values, meta, key)
+}
+
+// Decrypt decrypts the values file data with the identities, returning the
+// plain values file.
+func Decrypt(data []byte, ids Identities) ([]byte, error) {
+	values, meta, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if meta == nil {
+		return nil, ErrNotEncrypted
+	}
+	key, err := meta.dataKey(ids)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := open(values, meta, key)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(plain)
+}
+
+// Edit decrypts the values file data, passes the plain values file to edit,
+// and encrypts what edit returns for the same recipients.
+func Edit(data []byte, ids Identities, edit func(plain []byte) ([]byte, error)) ([]byte, error) {
+	values, meta, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if meta == nil {
+		return nil, ErrNotEncrypted
+	}
+	key, err := meta.dataKey(ids)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := open(values, meta, key)
+	if err != nil {
+		return nil, err
+	}
+	out, err := yaml.Marshal(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	edited, err := edit(out)
+	if err != nil {
+		return nil, err
+	}
+	values, newMeta, err := parse(edited)
+	if err != nil {
+		return nil, err
+	}
+	if newMeta != nil {
+		return nil, fmt.Errorf("the edited values file must not contain %s", MetadataKey)
+	}
+	return seal(values, meta, key)
+}
+
+// parse reads a values file, and its encryption metadata if it is
+// encrypted.
+func parse(data []byte) (map[string]interface{}, *metadata, error) {
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse values file: %s", err)
+	}
+	raw, ok := values[MetadataKey]
+	if !ok {
+		return values, nil, nil
+	}
+	delete(values, MetadataKey)
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	meta := &metadata{}
+	if err := json.Unmarshal(b, meta); err != nil {
+		return nil, nil, fmt.Errorf("malformed %s: %s", MetadataKey, err)
+	}
+	if meta.Version != formatVersion {
+		return nil, nil, fmt.Errorf("unsupported %s version %d", MetadataKey, meta.Version)
+	}
+	return values, meta, nil
+}
+
+// seal encrypts the leaves of values with key, and returns the encrypted
+// values file with its metadata.
+func seal(values map[string]interface{}, meta *metadata, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	mac, err := documentMAC(values, key)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := walk(nil, values, func(path []interface{}, v interface{}) (interface{}, error) {
+		plain, aad, err := leaf(path, v)
+		if err != nil {
+			return nil, err
+		}
+
+		iv := make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(iv); err != nil {
+			return nil, err
+		}
+		sealed := gcm.Seal(nil, iv, plain, aad)
+		data, tag := sealed[:len(plain)], sealed[len(plain):]
+		return fmt.Sprintf("ENC[AES256_GCM,data:%s,iv:%s,tag:%s]",
+			base64.StdEncoding.EncodeToString(data),
+			base64.StdEncoding.EncodeToString(iv),
+			base64.StdEncoding.EncodeToString(tag)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := enc.(map[string]interface{})
+	sealedMeta := *meta
+	sealedMeta.MAC = hex.EncodeToString(mac)
+	out[MetadataKey] = sealedMeta
+	return yaml.Marshal(out)
+}
+
+// open decrypts the leaves of values with key, and checks the decrypted
+// values against the MAC of meta.
+func open(values map[string]interface{}, meta *metadata, key []byte) (map[string]interface{}, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := walk(nil, values, func(path []interface{}, v interface{}) (interface{}, error) {
+		aad, err := json.Marshal(path)
+		if err != nil {
+			return nil, err
+		}
+		s, _ := v.(string)
+		m := encryptedLeaf.FindStringSubmatch(s)
+		if m == nil {
+			return nil, fmt.Errorf("value of %s is not encrypted", pathString(path))
+		}
+		var parts [3][]byte
+		for i := range parts {
+			if parts[i], err = base64.StdEncoding.DecodeString(m[i+1]); err != nil {
+				return nil, fmt.Errorf("malformed encrypted value of %s: %s", pathString(path), err)
+			}
+		}
+		if len(parts[1]) != gcm.NonceSize() {
+			return nil, fmt.Errorf("malformed encrypted value of %s", pathString(path))
+		}
+		b, err := gcm.Open(nil, parts[1], append(parts[0], parts[2]...), aad)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decrypt the value of %s: %s", pathString(path), err)
+		}
+
+		var val interface{}
+		if err := json.Unmarshal(b, &val); err != nil {
+			return nil, err
+		}
+		return val, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := documentMAC(plain, key)
+	if err != nil {
+		return nil, err
+	}
+	sum, err := hex.DecodeString(meta.MAC)
+	if err != nil || !hmac.Equal(sum, mac) {
+		return nil, ErrMAC
+	}
+	return plain.(map[string]interface{}), nil
+}
+
+// walk replaces the leaves of v with the result of f, visiting the keys of
+// maps in sorted order. Null values are left as they are.
+func walk(path []interface{}, v interface{}, f func(path []interface{}, v interface{}) (interface{}, error)) (interface{}, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		out := make(map[string]interface{}, len(v))
+		for _, k := range keys {
+			val, err := walk(append(path[:len(path):len(path)], k), v[k], f)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = val
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			val, err := walk(append(path[:len(path):len(path)], i), e, f)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = val
+		}
+		return out, nil
+	default:
+		return f(path, v)
+	}
+}
+
+// leaf returns the plaintext of a leaf value, its JSON encoding so that its
+// type is kept, and the additional data that binds it to its path.
+func leaf(path []interface{}, v interface{}) ([]byte, []byte, error) {
+	plain, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	aad, err := json.Marshal(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plain, aad, nil
+}
+
+func pathString(path []interface{}) string {
+	var b strings.Builder
+	for _, p := range path {
+		switch p := p.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%d]", p)
+		default:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			fmt.Fprint(&b, p)
+		}
+	}
+	return b.String()
+}
+
+// documentMAC authenticates the whole plain values document: the paths and
+// values of all leaves, as well as null values and empty maps and lists.
+// The JSON encoding of a document is canonical, as the keys of maps are
+// sorted.
+func documentMAC(values interface{}, key []byte) ([]byte, error) {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, macKey(key))
+	mac.Write(b)
+	return mac.Sum(nil), nil
+}
+
+func macKey(key []byte) []byte {
+	k := make([]byte, sha256.Size)
+	io.ReadFull(hkdf.New(sha256.New, key, nil, []byte("helm-secrets mac")), k)
+	return k
+}
+
+// dataKey decrypts the data key with the first identity that can.
+func (m *metadata) dataKey(ids Identities) ([]byte, error) {
+	if len(ids.Age) > 0 {
+		for _, k := range m.Age {
+			if key, err := ageDecrypt([]byte(k.Enc), ids.Age); err == nil {
+				return checkDataKey(key)
+			}
+		}
+	}
+	if len(ids.PGP) > 0 {
+		for _, k := range m.PGP {
+			if !hasSecretKey(ids.PGP, k.Fingerprint) {
+				continue
+			}
+			key, err := pgpDecrypt([]byte(k.Enc), ids)
+			if err != nil {
+				return nil, fmt.Errorf("cannot decrypt the data key for PGP key %s: %s", k.Fingerprint, err)
+			}
+			return checkDataKey(key)
+		}
+	}
+	return nil, ErrNoIdentity
+}
+
+func checkDataKey(key []byte) ([]byte, error) {
+	if len(key) != dataKeySize {
+		return nil, errors.New("malformed data key")
+	}
+	return key, nil
+}
+
+// fingerprint returns the fingerprint of the primary key of e.
+func fingerprint(e *openpgp.Entity) string {
+	return strings.ToUpper(hex.EncodeToString(e.PrimaryKey.Fingerprint[:]))
+}
+
+// hasSecretKey returns whether the keyring has the secret key of the
+// fingerprint.
+func hasSecretKey(ring openpgp.EntityList, fp string) bool {
+	for _, k := range ring.KeysById(keyID(fp)) {
+		if k.Entity.PrivateKey != nil && fingerprint(k.Entity) == fp {
+			return true
+		}
+	}
+	return false
+}
+
+// keyID returns the key ID of a fingerprint, or 0 if it is malformed.
+func keyID(fp string) uint64 {
+	b, err := hex.DecodeString(fp)
+	if err != nil || len(b) < 8 {
+		return 0
+	}
+	var id uint64
+	for _, c := range b[len(b)-8:] {
+		id = id<<8 | uint64(c)
+	}
+	return id
+}
+
+// ageEncrypt encrypts plaintext for r as an armored age file.
+func ageEncrypt(plaintext []byte, r *age.X25519Recipient) ([]byte, error) {
+	out := &bytes.Buffer{}
+	aw := armor.NewWriter(out)
+	w, err := age.Encrypt(aw, r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encrypt for age recipient %s: %s", r, err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	if err := aw.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// ageDecrypt decrypts an armored age file with the first of ids that can.
+func ageDecrypt(armored []byte, ids []*age.X25519Identity) ([]byte, error) {
+	identities := make([]age.Identity, len(ids))
+	for i, id := range ids {
+		identities[i] = id
+	}
+	r, err := age.Decrypt(armor.NewReader(bytes.NewReader(armored)), identities...)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+func pgpEncrypt(plaintext []byte, e *openpgp.Entity) ([]byte, error) {
+	out := &bytes.Buffer{}
+	aw, err := pgparmor.Encode(out, pgpMessage, nil)
+	if err != nil {
+		return nil, err
+	}
+	w, err := openpgp.Encrypt(aw, openpgp.EntityList{e}, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encrypt for PGP key %s: %s", fingerprint(e), err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	if err := aw.Close(); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+func pgpDecrypt(armored []byte, ids Identities) ([]byte, error) {
+	block, err := pgparmor.Decode(bytes.NewReader(armored))
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != pgpMessage {
+		return nil, fmt.Errorf("unexpected PGP block %q", block.Type)
+	}
+	md, err := openpgp.ReadMessage(block.Body, ids.PGP, pgpPrompt(ids.Passphrase), nil)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(md.UnverifiedBody)
+}
+
+// pgpPrompt unlocks the encrypted secret keys of a message with the
+// passphrases of fetch. Each key is tried once.
+func pgpPrompt(fetch provenance.PassphraseFetcher) openpgp.PromptFunction {
+	tried := map[uint64]bool{}
+	return func(keys []openpgp.Key, symmetric bool) ([]byte, error) {
+		if symmetric || fetch == nil {
+			return nil, errors.New("private key is encrypted and no passphrase is available")
+		}
+		for _, k := range keys {
+			if tried[k.PrivateKey.KeyId] {
+				continue
+			}
+			tried[k.PrivateKey.KeyId] = true
+
+			name := "Unknown"
+			for n := range k.Entity.Identities {
+				if n != "" {
+					name = n
+					break
+				}
+			}
+			p, err := fetch(name)
+			if err != nil {
+				return nil, err
+			}
+			if err := k.PrivateKey.Decrypt(p); err == nil {
+				return nil, nil
+			}
+		}
+		return nil, errors.New("private key could not be unlocked")
+	}
+}
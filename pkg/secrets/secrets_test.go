@@ -0,0 +1,289 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/provenance"
+)
+
+const (
+	// testKeyring is a keyring with an unencrypted secret key.
+	testKeyring = "testdata/helm-test-key.secret"
+	// testPubKeyring is the public key of testKeyring.
+	testPubKeyring = "testdata/helm-test-key.pub"
+	// testPasswordKeyring is a keyring with a secret key encrypted with the
+	// passphrase "secret".
+	testPasswordKeyring = "testdata/helm-password-key.secret"
+)
+
+const testValues = `db:
+  password: hunter2
+  port: 5432
+  tls: true
+  ca: null
+hosts:
+- name: a.example.com
+- name: b.example.com
+empty: {}
+`
+
+func keyring(t *testing.T, path string) *provenance.Signatory {
+	s, err := provenance.NewFromKeyring(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func assertValues(t *testing.T, expected string, got []byte) {
+	t.Helper()
+	var e, g map[string]interface{}
+	if err := yaml.Unmarshal([]byte(expected), &e); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(got, &g); err != nil {
+		t.Fatal(err)
+	}
+	eb, _ := yaml.Marshal(e)
+	gb, _ := yaml.Marshal(g)
+	if !bytes.Equal(eb, gb) {
+		t.Errorf("expected values\n%s\ngot\n%s", eb, gb)
+	}
+}
+
+func TestEncryptDecryptAge(t *testing.T) {
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enc, err := Encrypt([]byte(testValues), Recipients{Age: []*age.X25519Recipient{id.Recipient(), other.Recipient()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(enc) {
+		t.Fatal("expected the values file to be encrypted")
+	}
+	for _, s := range []string{"hunter2", "5432", "a.example.com"} {
+		if bytes.Contains(enc, []byte(s)) {
+			t.Errorf("encrypted values file contains %q:\n%s", s, enc)
+		}
+	}
+	for _, s := range []string{"password: ENC[AES256_GCM,", "ca: null", "empty: {}", id.Recipient().String()} {
+		if !bytes.Contains(enc, []byte(s)) {
+			t.Errorf("encrypted values file does not contain %q:\n%s", s, enc)
+		}
+	}
+
+	for _, i := range []*age.X25519Identity{id, other} {
+		plain, err := Decrypt(enc, Identities{Age: []*age.X25519Identity{i}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertValues(t, testValues, plain)
+	}
+
+	stranger, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decrypt(enc, Identities{Age: []*age.X25519Identity{stranger}}); err != ErrNoIdentity {
+		t.Errorf("expected ErrNoIdentity, got %v", err)
+	}
+
+	if _, err := Encrypt(enc, Recipients{Age: []*age.X25519Recipient{id.Recipient()}}); err != ErrEncrypted {
+		t.Errorf("expected ErrEncrypted, got %v", err)
+	}
+	if _, err := Decrypt([]byte(testValues), Identities{Age: []*age.X25519Identity{id}}); err != ErrNotEncrypted {
+		t.Errorf("expected ErrNotEncrypted, got %v", err)
+	}
+	if _, err := Encrypt([]byte(testValues), Recipients{}); err == nil {
+		t.Error("expected an error without recipients")
+	}
+}
+
+func TestEncryptDecryptPGP(t *testing.T) {
+	enc, err := Encrypt([]byte(testValues), Recipients{PGP: keyring(t, testPubKeyring).KeyRing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(enc, []byte("-----BEGIN PGP MESSAGE-----")) {
+		t.Errorf("expected a PGP message in\n%s", enc)
+	}
+
+	plain, err := Decrypt(enc, Identities{PGP: keyring(t, testKeyring).KeyRing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, testValues, plain)
+
+	// the public keyring cannot decrypt
+	if _, err := Decrypt(enc, Identities{PGP: keyring(t, testPubKeyring).KeyRing}); err != ErrNoIdentity {
+		t.Errorf("expected ErrNoIdentity, got %v", err)
+	}
+}
+
+func TestDecryptPGPPassphrase(t *testing.T) {
+	ring := keyring(t, testPasswordKeyring).KeyRing
+	enc, err := Encrypt([]byte(testValues), Recipients{PGP: ring})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passphrase := func(p string) provenance.PassphraseFetcher {
+		return func(string) ([]byte, error) { return []byte(p), nil }
+	}
+	if _, err := Decrypt(enc, Identities{PGP: keyring(t, testPasswordKeyring).KeyRing, Passphrase: passphrase("secrets_and_lies")}); err == nil {
+		t.Error("expected an error with a bogus passphrase")
+	}
+	if _, err := Decrypt(enc, Identities{PGP: keyring(t, testPasswordKeyring).KeyRing}); err == nil {
+		t.Error("expected an error without a passphrase")
+	}
+	plain, err := Decrypt(enc, Identities{PGP: keyring(t, testPasswordKeyring).KeyRing, Passphrase: passphrase("secret")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, testValues, plain)
+}
+
+func TestDecryptTampered(t *testing.T) {
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := Identities{Age: []*age.X25519Identity{id}}
+	enc, err := Encrypt([]byte(testValues), Recipients{Age: []*age.X25519Recipient{id.Recipient()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(enc, &values); err != nil {
+		t.Fatal(err)
+	}
+	db := values["db"].(map[string]interface{})
+
+	tests := []struct {
+		name   string
+		tamper func(map[string]interface{})
+		err    string
+	}{
+		{
+			name:   "value moved to another key",
+			tamper: func(v map[string]interface{}) { v["db"].(map[string]interface{})["user"] = db["password"] },
+			err:    "cannot decrypt the value of db.user",
+		},
+		{
+			name:   "value removed",
+			tamper: func(v map[string]interface{}) { delete(v["db"].(map[string]interface{}), "password") },
+			err:    ErrMAC.Error(),
+		},
+		{
+			name:   "plain value added",
+			tamper: func(v map[string]interface{}) { v["debug"] = true },
+			err:    "value of debug is not encrypted",
+		},
+		{
+			name:   "null value added",
+			tamper: func(v map[string]interface{}) { v["debug"] = nil },
+			err:    ErrMAC.Error(),
+		},
+		{
+			name:   "empty map added",
+			tamper: func(v map[string]interface{}) { v["db"].(map[string]interface{})["options"] = map[string]interface{}{} },
+			err:    ErrMAC.Error(),
+		},
+		{
+			name:   "null value removed",
+			tamper: func(v map[string]interface{}) { delete(v["db"].(map[string]interface{}), "ca") },
+			err:    ErrMAC.Error(),
+		},
+		{
+			name:   "empty map moved",
+			tamper: func(v map[string]interface{}) { v["other"] = v["empty"]; delete(v, "empty") },
+			err:    ErrMAC.Error(),
+		},
+		{
+			name:   "list items swapped",
+			tamper: func(v map[string]interface{}) { h := v["hosts"].([]interface{}); h[0], h[1] = h[1], h[0] },
+			err:    "cannot decrypt the value of hosts[0].name",
+		},
+	}
+
+	for _, tt := range tests {
+		v := map[string]interface{}{}
+		if err := yaml.Unmarshal(enc, &v); err != nil {
+			t.Fatal(err)
+		}
+		tt.tamper(v)
+		b, err := yaml.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = Decrypt(b, ids)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected error %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+func TestEdit(t *testing.T) {
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := Identities{Age: []*age.X25519Identity{id}}
+	enc, err := Encrypt([]byte(testValues), Recipients{Age: []*age.X25519Recipient{id.Recipient()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edited, err := Edit(enc, ids, func(plain []byte) ([]byte, error) {
+		assertValues(t, testValues, plain)
+		return []byte("db:\n  password: correct-horse\n"), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(edited, []byte("correct-horse")) {
+		t.Errorf("edited values file is not encrypted:\n%s", edited)
+	}
+	plain, err := Decrypt(edited, ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, "db:\n  password: correct-horse\n", plain)
+
+	abort := errors.New("abort")
+	if _, err := Edit(enc, ids, func([]byte) ([]byte, error) { return nil, abort }); err != abort {
+		t.Errorf("expected the error of edit, got %v", err)
+	}
+	if _, err := Edit(enc, ids, func([]byte) ([]byte, error) { return enc, nil }); err == nil {
+		t.Error("expected an error when the edited file has metadata")
+	}
+}
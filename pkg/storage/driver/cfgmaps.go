@@ -30,11 +30,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
+	"k8s.io/helm/pkg/logging"
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 	storageerrors "k8s.io/helm/pkg/storage/errors"
 )
 
 var _ Driver = (*ConfigMaps)(nil)
+var _ objectStore = (*ConfigMaps)(nil)
 
 // ConfigMapsDriverName is the string name of the driver.
 const ConfigMapsDriverName = "ConfigMap"
@@ -42,16 +44,18 @@ const ConfigMapsDriverName = "ConfigMap"
 // ConfigMaps is a wrapper around an implementation of a kubernetes
 // ConfigMapsInterface.
 type ConfigMaps struct {
-	impl corev1.ConfigMapInterface
-	Log  func(string, ...interface{})
+	impl      corev1.ConfigMapInterface
+	Log       logging.Logger
+	ChunkSize int
 }
 
 // NewConfigMaps initializes a new ConfigMaps wrapping an implementation of
 // the kubernetes ConfigMapsInterface.
 func NewConfigMaps(impl corev1.ConfigMapInterface) *ConfigMaps {
 	return &ConfigMaps{
-		impl: impl,
-		Log:  func(_ string, _ ...interface{}) {},
+		impl:      impl,
+		Log:       logging.Discard,
+		ChunkSize: DefaultChunkSize,
 	}
 }
 
@@ -70,13 +74,18 @@ func (cfgmaps *ConfigMaps) Get(key string) (*rspb.Release, error) {
 			return nil, storageerrors.ErrReleaseNotFound(key)
 		}
 
-		cfgmaps.Log("get: failed to get %q: %s", key, err)
+		cfgmaps.Log.Errorf("get: failed to get %q: %s", key, err)
 		return nil, err
 	}
 	// found the configmap, decode the base64 data string
-	r, err := decodeRelease(obj.Data["release"])
+	data, err := readRecord(cfgmaps, configmapObject(obj))
 	if err != nil {
-		cfgmaps.Log("get: failed to decode data %q: %s", key, err)
+		cfgmaps.Log.Errorf("get: failed to read %q: %s", key, err)
+		return nil, err
+	}
+	r, err := decodeRelease(data)
+	if err != nil {
+		cfgmaps.Log.Errorf("get: failed to decode data %q: %s", key, err)
 		return nil, err
 	}
 	// return the release object
@@ -92,7 +101,7 @@ func (cfgmaps *ConfigMaps) List(filter func(*rspb.Release) bool) ([]*rspb.Releas
 
 	list, err := cfgmaps.impl.List(context.TODO(), opts)
 	if err != nil {
-		cfgmaps.Log("list: failed to list: %s", err)
+		cfgmaps.Log.Errorf("list: failed to list: %s", err)
 		return nil, err
 	}
 
@@ -101,9 +110,14 @@ func (cfgmaps *ConfigMaps) List(filter func(*rspb.Release) bool) ([]*rspb.Releas
 	// iterate over the configmaps object list
 	// and decode each release
 	for _, item := range list.Items {
-		rls, err := decodeRelease(item.Data["release"])
+		data, err := readRecord(cfgmaps, configmapObject(&item))
 		if err != nil {
-			cfgmaps.Log("list: failed to decode release: %v: %s", item, err)
+			cfgmaps.Log.Errorf("list: failed to read release %q: %s", item.Name, err)
+			continue
+		}
+		rls, err := decodeRelease(data)
+		if err != nil {
+			cfgmaps.Log.Errorf("list: failed to decode release: %v: %s", item, err)
 			continue
 		}
 		if filter(rls) {
@@ -128,7 +142,7 @@ func (cfgmaps *ConfigMaps) Query(labels map[string]string) ([]*rspb.Release, err
 
 	list, err := cfgmaps.impl.List(context.TODO(), opts)
 	if err != nil {
-		cfgmaps.Log("query: failed to query with labels: %s", err)
+		cfgmaps.Log.Errorf("query: failed to query with labels: %s", err)
 		return nil, err
 	}
 
@@ -138,9 +152,14 @@ func (cfgmaps *ConfigMaps) Query(labels map[string]string) ([]*rspb.Release, err
 
 	var results []*rspb.Release
 	for _, item := range list.Items {
-		rls, err := decodeRelease(item.Data["release"])
+		data, err := readRecord(cfgmaps, configmapObject(&item))
+		if err != nil {
+			cfgmaps.Log.Errorf("query: failed to read release %q: %s", item.Name, err)
+			continue
+		}
+		rls, err := decodeRelease(data)
 		if err != nil {
-			cfgmaps.Log("query: failed to decode release: %s", err)
+			cfgmaps.Log.Errorf("query: failed to decode release: %s", err)
 			continue
 		}
 		results = append(results, rls)
@@ -160,16 +179,17 @@ func (cfgmaps *ConfigMaps) Create(key string, rls *rspb.Release) error {
 	// create a new configmap to hold the release
 	obj, err := newConfigMapsObject(key, rls, lbs)
 	if err != nil {
-		cfgmaps.Log("create: failed to encode release %q: %s", rls.Name, err)
+		cfgmaps.Log.Errorf("create: failed to encode release %q: %s", rls.Name, err)
 		return err
 	}
-	// push the configmap object out into the kubiverse
-	if _, err := cfgmaps.impl.Create(context.TODO(), obj, metav1.CreateOptions{}); err != nil {
+	// push the configmap object out into the kubiverse, preceded by the
+	// chunks of an oversized release
+	if err := createRecord(cfgmaps, configmapObject(obj), cfgmaps.ChunkSize, cfgmaps.Log); err != nil {
 		if apierrors.IsAlreadyExists(err) {
 			return storageerrors.ErrReleaseExists(key)
 		}
 
-		cfgmaps.Log("create: failed to create: %s", err)
+		cfgmaps.Log.Errorf("create: failed to create: %s", err)
 		return err
 	}
 	return nil
@@ -187,13 +207,16 @@ func (cfgmaps *ConfigMaps) Update(key string, rls *rspb.Release) error {
 	// create a new configmap object to hold the release
 	obj, err := newConfigMapsObject(key, rls, lbs)
 	if err != nil {
-		cfgmaps.Log("update: failed to encode release %q: %s", rls.Name, err)
+		cfgmaps.Log.Errorf("update: failed to encode release %q: %s", rls.Name, err)
 		return err
 	}
-	// push the configmap object out into the kubiverse
-	_, err = cfgmaps.impl.Update(context.TODO(), obj, metav1.UpdateOptions{})
-	if err != nil {
-		cfgmaps.Log("update: failed to update: %s", err)
+	// push the configmap object out into the kubiverse, replacing the chunks
+	// of the previous release
+	if err := updateRecord(cfgmaps, configmapObject(obj), cfgmaps.ChunkSize, cfgmaps.Log); err != nil {
+		if apierrors.IsNotFound(err) {
+			return storageerrors.ErrReleaseNotFound(key)
+		}
+		cfgmaps.Log.Errorf("update: failed to update: %s", err)
 		return err
 	}
 	return nil
@@ -207,29 +230,76 @@ func (cfgmaps *ConfigMaps) Delete(key string) (rls *rspb.Release, err error) {
 			return nil, storageerrors.ErrReleaseExists(rls.Name)
 		}
 
-		cfgmaps.Log("delete: failed to get release %q: %s", key, err)
+		cfgmaps.Log.Errorf("delete: failed to get release %q: %s", key, err)
 		return nil, err
 	}
-	// delete the release
+	// delete the release, its chunks are no longer referenced afterwards
 	if err = cfgmaps.impl.Delete(context.TODO(), key, metav1.DeleteOptions{}); err != nil {
 		return rls, err
 	}
+	deleteChunks(cfgmaps, key, nil, cfgmaps.Log)
 	return rls, nil
 }
 
+// configmapObject returns the object held by cfgmap.
+func configmapObject(cfgmap *v1.ConfigMap) *object {
+	return &object{name: cfgmap.Name, labels: cfgmap.Labels, data: cfgmap.Data}
+}
+
+// objectConfigMap returns the configmap holding obj.
+func objectConfigMap(obj *object) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: obj.name, Labels: obj.labels},
+		Data:       obj.data,
+	}
+}
+
+func (cfgmaps *ConfigMaps) getObject(name string) (*object, error) {
+	obj, err := cfgmaps.impl.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return configmapObject(obj), nil
+}
+
+func (cfgmaps *ConfigMaps) listObjects(selector string) ([]*object, error) {
+	list, err := cfgmaps.impl.List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]*object, 0, len(list.Items))
+	for i := range list.Items {
+		objs = append(objs, configmapObject(&list.Items[i]))
+	}
+	return objs, nil
+}
+
+func (cfgmaps *ConfigMaps) createObject(obj *object) error {
+	_, err := cfgmaps.impl.Create(context.TODO(), objectConfigMap(obj), metav1.CreateOptions{})
+	return err
+}
+
+func (cfgmaps *ConfigMaps) updateObject(obj *object) error {
+	_, err := cfgmaps.impl.Update(context.TODO(), objectConfigMap(obj), metav1.UpdateOptions{})
+	return err
+}
+
+func (cfgmaps *ConfigMaps) deleteObject(name string) error {
+	return cfgmaps.impl.Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
 // newConfigMapsObject constructs a kubernetes ConfigMap object
 // to store a release. Each configmap data entry is the base64
 // encoded string of a release's binary protobuf encoding.
 //
 // The following labels are used within each configmap:
 //
-//    "MODIFIED_AT"    - timestamp indicating when this configmap was last modified. (set in Update)
-//    "CREATED_AT"     - timestamp indicating when this configmap was created. (set in Create)
-//    "VERSION"        - version of the release.
-//    "STATUS"         - status of the release (see proto/hapi/release.status.pb.go for variants)
-//    "OWNER"          - owner of the configmap, currently "TILLER".
-//    "NAME"           - name of the release.
-//
+//	"MODIFIED_AT"    - timestamp indicating when this configmap was last modified. (set in Update)
+//	"CREATED_AT"     - timestamp indicating when this configmap was created. (set in Create)
+//	"VERSION"        - version of the release.
+//	"STATUS"         - status of the release (see proto/hapi/release.status.pb.go for variants)
+//	"OWNER"          - owner of the configmap, currently "TILLER".
+//	"NAME"           - name of the release.
 func newConfigMapsObject(key string, rls *rspb.Release, lbs labels) (*v1.ConfigMap, error) {
 	const owner = "TILLER"
 
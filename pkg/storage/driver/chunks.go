@@ -0,0 +1,273 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kblabels "k8s.io/apimachinery/pkg/labels"
+
+	"k8s.io/helm/pkg/logging"
+)
+
+// DefaultChunkSize is the size in bytes above which the ConfigMaps and
+// Secrets drivers split an encoded release across several objects. It keeps
+// every object well below the 1 MiB object size limit of etcd.
+const DefaultChunkSize = 512 * 1024
+
+// Records larger than the chunk size are stored as a head object and a number
+// of chunk objects. The head object carries the usual labels, the first part
+// of the record, the number of parts and the digest of the whole record, so
+// that Query keeps working on heads alone. The remaining parts are stored in
+// chunk objects named after the head and the digest. Chunk objects have their
+// own owner label, which keeps them out of List and Query.
+//
+// Chunks are written before and deleted after their head, so the head is the
+// point at which a record atomically appears or disappears.
+const (
+	// chunkOwner is the OWNER label of chunk objects.
+	chunkOwner = "TILLER_CHUNK"
+	// chunksLabel is the head label holding the number of parts of a record.
+	chunksLabel = "CHUNKS"
+	// chunkOfLabel is the chunk label holding the key of the head.
+	chunkOfLabel = "CHUNK_OF"
+	// digestKey is the data key of the head holding the record digest.
+	digestKey = "digest"
+)
+
+// object is the name, labels and data of a ConfigMap or Secret holding a
+// record or a chunk of one.
+type object struct {
+	name   string
+	labels map[string]string
+	data   map[string]string
+}
+
+// objectStore is implemented by the drivers storing records in ConfigMaps or
+// Secrets. Errors are returned as received from the Kubernetes API.
+type objectStore interface {
+	getObject(name string) (*object, error)
+	listObjects(selector string) ([]*object, error)
+	createObject(obj *object) error
+	updateObject(obj *object) error
+	deleteObject(name string) error
+}
+
+// splitRecord splits the encoded record s into parts of at most size bytes.
+func splitRecord(s string, size int) []string {
+	if size <= 0 || len(s) <= size {
+		return []string{s}
+	}
+	var parts []string
+	for len(s) > size {
+		parts = append(parts, s[:size])
+		s = s[size:]
+	}
+	return append(parts, s)
+}
+
+// joinRecord reassembles the parts of a record and verifies them against digest.
+func joinRecord(key string, parts []string, digest string) (string, error) {
+	s := strings.Join(parts, "")
+	if recordDigest(s) != digest {
+		return "", fmt.Errorf("release %q is corrupt: digest of its %d chunks does not match", key, len(parts))
+	}
+	return s, nil
+}
+
+// recordDigest returns the hex encoded SHA-256 digest of the encoded record s.
+func recordDigest(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// validDigest reports whether digest is a digest returned by recordDigest.
+func validDigest(digest string) bool {
+	_, err := hex.DecodeString(digest)
+	return err == nil && len(digest) == sha256.Size*2
+}
+
+// chunkName returns the object name of part i of the record stored under key.
+func chunkName(key, digest string, i int) string {
+	return fmt.Sprintf("%s.%s.%d", key, digest[:16], i)
+}
+
+// chunkLabels returns the labels of a chunk of the record stored under key.
+func chunkLabels(key string) labels {
+	var lbs labels
+	lbs.init()
+	lbs.set("OWNER", chunkOwner)
+	lbs.set(chunkOfLabel, key)
+	return lbs
+}
+
+// chunkCount returns the number of parts of the record with the head labels lbs.
+func chunkCount(lbs map[string]string) (int, error) {
+	v, ok := lbs[chunksLabel]
+	if !ok {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid %s label %q", chunksLabel, v)
+	}
+	return n, nil
+}
+
+// readRecord returns the encoded release held by obj, reassembling it
+// from its chunks if it was split.
+func readRecord(store objectStore, obj *object) (string, error) {
+	n, err := chunkCount(obj.labels)
+	if err != nil {
+		return "", err
+	}
+	if n == 1 {
+		return obj.data["release"], nil
+	}
+	digest := obj.data[digestKey]
+	if !validDigest(digest) {
+		return "", fmt.Errorf("release %q has an invalid digest", obj.name)
+	}
+	parts := []string{obj.data["release"]}
+	for i := 1; i < n; i++ {
+		chunk, err := store.getObject(chunkName(obj.name, digest, i))
+		if err != nil {
+			return "", fmt.Errorf("release %q is missing chunk %d of %d: %s", obj.name, i+1, n, err)
+		}
+		parts = append(parts, chunk.data["release"])
+	}
+	return joinRecord(obj.name, parts, digest)
+}
+
+// splitObject splits the release held by obj if it is larger than size.
+// obj keeps the first part and the returned chunks hold the rest.
+func splitObject(obj *object, size int) []*object {
+	data := obj.data["release"]
+	parts := splitRecord(data, size)
+	if len(parts) == 1 {
+		return nil
+	}
+	digest := recordDigest(data)
+	obj.labels[chunksLabel] = strconv.Itoa(len(parts))
+	obj.data = map[string]string{"release": parts[0], digestKey: digest}
+
+	chunks := make([]*object, 0, len(parts)-1)
+	for i := 1; i < len(parts); i++ {
+		chunks = append(chunks, &object{
+			name:   chunkName(obj.name, digest, i),
+			labels: chunkLabels(obj.name).toMap(),
+			data:   map[string]string{"release": parts[i]},
+		})
+	}
+	return chunks
+}
+
+// createRecord stores obj, splitting it if it is larger than size. The
+// chunks are written before obj, so that the record appears all at once.
+func createRecord(store objectStore, obj *object, size int, log logging.Logger) error {
+	chunks := splitObject(obj, size)
+	created, err := writeChunks(store, chunks)
+	if err != nil {
+		log.Errorf("failed to create chunks of %q: %s", obj.name, err)
+		deleteObjects(store, created, log)
+		return err
+	}
+	if err := store.createObject(obj); err != nil {
+		deleteObjects(store, created, log)
+		return err
+	}
+	return nil
+}
+
+// updateRecord replaces the record stored under the name of obj, splitting
+// it if it is larger than size. The chunks of the previous record are
+// deleted once obj no longer references them.
+func updateRecord(store objectStore, obj *object, size int, log logging.Logger) error {
+	chunks := splitObject(obj, size)
+	created, err := writeChunks(store, chunks)
+	if err != nil {
+		log.Errorf("failed to create chunks of %q: %s", obj.name, err)
+		deleteObjects(store, created, log)
+		return err
+	}
+	if err := store.updateObject(obj); err != nil {
+		deleteObjects(store, created, log)
+		return err
+	}
+	deleteChunks(store, obj.name, chunks, log)
+	return nil
+}
+
+// writeChunks stores chunks, replacing existing chunks of the same name. It
+// returns the names of the chunks it created.
+func writeChunks(store objectStore, chunks []*object) ([]string, error) {
+	var created []string
+	for _, chunk := range chunks {
+		err := store.createObject(chunk)
+		if apierrors.IsAlreadyExists(err) {
+			err = store.updateObject(chunk)
+		} else if err == nil {
+			created = append(created, chunk.name)
+		}
+		if err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// deleteChunks deletes the chunks of the record stored under key, except
+// for the chunks in keep.
+func deleteChunks(store objectStore, key string, keep []*object, log logging.Logger) {
+	lsel := kblabels.Set(chunkLabels(key).toMap()).AsSelector()
+	list, err := store.listObjects(lsel.String())
+	if err != nil {
+		log.Errorf("failed to list chunks of %q: %s", key, err)
+		return
+	}
+	var names []string
+	for _, item := range list {
+		if item.labels[chunkOfLabel] != key {
+			continue
+		}
+		stale := true
+		for _, chunk := range keep {
+			if chunk.name == item.name {
+				stale = false
+			}
+		}
+		if stale {
+			names = append(names, item.name)
+		}
+	}
+	deleteObjects(store, names, log)
+}
+
+// deleteObjects deletes the named objects, logging failures.
+func deleteObjects(store objectStore, names []string, log logging.Logger) {
+	for _, name := range names {
+		err := store.deleteObject(name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Errorf("failed to delete %q: %s", name, err)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+const testChunkSize = 256
+
+// largeReleaseStub returns a release whose encoding does not fit into a single chunk.
+func largeReleaseStub(t *testing.T, name string, vers int32, code rspb.Status_Code) *rspb.Release {
+	b := make([]byte, 4*testChunkSize)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	rls := releaseStub(name, vers, "default", code)
+	rls.Manifest = base64.StdEncoding.EncodeToString(b)
+	return rls
+}
+
+func TestSplitRecord(t *testing.T) {
+	tests := []struct {
+		data     string
+		size     int
+		expected []string
+	}{
+		{"abcdef", 0, []string{"abcdef"}},
+		{"abcdef", 6, []string{"abcdef"}},
+		{"abcdef", 4, []string{"abcd", "ef"}},
+		{"abcdef", 2, []string{"ab", "cd", "ef"}},
+	}
+	for _, tt := range tests {
+		parts := splitRecord(tt.data, tt.size)
+		if !reflect.DeepEqual(parts, tt.expected) {
+			t.Errorf("splitRecord(%q, %d): expected %q, got %q", tt.data, tt.size, tt.expected, parts)
+		}
+		joined, err := joinRecord("key", parts, recordDigest(tt.data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if joined != tt.data {
+			t.Errorf("expected %q, got %q", tt.data, joined)
+		}
+	}
+
+	if _, err := joinRecord("key", []string{"ab", "dc", "ef"}, recordDigest("abcdef")); err == nil {
+		t.Error("expected reordered chunks to fail verification")
+	}
+}
+
+func TestConfigMapChunks(t *testing.T) {
+	impl := fake.NewSimpleClientset().CoreV1().ConfigMaps("kube-system")
+	cfgmaps := NewConfigMaps(impl)
+	cfgmaps.ChunkSize = testChunkSize
+
+	count := func() int {
+		list, err := impl.List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(list.Items)
+	}
+
+	rls := largeReleaseStub(t, "smug-pigeon", 1, rspb.Status_DEPLOYED)
+	key := testKey(rls.Name, rls.Version)
+	if err := cfgmaps.Create(key, rls); err != nil {
+		t.Fatalf("failed to create release: %s", err)
+	}
+	if n := count(); n < 2 {
+		t.Fatalf("expected the release to be split across several configmaps, got %d", n)
+	}
+
+	got, err := cfgmaps.Get(key)
+	if err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if !proto.Equal(rls, got) {
+		t.Errorf("expected release %v, got %v", rls, got)
+	}
+
+	rels, err := cfgmaps.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 1 || rels[0].Manifest != rls.Manifest {
+		t.Errorf("expected List to return the reassembled release, got %d releases", len(rels))
+	}
+	rels, err = cfgmaps.Query(map[string]string{"NAME": rls.Name, "OWNER": "TILLER"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 1 || rels[0].Manifest != rls.Manifest {
+		t.Errorf("expected Query to return the reassembled release, got %d releases", len(rels))
+	}
+
+	// replacing the release with a different one must not leave old chunks behind
+	updated := largeReleaseStub(t, "smug-pigeon", 1, rspb.Status_SUPERSEDED)
+	before := count()
+	if err := cfgmaps.Update(key, updated); err != nil {
+		t.Fatalf("failed to update release: %s", err)
+	}
+	if n := count(); n != before {
+		t.Errorf("expected %d configmaps after update, got %d", before, n)
+	}
+	got, err = cfgmaps.Get(key)
+	if err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if !proto.Equal(updated, got) {
+		t.Errorf("expected release %v, got %v", updated, got)
+	}
+
+	// a small release fits into a single configmap again
+	if err := cfgmaps.Update(key, releaseStub("smug-pigeon", 1, "default", rspb.Status_SUPERSEDED)); err != nil {
+		t.Fatalf("failed to update release: %s", err)
+	}
+	if n := count(); n != 1 {
+		t.Errorf("expected 1 configmap after update, got %d", n)
+	}
+
+	if err := cfgmaps.Update(key, updated); err != nil {
+		t.Fatalf("failed to update release: %s", err)
+	}
+	if _, err := cfgmaps.Delete(key); err != nil {
+		t.Fatalf("failed to delete release: %s", err)
+	}
+	if n := count(); n != 0 {
+		t.Errorf("expected all configmaps to be deleted, got %d", n)
+	}
+}
+
+func TestConfigMapCorruptChunks(t *testing.T) {
+	impl := fake.NewSimpleClientset().CoreV1().ConfigMaps("kube-system")
+	cfgmaps := NewConfigMaps(impl)
+	cfgmaps.ChunkSize = testChunkSize
+
+	rls := largeReleaseStub(t, "smug-pigeon", 1, rspb.Status_DEPLOYED)
+	key := testKey(rls.Name, rls.Version)
+	if err := cfgmaps.Create(key, rls); err != nil {
+		t.Fatalf("failed to create release: %s", err)
+	}
+	head, err := impl.Get(context.TODO(), key, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := chunkName(key, head.Data[digestKey], 1)
+	chunk, err := impl.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunk.Data["release"] = strings.ToUpper(chunk.Data["release"])
+	if _, err := impl.Update(context.TODO(), chunk, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cfgmaps.Get(key); err == nil || !strings.Contains(err.Error(), "is corrupt") {
+		t.Errorf("expected corrupt release error, got %v", err)
+	}
+	rels, err := cfgmaps.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 0 {
+		t.Errorf("expected List to skip the corrupt release, got %d releases", len(rels))
+	}
+
+	if err := impl.Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cfgmaps.Get(key); err == nil || !strings.Contains(err.Error(), "is missing chunk 2") {
+		t.Errorf("expected missing chunk error, got %v", err)
+	}
+}
+
+func TestSecretChunks(t *testing.T) {
+	impl := fake.NewSimpleClientset().CoreV1().Secrets("kube-system")
+	secrets := NewSecrets(impl)
+	secrets.ChunkSize = testChunkSize
+
+	count := func() int {
+		list, err := impl.List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(list.Items)
+	}
+
+	rls := largeReleaseStub(t, "smug-pigeon", 1, rspb.Status_DEPLOYED)
+	key := testKey(rls.Name, rls.Version)
+	if err := secrets.Create(key, rls); err != nil {
+		t.Fatalf("failed to create release: %s", err)
+	}
+	if n := count(); n < 2 {
+		t.Fatalf("expected the release to be split across several secrets, got %d", n)
+	}
+	if err := secrets.Create(key, rls); err == nil {
+		t.Error("expected creating an existing release to fail")
+	}
+
+	got, err := secrets.Get(key)
+	if err != nil {
+		t.Fatalf("failed to get release: %s", err)
+	}
+	if !proto.Equal(rls, got) {
+		t.Errorf("expected release %v, got %v", rls, got)
+	}
+	rels, err := secrets.Query(map[string]string{"NAME": rls.Name, "OWNER": "TILLER"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 1 || rels[0].Manifest != rls.Manifest {
+		t.Errorf("expected Query to return the reassembled release, got %d releases", len(rels))
+	}
+
+	if _, err := secrets.Delete(key); err != nil {
+		t.Fatalf("failed to delete release: %s", err)
+	}
+	if n := count(); n != 0 {
+		t.Errorf("expected all secrets to be deleted, got %d", n)
+	}
+}
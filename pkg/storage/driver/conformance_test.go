@@ -0,0 +1,117 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/storage/driver/drivertest"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	drivertest.Run(t, func(*testing.T) driver.Driver {
+		return driver.NewMemory()
+	})
+}
+
+func TestConfigMapsConformance(t *testing.T) {
+	drivertest.Run(t, func(*testing.T) driver.Driver {
+		return driver.NewConfigMaps(fake.NewSimpleClientset().CoreV1().ConfigMaps("kube-system"))
+	})
+}
+
+func TestSecretsConformance(t *testing.T) {
+	drivertest.Run(t, func(*testing.T) driver.Driver {
+		return driver.NewSecrets(fake.NewSimpleClientset().CoreV1().Secrets("kube-system"))
+	})
+}
+
+func TestPluginConformance(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T) driver.Driver {
+		return servePlugin(t, driver.NewMemory())
+	})
+}
+
+// servePlugin serves d as a storage plugin and returns a driver connected to it.
+func servePlugin(t *testing.T, d driver.Driver) *driver.Plugin {
+	dir, err := ioutil.TempDir("", "helm-storage-plugin-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sock := filepath.Join(dir, "storage.sock")
+	lis, err := net.Listen("unix", sock)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	go driver.ServePlugin(lis, d)
+
+	p, err := driver.DialPlugin("unix://"+sock, 5*time.Second)
+	if err != nil {
+		lis.Close()
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		p.Close()
+		lis.Close()
+		os.RemoveAll(dir)
+	})
+	return p
+}
+
+func TestDialPluginRejectsNetworkAddresses(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1:44137", "unix://"} {
+		if _, err := driver.DialPlugin(addr, time.Second); err == nil {
+			t.Errorf("expected %q to be rejected", addr)
+		}
+	}
+}
+
+// failingDriver fails every call with err.
+type failingDriver struct {
+	driver.Driver
+	err error
+}
+
+func (d failingDriver) Get(string) (*rspb.Release, error) { return nil, d.err }
+
+func TestPluginErrorsByType(t *testing.T) {
+	// an error that merely reads like a missing release is not one
+	p := servePlugin(t, failingDriver{driver.NewMemory(), errors.New("backend not found")})
+	_, err := p.Get("smug-pigeon.v1")
+	if err == nil || storageerrors.IsReleaseNotFound(err) {
+		t.Errorf("expected a plain error, got %v", err)
+	}
+
+	p = servePlugin(t, failingDriver{driver.NewMemory(), fmt.Errorf("reading: %w", storageerrors.ErrReleaseNotFound("smug-pigeon.v1"))})
+	if _, err := p.Get("smug-pigeon.v1"); !storageerrors.IsReleaseNotFound(err) {
+		t.Errorf("expected a release not found error, got %v", err)
+	}
+}
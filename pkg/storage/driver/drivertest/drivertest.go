@@ -0,0 +1,264 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drivertest provides a conformance test suite for release storage
+// drivers.
+//
+// Both built-in drivers and storage plugins are expected to pass it:
+//
+//	func TestConformance(t *testing.T) {
+//		drivertest.Run(t, func(t *testing.T) driver.Driver {
+//			return newEmptyDriver(t)
+//		})
+//	}
+package drivertest // import "k8s.io/helm/pkg/storage/driver/drivertest"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+// Run runs the conformance suite. Every test calls newDriver for a driver
+// without any stored releases.
+func Run(t *testing.T, newDriver func(t *testing.T) driver.Driver) {
+	tests := []struct {
+		name string
+		fn   func(*testing.T, driver.Driver)
+	}{
+		{"Name", testName},
+		{"CreateAndGet", testCreateAndGet},
+		{"CreateExisting", testCreateExisting},
+		{"GetMissing", testGetMissing},
+		{"Update", testUpdate},
+		{"UpdateMissing", testUpdateMissing},
+		{"Delete", testDelete},
+		{"DeleteMissing", testDeleteMissing},
+		{"List", testList},
+		{"Query", testQuery},
+		{"QueryNoMatch", testQueryNoMatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newDriver(t))
+		})
+	}
+}
+
+// ReleaseStub returns a release as it is stored by Tiller.
+func ReleaseStub(name string, vers int32, code rspb.Status_Code) *rspb.Release {
+	return &rspb.Release{
+		Name:      name,
+		Version:   vers,
+		Namespace: "default",
+		Manifest:  fmt.Sprintf("# %s version %d\n", name, vers),
+		Info:      &rspb.Info{Status: &rspb.Status{Code: code}},
+	}
+}
+
+// Key returns the key Tiller stores a release under.
+func Key(rls *rspb.Release) string {
+	return fmt.Sprintf("%s.v%d", rls.Name, rls.Version)
+}
+
+func create(t *testing.T, d driver.Driver, rels ...*rspb.Release) {
+	t.Helper()
+	for _, rls := range rels {
+		if err := d.Create(Key(rls), rls); err != nil {
+			t.Fatalf("failed to create %q: %s", Key(rls), err)
+		}
+	}
+}
+
+func expectNotFound(t *testing.T, op string, err error) {
+	t.Helper()
+	if err == nil || !storageerrors.IsReleaseNotFound(err) {
+		t.Errorf("%s: expected a not found error, got %v", op, err)
+	}
+}
+
+// keys returns the sorted keys of rels.
+func keys(rels []*rspb.Release) []string {
+	ks := make([]string, 0, len(rels))
+	for _, rls := range rels {
+		ks = append(ks, Key(rls))
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+func expectKeys(t *testing.T, op string, rels []*rspb.Release, expected ...string) {
+	t.Helper()
+	sort.Strings(expected)
+	if got := keys(rels); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("%s: expected releases %v, got %v", op, expected, got)
+	}
+}
+
+func testName(t *testing.T, d driver.Driver) {
+	if d.Name() == "" {
+		t.Error("expected the driver to have a name")
+	}
+}
+
+func testCreateAndGet(t *testing.T, d driver.Driver) {
+	rls := ReleaseStub("smug-pigeon", 1, rspb.Status_DEPLOYED)
+	create(t, d, rls)
+
+	got, err := d.Get(Key(rls))
+	if err != nil {
+		t.Fatalf("failed to get %q: %s", Key(rls), err)
+	}
+	if !proto.Equal(rls, got) {
+		t.Errorf("expected release %v, got %v", rls, got)
+	}
+}
+
+func testCreateExisting(t *testing.T, d driver.Driver) {
+	rls := ReleaseStub("smug-pigeon", 1, rspb.Status_DEPLOYED)
+	create(t, d, rls)
+
+	err := d.Create(Key(rls), rls)
+	if err == nil || !storageerrors.IsReleaseExists(err) {
+		t.Errorf("expected an already exists error, got %v", err)
+	}
+}
+
+func testGetMissing(t *testing.T, d driver.Driver) {
+	_, err := d.Get("smug-pigeon.v1")
+	expectNotFound(t, "get", err)
+}
+
+func testUpdate(t *testing.T, d driver.Driver) {
+	rls := ReleaseStub("smug-pigeon", 1, rspb.Status_DEPLOYED)
+	create(t, d, rls)
+
+	updated := ReleaseStub("smug-pigeon", 1, rspb.Status_SUPERSEDED)
+	if err := d.Update(Key(updated), updated); err != nil {
+		t.Fatalf("failed to update %q: %s", Key(updated), err)
+	}
+	got, err := d.Get(Key(rls))
+	if err != nil {
+		t.Fatalf("failed to get %q: %s", Key(rls), err)
+	}
+	if !proto.Equal(updated, got) {
+		t.Errorf("expected release %v, got %v", updated, got)
+	}
+
+	rels, err := d.Query(map[string]string{"NAME": "smug-pigeon", "OWNER": "TILLER", "STATUS": "SUPERSEDED"})
+	if err != nil {
+		t.Fatalf("failed to query: %s", err)
+	}
+	expectKeys(t, "query after update", rels, "smug-pigeon.v1")
+}
+
+func testUpdateMissing(t *testing.T, d driver.Driver) {
+	rls := ReleaseStub("smug-pigeon", 1, rspb.Status_DEPLOYED)
+	expectNotFound(t, "update", d.Update(Key(rls), rls))
+}
+
+func testDelete(t *testing.T, d driver.Driver) {
+	rls := ReleaseStub("smug-pigeon", 1, rspb.Status_DEPLOYED)
+	other := ReleaseStub("smug-pigeon", 2, rspb.Status_DEPLOYED)
+	create(t, d, rls, other)
+
+	got, err := d.Delete(Key(rls))
+	if err != nil {
+		t.Fatalf("failed to delete %q: %s", Key(rls), err)
+	}
+	if !proto.Equal(rls, got) {
+		t.Errorf("expected deleted release %v, got %v", rls, got)
+	}
+	_, err = d.Get(Key(rls))
+	expectNotFound(t, "get after delete", err)
+
+	if _, err := d.Get(Key(other)); err != nil {
+		t.Errorf("expected %q to survive the delete: %s", Key(other), err)
+	}
+}
+
+func testDeleteMissing(t *testing.T, d driver.Driver) {
+	_, err := d.Delete("smug-pigeon.v1")
+	expectNotFound(t, "delete", err)
+}
+
+func testList(t *testing.T, d driver.Driver) {
+	create(t, d,
+		ReleaseStub("smug-pigeon", 1, rspb.Status_SUPERSEDED),
+		ReleaseStub("smug-pigeon", 2, rspb.Status_DEPLOYED),
+		ReleaseStub("angry-bird", 1, rspb.Status_DELETED),
+	)
+
+	rels, err := d.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatalf("failed to list: %s", err)
+	}
+	expectKeys(t, "list", rels, "smug-pigeon.v1", "smug-pigeon.v2", "angry-bird.v1")
+
+	rels, err = d.List(func(rls *rspb.Release) bool {
+		return rls.Info.Status.Code == rspb.Status_DEPLOYED
+	})
+	if err != nil {
+		t.Fatalf("failed to list: %s", err)
+	}
+	expectKeys(t, "list deployed", rels, "smug-pigeon.v2")
+}
+
+func testQuery(t *testing.T, d driver.Driver) {
+	create(t, d,
+		ReleaseStub("smug-pigeon", 1, rspb.Status_SUPERSEDED),
+		ReleaseStub("smug-pigeon", 2, rspb.Status_DEPLOYED),
+		ReleaseStub("angry-bird", 1, rspb.Status_DEPLOYED),
+	)
+
+	tests := []struct {
+		labels   map[string]string
+		expected []string
+	}{
+		{map[string]string{"NAME": "smug-pigeon", "OWNER": "TILLER"}, []string{"smug-pigeon.v1", "smug-pigeon.v2"}},
+		{map[string]string{"NAME": "smug-pigeon", "OWNER": "TILLER", "STATUS": "DEPLOYED"}, []string{"smug-pigeon.v2"}},
+		{map[string]string{"NAME": "smug-pigeon", "OWNER": "TILLER", "VERSION": "1"}, []string{"smug-pigeon.v1"}},
+		{map[string]string{"OWNER": "TILLER", "STATUS": "DEPLOYED"}, []string{"smug-pigeon.v2", "angry-bird.v1"}},
+	}
+	for _, tt := range tests {
+		rels, err := d.Query(tt.labels)
+		if err != nil {
+			t.Errorf("failed to query %v: %s", tt.labels, err)
+			continue
+		}
+		expectKeys(t, fmt.Sprintf("query %v", tt.labels), rels, tt.expected...)
+	}
+}
+
+// testQueryNoMatch accepts both behaviours of the built-in drivers: an empty
+// result or a not found error.
+func testQueryNoMatch(t *testing.T, d driver.Driver) {
+	create(t, d, ReleaseStub("smug-pigeon", 1, rspb.Status_DEPLOYED))
+
+	rels, err := d.Query(map[string]string{"NAME": "angry-bird", "OWNER": "TILLER"})
+	if err != nil {
+		expectNotFound(t, "query", err)
+		return
+	}
+	expectKeys(t, "query", rels)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package driver // import "k8s.io/helm/pkg/storage/driver"
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -28,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
+	"k8s.io/helm/pkg/logging"
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 )
 
@@ -110,7 +112,7 @@ func (mock *MockConfigMapsInterface) Init(t *testing.T, releases ...*rspb.Releas
 }
 
 // Get returns the ConfigMap by name.
-func (mock *MockConfigMapsInterface) Get(name string, options metav1.GetOptions) (*v1.ConfigMap, error) {
+func (mock *MockConfigMapsInterface) Get(_ context.Context, name string, options metav1.GetOptions) (*v1.ConfigMap, error) {
 	object, ok := mock.objects[name]
 	if !ok {
 		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "tests"}, name)
@@ -119,7 +121,7 @@ func (mock *MockConfigMapsInterface) Get(name string, options metav1.GetOptions)
 }
 
 // List returns the a of ConfigMaps.
-func (mock *MockConfigMapsInterface) List(opts metav1.ListOptions) (*v1.ConfigMapList, error) {
+func (mock *MockConfigMapsInterface) List(_ context.Context, opts metav1.ListOptions) (*v1.ConfigMapList, error) {
 	var list v1.ConfigMapList
 	for _, cfgmap := range mock.objects {
 		list.Items = append(list.Items, *cfgmap)
@@ -128,7 +130,7 @@ func (mock *MockConfigMapsInterface) List(opts metav1.ListOptions) (*v1.ConfigMa
 }
 
 // Create creates a new ConfigMap.
-func (mock *MockConfigMapsInterface) Create(cfgmap *v1.ConfigMap) (*v1.ConfigMap, error) {
+func (mock *MockConfigMapsInterface) Create(_ context.Context, cfgmap *v1.ConfigMap, opts metav1.CreateOptions) (*v1.ConfigMap, error) {
 	name := cfgmap.ObjectMeta.Name
 	if object, ok := mock.objects[name]; ok {
 		return object, apierrors.NewAlreadyExists(schema.GroupResource{Resource: "tests"}, name)
@@ -138,7 +140,7 @@ func (mock *MockConfigMapsInterface) Create(cfgmap *v1.ConfigMap) (*v1.ConfigMap
 }
 
 // Update updates a ConfigMap.
-func (mock *MockConfigMapsInterface) Update(cfgmap *v1.ConfigMap) (*v1.ConfigMap, error) {
+func (mock *MockConfigMapsInterface) Update(_ context.Context, cfgmap *v1.ConfigMap, opts metav1.UpdateOptions) (*v1.ConfigMap, error) {
 	name := cfgmap.ObjectMeta.Name
 	if _, ok := mock.objects[name]; !ok {
 		return nil, apierrors.NewNotFound(v1.Resource("tests"), name)
@@ -148,7 +150,7 @@ func (mock *MockConfigMapsInterface) Update(cfgmap *v1.ConfigMap) (*v1.ConfigMap
 }
 
 // Delete deletes a ConfigMap by name.
-func (mock *MockConfigMapsInterface) Delete(name string, opts *metav1.DeleteOptions) error {
+func (mock *MockConfigMapsInterface) Delete(_ context.Context, name string, opts metav1.DeleteOptions) error {
 	if _, ok := mock.objects[name]; !ok {
 		return apierrors.NewNotFound(v1.Resource("tests"), name)
 	}
@@ -188,7 +190,7 @@ func (mock *MockSecretsInterface) Init(t *testing.T, releases ...*rspb.Release)
 }
 
 // Get returns the Secret by name.
-func (mock *MockSecretsInterface) Get(name string, options metav1.GetOptions) (*v1.Secret, error) {
+func (mock *MockSecretsInterface) Get(_ context.Context, name string, options metav1.GetOptions) (*v1.Secret, error) {
 	object, ok := mock.objects[name]
 	if !ok {
 		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "tests"}, name)
@@ -197,7 +199,7 @@ func (mock *MockSecretsInterface) Get(name string, options metav1.GetOptions) (*
 }
 
 // List returns the a of Secret.
-func (mock *MockSecretsInterface) List(opts metav1.ListOptions) (*v1.SecretList, error) {
+func (mock *MockSecretsInterface) List(_ context.Context, opts metav1.ListOptions) (*v1.SecretList, error) {
 	var list v1.SecretList
 	for _, secret := range mock.objects {
 		list.Items = append(list.Items, *secret)
@@ -206,7 +208,7 @@ func (mock *MockSecretsInterface) List(opts metav1.ListOptions) (*v1.SecretList,
 }
 
 // Create creates a new Secret.
-func (mock *MockSecretsInterface) Create(secret *v1.Secret) (*v1.Secret, error) {
+func (mock *MockSecretsInterface) Create(_ context.Context, secret *v1.Secret, opts metav1.CreateOptions) (*v1.Secret, error) {
 	name := secret.ObjectMeta.Name
 	if object, ok := mock.objects[name]; ok {
 		return object, apierrors.NewAlreadyExists(schema.GroupResource{Resource: "tests"}, name)
@@ -216,7 +218,7 @@ func (mock *MockSecretsInterface) Create(secret *v1.Secret) (*v1.Secret, error)
 }
 
 // Update updates a Secret.
-func (mock *MockSecretsInterface) Update(secret *v1.Secret) (*v1.Secret, error) {
+func (mock *MockSecretsInterface) Update(_ context.Context, secret *v1.Secret, opts metav1.UpdateOptions) (*v1.Secret, error) {
 	name := secret.ObjectMeta.Name
 	if _, ok := mock.objects[name]; !ok {
 		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "tests"}, name)
@@ -226,7 +228,7 @@ func (mock *MockSecretsInterface) Update(secret *v1.Secret) (*v1.Secret, error)
 }
 
 // Delete deletes a Secret by name.
-func (mock *MockSecretsInterface) Delete(name string, opts *metav1.DeleteOptions) error {
+func (mock *MockSecretsInterface) Delete(_ context.Context, name string, opts metav1.DeleteOptions) error {
 	if _, ok := mock.objects[name]; !ok {
 		return apierrors.NewNotFound(schema.GroupResource{Resource: "tests"}, name)
 	}
@@ -244,6 +246,6 @@ func newTestFixtureSQL(t *testing.T, releases ...*rspb.Release) (*SQL, sqlmock.S
 	sqlxDB := sqlx.NewDb(sqlDB, "sqlmock")
 	return &SQL{
 		db:  sqlxDB,
-		Log: func(_ string, _ ...interface{}) {},
+		Log: logging.Discard,
 	}, mock
 }
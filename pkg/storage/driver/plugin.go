@@ -0,0 +1,327 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"k8s.io/helm/pkg/logging"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	storagepb "k8s.io/helm/pkg/proto/hapi/storage"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+var _ Driver = (*Plugin)(nil)
+
+// PluginDriverName is the string name of the driver.
+const PluginDriverName = "Plugin"
+
+// maxPluginMsgSize is the largest message exchanged with a storage plugin.
+const maxPluginMsgSize = 1024 * 1024 * 20
+
+// DefaultPluginTimeout is the default timeout of a single call to a storage plugin.
+const DefaultPluginTimeout = 30 * time.Second
+
+// Plugin is a storage driver that proxies to an external process serving the
+// hapi.storage.StorageDriver gRPC service.
+type Plugin struct {
+	client storagepb.StorageDriverClient
+	conn   *grpc.ClientConn
+
+	// Timeout bounds every call to the plugin.
+	Timeout time.Duration
+	Log     logging.Logger
+}
+
+// NewPlugin initializes a new Plugin driver using conn.
+func NewPlugin(conn *grpc.ClientConn) *Plugin {
+	return &Plugin{
+		client:  storagepb.NewStorageDriverClient(conn),
+		conn:    conn,
+		Timeout: DefaultPluginTimeout,
+		Log:     logging.Discard,
+	}
+}
+
+// DialPlugin connects to the storage plugin listening on addr, which must be
+// a Unix socket of the form "unix:///path/to/socket". Plugins are not reached
+// over the network, as the connection is not authenticated.
+func DialPlugin(addr string, timeout time.Duration) (*Plugin, error) {
+	path := strings.TrimPrefix(addr, "unix://")
+	if path == addr || path == "" {
+		return nil, fmt.Errorf("storage plugin address %q is not a Unix socket", addr)
+	}
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithInsecure(),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxPluginMsgSize),
+			grpc.MaxCallSendMsgSize(maxPluginMsgSize),
+		),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		}),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to storage plugin at %s: %s", addr, err)
+	}
+	return NewPlugin(conn), nil
+}
+
+// Close closes the connection to the plugin.
+func (p *Plugin) Close() error {
+	return p.conn.Close()
+}
+
+// Name returns the name of the driver.
+func (p *Plugin) Name() string {
+	return PluginDriverName
+}
+
+// Get fetches the release named by key.
+func (p *Plugin) Get(key string) (*rspb.Release, error) {
+	ctx, cancel := p.context()
+	defer cancel()
+
+	res, err := p.client.Get(ctx, &storagepb.GetRequest{Key: key})
+	if err != nil {
+		p.Log.Errorf("get: failed to get %q: %s", key, err)
+		return nil, pluginError(err, key)
+	}
+	return res.Release, nil
+}
+
+// List fetches all releases and returns the list of releases such
+// that filter(release) == true. The filter is applied by the driver,
+// so every release is transferred from the plugin.
+func (p *Plugin) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
+	ctx, cancel := p.context()
+	defer cancel()
+
+	stream, err := p.client.List(ctx, &storagepb.ListRequest{})
+	if err != nil {
+		p.Log.Errorf("list: failed to list: %s", err)
+		return nil, pluginError(err, "")
+	}
+	var results []*rspb.Release
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			p.Log.Errorf("list: failed to list: %s", err)
+			return nil, pluginError(err, "")
+		}
+		if filter(res.Release) {
+			results = append(results, res.Release)
+		}
+	}
+}
+
+// Query fetches all releases that match the provided map of labels.
+func (p *Plugin) Query(labels map[string]string) ([]*rspb.Release, error) {
+	ctx, cancel := p.context()
+	defer cancel()
+
+	stream, err := p.client.Query(ctx, &storagepb.QueryRequest{Labels: labels})
+	if err != nil {
+		p.Log.Errorf("query: failed to query with labels: %s", err)
+		return nil, pluginError(err, labels["NAME"])
+	}
+	var results []*rspb.Release
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			p.Log.Errorf("query: failed to query with labels: %s", err)
+			return nil, pluginError(err, labels["NAME"])
+		}
+		results = append(results, res.Release)
+	}
+}
+
+// Create creates a new release stored under key.
+func (p *Plugin) Create(key string, rls *rspb.Release) error {
+	ctx, cancel := p.context()
+	defer cancel()
+
+	if _, err := p.client.Create(ctx, &storagepb.CreateRequest{Key: key, Release: rls}); err != nil {
+		p.Log.Errorf("create: failed to create %q: %s", key, err)
+		return pluginError(err, key)
+	}
+	return nil
+}
+
+// Update updates the release stored under key.
+func (p *Plugin) Update(key string, rls *rspb.Release) error {
+	ctx, cancel := p.context()
+	defer cancel()
+
+	if _, err := p.client.Update(ctx, &storagepb.UpdateRequest{Key: key, Release: rls}); err != nil {
+		p.Log.Errorf("update: failed to update %q: %s", key, err)
+		return pluginError(err, key)
+	}
+	return nil
+}
+
+// Delete deletes the release stored under key and returns it.
+func (p *Plugin) Delete(key string) (*rspb.Release, error) {
+	ctx, cancel := p.context()
+	defer cancel()
+
+	res, err := p.client.Delete(ctx, &storagepb.DeleteRequest{Key: key})
+	if err != nil {
+		p.Log.Errorf("delete: failed to delete %q: %s", key, err)
+		return nil, pluginError(err, key)
+	}
+	return res.Release, nil
+}
+
+func (p *Plugin) context() (context.Context, context.CancelFunc) {
+	if p.Timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), p.Timeout)
+}
+
+// pluginError converts the status returned by a plugin into the error the
+// built-in drivers return in the same situation.
+func pluginError(err error, key string) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return storageerrors.ErrReleaseNotFound(key)
+	case codes.AlreadyExists:
+		return storageerrors.ErrReleaseExists(key)
+	case codes.InvalidArgument:
+		return storageerrors.ErrInvalidKey(key)
+	}
+	return fmt.Errorf("storage plugin: %s", status.Convert(err).Message())
+}
+
+// PluginServer serves a Driver as a storage plugin.
+type PluginServer struct {
+	driver Driver
+}
+
+var _ storagepb.StorageDriverServer = (*PluginServer)(nil)
+
+// NewPluginServer returns a hapi.storage.StorageDriver service backed by d.
+func NewPluginServer(d Driver) *PluginServer {
+	return &PluginServer{driver: d}
+}
+
+// ServePlugin serves d as a storage plugin on lis. It blocks until lis fails
+// or is closed.
+func ServePlugin(lis net.Listener, d Driver) error {
+	srv := grpc.NewServer(
+		grpc.MaxRecvMsgSize(maxPluginMsgSize),
+		grpc.MaxSendMsgSize(maxPluginMsgSize),
+	)
+	storagepb.RegisterStorageDriverServer(srv, NewPluginServer(d))
+	return srv.Serve(lis)
+}
+
+// Create implements the StorageDriver service.
+func (s *PluginServer) Create(_ context.Context, req *storagepb.CreateRequest) (*storagepb.CreateResponse, error) {
+	if err := s.driver.Create(req.Key, req.Release); err != nil {
+		return nil, statusError(err)
+	}
+	return &storagepb.CreateResponse{}, nil
+}
+
+// Update implements the StorageDriver service.
+func (s *PluginServer) Update(_ context.Context, req *storagepb.UpdateRequest) (*storagepb.UpdateResponse, error) {
+	if err := s.driver.Update(req.Key, req.Release); err != nil {
+		return nil, statusError(err)
+	}
+	return &storagepb.UpdateResponse{}, nil
+}
+
+// Delete implements the StorageDriver service.
+func (s *PluginServer) Delete(_ context.Context, req *storagepb.DeleteRequest) (*storagepb.DeleteResponse, error) {
+	rls, err := s.driver.Delete(req.Key)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &storagepb.DeleteResponse{Release: rls}, nil
+}
+
+// Get implements the StorageDriver service.
+func (s *PluginServer) Get(_ context.Context, req *storagepb.GetRequest) (*storagepb.GetResponse, error) {
+	rls, err := s.driver.Get(req.Key)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &storagepb.GetResponse{Release: rls}, nil
+}
+
+// List implements the StorageDriver service.
+func (s *PluginServer) List(_ *storagepb.ListRequest, stream storagepb.StorageDriver_ListServer) error {
+	rels, err := s.driver.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		return statusError(err)
+	}
+	for _, rls := range rels {
+		if err := stream.Send(&storagepb.ListResponse{Release: rls}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Query implements the StorageDriver service.
+func (s *PluginServer) Query(req *storagepb.QueryRequest, stream storagepb.StorageDriver_QueryServer) error {
+	rels, err := s.driver.Query(req.Labels)
+	if err != nil {
+		return statusError(err)
+	}
+	for _, rls := range rels {
+		if err := stream.Send(&storagepb.QueryResponse{Release: rls}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statusError converts an error returned by a driver into a gRPC status error.
+func statusError(err error) error {
+	switch {
+	case storageerrors.IsReleaseNotFound(err):
+		return status.Error(codes.NotFound, err.Error())
+	case storageerrors.IsReleaseExists(err):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case storageerrors.IsInvalidKey(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
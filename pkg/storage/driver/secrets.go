@@ -30,11 +30,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
+	"k8s.io/helm/pkg/logging"
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 	storageerrors "k8s.io/helm/pkg/storage/errors"
 )
 
 var _ Driver = (*Secrets)(nil)
+var _ objectStore = (*Secrets)(nil)
 
 // SecretsDriverName is the string name of the driver.
 const SecretsDriverName = "Secret"
@@ -42,16 +44,18 @@ const SecretsDriverName = "Secret"
 // Secrets is a wrapper around an implementation of a kubernetes
 // SecretsInterface.
 type Secrets struct {
-	impl corev1.SecretInterface
-	Log  func(string, ...interface{})
+	impl      corev1.SecretInterface
+	Log       logging.Logger
+	ChunkSize int
 }
 
 // NewSecrets initializes a new Secrets wrapping an implementation of
 // the kubernetes SecretsInterface.
 func NewSecrets(impl corev1.SecretInterface) *Secrets {
 	return &Secrets{
-		impl: impl,
-		Log:  func(_ string, _ ...interface{}) {},
+		impl:      impl,
+		Log:       logging.Discard,
+		ChunkSize: DefaultChunkSize,
 	}
 }
 
@@ -70,13 +74,18 @@ func (secrets *Secrets) Get(key string) (*rspb.Release, error) {
 			return nil, storageerrors.ErrReleaseNotFound(key)
 		}
 
-		secrets.Log("get: failed to get %q: %s", key, err)
+		secrets.Log.Errorf("get: failed to get %q: %s", key, err)
 		return nil, err
 	}
 	// found the secret, decode the base64 data string
-	r, err := decodeRelease(string(obj.Data["release"]))
+	data, err := readRecord(secrets, secretObject(obj))
 	if err != nil {
-		secrets.Log("get: failed to decode data %q: %s", key, err)
+		secrets.Log.Errorf("get: failed to read %q: %s", key, err)
+		return nil, err
+	}
+	r, err := decodeRelease(data)
+	if err != nil {
+		secrets.Log.Errorf("get: failed to decode data %q: %s", key, err)
 		return nil, err
 	}
 	// return the release object
@@ -92,7 +101,7 @@ func (secrets *Secrets) List(filter func(*rspb.Release) bool) ([]*rspb.Release,
 
 	list, err := secrets.impl.List(context.TODO(), opts)
 	if err != nil {
-		secrets.Log("list: failed to list: %s", err)
+		secrets.Log.Errorf("list: failed to list: %s", err)
 		return nil, err
 	}
 
@@ -101,9 +110,14 @@ func (secrets *Secrets) List(filter func(*rspb.Release) bool) ([]*rspb.Release,
 	// iterate over the secrets object list
 	// and decode each release
 	for _, item := range list.Items {
-		rls, err := decodeRelease(string(item.Data["release"]))
+		data, err := readRecord(secrets, secretObject(&item))
+		if err != nil {
+			secrets.Log.Errorf("list: failed to read release %q: %s", item.Name, err)
+			continue
+		}
+		rls, err := decodeRelease(data)
 		if err != nil {
-			secrets.Log("list: failed to decode release: %v: %s", item, err)
+			secrets.Log.Errorf("list: failed to decode release: %v: %s", item, err)
 			continue
 		}
 		if filter(rls) {
@@ -128,7 +142,7 @@ func (secrets *Secrets) Query(labels map[string]string) ([]*rspb.Release, error)
 
 	list, err := secrets.impl.List(context.TODO(), opts)
 	if err != nil {
-		secrets.Log("query: failed to query with labels: %s", err)
+		secrets.Log.Errorf("query: failed to query with labels: %s", err)
 		return nil, err
 	}
 
@@ -138,9 +152,14 @@ func (secrets *Secrets) Query(labels map[string]string) ([]*rspb.Release, error)
 
 	var results []*rspb.Release
 	for _, item := range list.Items {
-		rls, err := decodeRelease(string(item.Data["release"]))
+		data, err := readRecord(secrets, secretObject(&item))
 		if err != nil {
-			secrets.Log("query: failed to decode release: %s", err)
+			secrets.Log.Errorf("query: failed to read release %q: %s", item.Name, err)
+			continue
+		}
+		rls, err := decodeRelease(data)
+		if err != nil {
+			secrets.Log.Errorf("query: failed to decode release: %s", err)
 			continue
 		}
 		results = append(results, rls)
@@ -160,16 +179,17 @@ func (secrets *Secrets) Create(key string, rls *rspb.Release) error {
 	// create a new secret to hold the release
 	obj, err := newSecretsObject(key, rls, lbs)
 	if err != nil {
-		secrets.Log("create: failed to encode release %q: %s", rls.Name, err)
+		secrets.Log.Errorf("create: failed to encode release %q: %s", rls.Name, err)
 		return err
 	}
-	// push the secret object out into the kubiverse
-	if _, err := secrets.impl.Create(context.TODO(), obj, metav1.CreateOptions{}); err != nil {
+	// push the secret object out into the kubiverse, preceded by the
+	// chunks of an oversized release
+	if err := createRecord(secrets, secretObject(obj), secrets.ChunkSize, secrets.Log); err != nil {
 		if apierrors.IsAlreadyExists(err) {
 			return storageerrors.ErrReleaseExists(rls.Name)
 		}
 
-		secrets.Log("create: failed to create: %s", err)
+		secrets.Log.Errorf("create: failed to create: %s", err)
 		return err
 	}
 	return nil
@@ -187,13 +207,16 @@ func (secrets *Secrets) Update(key string, rls *rspb.Release) error {
 	// create a new secret object to hold the release
 	obj, err := newSecretsObject(key, rls, lbs)
 	if err != nil {
-		secrets.Log("update: failed to encode release %q: %s", rls.Name, err)
+		secrets.Log.Errorf("update: failed to encode release %q: %s", rls.Name, err)
 		return err
 	}
-	// push the secret object out into the kubiverse
-	_, err = secrets.impl.Update(context.TODO(), obj, metav1.UpdateOptions{})
-	if err != nil {
-		secrets.Log("update: failed to update: %s", err)
+	// push the secret object out into the kubiverse, replacing the chunks
+	// of the previous release
+	if err := updateRecord(secrets, secretObject(obj), secrets.ChunkSize, secrets.Log); err != nil {
+		if apierrors.IsNotFound(err) {
+			return storageerrors.ErrReleaseNotFound(key)
+		}
+		secrets.Log.Errorf("update: failed to update: %s", err)
 		return err
 	}
 	return nil
@@ -207,29 +230,84 @@ func (secrets *Secrets) Delete(key string) (rls *rspb.Release, err error) {
 			return nil, storageerrors.ErrReleaseExists(rls.Name)
 		}
 
-		secrets.Log("delete: failed to get release %q: %s", key, err)
+		secrets.Log.Errorf("delete: failed to get release %q: %s", key, err)
 		return nil, err
 	}
-	// delete the release
+	// delete the release, its chunks are no longer referenced afterwards
 	if err = secrets.impl.Delete(context.TODO(), key, metav1.DeleteOptions{}); err != nil {
 		return rls, err
 	}
+	deleteChunks(secrets, key, nil, secrets.Log)
 	return rls, nil
 }
 
+// secretObject returns the object held by secret.
+func secretObject(secret *v1.Secret) *object {
+	data := make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		data[k] = string(v)
+	}
+	return &object{name: secret.Name, labels: secret.Labels, data: data}
+}
+
+// objectSecret returns the secret holding obj.
+func objectSecret(obj *object) *v1.Secret {
+	data := make(map[string][]byte, len(obj.data))
+	for k, v := range obj.data {
+		data[k] = []byte(v)
+	}
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: obj.name, Labels: obj.labels},
+		Data:       data,
+	}
+}
+
+func (secrets *Secrets) getObject(name string) (*object, error) {
+	obj, err := secrets.impl.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return secretObject(obj), nil
+}
+
+func (secrets *Secrets) listObjects(selector string) ([]*object, error) {
+	list, err := secrets.impl.List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]*object, 0, len(list.Items))
+	for i := range list.Items {
+		objs = append(objs, secretObject(&list.Items[i]))
+	}
+	return objs, nil
+}
+
+func (secrets *Secrets) createObject(obj *object) error {
+	_, err := secrets.impl.Create(context.TODO(), objectSecret(obj), metav1.CreateOptions{})
+	return err
+}
+
+func (secrets *Secrets) updateObject(obj *object) error {
+	_, err := secrets.impl.Update(context.TODO(), objectSecret(obj), metav1.UpdateOptions{})
+	return err
+}
+
+func (secrets *Secrets) deleteObject(name string) error {
+	return secrets.impl.Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
 // newSecretsObject constructs a kubernetes Secret object
 // to store a release. Each secret data entry is the base64
 // encoded string of a release's binary protobuf encoding.
 //
 // The following labels are used within each secret:
 //
-//    "MODIFIED_AT"    - timestamp indicating when this secret was last modified. (set in Update)
-//    "CREATED_AT"     - timestamp indicating when this secret was created. (set in Create)
-//    "VERSION"        - version of the release.
-//    "STATUS"         - status of the release (see proto/hapi/release.status.pb.go for variants)
-//    "OWNER"          - owner of the secret, currently "TILLER".
-//    "NAME"           - name of the release.
-//
+//	"MODIFIED_AT"    - timestamp indicating when this secret was last modified. (set in Update)
+//	"CREATED_AT"     - timestamp indicating when this secret was created. (set in Create)
+//	"VERSION"        - version of the release.
+//	"STATUS"         - status of the release (see proto/hapi/release.status.pb.go for variants)
+//	"OWNER"          - owner of the secret, currently "TILLER".
+//	"NAME"           - name of the release.
 func newSecretsObject(key string, rls *rspb.Release, lbs labels) (*v1.Secret, error) {
 	const owner = "TILLER"
 
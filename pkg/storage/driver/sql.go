@@ -28,6 +28,7 @@ import (
 	// Import pq for postgres dialect
 	_ "github.com/lib/pq"
 
+	"k8s.io/helm/pkg/logging"
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 	storageerrors "k8s.io/helm/pkg/storage/errors"
 )
@@ -53,7 +54,7 @@ const SQLDriverName = "SQL"
 // SQL is the sql storage driver implementation.
 type SQL struct {
 	db  *sqlx.DB
-	Log func(string, ...interface{})
+	Log logging.Logger
 }
 
 // Name returns the name of the driver.
@@ -122,7 +123,7 @@ type SQLReleaseWrapper struct {
 }
 
 // NewSQL initializes a new memory driver.
-func NewSQL(dialect, connectionString string, logger func(string, ...interface{})) (*SQL, error) {
+func NewSQL(dialect, connectionString string, logger logging.Logger) (*SQL, error) {
 	if _, ok := supportedSQLDialects[dialect]; !ok {
 		return nil, fmt.Errorf("%s dialect isn't supported, only \"postgres\" is available for now", dialect)
 	}
@@ -150,13 +151,13 @@ func (s *SQL) Get(key string) (*rspb.Release, error) {
 	// Get will return an error if the result is empty
 	err := s.db.Get(&record, "SELECT body FROM releases WHERE key = $1", key)
 	if err != nil {
-		s.Log("got SQL error when getting release %s: %v", key, err)
+		s.Log.Debugf("got SQL error when getting release %s: %v", key, err)
 		return nil, storageerrors.ErrReleaseNotFound(key)
 	}
 
 	release, err := decodeRelease(record.Body)
 	if err != nil {
-		s.Log("get: failed to decode data %q: %v", key, err)
+		s.Log.Errorf("get: failed to decode data %q: %v", key, err)
 		return nil, err
 	}
 
@@ -167,7 +168,7 @@ func (s *SQL) Get(key string) (*rspb.Release, error) {
 func (s *SQL) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
 	var records = []SQLReleaseWrapper{}
 	if err := s.db.Select(&records, "SELECT body FROM releases WHERE owner = 'TILLER'"); err != nil {
-		s.Log("list: failed to list: %v", err)
+		s.Log.Errorf("list: failed to list: %v", err)
 		return nil, err
 	}
 
@@ -175,7 +176,7 @@ func (s *SQL) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
 	for _, record := range records {
 		release, err := decodeRelease(record.Body)
 		if err != nil {
-			s.Log("list: failed to decode release: %v: %v", record, err)
+			s.Log.Errorf("list: failed to decode release: %v: %v", record, err)
 			continue
 		}
 		if filter(release) {
@@ -198,7 +199,7 @@ func (s *SQL) Query(labels map[string]string) ([]*rspb.Release, error) {
 			sqlFilterKeys = append(sqlFilterKeys, strings.Join([]string{dbField, "=:", dbField}, ""))
 			sqlFilter[dbField] = val
 		} else {
-			s.Log("unknown label %s", key)
+			s.Log.Errorf("unknown label %s", key)
 			return nil, fmt.Errorf("unknown label %s", key)
 		}
 	}
@@ -213,7 +214,7 @@ func (s *SQL) Query(labels map[string]string) ([]*rspb.Release, error) {
 
 	rows, err := s.db.NamedQuery(query, sqlFilter)
 	if err != nil {
-		s.Log("failed to query with labels: %v", err)
+		s.Log.Errorf("failed to query with labels: %v", err)
 		return nil, err
 	}
 
@@ -221,13 +222,13 @@ func (s *SQL) Query(labels map[string]string) ([]*rspb.Release, error) {
 	for rows.Next() {
 		var record SQLReleaseWrapper
 		if err = rows.StructScan(&record); err != nil {
-			s.Log("failed to scan record %q: %v", record, err)
+			s.Log.Errorf("failed to scan record %q: %v", record, err)
 			return nil, err
 		}
 
 		release, err := decodeRelease(record.Body)
 		if err != nil {
-			s.Log("failed to decode release: %v", err)
+			s.Log.Errorf("failed to decode release: %v", err)
 			continue
 		}
 		releases = append(releases, release)
@@ -244,13 +245,13 @@ func (s *SQL) Query(labels map[string]string) ([]*rspb.Release, error) {
 func (s *SQL) Create(key string, rls *rspb.Release) error {
 	body, err := encodeRelease(rls)
 	if err != nil {
-		s.Log("failed to encode release: %v", err)
+		s.Log.Errorf("failed to encode release: %v", err)
 		return err
 	}
 
 	transaction, err := s.db.Beginx()
 	if err != nil {
-		s.Log("failed to start SQL transaction: %v", err)
+		s.Log.Errorf("failed to start SQL transaction: %v", err)
 		return fmt.Errorf("error beginning transaction: %v", err)
 	}
 
@@ -269,11 +270,11 @@ func (s *SQL) Create(key string, rls *rspb.Release) error {
 		defer transaction.Rollback()
 		var record SQLReleaseWrapper
 		if err := transaction.Get(&record, "SELECT key FROM releases WHERE key = ?", key); err == nil {
-			s.Log("release %s already exists", key)
+			s.Log.Errorf("release %s already exists", key)
 			return storageerrors.ErrReleaseExists(key)
 		}
 
-		s.Log("failed to store release %s in SQL database: %v", key, err)
+		s.Log.Errorf("failed to store release %s in SQL database: %v", key, err)
 		return err
 	}
 	defer transaction.Commit()
@@ -285,7 +286,7 @@ func (s *SQL) Create(key string, rls *rspb.Release) error {
 func (s *SQL) Update(key string, rls *rspb.Release) error {
 	body, err := encodeRelease(rls)
 	if err != nil {
-		s.Log("failed to encode release: %v", err)
+		s.Log.Errorf("failed to encode release: %v", err)
 		return err
 	}
 
@@ -301,7 +302,7 @@ func (s *SQL) Update(key string, rls *rspb.Release) error {
 			ModifiedAt: int(time.Now().Unix()),
 		},
 	); err != nil {
-		s.Log("failed to update release %s in SQL database: %v", key, err)
+		s.Log.Errorf("failed to update release %s in SQL database: %v", key, err)
 		return err
 	}
 
@@ -312,20 +313,20 @@ func (s *SQL) Update(key string, rls *rspb.Release) error {
 func (s *SQL) Delete(key string) (*rspb.Release, error) {
 	transaction, err := s.db.Beginx()
 	if err != nil {
-		s.Log("failed to start SQL transaction: %v", err)
+		s.Log.Errorf("failed to start SQL transaction: %v", err)
 		return nil, fmt.Errorf("error beginning transaction: %v", err)
 	}
 
 	var record SQLReleaseWrapper
 	err = transaction.Get(&record, "SELECT body FROM releases WHERE key = $1", key)
 	if err != nil {
-		s.Log("release %s not found: %v", key, err)
+		s.Log.Debugf("release %s not found: %v", key, err)
 		return nil, storageerrors.ErrReleaseNotFound(key)
 	}
 
 	release, err := decodeRelease(record.Body)
 	if err != nil {
-		s.Log("failed to decode release %s: %v", key, err)
+		s.Log.Errorf("failed to decode release %s: %v", key, err)
 		transaction.Rollback()
 		return nil, err
 	}
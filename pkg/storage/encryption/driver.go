@@ -0,0 +1,229 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"k8s.io/helm/pkg/logging"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+// sealedPrefix prefixes the manifest of a sealed release, which holds the
+// encrypted release. A rendered manifest never starts with it.
+const sealedPrefix = "helm-encrypted:"
+
+var _ driver.Driver = (*Driver)(nil)
+
+// Driver is a storage driver encrypting the releases it stores in another
+// driver, so that any driver can store encrypted releases.
+//
+// In place of a release, the underlying driver stores a sealed release that
+// only keeps the name, version, namespace and status the drivers label and
+// look records up by. Its manifest holds the whole release, encrypted by an
+// Envelope for the key of the record.
+type Driver struct {
+	driver   driver.Driver
+	envelope *Envelope
+	Log      logging.Logger
+}
+
+// NewDriver returns a Driver storing the releases in d, encrypted with e.
+func NewDriver(d driver.Driver, e *Envelope) *Driver {
+	return &Driver{
+		driver:   d,
+		envelope: e,
+		Log:      logging.Discard,
+	}
+}
+
+// Name returns the name of the underlying driver.
+func (d *Driver) Name() string {
+	return d.driver.Name()
+}
+
+// Get fetches and decrypts the release named by key.
+func (d *Driver) Get(key string) (*rspb.Release, error) {
+	rls, err := d.driver.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return d.open(key, rls)
+}
+
+// List fetches all releases and returns the decrypted releases such that
+// filter(release) == true. It fails if a release cannot be decrypted, as
+// skipping it would leave a gap in the history of its release.
+func (d *Driver) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
+	rels, err := d.driver.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	opened, err := d.openAll(rels)
+	if err != nil {
+		return nil, err
+	}
+	var results []*rspb.Release
+	for _, rls := range opened {
+		if filter(rls) {
+			results = append(results, rls)
+		}
+	}
+	return results, nil
+}
+
+// Query fetches and decrypts all releases that match the provided map of
+// labels. It fails if a release cannot be decrypted.
+func (d *Driver) Query(labels map[string]string) ([]*rspb.Release, error) {
+	rels, err := d.driver.Query(labels)
+	if err != nil {
+		return nil, err
+	}
+	return d.openAll(rels)
+}
+
+// Create encrypts the release and stores it under key.
+func (d *Driver) Create(key string, rls *rspb.Release) error {
+	sealed, err := d.seal(key, rls)
+	if err != nil {
+		d.Log.Errorf("create: failed to encrypt release %q: %s", key, err)
+		return err
+	}
+	return d.driver.Create(key, sealed)
+}
+
+// Update encrypts the release and replaces the one stored under key.
+func (d *Driver) Update(key string, rls *rspb.Release) error {
+	sealed, err := d.seal(key, rls)
+	if err != nil {
+		d.Log.Errorf("update: failed to encrypt release %q: %s", key, err)
+		return err
+	}
+	return d.driver.Update(key, sealed)
+}
+
+// Delete deletes the release named by key and returns it decrypted.
+func (d *Driver) Delete(key string) (*rspb.Release, error) {
+	rls, err := d.driver.Delete(key)
+	if err != nil {
+		return nil, err
+	}
+	return d.open(key, rls)
+}
+
+// ReEncrypt rewrites every release stored in the underlying driver, so that
+// it is sealed with the current key encryption key. Releases that were stored
+// before encryption was enabled are encrypted as well.
+//
+// Every stored record is rewritten, and an error names the records that
+// could not be. It returns the number of releases that were rewritten.
+func (d *Driver) ReEncrypt(log func(string, ...interface{})) (int, error) {
+	if log == nil {
+		log = func(string, ...interface{}) {}
+	}
+	rels, err := d.driver.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	var failed []string
+	for _, stored := range rels {
+		key := recordKey(stored)
+		log("re-encrypting %q", key)
+		rls, err := d.open(key, stored)
+		if err == nil {
+			err = d.Update(key, rls)
+		}
+		if err != nil {
+			log("cannot re-encrypt %q: %s", key, err)
+			failed = append(failed, fmt.Sprintf("%q: %s", key, err))
+			continue
+		}
+		n++
+	}
+	if len(failed) > 0 {
+		return n, fmt.Errorf("cannot re-encrypt %d of %d releases:\n\t%s", len(failed), len(rels), strings.Join(failed, "\n\t"))
+	}
+	return n, nil
+}
+
+// seal returns the sealed release stored in place of rls under key.
+func (d *Driver) seal(key string, rls *rspb.Release) (*rspb.Release, error) {
+	b, err := proto.Marshal(rls)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := d.envelope.Encrypt(key, b)
+	if err != nil {
+		return nil, err
+	}
+	sealed := &rspb.Release{
+		Name:      rls.Name,
+		Version:   rls.Version,
+		Namespace: rls.Namespace,
+		Manifest:  sealedPrefix + base64.StdEncoding.EncodeToString(enc),
+	}
+	if rls.Info != nil && rls.Info.Status != nil {
+		sealed.Info = &rspb.Info{Status: &rspb.Status{Code: rls.Info.Status.Code}}
+	}
+	return sealed, nil
+}
+
+// open returns the release sealed in rls, stored under key. Releases stored
+// before encryption was enabled are returned as they are.
+func (d *Driver) open(key string, rls *rspb.Release) (*rspb.Release, error) {
+	if !strings.HasPrefix(rls.Manifest, sealedPrefix) {
+		return rls, nil
+	}
+	enc, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(rls.Manifest, sealedPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("malformed encrypted release %q: %s", key, err)
+	}
+	b, err := d.envelope.Decrypt(key, enc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt release %q: %s", key, err)
+	}
+	var opened rspb.Release
+	if err := proto.Unmarshal(b, &opened); err != nil {
+		return nil, err
+	}
+	return &opened, nil
+}
+
+// openAll returns the releases sealed in rels, or the error of the first one
+// that cannot be opened.
+func (d *Driver) openAll(rels []*rspb.Release) ([]*rspb.Release, error) {
+	results := make([]*rspb.Release, 0, len(rels))
+	for _, rls := range rels {
+		opened, err := d.open(recordKey(rls), rls)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, opened)
+	}
+	return results, nil
+}
+
+// recordKey returns the storage key of a release, as used by storage.Storage.
+func recordKey(rls *rspb.Release) string {
+	return fmt.Sprintf("%s.v%d", rls.Name, rls.Version)
+}
@@ -0,0 +1,168 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encryption provides envelope encryption of stored release records.
+//
+// Every record is sealed with its own randomly generated data key. The data
+// key is then wrapped by a KeyProvider holding the key encryption key, and the
+// wrapped data key is stored next to the record. Rotating the key encryption
+// key therefore only requires the provider to keep the old key available for
+// unwrapping until all records have been re-encrypted with Driver.ReEncrypt.
+//
+// Driver encrypts the releases of any other storage driver. The storage key
+// of a record is authenticated along with it, so a sealed record copied to
+// the key of another release or revision fails to decrypt.
+package encryption // import "k8s.io/helm/pkg/storage/encryption"
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// dataKeySize is the size of the AES-256 data keys.
+const dataKeySize = 32
+
+// envelopeVersion is the version of the format written by Envelope.
+const envelopeVersion = 1
+
+// KeyProvider wraps and unwraps data keys with a key encryption key.
+//
+// Implementations may hold the key encryption key locally, like
+// LocalKeyProvider, or delegate to an external key management service.
+type KeyProvider interface {
+	// KeyID identifies the key encryption key used by WrapKey.
+	KeyID() string
+	// WrapKey encrypts a data key with the current key encryption key.
+	WrapKey(dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key that was wrapped by the key encryption
+	// key named keyID.
+	UnwrapKey(keyID string, wrapped []byte) ([]byte, error)
+}
+
+// Envelope encrypts release records with data keys wrapped by a KeyProvider.
+type Envelope struct {
+	provider KeyProvider
+}
+
+// New returns an Envelope wrapping its data keys with provider.
+func New(provider KeyProvider) *Envelope {
+	return &Envelope{provider: provider}
+}
+
+// Encrypt seals data, the record stored under key, with a new data key.
+//
+// The result holds the format version, the ID of the key encryption key, the
+// wrapped data key, the nonce and the ciphertext.
+func (e *Envelope) Encrypt(key string, data []byte) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+	wrapped, err := e.provider.WrapKey(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("wrapping data key: %s", err)
+	}
+	sealed, err := seal(dataKey, data, []byte(key))
+	if err != nil {
+		return nil, err
+	}
+
+	keyID := e.provider.KeyID()
+	out := []byte{envelopeVersion}
+	out = appendField(out, []byte(keyID))
+	out = appendField(out, wrapped)
+	return append(out, sealed...), nil
+}
+
+// Decrypt opens data sealed by Encrypt for the same key.
+func (e *Envelope) Decrypt(key string, data []byte) ([]byte, error) {
+	if len(data) == 0 || data[0] != envelopeVersion {
+		return nil, errors.New("unsupported encrypted release format")
+	}
+	keyID, rest, err := readField(data[1:])
+	if err != nil {
+		return nil, err
+	}
+	wrapped, sealed, err := readField(rest)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := e.provider.UnwrapKey(string(keyID), wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key: %s", err)
+	}
+	return open(dataKey, sealed, []byte(key))
+}
+
+// seal encrypts plaintext with AES-GCM, authenticating additionalData with it,
+// and prefixes the result with the nonce.
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts data sealed by seal with the same additionalData.
+func open(key, data, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("encrypted data is too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// appendField appends b to out, prefixed with its length.
+func appendField(out, b []byte) []byte {
+	var n [2]byte
+	binary.BigEndian.PutUint16(n[:], uint16(len(b)))
+	return append(append(out, n[:]...), b...)
+}
+
+// readField reads a length prefixed field from data and returns it with the
+// remaining data.
+func readField(data []byte) ([]byte, []byte, error) {
+	if len(data) < 2 {
+		return nil, nil, errors.New("encrypted release is truncated")
+	}
+	n := int(binary.BigEndian.Uint16(data))
+	if len(data) < 2+n {
+		return nil, nil, errors.New("encrypted release is truncated")
+	}
+	return data[2 : 2+n], data[2+n:], nil
+}
@@ -0,0 +1,332 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/storage/driver/drivertest"
+)
+
+func testKey(id string) Key {
+	return Key{ID: id, Secret: bytes.Repeat([]byte(id[:1]), dataKeySize)}
+}
+
+func testProvider(t *testing.T, keys ...Key) *LocalKeyProvider {
+	t.Helper()
+	p, err := NewLocalKeyProvider(keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func releaseStub(name string, vers int32) *rspb.Release {
+	return &rspb.Release{
+		Name:      name,
+		Version:   vers,
+		Namespace: "default",
+		Config:    &chart.Config{Raw: chartConfig.Raw},
+		Info:      &rspb.Info{Status: &rspb.Status{Code: rspb.Status_DEPLOYED}},
+	}
+}
+
+var chartConfig = chart.Config{Raw: "password: hunter2\n"}
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	e := New(testProvider(t, testKey("a-key")))
+	plaintext := []byte("password: hunter2")
+
+	sealed, err := e.Encrypt("angry-bird.v1", plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(sealed, plaintext) {
+		t.Error("expected sealed data not to contain the plaintext")
+	}
+	other, err := e.Encrypt("angry-bird.v1", plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(sealed, other) {
+		t.Error("expected every encryption to use a new data key and nonce")
+	}
+
+	opened, err := e.Decrypt("angry-bird.v1", sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("expected %q, got %q", plaintext, opened)
+	}
+}
+
+func TestEnvelopeTampered(t *testing.T) {
+	e := New(testProvider(t, testKey("a-key")))
+	sealed, err := e.Encrypt("angry-bird.v1", []byte("password: hunter2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := append([]byte{}, sealed...)
+	tampered[len(tampered)-1] ^= 0xff
+	if _, err := e.Decrypt("angry-bird.v1", tampered); err == nil {
+		t.Error("expected tampered data to fail decryption")
+	}
+	if _, err := e.Decrypt("angry-bird.v1", sealed[:5]); err == nil {
+		t.Error("expected truncated data to fail decryption")
+	}
+	if _, err := e.Decrypt("angry-bird.v2", sealed); err == nil {
+		t.Error("expected data sealed for another record to fail decryption")
+	}
+	if _, err := New(testProvider(t, testKey("b-key"))).Decrypt("angry-bird.v1", sealed); err == nil || !strings.Contains(err.Error(), `unknown key encryption key "a-key"`) {
+		t.Errorf("expected unknown key error, got %v", err)
+	}
+}
+
+func newConfigMaps(t *testing.T, keys ...Key) (*Driver, *driver.ConfigMaps, corev1.ConfigMapInterface) {
+	impl := fake.NewSimpleClientset().CoreV1().ConfigMaps("kube-system")
+	plain := driver.NewConfigMaps(impl)
+	return NewDriver(plain, New(testProvider(t, keys...))), plain, impl
+}
+
+func storedData(t *testing.T, impl corev1.ConfigMapInterface, key string) []byte {
+	t.Helper()
+	obj, err := impl.Get(context.TODO(), key, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := base64.StdEncoding.DecodeString(obj.Data["release"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err = ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestDriverEncryption(t *testing.T) {
+	d, plain, impl := newConfigMaps(t, testKey("a-key"))
+	if err := d.Create("angry-bird.v1", releaseStub("angry-bird", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if b := storedData(t, impl, "angry-bird.v1"); bytes.Contains(b, []byte(chartConfig.Raw)) {
+		t.Errorf("expected the stored release to be encrypted, got %q", b)
+	}
+	obj, err := impl.Get(context.TODO(), "angry-bird.v1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.Labels["NAME"] != "angry-bird" || obj.Labels["STATUS"] != "DEPLOYED" || obj.Labels["VERSION"] != "1" {
+		t.Errorf("expected the record to be labeled as the release, got %v", obj.Labels)
+	}
+
+	rls, err := d.Get("angry-bird.v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rls.Config.Raw != chartConfig.Raw {
+		t.Errorf("expected values %q, got %q", chartConfig.Raw, rls.Config.Raw)
+	}
+	rels, err := d.Query(map[string]string{"NAME": "angry-bird", "OWNER": "TILLER"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 1 || rels[0].Config.Raw != chartConfig.Raw {
+		t.Errorf("expected the decrypted release, got %v", rels)
+	}
+
+	// the record cannot be read without the key
+	sealed, err := plain.Get("angry-bird.v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sealed.Config != nil || !strings.HasPrefix(sealed.Manifest, sealedPrefix) {
+		t.Errorf("expected a sealed release, got %v", sealed)
+	}
+}
+
+func TestDriverEncryptsMemory(t *testing.T) {
+	mem := driver.NewMemory()
+	d := NewDriver(mem, New(testProvider(t, testKey("a-key"))))
+	if err := d.Create("angry-bird.v1", releaseStub("angry-bird", 1)); err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := mem.Get("angry-bird.v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sealed.Config != nil {
+		t.Errorf("expected the stored release to be encrypted, got %v", sealed)
+	}
+	rls, err := d.Get("angry-bird.v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rls.Config.Raw != chartConfig.Raw {
+		t.Errorf("expected values %q, got %q", chartConfig.Raw, rls.Config.Raw)
+	}
+}
+
+func TestDriverRejectsMovedRecords(t *testing.T) {
+	d, _, impl := newConfigMaps(t, testKey("a-key"))
+	if err := d.Create("angry-bird.v1", releaseStub("angry-bird", 1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Create("happy-cat.v1", releaseStub("happy-cat", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// copy the sealed record of one release onto the key of another
+	from, err := impl.Get(context.TODO(), "angry-bird.v1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := impl.Get(context.TODO(), "happy-cat.v1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	to.Data["release"] = from.Data["release"]
+	if _, err := impl.Update(context.TODO(), to, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := d.Get("happy-cat.v1"); err == nil {
+		t.Error("expected a record moved to another key to fail decryption")
+	}
+}
+
+func TestDriverFailsOnUnreadableReleases(t *testing.T) {
+	old, plain, _ := newConfigMaps(t, testKey("a-key"))
+	if err := plain.Create("angry-bird.v1", releaseStub("angry-bird", 1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := old.Create("angry-bird.v2", releaseStub("angry-bird", 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the key of the second revision is no longer available
+	d := NewDriver(plain, New(testProvider(t, testKey("b-key"))))
+	if _, err := d.List(func(*rspb.Release) bool { return true }); err == nil || !strings.Contains(err.Error(), `"angry-bird.v2"`) {
+		t.Errorf("expected listing to fail on the unreadable release, got %v", err)
+	}
+	if _, err := d.Query(map[string]string{"NAME": "angry-bird"}); err == nil || !strings.Contains(err.Error(), `"angry-bird.v2"`) {
+		t.Errorf("expected a query to fail on the unreadable release, got %v", err)
+	}
+}
+
+func TestDriverReadsUnencryptedReleases(t *testing.T) {
+	d, plain, _ := newConfigMaps(t, testKey("a-key"))
+	if err := plain.Create("angry-bird.v1", releaseStub("angry-bird", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	rls, err := d.Get("angry-bird.v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rls.Name != "angry-bird" || rls.Config.Raw != chartConfig.Raw {
+		t.Errorf("unexpected release: %v", rls)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey, newKey := testKey("a-key"), testKey("b-key")
+
+	old, plain, impl := newConfigMaps(t, oldKey)
+	if err := old.Create("angry-bird.v1", releaseStub("angry-bird", 1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := old.Create("angry-bird.v2", releaseStub("angry-bird", 2)); err != nil {
+		t.Fatal(err)
+	}
+	// a release stored before encryption was enabled
+	if err := plain.Create("happy-cat.v1", releaseStub("happy-cat", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the new key is current, the old one is still available for decryption
+	rotated := NewDriver(plain, New(testProvider(t, newKey, oldKey)))
+	n, err := rotated.ReEncrypt(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 re-encrypted releases, got %d", n)
+	}
+
+	// once re-encrypted, the old key is no longer needed
+	current := NewDriver(driver.NewConfigMaps(impl), New(testProvider(t, newKey)))
+	rels, err := current.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 3 {
+		t.Errorf("expected 3 readable releases, got %d", len(rels))
+	}
+	for _, key := range []string{"angry-bird.v1", "angry-bird.v2", "happy-cat.v1"} {
+		if _, err := current.Get(key); err != nil {
+			t.Errorf("expected %q to be readable with the new key: %s", key, err)
+		}
+	}
+}
+
+func TestReEncryptFailsOnUnknownKeys(t *testing.T) {
+	old, plain, _ := newConfigMaps(t, testKey("a-key"))
+	if err := old.Create("angry-bird.v1", releaseStub("angry-bird", 1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := plain.Create("happy-cat.v1", releaseStub("happy-cat", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the old key was dropped before the records were re-encrypted
+	rotated := NewDriver(plain, New(testProvider(t, testKey("b-key"))))
+	n, err := rotated.ReEncrypt(nil)
+	if err == nil || !strings.Contains(err.Error(), `"angry-bird.v1"`) {
+		t.Errorf("expected an error for the record sealed with the old key, got %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 re-encrypted release, got %d", n)
+	}
+}
+
+func TestDriverConformance(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T) driver.Driver {
+		return NewDriver(driver.NewMemory(), New(testProvider(t, testKey("a-key"))))
+	})
+}
@@ -0,0 +1,113 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ghodss/yaml"
+)
+
+// Key is a named key encryption key.
+type Key struct {
+	// ID identifies the key. It is stored with every data key wrapped by it.
+	ID string `json:"id"`
+	// Secret is the 32 byte AES-256 key. It is base64 encoded in a keyfile.
+	Secret []byte `json:"secret"`
+}
+
+// KeyFile is the format of a file holding key encryption keys.
+//
+//	keys:
+//	- id: "2019-10"
+//	  secret: cGxlYXNlIGdlbmVyYXRlIGEgcmFuZG9tIGtleSEhISE=
+//	- id: "2019-01"
+//	  secret: b2xkIGtleXMgYXJlIG9ubHkgdXNlZCB0byBkZWNyeXB0
+//
+// The first key wraps new data keys. The other keys are only used to unwrap
+// data keys of records that have not been re-encrypted yet.
+type KeyFile struct {
+	Keys []Key `json:"keys"`
+}
+
+// LocalKeyProvider is a KeyProvider holding its key encryption keys in memory.
+type LocalKeyProvider struct {
+	current Key
+	keys    map[string][]byte
+}
+
+var _ KeyProvider = (*LocalKeyProvider)(nil)
+
+// NewLocalKeyProvider returns a provider wrapping data keys with the first of
+// keys and unwrapping them with any of keys.
+func NewLocalKeyProvider(keys ...Key) (*LocalKeyProvider, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no key encryption keys given")
+	}
+	p := &LocalKeyProvider{current: keys[0], keys: make(map[string][]byte, len(keys))}
+	for _, k := range keys {
+		if k.ID == "" {
+			return nil, errors.New("key encryption key without id")
+		}
+		if len(k.Secret) != dataKeySize {
+			return nil, fmt.Errorf("key encryption key %q must be %d bytes long, got %d", k.ID, dataKeySize, len(k.Secret))
+		}
+		if _, ok := p.keys[k.ID]; ok {
+			return nil, fmt.Errorf("duplicate key encryption key %q", k.ID)
+		}
+		p.keys[k.ID] = k.Secret
+	}
+	return p, nil
+}
+
+// LoadKeyFile reads a KeyFile and returns a provider for its keys.
+func LoadKeyFile(path string) (*LocalKeyProvider, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f KeyFile
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("parsing key file %s: %s", path, err)
+	}
+	p, err := NewLocalKeyProvider(f.Keys...)
+	if err != nil {
+		return nil, fmt.Errorf("loading key file %s: %s", path, err)
+	}
+	return p, nil
+}
+
+// KeyID returns the ID of the key used to wrap new data keys.
+func (p *LocalKeyProvider) KeyID() string {
+	return p.current.ID
+}
+
+// WrapKey encrypts dataKey with the current key.
+func (p *LocalKeyProvider) WrapKey(dataKey []byte) ([]byte, error) {
+	return seal(p.current.Secret, dataKey, nil)
+}
+
+// UnwrapKey decrypts a data key wrapped by the key named keyID.
+func (p *LocalKeyProvider) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	secret, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key encryption key %q", keyID)
+	}
+	return open(secret, wrapped, nil)
+}
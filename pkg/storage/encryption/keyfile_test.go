@@ -0,0 +1,89 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadKeyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-keyfile-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyfile := filepath.Join(dir, "keys.yaml")
+	data := `keys:
+- id: new
+  secret: bmV3bmV3bmV3bmV3bmV3bmV3bmV3bmV3bmV3bmV3bmU=
+- id: old
+  secret: b2xkb2xkb2xkb2xkb2xkb2xkb2xkb2xkb2xkb2xkb2w=
+`
+	if err := ioutil.WriteFile(keyfile, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := LoadKeyFile(keyfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.KeyID() != "new" {
+		t.Errorf("expected the first key to be current, got %q", p.KeyID())
+	}
+
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	wrapped, err := p.WrapKey(dataKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.UnwrapKey("old", wrapped); err == nil {
+		t.Error("expected unwrapping with the wrong key to fail")
+	}
+	unwrapped, err := p.UnwrapKey("new", wrapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(unwrapped) != string(dataKey) {
+		t.Errorf("expected %q, got %q", dataKey, unwrapped)
+	}
+}
+
+func TestNewLocalKeyProviderErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []Key
+		expected string
+	}{
+		{"no keys", nil, "no key encryption keys given"},
+		{"missing id", []Key{{Secret: make([]byte, 32)}}, "key encryption key without id"},
+		{"short secret", []Key{{ID: "short", Secret: make([]byte, 16)}}, `key encryption key "short" must be 32 bytes long, got 16`},
+		{"duplicate id", []Key{testKey("a-key"), testKey("a-key")}, `duplicate key encryption key "a-key"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLocalKeyProvider(tt.keys...)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
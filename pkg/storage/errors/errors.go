@@ -15,13 +15,34 @@ limitations under the License.
 */
 
 package errors // import "k8s.io/helm/pkg/storage/errors"
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	errNotFound   = errors.New("not found")
+	errExists     = errors.New("already exists")
+	errInvalidKey = errors.New("invalid key")
+)
 
 var (
 	// ErrReleaseNotFound indicates that a release is not found.
-	ErrReleaseNotFound = func(release string) error { return fmt.Errorf("release: %q not found", release) }
+	ErrReleaseNotFound = func(release string) error { return fmt.Errorf("release: %q %w", release, errNotFound) }
 	// ErrReleaseExists indicates that a release already exists.
-	ErrReleaseExists = func(release string) error { return fmt.Errorf("release: %q already exists", release) }
+	ErrReleaseExists = func(release string) error { return fmt.Errorf("release: %q %w", release, errExists) }
 	// ErrInvalidKey indicates that a release key could not be parsed.
-	ErrInvalidKey = func(release string) error { return fmt.Errorf("release: %q invalid key", release) }
+	ErrInvalidKey = func(release string) error { return fmt.Errorf("release: %q %w", release, errInvalidKey) }
 )
+
+// IsReleaseNotFound reports whether err is, or wraps, an error returned by
+// ErrReleaseNotFound.
+func IsReleaseNotFound(err error) bool { return errors.Is(err, errNotFound) }
+
+// IsReleaseExists reports whether err is, or wraps, an error returned by
+// ErrReleaseExists.
+func IsReleaseExists(err error) bool { return errors.Is(err, errExists) }
+
+// IsInvalidKey reports whether err is, or wraps, an error returned by
+// ErrInvalidKey.
+func IsInvalidKey(err error) bool { return errors.Is(err, errInvalidKey) }
@@ -0,0 +1,198 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migrate copies release records between storage drivers.
+//
+// Every revision found in the source driver is written to the target driver
+// under the same key. Revisions that already exist in the target with identical
+// content are skipped, so an interrupted migration can simply be run again. Once
+// all revisions are copied the target is verified against the source, and only
+// then are the source records optionally deleted.
+package migrate // import "k8s.io/helm/pkg/storage/migrate"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+// Options controls the behavior of a migration.
+type Options struct {
+	// DryRun reports what would be copied without writing to the target.
+	DryRun bool
+	// DeleteSource removes the source records once the target is verified.
+	DeleteSource bool
+	// Log receives progress messages. It may be nil.
+	Log func(string, ...interface{})
+}
+
+// Result summarizes a migration.
+type Result struct {
+	// Copied is the number of revisions written to the target.
+	Copied int
+	// Skipped is the number of revisions that already existed in the target.
+	Skipped int
+	// Deleted is the number of revisions removed from the source.
+	Deleted int
+}
+
+// Migrate copies every release revision stored in from into to.
+//
+// It returns an error without deleting anything if a revision exists in the
+// target with different content, or if the target does not hold an identical
+// copy of every source revision after copying.
+func Migrate(from, to driver.Driver, opts Options) (*Result, error) {
+	log := opts.Log
+	if log == nil {
+		log = func(string, ...interface{}) {}
+	}
+
+	rels, err := from.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		return nil, fmt.Errorf("listing releases in %s storage: %s", from.Name(), err)
+	}
+	sort.Slice(rels, func(i, j int) bool {
+		if rels[i].Name != rels[j].Name {
+			return rels[i].Name < rels[j].Name
+		}
+		return rels[i].Version < rels[j].Version
+	})
+	log("found %d release revision(s) in %s storage", len(rels), from.Name())
+
+	digests := make(map[string]string, len(rels))
+	res := &Result{}
+	for _, rls := range rels {
+		key := makeKey(rls.Name, rls.Version)
+		sum, err := Digest(rls)
+		if err != nil {
+			return res, fmt.Errorf("computing digest of %q: %s", key, err)
+		}
+		digests[key] = sum
+
+		existing, err := to.Get(key)
+		switch {
+		case err == nil:
+			existingSum, err := Digest(existing)
+			if err != nil {
+				return res, fmt.Errorf("computing digest of %q in %s storage: %s", key, to.Name(), err)
+			}
+			if existingSum != sum {
+				return res, fmt.Errorf("release %q already exists in %s storage with different content", key, to.Name())
+			}
+			log("skipping %q: already migrated", key)
+			res.Skipped++
+			continue
+		case !isNotFound(err):
+			return res, fmt.Errorf("getting %q from %s storage: %s", key, to.Name(), err)
+		}
+
+		if opts.DryRun {
+			log("would copy %q", key)
+			res.Copied++
+			continue
+		}
+		log("copying %q", key)
+		if err := to.Create(key, rls); err != nil {
+			return res, fmt.Errorf("creating %q in %s storage: %s", key, to.Name(), err)
+		}
+		res.Copied++
+	}
+
+	if opts.DryRun {
+		return res, nil
+	}
+
+	if err := verify(to, digests); err != nil {
+		return res, err
+	}
+	log("verified %d release revision(s) in %s storage", len(digests), to.Name())
+
+	if !opts.DeleteSource {
+		return res, nil
+	}
+	for _, rls := range rels {
+		key := makeKey(rls.Name, rls.Version)
+		log("deleting %q from %s storage", key, from.Name())
+		if _, err := from.Delete(key); err != nil && !isNotFound(err) {
+			return res, fmt.Errorf("deleting %q from %s storage: %s", key, from.Name(), err)
+		}
+		res.Deleted++
+	}
+	return res, nil
+}
+
+// Digest returns the hex encoded SHA-256 digest of the deterministic
+// protobuf encoding of rls.
+func Digest(rls *rspb.Release) (string, error) {
+	var buf proto.Buffer
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(rls); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// verify checks that the target holds a revision matching each digest.
+func verify(to driver.Driver, digests map[string]string) error {
+	rels, err := to.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		return fmt.Errorf("listing releases in %s storage: %s", to.Name(), err)
+	}
+	stored := make(map[string]*rspb.Release, len(rels))
+	for _, rls := range rels {
+		stored[makeKey(rls.Name, rls.Version)] = rls
+	}
+
+	found := 0
+	for key := range digests {
+		if _, ok := stored[key]; ok {
+			found++
+		}
+	}
+	if found != len(digests) {
+		return fmt.Errorf("verification failed: expected %d release revision(s) in %s storage, found %d", len(digests), to.Name(), found)
+	}
+
+	for key, want := range digests {
+		got, err := Digest(stored[key])
+		if err != nil {
+			return fmt.Errorf("verifying %q in %s storage: %s", key, to.Name(), err)
+		}
+		if got != want {
+			return fmt.Errorf("verification failed: %q in %s storage does not match the source", key, to.Name())
+		}
+	}
+	return nil
+}
+
+// isNotFound reports whether err is the not found error of a storage driver.
+func isNotFound(err error) bool {
+	return storageerrors.IsReleaseNotFound(err)
+}
+
+// makeKey concatenates a release name and version into
+// the key used by the storage package.
+func makeKey(rlsname string, version int32) string {
+	return fmt.Sprintf("%s.v%d", rlsname, version)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+func releaseStub(name string, vers int32, code rspb.Status_Code) *rspb.Release {
+	return &rspb.Release{
+		Name:      name,
+		Version:   vers,
+		Namespace: "default",
+		Manifest:  "kind: ConfigMap\nmetadata:\n  name: " + name,
+		Info:      &rspb.Info{Status: &rspb.Status{Code: code}},
+	}
+}
+
+func newSource(t *testing.T, rels ...*rspb.Release) driver.Driver {
+	t.Helper()
+	d := driver.NewMemory()
+	for _, rls := range rels {
+		if err := d.Create(makeKey(rls.Name, rls.Version), rls); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return d
+}
+
+func sourceFixture(t *testing.T) driver.Driver {
+	return newSource(t,
+		releaseStub("angry-bird", 1, rspb.Status_SUPERSEDED),
+		releaseStub("angry-bird", 2, rspb.Status_DEPLOYED),
+		releaseStub("happy-cat", 1, rspb.Status_DEPLOYED),
+	)
+}
+
+func count(t *testing.T, d driver.Driver) int {
+	t.Helper()
+	rels, err := d.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(rels)
+}
+
+func TestMigrate(t *testing.T) {
+	from := sourceFixture(t)
+	to := driver.NewConfigMaps(fake.NewSimpleClientset().CoreV1().ConfigMaps("kube-system"))
+
+	res, err := Migrate(from, to, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Copied != 3 || res.Skipped != 0 || res.Deleted != 0 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	if n := count(t, to); n != 3 {
+		t.Errorf("expected 3 releases in target, got %d", n)
+	}
+	if n := count(t, from); n != 3 {
+		t.Errorf("expected source to be kept, got %d releases", n)
+	}
+
+	rls, err := to.Get("angry-bird.v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rls.Info.Status.Code != rspb.Status_DEPLOYED || rls.Manifest != "kind: ConfigMap\nmetadata:\n  name: angry-bird" {
+		t.Errorf("unexpected release in target: %v", rls)
+	}
+}
+
+func TestMigrateIsResumable(t *testing.T) {
+	from := sourceFixture(t)
+	to := newSource(t, releaseStub("angry-bird", 1, rspb.Status_SUPERSEDED))
+
+	res, err := Migrate(from, to, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Copied != 2 || res.Skipped != 1 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+
+	// running the migration again must not copy anything
+	res, err = Migrate(from, to, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Copied != 0 || res.Skipped != 3 {
+		t.Errorf("unexpected result of second run: %+v", res)
+	}
+}
+
+func TestMigrateConflict(t *testing.T) {
+	from := sourceFixture(t)
+	to := newSource(t, releaseStub("angry-bird", 1, rspb.Status_DELETED))
+
+	_, err := Migrate(from, to, Options{DeleteSource: true})
+	if err == nil {
+		t.Fatal("expected conflict error")
+	}
+	if !strings.Contains(err.Error(), `"angry-bird.v1" already exists`) {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if n := count(t, from); n != 3 {
+		t.Errorf("expected source to be kept after a failed migration, got %d releases", n)
+	}
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	from := sourceFixture(t)
+	to := driver.NewMemory()
+
+	res, err := Migrate(from, to, Options{DryRun: true, DeleteSource: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Copied != 3 || res.Deleted != 0 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	if n := count(t, to); n != 0 {
+		t.Errorf("expected no releases in target, got %d", n)
+	}
+	if n := count(t, from); n != 3 {
+		t.Errorf("expected source to be kept, got %d releases", n)
+	}
+}
+
+func TestMigrateDeleteSource(t *testing.T) {
+	from := driver.NewSecrets(fake.NewSimpleClientset().CoreV1().Secrets("kube-system"))
+	for _, rls := range []*rspb.Release{
+		releaseStub("angry-bird", 1, rspb.Status_SUPERSEDED),
+		releaseStub("angry-bird", 2, rspb.Status_DEPLOYED),
+	} {
+		if err := from.Create(makeKey(rls.Name, rls.Version), rls); err != nil {
+			t.Fatal(err)
+		}
+	}
+	to := driver.NewMemory()
+
+	res, err := Migrate(from, to, Options{DeleteSource: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Copied != 2 || res.Deleted != 2 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	if n := count(t, from); n != 0 {
+		t.Errorf("expected source to be empty, got %d releases", n)
+	}
+	if n := count(t, to); n != 2 {
+		t.Errorf("expected 2 releases in target, got %d", n)
+	}
+}
+
+// lossyDriver drops every release it is asked to store.
+type lossyDriver struct {
+	*driver.Memory
+}
+
+func (d lossyDriver) Create(key string, rls *rspb.Release) error { return nil }
+
+func TestMigrateVerifyFailure(t *testing.T) {
+	from := sourceFixture(t)
+	to := lossyDriver{driver.NewMemory()}
+
+	_, err := Migrate(from, to, Options{DeleteSource: true})
+	if err == nil {
+		t.Fatal("expected verification error")
+	}
+	if !strings.Contains(err.Error(), "expected 3 release revision(s) in Memory storage, found 0") {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if n := count(t, from); n != 3 {
+		t.Errorf("expected source to be kept after a failed verification, got %d releases", n)
+	}
+}
+
+// failingDriver fails every lookup.
+type failingDriver struct {
+	*driver.Memory
+}
+
+func (d failingDriver) Get(key string) (*rspb.Release, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestMigrateTargetError(t *testing.T) {
+	from := sourceFixture(t)
+	to := failingDriver{driver.NewMemory()}
+
+	if _, err := Migrate(from, to, Options{}); err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected target error, got %v", err)
+	}
+}
+
+func TestDigest(t *testing.T) {
+	a, err := Digest(releaseStub("angry-bird", 1, rspb.Status_DEPLOYED))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Digest(releaseStub("angry-bird", 1, rspb.Status_DEPLOYED))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := Digest(releaseStub("angry-bird", 2, rspb.Status_DEPLOYED))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Errorf("expected identical releases to have the same digest")
+	}
+	if a == c {
+		t.Errorf("expected different releases to have different digests")
+	}
+}
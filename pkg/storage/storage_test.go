@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"testing"
 
+	"k8s.io/helm/pkg/logging"
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/storage/driver"
 )
@@ -238,7 +239,7 @@ func TestStorageHistory(t *testing.T) {
 
 func TestStorageRemoveLeastRecent(t *testing.T) {
 	storage := Init(driver.NewMemory())
-	storage.Log = t.Logf
+	storage.Log = testLogger(t)
 
 	// Make sure that specifying this at the outset doesn't cause any bugs.
 	storage.MaxHistory = 10
@@ -295,7 +296,7 @@ func TestStorageRemoveLeastRecent(t *testing.T) {
 
 func TestStorageDontDeleteDeployed(t *testing.T) {
 	storage := Init(driver.NewMemory())
-	storage.Log = t.Logf
+	storage.Log = testLogger(t)
 	storage.MaxHistory = 3
 
 	const name = "angry-bird"
@@ -399,3 +400,15 @@ func assertErrNil(eh func(args ...interface{}), err error, message string) {
 		eh(fmt.Sprintf("%s: %q", message, err))
 	}
 }
+
+// testLogger returns a logger writing to the log of the test.
+func testLogger(t *testing.T) logging.Logger {
+	return logging.New(testWriter{t}, logging.TextFormat, logging.DebugLevel)
+}
+
+type testWriter struct{ t *testing.T }
+
+func (w testWriter) Write(p []byte) (int, error) {
+	w.t.Log(string(p))
+	return len(p), nil
+}
@@ -26,6 +26,17 @@ The above is equivalent to the YAML document
 	topname:
 	  subname: value
 
+Keys are paths of names separated by dots, and list indexes in brackets.
+An index of + appends to the list. Dots, brackets, commas and equal signs
+are escaped with a backslash, e.g. in annotation names:
+
+	podAnnotations.prometheus\.io/scrape=true,args[+]=--verbose
+
+A value of null deletes the key from the values of the chart. With
+ParseIntoJSON, values are JSON documents:
+
+	resources={"limits":{"cpu":"100m"}},tolerations[+]={"operator":"Exists"}
+
 This package provides a parser and utilities for converting the strvals format
 to other formats.
 */
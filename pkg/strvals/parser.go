@@ -17,6 +17,7 @@ package strvals
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -94,6 +95,16 @@ func ParseIntoFile(s string, dest map[string]interface{}, runesToVal runesToVal)
 	return t.parse()
 }
 
+// ParseIntoJSON parses a strvals line whose values are JSON documents, e.g.
+// name={"a":[1,2]}, and merges the result into dest.
+//
+// A JSON null deletes the key, like null does in a --set line.
+func ParseIntoJSON(s string, dest map[string]interface{}) error {
+	scanner := bytes.NewBufferString(s)
+	t := &parser{sc: scanner, data: dest, isJSON: true}
+	return t.parse()
+}
+
 // ParseIntoString parses a strvals line and merges the result into dest.
 //
 // This method always returns a string as the value.
@@ -109,12 +120,17 @@ func ParseIntoString(s string, dest map[string]interface{}) error {
 // where sc is the source of the original data being parsed
 // where data is the final parsed data from the parses with correct types
 // where st is a boolean to figure out if we're forcing it to parse values as string
+// where isJSON is a boolean to figure out if values are JSON documents
 type parser struct {
 	sc         *bytes.Buffer
 	data       map[string]interface{}
 	runesToVal runesToVal
+	isJSON     bool
 }
 
+// appendIndex is the index of a [+] key, which appends to the list.
+const appendIndex = -1
+
 type runesToVal func([]rune) (interface{}, error)
 
 func newParser(sc *bytes.Buffer, data map[string]interface{}, stringBool bool) *parser {
@@ -169,14 +185,21 @@ func (t *parser) key(data map[string]interface{}) error {
 			kk := string(k)
 			// Find or create target list
 			list := []interface{}{}
-			if _, ok := data[kk]; ok {
-				list = data[kk].([]interface{})
+			if l, ok := data[kk].([]interface{}); ok {
+				list = l
+			}
+			if i == appendIndex {
+				i = len(list)
 			}
 
 			// Now we need to get the value after the ].
 			list, err = t.listItem(list, i)
 			set(data, kk, list)
 			return err
+		case last == '=' && t.isJSON:
+			v, e := t.jsonVal()
+			set(data, string(k), v)
+			return e
 		case last == '=':
 			//End of key. Consume =, Get value.
 			// FIXME: Get value list first
@@ -207,8 +230,8 @@ func (t *parser) key(data map[string]interface{}) error {
 		case last == '.':
 			// First, create or find the target map.
 			inner := map[string]interface{}{}
-			if _, ok := data[string(k)]; ok {
-				inner = data[string(k)].(map[string]interface{})
+			if m, ok := data[string(k)].(map[string]interface{}); ok {
+				inner = m
 			}
 
 			// Recurse
@@ -247,9 +270,18 @@ func (t *parser) keyIndex() (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	// v should be the index
-	return strconv.Atoi(string(v))
-
+	// v should be the index, or + to append
+	if string(v) == "+" {
+		return appendIndex, nil
+	}
+	i, err := strconv.Atoi(string(v))
+	if err != nil {
+		return 0, err
+	}
+	if i < 0 {
+		return 0, fmt.Errorf("negative index %d", i)
+	}
+	return i, nil
 }
 func (t *parser) listItem(list []interface{}, i int) ([]interface{}, error) {
 	stop := runeSet([]rune{'[', '.', '='})
@@ -258,6 +290,9 @@ func (t *parser) listItem(list []interface{}, i int) ([]interface{}, error) {
 		return list, fmt.Errorf("unexpected data at end of array index: %q", k)
 	case err != nil:
 		return list, err
+	case last == '=' && t.isJSON:
+		v, e := t.jsonVal()
+		return setIndex(list, i, v), e
 	case last == '=':
 		vl, e := t.valList()
 		switch e {
@@ -277,12 +312,19 @@ func (t *parser) listItem(list []interface{}, i int) ([]interface{}, error) {
 		}
 	case last == '[':
 		// now we have a nested list. Read the index and handle.
-		i, err := t.keyIndex()
+		nextI, err := t.keyIndex()
 		if err != nil {
 			return list, fmt.Errorf("error parsing index: %s", err)
 		}
+		var inner []interface{}
+		if len(list) > i {
+			inner, _ = list[i].([]interface{})
+		}
+		if nextI == appendIndex {
+			nextI = len(inner)
+		}
 		// Now we need to get the value after the ].
-		list2, err := t.listItem(list, i)
+		list2, err := t.listItem(inner, nextI)
 		return setIndex(list, i, list2), err
 	case last == '.':
 		// We have a nested object. Send to t.key
@@ -305,6 +347,48 @@ func (t *parser) listItem(list []interface{}, i int) ([]interface{}, error) {
 	}
 }
 
+// jsonVal reads a JSON document, and the comma ending it if any.
+func (t *parser) jsonVal() (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(t.sc.Bytes()))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid JSON value: %s", err)
+	}
+	t.sc.Next(int(dec.InputOffset()))
+
+	rs, _, err := runesUntil(t.sc, runeSet([]rune{','}))
+	if len(strings.TrimSpace(string(rs))) > 0 {
+		return nil, fmt.Errorf("unexpected data after JSON value: %q", string(rs))
+	}
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return jsonNumbers(v), nil
+}
+
+// jsonNumbers converts the numbers of a decoded JSON document to int64, like
+// those of --set, or to float64 if they are not integers.
+func jsonNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, vv := range v {
+			v[k] = jsonNumbers(vv)
+		}
+	case []interface{}:
+		for i, vv := range v {
+			v[i] = jsonNumbers(vv)
+		}
+	}
+	return v
+}
+
 func (t *parser) val() ([]rune, error) {
 	stop := runeSet([]rune{','})
 	v, _, err := runesUntil(t.sc, stop)
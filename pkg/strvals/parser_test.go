@@ -327,6 +327,61 @@ func TestParseSet(t *testing.T) {
 				},
 			},
 		},
+		{
+			str:    "nested[1][0]=1",
+			expect: map[string]interface{}{"nested": []interface{}{nil, []interface{}{1}}},
+		},
+		{
+			str:    "list[+]=a,list[+]=b",
+			expect: map[string]interface{}{"list": []string{"a", "b"}},
+		},
+		{
+			str:    "list[1]=b,list[+]=c",
+			expect: map[string]interface{}{"list": []interface{}{nil, "b", "c"}},
+		},
+		{
+			str:    "list[0][+]=a,list[0][+]=b",
+			expect: map[string]interface{}{"list": []interface{}{[]string{"a", "b"}}},
+		},
+		{
+			str: "ports[+].name=http,ports[+].name=https",
+			expect: map[string]interface{}{
+				"ports": []map[string]interface{}{{"name": "http"}, {"name": "https"}},
+			},
+		},
+		{
+			str: "list[-1]=a",
+			err: true,
+		},
+		{
+			str: "list[x]=a",
+			err: true,
+		},
+		{
+			str: `podAnnotations.prometheus\.io/scrape=true`,
+			expect: map[string]interface{}{
+				"podAnnotations": map[string]interface{}{"prometheus.io/scrape": true},
+			},
+		},
+		{
+			str: `ingress.annotations.kubernetes\.io/ingress\.class=nginx,ingress.hosts[0].paths[0]=/`,
+			expect: map[string]interface{}{
+				"ingress": map[string]interface{}{
+					"annotations": map[string]interface{}{"kubernetes.io/ingress.class": "nginx"},
+					"hosts":       []map[string]interface{}{{"paths": []string{"/"}}},
+				},
+			},
+		},
+		{
+			str: `items[0].labels.app\.kubernetes\.io/name=web`,
+			expect: map[string]interface{}{
+				"items": []map[string]interface{}{{"labels": map[string]interface{}{"app.kubernetes.io/name": "web"}}},
+			},
+		},
+		{
+			str:    `path=c:\\temp,dotted\.key[+]=a`,
+			expect: map[string]interface{}{"path": `c:\temp`, "dotted.key": []string{"a"}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -415,6 +470,95 @@ func TestParseInto(t *testing.T) {
 		t.Errorf("%s: Expected:\n%s\nGot:\n%s", input, y1, y2)
 	}
 }
+func TestParseIntoJSON(t *testing.T) {
+	tests := []struct {
+		str    string
+		expect map[string]interface{}
+		err    bool
+	}{
+		{
+			str: `resources={"limits":{"cpu":"100m","memory":"128Mi"}},replicas=3`,
+			expect: map[string]interface{}{
+				"outer": map[string]interface{}{"inner1": "overwrite"},
+				"resources": map[string]interface{}{
+					"limits": map[string]interface{}{"cpu": "100m", "memory": "128Mi"},
+				},
+				"replicas": 3,
+			},
+		},
+		{
+			str: `outer.inner=[1, 2.5, "three", true, null]`,
+			expect: map[string]interface{}{
+				"outer": map[string]interface{}{
+					"inner1": "overwrite",
+					"inner":  []interface{}{1, 2.5, "three", true, nil},
+				},
+			},
+		},
+		{
+			str: `outer.inner1=null`,
+			expect: map[string]interface{}{
+				"outer": map[string]interface{}{"inner1": nil},
+			},
+		},
+		{
+			str: `tolerations[+]={"key":"dedicated","operator":"Exists"}`,
+			expect: map[string]interface{}{
+				"outer":       map[string]interface{}{"inner1": "overwrite"},
+				"tolerations": []map[string]interface{}{{"key": "dedicated", "operator": "Exists"}},
+			},
+		},
+		{
+			str: `annotations.prometheus\.io/path="/metrics"`,
+			expect: map[string]interface{}{
+				"outer":       map[string]interface{}{"inner1": "overwrite"},
+				"annotations": map[string]interface{}{"prometheus.io/path": "/metrics"},
+			},
+		},
+		{
+			str: `name={"a":}`,
+			err: true,
+		},
+		{
+			str: `name={"a":1} trailing`,
+			err: true,
+		},
+		{
+			str: `name=`,
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		got := map[string]interface{}{
+			"outer": map[string]interface{}{"inner1": "overwrite"},
+		}
+		err := ParseIntoJSON(tt.str, got)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: Expected error. Got nil", tt.str)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tt.str, err)
+		}
+
+		y1, err := yaml.Marshal(tt.expect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y2, err := yaml.Marshal(got)
+		if err != nil {
+			t.Fatalf("Error serializing parsed value: %s", err)
+		}
+
+		if string(y1) != string(y2) {
+			t.Errorf("%s: Expected:\n%s\nGot:\n%s", tt.str, y1, y2)
+		}
+	}
+}
+
 func TestParseIntoString(t *testing.T) {
 	got := map[string]interface{}{
 		"outer": map[string]interface{}{
@@ -110,6 +110,13 @@ type KubeClient interface {
 	// by "\n---\n").
 	Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error
 
+	// CreateWithOptions creates one or more resources. Depending on opts,
+	// resources that already exist are adopted instead of created.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	CreateWithOptions(namespace string, reader io.Reader, opts kube.CreateOptions) error
+
 	// Get gets one or more resources. Returned string hsa the format like kubectl
 	// provides with the column headers separating the resource types.
 	//
@@ -192,6 +199,12 @@ func (p *PrintingKubeClient) Create(ns string, r io.Reader, timeout int64, shoul
 	return err
 }
 
+// CreateWithOptions implements KubeClient CreateWithOptions.
+func (p *PrintingKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	_, err := io.Copy(p.Out, r)
+	return err
+}
+
 // Get prints the values of what would be created with a real KubeClient.
 func (p *PrintingKubeClient) Get(ns string, r io.Reader) (string, error) {
 	_, err := io.Copy(p.Out, r)
@@ -43,6 +43,9 @@ type mockKubeClient struct{}
 func (k *mockKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
 	return nil
 }
+func (k *mockKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	return nil
+}
 func (k *mockKubeClient) Get(ns string, r io.Reader) (string, error) {
 	return "", nil
 }
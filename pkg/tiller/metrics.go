@@ -0,0 +1,66 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// operationDuration observes the duration of release operations.
+	operationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "tiller",
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of release operations by operation and outcome.",
+		Buckets:   []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{"operation", "outcome"})
+
+	// hookFailures counts hooks that failed to run.
+	hookFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tiller",
+		Name:      "hook_failures_total",
+		Help:      "Number of hooks that failed, by hook event.",
+	}, []string{"hook"})
+
+	// storageDuration observes the latency of release storage calls.
+	storageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "tiller",
+		Name:      "storage_duration_seconds",
+		Help:      "Latency of release storage calls by driver and call.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"driver", "call"})
+
+	// storageRecordSize observes the size of the release records written.
+	storageRecordSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "tiller",
+		Name:      "storage_record_bytes",
+		Help:      "Size of the release records written to storage, before encoding.",
+		Buckets:   prometheus.ExponentialBuckets(1024, 4, 8),
+	}, []string{"driver"})
+)
+
+func init() {
+	prometheus.MustRegister(operationDuration, hookFailures, storageDuration, storageRecordSize)
+}
+
+// outcome is the outcome label of an operation that returned err.
+func outcome(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
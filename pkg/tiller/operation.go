@@ -0,0 +1,127 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/logging"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/tracing"
+)
+
+// operation is a release operation being traced, measured and logged.
+type operation struct {
+	name  string
+	start time.Time
+	span  *tracing.Span
+	log   logging.Logger
+
+	running  *runningReleases
+	releases []string
+}
+
+// startOperation starts the span of a release operation, as a child of the
+// caller's span in c. It returns a copy of the server that runs the operation
+// within that span: its rendering, hooks, Kubernetes and storage calls are
+// traced as children of the operation, and logged with the operation, release
+// and request ID as fields.
+func (s *ReleaseServer) startOperation(c context.Context, name, releaseName string) (*ReleaseServer, *operation) {
+	c, span := tracing.Start(c, "tiller."+name, tracing.String("release.name", releaseName))
+	op := &operation{name: name, start: time.Now(), span: span, running: s.running}
+	op.claim(releaseName)
+
+	fields := []logging.Field{
+		logging.F(logging.OperationKey, name),
+		logging.F(logging.ReleaseKey, releaseName),
+		logging.F(logging.RequestIDKey, logging.RequestIDFromContext(c)),
+	}
+	op.log = s.Log.With(fields...)
+
+	rs := *s
+	env := *s.env
+	rs.Log = op.log
+	if kc, ok := env.KubeClient.(*kube.Client); ok {
+		k := *kc
+		k.Log = kc.Log.With(fields...)
+		env.KubeClient = &k
+	}
+	if env.Releases != nil {
+		releases := *env.Releases
+		releases.Driver = &tracedDriver{Driver: releases.Driver, ctx: c}
+		releases.Log = env.Releases.Log.With(fields...)
+		env.Releases = &releases
+	}
+	if env.KubeClient != nil {
+		env.KubeClient = &tracedKubeClient{KubeClient: env.KubeClient, ctx: c}
+	}
+	if _, ok := s.ReleaseModule.(*RemoteReleaseModule); ok {
+		rs.ReleaseModule = &RemoteReleaseModule{ctx: c}
+	}
+
+	rs.env = &env
+	rs.ctx = c
+	rs.op = op
+	return &rs, op
+}
+
+// claim records that the operation runs on the release name, so that its
+// pending revisions are not recovered while it runs. Operations on releases
+// with generated names claim them once the name is known.
+func (op *operation) claim(name string) {
+	if op == nil || op.running == nil || name == "" {
+		return
+	}
+	for _, r := range op.releases {
+		if r == name {
+			return
+		}
+	}
+	op.running.add(name)
+	op.releases = append(op.releases, name)
+}
+
+// end finishes the operation, recording the release it produced.
+func (op *operation) end(rel *release.Release, err error) {
+	for _, name := range op.releases {
+		op.running.remove(name)
+	}
+	duration := time.Since(op.start)
+	log := op.log.With(logging.F("duration", duration.String()))
+	if rel != nil {
+		op.span.SetAttributes(
+			tracing.String("release.name", rel.Name),
+			tracing.String("release.namespace", rel.Namespace),
+			tracing.Int64("release.revision", int64(rel.Version)),
+		)
+		log = log.With(
+			logging.F(logging.ReleaseKey, rel.Name),
+			logging.F(logging.NamespaceKey, rel.Namespace),
+			logging.F(logging.RevisionKey, rel.Version),
+		)
+	}
+	op.span.End(err)
+	operationDuration.WithLabelValues(op.name, outcome(err)).Observe(duration.Seconds())
+
+	if err != nil {
+		log.With(logging.F(logging.ErrorKey, err)).Errorf("%s failed", op.name)
+		return
+	}
+	log.Infof("%s complete", op.name)
+}
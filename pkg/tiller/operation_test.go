@@ -0,0 +1,68 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/logging"
+)
+
+func TestOperationLogging(t *testing.T) {
+	var b bytes.Buffer
+	rs := rsFixture()
+	rs.Log = logging.New(&b, logging.JSONFormat, logging.DebugLevel).With(logging.Component("tiller"))
+
+	c := logging.WithRequestID(helm.NewContext(), "0123456789abcdef")
+	res, err := rs.InstallRelease(c, installRequest())
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected the operation to be logged, got %q", b.String())
+	}
+	var entry map[string]interface{}
+	for _, line := range lines {
+		entry = map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("expected a JSON line, got %q: %s", line, err)
+		}
+		if entry["request_id"] != "0123456789abcdef" || entry["operation"] != "install" {
+			t.Errorf("expected the request ID and operation of the install, got %q", line)
+		}
+	}
+
+	expect := map[string]interface{}{
+		"msg":       "install complete",
+		"level":     "info",
+		"component": "tiller",
+		"release":   res.Release.Name,
+		"namespace": "spaced",
+		"revision":  float64(1),
+	}
+	for k, v := range expect {
+		if entry[k] != v {
+			t.Errorf("expected %s of the last entry to be %v, got %v", k, v, entry[k])
+		}
+	}
+}
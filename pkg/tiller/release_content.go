@@ -25,7 +25,7 @@ import (
 // GetReleaseContent gets all of the stored information for the given release.
 func (s *ReleaseServer) GetReleaseContent(c ctx.Context, req *services.GetReleaseContentRequest) (*services.GetReleaseContentResponse, error) {
 	if err := validateReleaseName(req.Name); err != nil {
-		s.Log("releaseContent: Release name is invalid: %s", req.Name)
+		s.Log.Errorf("releaseContent: Release name is invalid: %s", req.Name)
 		return nil, err
 	}
 
@@ -0,0 +1,188 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	ctx "golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	relutil "k8s.io/helm/pkg/releaseutil"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+// ExportRelease streams the latest revision of a release, or all of its
+// stored revisions, oldest first.
+func (s *ReleaseServer) ExportRelease(req *services.ExportReleaseRequest, stream services.ReleaseService_ExportReleaseServer) error {
+	if err := validateReleaseName(req.Name); err != nil {
+		s.Log.Errorf("exportRelease: Release name is invalid: %s", req.Name)
+		return err
+	}
+
+	var rels []*release.Release
+	if req.AllRevisions {
+		h, err := s.env.Releases.History(req.Name)
+		if err != nil {
+			return err
+		}
+		relutil.SortByRevision(h)
+		rels = h
+	} else {
+		last, err := s.env.Releases.Last(req.Name)
+		if err != nil {
+			return err
+		}
+		rels = []*release.Release{last}
+	}
+
+	s.Log.Infof("exporting %d revisions of %s", len(rels), req.Name)
+	for _, rel := range rels {
+		if err := stream.Send(&services.ExportReleaseResponse{Release: rel}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportRelease stores revisions of releases exported from another Tiller,
+// without touching the resources in the cluster.
+//
+// All revisions are checked before any is stored, so an import failing on a
+// conflict leaves the storage as it is.
+func (s *ReleaseServer) ImportRelease(c ctx.Context, req *services.ImportReleaseRequest) (*services.ImportReleaseResponse, error) {
+	res := &services.ImportReleaseResponse{}
+	var (
+		create    []*release.Release
+		overwrite []*release.Release
+	)
+	for _, r := range req.Releases {
+		if err := validateReleaseName(r.GetName()); err != nil {
+			s.Log.Errorf("importRelease: Release name is invalid: %s", r.GetName())
+			return nil, err
+		}
+		if r.Version < 1 {
+			return nil, fmt.Errorf("release %s has invalid revision %d", r.Name, r.Version)
+		}
+		rel := proto.Clone(r).(*release.Release)
+		if req.Namespace != "" {
+			rel.Namespace = req.Namespace
+		}
+
+		existing, err := s.env.Releases.Get(rel.Name, rel.Version)
+		if storageerrors.IsReleaseNotFound(err) {
+			create = append(create, rel)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch req.OnConflict {
+		case services.ImportReleaseRequest_SKIP:
+			res.Skipped = append(res.Skipped, fmt.Sprintf("%s.v%d", rel.Name, rel.Version))
+		case services.ImportReleaseRequest_OVERWRITE:
+			overwrite = append(overwrite, rel)
+		default:
+			return nil, fmt.Errorf("revision %d of release %s is already stored (namespace %s, status %s)", existing.Version, existing.Name, existing.Namespace, existing.GetInfo().GetStatus().GetCode())
+		}
+	}
+
+	res.Releases = append(create, overwrite...)
+	supersede, err := s.supersedeImported(res.Releases)
+	if err != nil {
+		return nil, err
+	}
+	if req.DryRun {
+		return res, nil
+	}
+
+	for _, rel := range create {
+		s.Log.Infof("importing %s (v%d) to namespace %s", rel.Name, rel.Version, rel.Namespace)
+		if err := s.env.Releases.Create(rel); err != nil {
+			return nil, err
+		}
+	}
+	for _, rel := range overwrite {
+		s.Log.Infof("importing %s (v%d) to namespace %s, replacing the stored revision", rel.Name, rel.Version, rel.Namespace)
+		if err := s.env.Releases.Update(rel); err != nil {
+			return nil, err
+		}
+	}
+	for _, rel := range supersede {
+		s.Log.Infof("superseding %s (v%d), a later revision was imported", rel.Name, rel.Version)
+		if err := s.env.Releases.Update(rel); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// supersedeImported leaves a single deployed revision of each release with
+// imported deployed revisions: the latest one of the imported and the stored
+// deployed revisions. The other imported revisions are marked as superseded,
+// and the stored revisions to mark as superseded are returned.
+func (s *ReleaseServer) supersedeImported(imported []*release.Release) ([]*release.Release, error) {
+	isDeployed := func(rel *release.Release) bool {
+		return rel.GetInfo().GetStatus().GetCode() == release.Status_DEPLOYED
+	}
+	key := func(rel *release.Release) string {
+		return fmt.Sprintf("%s.v%d", rel.Name, rel.Version)
+	}
+
+	keys := map[string]bool{}
+	latest := map[string]*release.Release{}
+	for _, rel := range imported {
+		keys[key(rel)] = true
+		if l, ok := latest[rel.Name]; isDeployed(rel) && (!ok || rel.Version > l.Version) {
+			latest[rel.Name] = rel
+		}
+	}
+
+	var stored []*release.Release
+	for name := range latest {
+		h, err := s.env.Releases.History(name)
+		if err != nil && !storageerrors.IsReleaseNotFound(err) {
+			return nil, err
+		}
+		for _, rel := range h {
+			if !isDeployed(rel) || keys[key(rel)] {
+				continue
+			}
+			stored = append(stored, rel)
+			if rel.Version > latest[name].Version {
+				latest[name] = rel
+			}
+		}
+	}
+
+	for _, rel := range imported {
+		if isDeployed(rel) && latest[rel.Name] != rel {
+			rel.Info.Status.Code = release.Status_SUPERSEDED
+		}
+	}
+	var supersede []*release.Release
+	for _, rel := range stored {
+		if latest[rel.Name] != rel {
+			rel = proto.Clone(rel).(*release.Release)
+			rel.Info.Status.Code = release.Status_SUPERSEDED
+			supersede = append(supersede, rel)
+		}
+	}
+	return supersede, nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+type mockExportReleaseServer struct {
+	sent []*release.Release
+}
+
+func (rs *mockExportReleaseServer) Send(m *services.ExportReleaseResponse) error {
+	rs.sent = append(rs.sent, m.Release)
+	return nil
+}
+func (rs *mockExportReleaseServer) SetHeader(m metadata.MD) error  { return nil }
+func (rs *mockExportReleaseServer) SendHeader(m metadata.MD) error { return nil }
+func (rs *mockExportReleaseServer) SetTrailer(m metadata.MD)       {}
+func (rs *mockExportReleaseServer) SendMsg(v interface{}) error    { return nil }
+func (rs *mockExportReleaseServer) RecvMsg(v interface{}) error    { return nil }
+func (rs *mockExportReleaseServer) Context() context.Context {
+	return helm.NewContext()
+}
+
+func TestExportRelease(t *testing.T) {
+	rs := rsFixture()
+	v1 := releaseStub()
+	v2 := upgradeReleaseVersion(v1)
+	rs.env.Releases.Create(v2)
+	rs.env.Releases.Create(v1)
+
+	stream := &mockExportReleaseServer{}
+	if err := rs.ExportRelease(&services.ExportReleaseRequest{Name: v1.Name}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.sent) != 1 || stream.sent[0].Version != 2 {
+		t.Errorf("expected the latest revision, got %v", stream.sent)
+	}
+
+	stream = &mockExportReleaseServer{}
+	if err := rs.ExportRelease(&services.ExportReleaseRequest{Name: v1.Name, AllRevisions: true}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.sent) != 2 || stream.sent[0].Version != 1 || stream.sent[1].Version != 2 {
+		t.Errorf("expected all revisions, oldest first, got %v", stream.sent)
+	}
+
+	if err := rs.ExportRelease(&services.ExportReleaseRequest{Name: "nope"}, &mockExportReleaseServer{}); err == nil {
+		t.Error("expected an error exporting an unknown release")
+	}
+}
+
+func TestImportRelease(t *testing.T) {
+	rs := rsFixture()
+	v1 := releaseStub()
+	v2 := upgradeReleaseVersion(v1)
+
+	req := &services.ImportReleaseRequest{
+		Releases:  []*release.Release{v1, v2},
+		Namespace: "restored",
+	}
+	res, err := rs.ImportRelease(helm.NewContext(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Releases) != 2 {
+		t.Fatalf("expected 2 imported revisions, got %d", len(res.Releases))
+	}
+	stored, err := rs.env.Releases.Get(v1.Name, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Namespace != "restored" || stored.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("unexpected stored release %v", stored)
+	}
+	if v1.Namespace == "restored" {
+		t.Error("expected the request not to be changed")
+	}
+}
+
+func TestImportRelease_Conflicts(t *testing.T) {
+	rs := rsFixture()
+	v1 := releaseStub()
+	v2 := upgradeReleaseVersion(v1)
+	rs.env.Releases.Create(v1)
+
+	v1.Info.Description = "imported"
+	req := &services.ImportReleaseRequest{Releases: []*release.Release{v1, v2}}
+	if _, err := rs.ImportRelease(helm.NewContext(), req); err == nil || !strings.Contains(err.Error(), "revision 1 of release angry-panda is already stored") {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+	if _, err := rs.env.Releases.Get(v1.Name, 2); err == nil {
+		t.Error("expected a failed import to store nothing")
+	}
+
+	req.OnConflict = services.ImportReleaseRequest_SKIP
+	req.DryRun = true
+	res, err := rs.ImportRelease(helm.NewContext(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Skipped) != 1 || res.Skipped[0] != "angry-panda.v1" || len(res.Releases) != 1 {
+		t.Errorf("expected revision 1 to be skipped, got %v", res)
+	}
+	if _, err := rs.env.Releases.Get(v1.Name, 2); err == nil {
+		t.Error("expected a dry run to store nothing")
+	}
+
+	req.OnConflict = services.ImportReleaseRequest_OVERWRITE
+	req.DryRun = false
+	if _, err := rs.ImportRelease(helm.NewContext(), req); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := rs.env.Releases.Get(v1.Name, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Info.Description != "imported" {
+		t.Errorf("expected revision 1 to be overwritten, got %q", stored.Info.Description)
+	}
+}
+
+func TestImportRelease_Deployed(t *testing.T) {
+	rs := rsFixture()
+	v1 := releaseStub()
+	rs.env.Releases.Create(v1)
+
+	// an exported later revision, deployed elsewhere
+	v2 := proto.Clone(v1).(*release.Release)
+	v2.Version = 2
+	// and an exported earlier one
+	v0 := proto.Clone(v1).(*release.Release)
+	v0.Name = "other-panda"
+	rs.env.Releases.Create(upgradeReleaseVersion(proto.Clone(v0).(*release.Release)))
+
+	req := &services.ImportReleaseRequest{Releases: []*release.Release{v2, v0}}
+	if _, err := rs.ImportRelease(helm.NewContext(), req); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		deployed int32
+	}{{v1.Name, 2}, {v0.Name, 2}} {
+		deployed, err := rs.env.Releases.DeployedAll(tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(deployed) != 1 || deployed[0].Version != tt.deployed {
+			t.Errorf("expected only revision %d of %s to be deployed, got %v", tt.deployed, tt.name, deployed)
+		}
+	}
+	stored, err := rs.env.Releases.Get(v1.Name, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Info.Status.Code != release.Status_SUPERSEDED {
+		t.Errorf("expected revision 1 to be superseded, got %s", stored.Info.Status.Code)
+	}
+}
+
+// unreadableDriver fails to read the release records it stores.
+type unreadableDriver struct {
+	driver.Driver
+}
+
+func (unreadableDriver) Get(key string) (*release.Release, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestImportRelease_StorageError(t *testing.T) {
+	rs := rsFixture()
+	rs.env.Releases = storage.Init(unreadableDriver{driver.NewMemory()})
+
+	req := &services.ImportReleaseRequest{Releases: []*release.Release{releaseStub()}}
+	if _, err := rs.ImportRelease(helm.NewContext(), req); err == nil || err.Error() != "connection refused" {
+		t.Errorf("expected the storage error, got %v", err)
+	}
+	if rels, _ := rs.env.Releases.ListReleases(); len(rels) != 0 {
+		t.Errorf("expected nothing to be imported, got %v", rels)
+	}
+}
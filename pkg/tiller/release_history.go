@@ -26,11 +26,11 @@ import (
 // GetHistory gets the history for a given release.
 func (s *ReleaseServer) GetHistory(ctx context.Context, req *tpb.GetHistoryRequest) (*tpb.GetHistoryResponse, error) {
 	if err := validateReleaseName(req.Name); err != nil {
-		s.Log("getHistory: Release name is invalid: %s", req.Name)
+		s.Log.Errorf("getHistory: Release name is invalid: %s", req.Name)
 		return nil, err
 	}
 
-	s.Log("getting history for release %s", req.Name)
+	s.Log.Debugf("getting history for release %s", req.Name)
 	h, err := s.env.Releases.History(req.Name)
 	if err != nil {
 		return nil, err
@@ -31,11 +31,14 @@ import (
 )
 
 // InstallRelease installs a release and stores the release record.
-func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallReleaseRequest) (*services.InstallReleaseResponse, error) {
-	s.Log("preparing install for %s", req.Name)
+func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallReleaseRequest) (res *services.InstallReleaseResponse, err error) {
+	s, op := s.startOperation(c, "install", req.Name)
+	defer func() { op.end(res.GetRelease(), err) }()
+
+	s.Log.Infof("preparing install for %s", req.Name)
 	rel, err := s.prepareRelease(req)
 	if err != nil {
-		s.Log("failed install prepare step: %s", err)
+		s.Log.Errorf("failed install prepare step: %s", err)
 		res := &services.InstallReleaseResponse{Release: rel}
 
 		// On dry run, append the manifest contents to a failed release. This is
@@ -46,10 +49,10 @@ func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallRelea
 		return res, err
 	}
 
-	s.Log("performing install for %s", req.Name)
-	res, err := s.performRelease(rel, req)
+	s.Log.Infof("performing install for %s", req.Name)
+	res, err = s.performRelease(rel, req)
 	if err != nil {
-		s.Log("failed install perform step: %s", err)
+		s.Log.Errorf("failed install perform step: %s", err)
 	}
 	return res, err
 }
@@ -59,13 +62,17 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 	if req.Chart == nil {
 		return nil, errMissingChart
 	}
+	if chartutil.IsLibraryChart(req.Chart) {
+		return nil, chartutil.ErrLibraryChart
+	}
 
 	name, err := s.uniqName(req.Name, req.ReuseName)
 	if err != nil {
 		return nil, err
 	}
+	s.op.claim(name)
 
-	caps, err := capabilities(s.clientset.Discovery())
+	caps, err := Capabilities(s.clientset.Discovery())
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +90,10 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 	if err != nil {
 		return nil, err
 	}
+	sensitive, err := sensitiveValues(req.Chart, req.SensitiveValues)
+	if err != nil {
+		return nil, err
+	}
 
 	hooks, manifestDoc, notesTxt, err := s.renderResources(req.Chart, valuesToRender, req.SubNotes, caps.APIVersions)
 	if err != nil {
@@ -99,7 +110,8 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 				Status:        &release.Status{Code: release.Status_UNKNOWN},
 				Description:   fmt.Sprintf("Install failed: %s", err),
 			},
-			Version: 0,
+			Version:         0,
+			SensitiveValues: sensitive,
 		}
 		if manifestDoc != nil {
 			rel.Manifest = manifestDoc.String()
@@ -118,13 +130,16 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 			LastDeployed:  ts,
 			Status:        &release.Status{Code: release.Status_PENDING_INSTALL},
 			Description:   "Initial install underway", // Will be overwritten.
+			Owner:         s.Instance,
+			Timeout:       req.Timeout,
 		},
-		Manifest: manifestDoc.String(),
-		Hooks:    hooks,
-		Version:  int32(revision),
+		Manifest:        manifestDoc.String(),
+		Hooks:           hooks,
+		Version:         int32(revision),
+		SensitiveValues: sensitive,
 	}
 	if len(notesTxt) > 0 {
-		rel.Info.Status.Notes = notesTxt
+		rel.Info.Status.Notes = redactNotes(notesTxt, valuesToRender, sensitive)
 	}
 
 	return rel, nil
@@ -147,10 +162,10 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 	manifestDoc := []byte(r.Manifest)
 
 	if req.DryRun {
-		s.Log("dry run for %s", r.Name)
+		s.Log.Infof("dry run for %s", r.Name)
 
 		if !req.DisableCrdHook && hasCRDHook(r.Hooks) {
-			s.Log("validation skipped because CRD hook is present")
+			s.Log.Infof("validation skipped because CRD hook is present")
 			res.Release.Info.Description = "Validation skipped because CRDs are not installed"
 			return res, nil
 		}
@@ -172,7 +187,7 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 			return res, err
 		}
 	} else {
-		s.Log("CRD install hooks disabled for %s", req.Name)
+		s.Log.Infof("CRD install hooks disabled for %s", req.Name)
 	}
 
 	// Because the CRDs are installed, they are used for validation during this step.
@@ -186,13 +201,13 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 			return res, err
 		}
 	} else {
-		s.Log("install hooks disabled for %s", req.Name)
+		s.Log.Infof("install hooks disabled for %s", req.Name)
 	}
 
 	switch h, err := s.env.Releases.History(req.Name); {
 	// if this is a replace operation, append to the release history
 	case req.ReuseName && err == nil && len(h) >= 1:
-		s.Log("name reuse for %s requested, replacing release", req.Name)
+		s.Log.Infof("name reuse for %s requested, replacing release", req.Name)
 		// get latest release revision
 		relutil.Reverse(h, relutil.SortByRevision)
 
@@ -210,11 +225,12 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 			Wait:     req.Wait,
 			Recreate: false,
 			Timeout:  req.Timeout,
+			Adopt:    req.Adopt,
 		}
 		s.recordRelease(r, false)
 		if err := s.ReleaseModule.Update(old, r, updateReq, s.env); err != nil {
 			msg := fmt.Sprintf("Release replace %q failed: %s", r.Name, err)
-			s.Log("warning: %s", msg)
+			s.Log.Warnf("%s", msg)
 			old.Info.Status.Code = release.Status_SUPERSEDED
 			r.Info.Status.Code = release.Status_FAILED
 			r.Info.Description = msg
@@ -229,7 +245,7 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 		s.recordRelease(r, false)
 		if err := s.ReleaseModule.Create(r, req, s.env); err != nil {
 			msg := fmt.Sprintf("Release %q failed: %s", r.Name, err)
-			s.Log("warning: %s", msg)
+			s.Log.Warnf("%s", msg)
 			r.Info.Status.Code = release.Status_FAILED
 			r.Info.Description = msg
 			s.recordRelease(r, true)
@@ -241,7 +257,7 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 	if !req.DisableHooks {
 		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.PostInstall, req.Timeout); err != nil {
 			msg := fmt.Sprintf("Release %q failed post-install: %s", r.Name, err)
-			s.Log("warning: %s", msg)
+			s.Log.Warnf("%s", msg)
 			r.Info.Status.Code = release.Status_FAILED
 			r.Info.Description = msg
 			s.recordRelease(r, true)
@@ -18,13 +18,18 @@ package tiller
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
 	"strings"
 	"testing"
 
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
 	"k8s.io/helm/pkg/version"
 )
 
@@ -114,6 +119,37 @@ func TestInstallRelease(t *testing.T) {
 	}
 }
 
+// ownerRecordingKubeClient records the release name passed to it as the owner
+// of the created resources.
+type ownerRecordingKubeClient struct {
+	environment.PrintingKubeClient
+	owner string
+}
+
+func (k *ownerRecordingKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	k.owner = opts.ReleaseName
+	return nil
+}
+
+func TestInstallRelease_Owner(t *testing.T) {
+	for _, adopt := range []bool{false, true} {
+		rs := rsFixture()
+		kc := &ownerRecordingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+		rs.env.KubeClient = kc
+
+		req := installRequest()
+		req.Adopt = adopt
+		res, err := rs.InstallRelease(helm.NewContext(), req)
+		if err != nil {
+			t.Fatalf("Failed install: %s", err)
+		}
+
+		if kc.owner != res.Release.Name {
+			t.Errorf("adopt %v: expected owner %q, got %q", adopt, res.Release.Name, kc.owner)
+		}
+	}
+}
+
 func TestInstallRelease_WithNotes(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -548,3 +584,69 @@ func TestInstallRelease_Description(t *testing.T) {
 		t.Errorf("Expected description %q. Got %q", customDescription, desc)
 	}
 }
+
+func TestInstallRelease_LibraryChart(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := installRequest(withChart(withType(chartutil.TypeLibrary)))
+	if _, err := rs.InstallRelease(c, req); err != chartutil.ErrLibraryChart {
+		t.Errorf("Expected %q, got %v", chartutil.ErrLibraryChart, err)
+	}
+}
+
+func TestInstallRelease_LibraryDependency(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := installRequest(withChart(
+		withTemplates(&chart.Template{Name: "templates/labels", Data: []byte(`labels: {{ include "library.labels" . }}`)}),
+		withDependency(
+			withType(chartutil.TypeLibrary),
+			withTemplates(
+				&chart.Template{Name: "templates/_helpers.tpl", Data: []byte(`{{ define "library.labels" }}shared{{ end }}`)},
+				&chart.Template{Name: "templates/configmap", Data: []byte("library: rendered")},
+			),
+		),
+	))
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	if !strings.Contains(res.Release.Manifest, "labels: shared") {
+		t.Errorf("Expected the named template of the library, got manifest %q", res.Release.Manifest)
+	}
+	if strings.Contains(res.Release.Manifest, "library: rendered") {
+		t.Errorf("Expected the templates of the library not to be rendered, got manifest %q", res.Release.Manifest)
+	}
+}
+
+func TestInstallRelease_SensitiveValues(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := installRequest(withChart(
+		withNotes("Log in with {{ .Values.auth.user }}/{{ .Values.auth.password }}"),
+	))
+	req.Chart.Metadata.SensitiveValues = []string{"auth.password"}
+	req.Values = &chart.Config{Raw: "auth:\n  user: admin\n  password: changeme\ntoken: s3cr3t-token\n"}
+	req.SensitiveValues = []string{"token"}
+
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	rel, err := rs.env.Releases.Get(res.Release.Name, res.Release.Version)
+	if err != nil {
+		t.Fatalf("Expected release for %s (%v).", res.Release.Name, rs.env.Releases)
+	}
+	expect := []string{"auth.password", "token"}
+	if strings.Join(rel.SensitiveValues, ",") != strings.Join(expect, ",") {
+		t.Errorf("Expected sensitive values %v, got %v", expect, rel.SensitiveValues)
+	}
+	if notes := rel.Info.Status.Notes; notes != "Log in with admin/REDACTED" {
+		t.Errorf("Expected the password to be redacted from the notes, got %q", notes)
+	}
+}
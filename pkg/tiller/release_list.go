@@ -138,7 +138,7 @@ func (s *ReleaseServer) partition(rels []*release.Release, cap int) <-chan []*re
 		for _, rls := range rels {
 			if size = proto.Size(rls); size+fill > cap {
 				// Over-cap, push chunk onto channel to send over gRPC stream
-				s.Log("partitioned at %d with %d releases (cap=%d)", fill, len(chunk), cap)
+				s.Log.Debugf("partitioned at %d with %d releases (cap=%d)", fill, len(chunk), cap)
 				chunks <- chunk
 				// reset partitioning state
 				chunk = nil
@@ -278,7 +278,7 @@ func TestReleasesNamespace(t *testing.T) {
 func TestReleasePartition(t *testing.T) {
 	var rl []*release.Release
 	rs := rsFixture()
-	rs.Log = t.Logf
+	rs.Log = testLogger(t)
 	num := 7
 	for i := 0; i < num; i++ {
 		rel := releaseStub()
@@ -18,6 +18,7 @@ package tiller
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -51,7 +52,13 @@ type LocalReleaseModule struct {
 // Create creates a release via kubeclient from provided environment
 func (m *LocalReleaseModule) Create(r *release.Release, req *services.InstallReleaseRequest, env *environment.Environment) error {
 	b := bytes.NewBufferString(r.Manifest)
-	return env.KubeClient.Create(r.Namespace, b, req.Timeout, req.Wait)
+	return env.KubeClient.CreateWithOptions(r.Namespace, b, kube.CreateOptions{
+		Timeout:     req.Timeout,
+		ShouldWait:  req.Wait,
+		Adopt:       req.Adopt,
+		AdoptOnly:   req.AdoptOnly,
+		ReleaseName: r.Name,
+	})
 }
 
 // Update performs an update from current to target release
@@ -64,6 +71,8 @@ func (m *LocalReleaseModule) Update(current, target *release.Release, req *servi
 		Timeout:       req.Timeout,
 		ShouldWait:    req.Wait,
 		CleanupOnFail: req.CleanupOnFail,
+		Adopt:         req.Adopt,
+		ReleaseName:   target.Name,
 	})
 }
 
@@ -77,6 +86,7 @@ func (m *LocalReleaseModule) Rollback(current, target *release.Release, req *ser
 		Timeout:       req.Timeout,
 		ShouldWait:    req.Wait,
 		CleanupOnFail: req.CleanupOnFail,
+		ReleaseName:   target.Name,
 	})
 }
 
@@ -95,46 +105,65 @@ func (m *LocalReleaseModule) Delete(rel *release.Release, req *services.Uninstal
 }
 
 // RemoteReleaseModule is a ReleaseModule which calls Rudder service to operate on a release
-type RemoteReleaseModule struct{}
+type RemoteReleaseModule struct {
+	// ctx carries the trace context and request ID of the operation the
+	// module is used for, passed on to Rudder.
+	ctx context.Context
+}
+
+func (m *RemoteReleaseModule) rudderContext() context.Context {
+	if m.ctx == nil {
+		return context.Background()
+	}
+	return m.ctx
+}
 
 // Create calls rudder.InstallRelease
 func (m *RemoteReleaseModule) Create(r *release.Release, req *services.InstallReleaseRequest, env *environment.Environment) error {
-	request := &rudderAPI.InstallReleaseRequest{Release: r}
-	_, err := rudder.InstallRelease(request)
+	request := &rudderAPI.InstallReleaseRequest{
+		Release:   r,
+		Adopt:     req.Adopt,
+		AdoptOnly: req.AdoptOnly,
+	}
+	_, err := rudder.InstallRelease(m.rudderContext(), request)
 	return err
 }
 
 // Update calls rudder.UpgradeRelease
 func (m *RemoteReleaseModule) Update(current, target *release.Release, req *services.UpdateReleaseRequest, env *environment.Environment) error {
 	upgrade := &rudderAPI.UpgradeReleaseRequest{
-		Current:  current,
-		Target:   target,
-		Recreate: req.Recreate,
-		Timeout:  req.Timeout,
-		Wait:     req.Wait,
-		Force:    req.Force,
+		Current:       current,
+		Target:        target,
+		Recreate:      req.Recreate,
+		Timeout:       req.Timeout,
+		Wait:          req.Wait,
+		Force:         req.Force,
+		CleanupOnFail: req.CleanupOnFail,
+		Adopt:         req.Adopt,
 	}
-	_, err := rudder.UpgradeRelease(upgrade)
+	_, err := rudder.UpgradeRelease(m.rudderContext(), upgrade)
 	return err
 }
 
 // Rollback calls rudder.Rollback
 func (m *RemoteReleaseModule) Rollback(current, target *release.Release, req *services.RollbackReleaseRequest, env *environment.Environment) error {
 	rollback := &rudderAPI.RollbackReleaseRequest{
-		Current:  current,
-		Target:   target,
-		Recreate: req.Recreate,
-		Timeout:  req.Timeout,
-		Wait:     req.Wait,
+		Current:       current,
+		Target:        target,
+		Recreate:      req.Recreate,
+		Timeout:       req.Timeout,
+		Wait:          req.Wait,
+		Force:         req.Force,
+		CleanupOnFail: req.CleanupOnFail,
 	}
-	_, err := rudder.RollbackRelease(rollback)
+	_, err := rudder.RollbackRelease(m.rudderContext(), rollback)
 	return err
 }
 
 // Status returns status retrieved from rudder.ReleaseStatus
 func (m *RemoteReleaseModule) Status(r *release.Release, req *services.GetReleaseStatusRequest, env *environment.Environment) (string, error) {
 	statusRequest := &rudderAPI.ReleaseStatusRequest{Release: r}
-	resp, err := rudder.ReleaseStatus(statusRequest)
+	resp, err := rudder.ReleaseStatus(m.rudderContext(), statusRequest)
 	if resp == nil {
 		return "", err
 	}
@@ -144,7 +173,7 @@ func (m *RemoteReleaseModule) Status(r *release.Release, req *services.GetReleas
 // Delete calls rudder.DeleteRelease
 func (m *RemoteReleaseModule) Delete(r *release.Release, req *services.UninstallReleaseRequest, env *environment.Environment) (string, []error) {
 	deleteRequest := &rudderAPI.DeleteReleaseRequest{Release: r}
-	resp, err := rudder.DeleteRelease(deleteRequest)
+	resp, err := rudder.DeleteRelease(m.rudderContext(), deleteRequest)
 
 	errs := make([]error, 0)
 	result := ""
@@ -0,0 +1,167 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	ctx "golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	relutil "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+// defaultPendingTimeout is the timeout of operations that did not record
+// one, like those of Tillers predating timeouts in release records.
+const defaultPendingTimeout = 300 * time.Second
+
+var pendingStatuses = []release.Status_Code{
+	release.Status_PENDING_INSTALL,
+	release.Status_PENDING_UPGRADE,
+	release.Status_PENDING_ROLLBACK,
+}
+
+// runningReleases counts the operations in flight on each release.
+type runningReleases struct {
+	mu    sync.Mutex
+	names map[string]int
+}
+
+func newRunningReleases() *runningReleases {
+	return &runningReleases{names: map[string]int{}}
+}
+
+func (r *runningReleases) add(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names[name]++
+}
+
+func (r *runningReleases) remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.names[name]--; r.names[name] <= 0 {
+		delete(r.names, name)
+	}
+}
+
+// has reports whether an operation is in flight on the release name.
+func (r *runningReleases) has(name string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.names[name] > 0
+}
+
+// RecoverPendingReleases marks revisions that are still pending grace after
+// the timeout of their operation as failed. Such revisions are left behind
+// when a Tiller dies in the middle of an operation, and block further
+// operations on their release. Revisions of releases with an operation in
+// flight on this Tiller are left alone, however long the operation takes,
+// as their operation is still going to record its outcome.
+//
+// It returns the revisions marked as failed.
+func (s *ReleaseServer) RecoverPendingReleases(grace time.Duration) ([]*release.Release, error) {
+	filters := make([]relutil.FilterFunc, len(pendingStatuses))
+	for i, code := range pendingStatuses {
+		filters[i] = relutil.StatusFilter(code)
+	}
+	pending, err := s.env.Releases.ListFilterAny(filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var recovered []*release.Release
+	for _, rel := range pending {
+		if s.running.has(rel.Name) {
+			continue
+		}
+		timeout := time.Duration(rel.Info.Timeout) * time.Second
+		if timeout <= 0 {
+			timeout = defaultPendingTimeout
+		}
+		since := timeconv.Time(rel.Info.LastDeployed)
+		if now.Before(since.Add(timeout + grace)) {
+			continue
+		}
+		reason := fmt.Sprintf("timed out after %s", timeout)
+		if err := s.failPending(rel, reason); err != nil {
+			return recovered, err
+		}
+		recovered = append(recovered, rel)
+	}
+	return recovered, nil
+}
+
+// UnlockRelease marks the pending revision of a release as failed, whether
+// or not its operation timed out.
+func (s *ReleaseServer) UnlockRelease(c ctx.Context, req *services.UnlockReleaseRequest) (*services.UnlockReleaseResponse, error) {
+	if err := validateReleaseName(req.Name); err != nil {
+		s.Log.Errorf("unlockRelease: Release name is invalid: %s", req.Name)
+		return nil, err
+	}
+
+	rel, err := s.env.Releases.Last(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !isPending(rel) {
+		return nil, fmt.Errorf("release %s has no pending revision (revision %d is %s)", rel.Name, rel.Version, rel.Info.Status.Code)
+	}
+	if err := s.failPending(rel, "unlocked on request"); err != nil {
+		return nil, err
+	}
+	return &services.UnlockReleaseResponse{Release: rel}, nil
+}
+
+func isPending(rel *release.Release) bool {
+	for _, code := range pendingStatuses {
+		if rel.GetInfo().GetStatus().GetCode() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// failPending marks a pending revision as failed, describing the interrupted
+// operation and the Tiller that owned it.
+func (s *ReleaseServer) failPending(rel *release.Release, reason string) error {
+	owner := rel.Info.Owner
+	if owner == "" {
+		owner = "unknown"
+	}
+	code := rel.Info.Status.Code
+	rel.Info.Description = fmt.Sprintf("%s interrupted: %s (started %s by Tiller %s, marked failed by Tiller %s)",
+		code, reason, timeconv.String(rel.Info.LastDeployed), owner, s.instance())
+	rel.Info.Status.Code = release.Status_FAILED
+	s.Log.Infof("marking %s (v%d) failed: was %s since %s, owned by Tiller %s", rel.Name, rel.Version, code, timeconv.String(rel.Info.LastDeployed), owner)
+	return s.env.Releases.Update(rel)
+}
+
+func (s *ReleaseServer) instance() string {
+	if s.Instance == "" {
+		return "unknown"
+	}
+	return s.Instance
+}
@@ -0,0 +1,133 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+func TestRecoverPendingReleases(t *testing.T) {
+	rs := rsFixture()
+	rs.Instance = "tiller-b"
+
+	deployed := namedReleaseStub("deployed", release.Status_DEPLOYED)
+	stuck := namedReleaseStub("stuck", release.Status_PENDING_UPGRADE)
+	stuck.Info.Owner = "tiller-a"
+	running := namedReleaseStub("running", release.Status_PENDING_INSTALL)
+	running.Info.LastDeployed = timeconv.Now()
+	running.Info.Timeout = 600
+	for _, rel := range []*release.Release{deployed, stuck, running} {
+		if err := rs.env.Releases.Create(rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recovered, err := rs.RecoverPendingReleases(time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recovered) != 1 || recovered[0].Name != "stuck" {
+		t.Fatalf("expected only the stuck release to be recovered, got %v", recovered)
+	}
+
+	rel, err := rs.env.Releases.Get("stuck", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Info.Status.Code != release.Status_FAILED {
+		t.Errorf("expected the stuck release to be failed, got %s", rel.Info.Status.Code)
+	}
+	for _, s := range []string{"PENDING_UPGRADE interrupted: timed out after 5m0s", "by Tiller tiller-a", "marked failed by Tiller tiller-b"} {
+		if !strings.Contains(rel.Info.Description, s) {
+			t.Errorf("expected description to contain %q, got %q", s, rel.Info.Description)
+		}
+	}
+
+	if rel, _ := rs.env.Releases.Get("running", 1); rel.Info.Status.Code != release.Status_PENDING_INSTALL {
+		t.Errorf("expected the running release to stay pending, got %s", rel.Info.Status.Code)
+	}
+}
+
+func TestRecoverPendingReleasesSkipsRunningOperations(t *testing.T) {
+	rs := rsFixture()
+	slow := namedReleaseStub("slow", release.Status_PENDING_UPGRADE)
+	slow.Info.Owner = rs.Instance
+	if err := rs.env.Releases.Create(slow); err != nil {
+		t.Fatal(err)
+	}
+
+	// the upgrade outlives its timeout, e.g. while waiting for hooks
+	_, op := rs.startOperation(context.Background(), "upgrade", "slow")
+	recovered, err := rs.RecoverPendingReleases(time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recovered) != 0 {
+		t.Fatalf("expected the release of the running upgrade to stay pending, got %v", recovered)
+	}
+
+	op.end(nil, nil)
+	if recovered, err = rs.RecoverPendingReleases(time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if len(recovered) != 1 || recovered[0].Name != "slow" {
+		t.Errorf("expected the release to be recovered once the upgrade ended, got %v", recovered)
+	}
+}
+
+func TestUnlockRelease(t *testing.T) {
+	rs := rsFixture()
+	pending := namedReleaseStub("pending", release.Status_PENDING_ROLLBACK)
+	pending.Info.LastDeployed = timeconv.Now()
+	rs.env.Releases.Create(pending)
+	rs.env.Releases.Create(releaseStub())
+
+	res, err := rs.UnlockRelease(helm.NewContext(), &services.UnlockReleaseRequest{Name: "pending"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Release.Info.Status.Code != release.Status_FAILED || !strings.Contains(res.Release.Info.Description, "unlocked on request") {
+		t.Errorf("expected the release to be failed on request, got %v", res.Release.Info)
+	}
+
+	if _, err := rs.UnlockRelease(helm.NewContext(), &services.UnlockReleaseRequest{Name: "angry-panda"}); err == nil || !strings.Contains(err.Error(), "has no pending revision") {
+		t.Errorf("expected an error unlocking a deployed release, got %v", err)
+	}
+}
+
+func TestInstallRelease_RecordsOwner(t *testing.T) {
+	rs := rsFixture()
+	rs.Instance = "tiller-a"
+
+	req := installRequest()
+	req.Timeout = 120
+	res, err := rs.InstallRelease(helm.NewContext(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Release.Info.Owner != "tiller-a" || res.Release.Info.Timeout != 120 {
+		t.Errorf("expected owner tiller-a and timeout 120, got %q and %d", res.Release.Info.Owner, res.Release.Info.Timeout)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	ctx "golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/deprecation"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	relutil "k8s.io/helm/pkg/releaseutil"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+// RewriteReleaseAPIs moves the objects and hooks of the deployed revision of a
+// release from deprecated apiVersions to the ones replacing them.
+//
+// Tiller builds the objects of the deployed manifest on every upgrade, which
+// fails once the cluster stops serving their apiVersions. Rewriting the
+// stored manifest lets such releases be upgraded again.
+func (s *ReleaseServer) RewriteReleaseAPIs(c ctx.Context, req *services.RewriteReleaseAPIsRequest) (*services.RewriteReleaseAPIsResponse, error) {
+	if err := validateReleaseName(req.Name); err != nil {
+		s.Log.Errorf("rewriteReleaseAPIs: Release name is invalid: %s", req.Name)
+		return nil, err
+	}
+
+	kubeVersion := req.KubeVersion
+	if kubeVersion == "" {
+		sv, err := s.clientset.Discovery().ServerVersion()
+		if err != nil {
+			return nil, err
+		}
+		kubeVersion = sv.GitVersion
+	}
+
+	target, err := s.rewriteTarget(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	rel := proto.Clone(target).(*release.Release)
+	res := &services.RewriteReleaseAPIsResponse{Release: rel, OriginalManifest: target.Manifest}
+
+	var rewritten []deprecation.Finding
+	manifest, findings, err := deprecation.Rewrite(rel.Manifest, kubeVersion)
+	if err != nil {
+		return nil, err
+	}
+	rel.Manifest = manifest
+	rewritten = append(rewritten, findings...)
+	remaining := deprecation.Check(rel.Manifest, kubeVersion)
+
+	for _, h := range rel.Hooks {
+		manifest, findings, err := deprecation.Rewrite(h.Manifest, kubeVersion)
+		if err != nil {
+			return nil, fmt.Errorf("hook %s: %s", h.Name, err)
+		}
+		h.Manifest = manifest
+		rewritten = append(rewritten, findings...)
+		remaining = append(remaining, deprecation.Check(h.Manifest, kubeVersion)...)
+	}
+
+	for i := range rewritten {
+		res.Rewritten = append(res.Rewritten, rewritten[i].Error())
+	}
+	for i := range remaining {
+		res.Remaining = append(res.Remaining, remaining[i].Error())
+	}
+
+	if len(rewritten) == 0 || req.DryRun {
+		return res, nil
+	}
+
+	s.Log.Infof("rewriting %d deprecated apiVersions of %s (v%d) for Kubernetes %s", len(rewritten), rel.Name, rel.Version, kubeVersion)
+	if err := s.env.Releases.Update(rel); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// rewriteTarget returns the revision of a release that upgrades diff against:
+// the deployed revision, or the latest one if none is deployed. After a failed
+// upgrade, the latest revision is not the deployed one.
+func (s *ReleaseServer) rewriteTarget(name string) (*release.Release, error) {
+	h, err := s.env.Releases.History(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(h) == 0 {
+		return nil, storageerrors.ErrReleaseNotFound(name)
+	}
+	relutil.Reverse(h, relutil.SortByRevision)
+	for _, rel := range h {
+		if rel.Info.Status.Code == release.Status_DEPLOYED {
+			return rel, nil
+		}
+	}
+	return h[0], nil
+}
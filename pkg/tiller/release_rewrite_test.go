@@ -0,0 +1,164 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var manifestWithDeprecatedAPI = `apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+`
+
+func withServerVersion(rs *ReleaseServer, v string) {
+	rs.clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: v}
+}
+
+func TestRewriteReleaseAPIs(t *testing.T) {
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Manifest = manifestWithDeprecatedAPI
+	rs.env.Releases.Create(rel)
+
+	req := &services.RewriteReleaseAPIsRequest{Name: rel.Name, DryRun: true, KubeVersion: "v1.16.0"}
+	res, err := rs.RewriteReleaseAPIs(helm.NewContext(), req)
+	if err != nil {
+		t.Fatalf("Failed dry run: %s", err)
+	}
+	if len(res.Rewritten) != 1 || len(res.Remaining) != 0 {
+		t.Errorf("Expected 1 rewritten object, got %v (remaining %v)", res.Rewritten, res.Remaining)
+	}
+	if res.OriginalManifest != manifestWithDeprecatedAPI {
+		t.Errorf("Expected the original manifest, got %q", res.OriginalManifest)
+	}
+	if !strings.HasPrefix(res.Release.Manifest, "apiVersion: apps/v1\n") {
+		t.Errorf("Expected a rewritten manifest, got %q", res.Release.Manifest)
+	}
+	stored, err := rs.env.Releases.Get(rel.Name, rel.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Manifest != manifestWithDeprecatedAPI {
+		t.Errorf("Expected a dry run to leave the stored release alone, got %q", stored.Manifest)
+	}
+
+	req.DryRun = false
+	if _, err := rs.RewriteReleaseAPIs(helm.NewContext(), req); err != nil {
+		t.Fatalf("Failed rewrite: %s", err)
+	}
+	stored, err = rs.env.Releases.Get(rel.Name, rel.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Manifest != res.Release.Manifest {
+		t.Errorf("Expected the stored manifest to be rewritten, got %q", stored.Manifest)
+	}
+}
+
+func TestRewriteReleaseAPIs_FailedUpgrade(t *testing.T) {
+	rs := rsFixture()
+	deployed := releaseStub()
+	deployed.Manifest = manifestWithDeprecatedAPI
+	rs.env.Releases.Create(deployed)
+	failed := upgradeReleaseVersion(proto.Clone(deployed).(*release.Release))
+	failed.Info.Status.Code = release.Status_FAILED
+	failed.Manifest = manifestWithDeprecatedAPI
+	rs.env.Releases.Create(failed)
+
+	req := &services.RewriteReleaseAPIsRequest{Name: deployed.Name, KubeVersion: "v1.16.0"}
+	res, err := rs.RewriteReleaseAPIs(helm.NewContext(), req)
+	if err != nil {
+		t.Fatalf("Failed rewrite: %s", err)
+	}
+	if res.Release.Version != deployed.Version {
+		t.Errorf("Expected the deployed revision %d to be rewritten, got %d", deployed.Version, res.Release.Version)
+	}
+	stored, err := rs.env.Releases.Deployed(deployed.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(stored.Manifest, "apiVersion: apps/v1\n") {
+		t.Errorf("Expected the deployed manifest to be rewritten, got %q", stored.Manifest)
+	}
+	stored, err = rs.env.Releases.Get(failed.Name, failed.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Manifest != manifestWithDeprecatedAPI {
+		t.Errorf("Expected the failed revision to be left alone, got %q", stored.Manifest)
+	}
+}
+
+func TestRewriteReleaseAPIs_ServerVersion(t *testing.T) {
+	rs := rsFixture()
+	withServerVersion(rs, "v1.8.0")
+	rel := releaseStub()
+	rel.Manifest = manifestWithDeprecatedAPI
+	rs.env.Releases.Create(rel)
+
+	res, err := rs.RewriteReleaseAPIs(helm.NewContext(), &services.RewriteReleaseAPIsRequest{Name: rel.Name})
+	if err != nil {
+		t.Fatalf("Failed rewrite: %s", err)
+	}
+	if len(res.Rewritten) != 0 {
+		t.Errorf("Expected nothing to rewrite before the deprecation, got %v", res.Rewritten)
+	}
+}
+
+func TestUpdateRelease_DeprecatedAPIWarnings(t *testing.T) {
+	rs := rsFixture()
+	withServerVersion(rs, "v1.14.0")
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name: rel.Name,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/deployment", Data: []byte(manifestWithDeprecatedAPI)},
+			},
+		},
+	}
+	res, err := rs.UpdateRelease(helm.NewContext(), req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	if len(res.Warnings) != 1 || !strings.Contains(res.Warnings[0], "deprecated since Kubernetes v1.9") {
+		t.Errorf("Expected a deprecation warning, got %v", res.Warnings)
+	}
+}
@@ -30,27 +30,30 @@ import (
 )
 
 // RollbackRelease rolls back to a previous version of the given release.
-func (s *ReleaseServer) RollbackRelease(c ctx.Context, req *services.RollbackReleaseRequest) (*services.RollbackReleaseResponse, error) {
-	s.Log("preparing rollback of %s", req.Name)
+func (s *ReleaseServer) RollbackRelease(c ctx.Context, req *services.RollbackReleaseRequest) (res *services.RollbackReleaseResponse, err error) {
+	s, op := s.startOperation(c, "rollback", req.Name)
+	defer func() { op.end(res.GetRelease(), err) }()
+
+	s.Log.Infof("preparing rollback of %s", req.Name)
 	currentRelease, targetRelease, err := s.prepareRollback(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if !req.DryRun {
-		s.Log("creating rolled back release for %s", req.Name)
+		s.Log.Infof("creating rolled back release for %s", req.Name)
 		if err := s.env.Releases.Create(targetRelease); err != nil {
 			return nil, err
 		}
 	}
-	s.Log("performing rollback of %s", req.Name)
-	res, err := s.performRollback(currentRelease, targetRelease, req)
+	s.Log.Infof("performing rollback of %s", req.Name)
+	res, err = s.performRollback(currentRelease, targetRelease, req)
 	if err != nil {
 		return res, err
 	}
 
 	if !req.DryRun {
-		s.Log("updating status for rolled back release for %s", req.Name)
+		s.Log.Infof("updating status for rolled back release for %s", req.Name)
 		if err := s.env.Releases.Update(targetRelease); err != nil {
 			return res, err
 		}
@@ -63,7 +66,7 @@ func (s *ReleaseServer) RollbackRelease(c ctx.Context, req *services.RollbackRel
 // the previous release's configuration
 func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*release.Release, *release.Release, error) {
 	if err := validateReleaseName(req.Name); err != nil {
-		s.Log("prepareRollback: Release name is invalid: %s", req.Name)
+		s.Log.Errorf("prepareRollback: Release name is invalid: %s", req.Name)
 		return nil, nil, err
 	}
 
@@ -81,7 +84,7 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 		previousVersion = currentRelease.Version - 1
 	}
 
-	s.Log("rolling back %s (current: v%d, target: v%d)", req.Name, currentRelease.Version, previousVersion)
+	s.Log.Infof("rolling back %s (current: v%d, target: v%d)", req.Name, currentRelease.Version, previousVersion)
 
 	previousRelease, err := s.env.Releases.Get(req.Name, previousVersion)
 	if err != nil {
@@ -109,10 +112,13 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 			// Because we lose the reference to previous version elsewhere, we set the
 			// message here, and only override it later if we experience failure.
 			Description: description,
+			Owner:       s.Instance,
+			Timeout:     req.Timeout,
 		},
-		Version:  currentRelease.Version + 1,
-		Manifest: previousRelease.Manifest,
-		Hooks:    previousRelease.Hooks,
+		Version:         currentRelease.Version + 1,
+		Manifest:        previousRelease.Manifest,
+		Hooks:           previousRelease.Hooks,
+		SensitiveValues: previousRelease.SensitiveValues,
 	}
 
 	return currentRelease, targetRelease, nil
@@ -122,7 +128,7 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 	res := &services.RollbackReleaseResponse{Release: targetRelease}
 
 	if req.DryRun {
-		s.Log("dry run for %s", targetRelease.Name)
+		s.Log.Infof("dry run for %s", targetRelease.Name)
 		return res, nil
 	}
 
@@ -132,12 +138,12 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 			return res, err
 		}
 	} else {
-		s.Log("rollback hooks disabled for %s", req.Name)
+		s.Log.Infof("rollback hooks disabled for %s", req.Name)
 	}
 
 	if err := s.ReleaseModule.Rollback(currentRelease, targetRelease, req, s.env); err != nil {
 		msg := fmt.Sprintf("Rollback %q failed: %s", targetRelease.Name, err)
-		s.Log("warning: %s", msg)
+		s.Log.Warnf("%s", msg)
 		currentRelease.Info.Status.Code = release.Status_SUPERSEDED
 		targetRelease.Info.Status.Code = release.Status_FAILED
 		targetRelease.Info.Description = msg
@@ -154,7 +160,7 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 	}
 
 	// update the current release
-	s.Log("superseding previous deployment %d", currentRelease.Version)
+	s.Log.Infof("superseding previous deployment %d", currentRelease.Version)
 	currentRelease.Info.Status.Code = release.Status_SUPERSEDED
 	s.recordRelease(currentRelease, true)
 
@@ -164,7 +170,7 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 		return nil, err
 	}
 	for _, r := range deployed {
-		s.Log("superseding previous deployment %d", r.Version)
+		s.Log.Infof("superseding previous deployment %d", r.Version)
 		r.Info.Status.Code = release.Status_SUPERSEDED
 		s.recordRelease(r, true)
 	}
@@ -139,8 +139,8 @@ func TestRollbackRelease(t *testing.T) {
 func TestRollbackWithReleaseVersion(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
-	rs.Log = t.Logf
-	rs.env.Releases.Log = t.Logf
+	rs.Log = testLogger(t)
+	rs.env.Releases.Log = testLogger(t)
 	rel2 := releaseStub()
 	rel2.Name = "other"
 	rs.env.Releases.Create(rel2)
@@ -187,8 +187,8 @@ func TestRollbackWithReleaseVersion(t *testing.T) {
 func TestRollbackDeleted(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
-	rs.Log = t.Logf
-	rs.env.Releases.Log = t.Logf
+	rs.Log = testLogger(t)
+	rs.env.Releases.Log = testLogger(t)
 	rel2 := releaseStub()
 	rel2.Name = "other"
 	rs.env.Releases.Create(rel2)
@@ -18,10 +18,12 @@ package tiller
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -32,12 +34,14 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/hooks"
+	"k8s.io/helm/pkg/logging"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
 	relutil "k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/tiller/environment"
 	"k8s.io/helm/pkg/timeconv"
+	"k8s.io/helm/pkg/tracing"
 	"k8s.io/helm/pkg/version"
 )
 
@@ -86,7 +90,21 @@ type ReleaseServer struct {
 	ReleaseModule
 	env       *environment.Environment
 	clientset kubernetes.Interface
-	Log       func(string, ...interface{})
+	// Log receives the entries of the server. Those of release operations
+	// carry the operation, release and request ID as fields.
+	Log logging.Logger
+	// Instance identifies this Tiller, e.g. by the name of its pod. It is
+	// recorded as the owner of the revisions it creates.
+	Instance string
+
+	// running holds the releases with operations in flight on this Tiller.
+	running *runningReleases
+
+	// ctx carries the span of the operation a copy of the server runs, see
+	// startOperation.
+	ctx context.Context
+	// op is the operation a copy of the server runs.
+	op *operation
 }
 
 // NewReleaseServer creates a new release server.
@@ -104,7 +122,8 @@ func NewReleaseServer(env *environment.Environment, clientset kubernetes.Interfa
 		env:           env,
 		clientset:     clientset,
 		ReleaseModule: releaseModule,
-		Log:           func(_ string, _ ...interface{}) {},
+		Log:           logging.Discard,
+		running:       newRunningReleases(),
 	}
 }
 
@@ -119,19 +138,19 @@ func NewReleaseServer(env *environment.Environment, clientset kubernetes.Interfa
 func (s *ReleaseServer) reuseValues(req *services.UpdateReleaseRequest, current *release.Release) error {
 	if req.ResetValues {
 		// If ResetValues is set, we completely ignore current.Config.
-		s.Log("resetting values to the chart's original version")
+		s.Log.Infof("resetting values to the chart's original version")
 		return nil
 	}
 
 	// If the ReuseValues flag is set, we always copy the old values over the new config's values.
 	if req.ReuseValues {
-		s.Log("reusing the old release's values")
+		s.Log.Infof("reusing the old release's values")
 
 		// We have to regenerate the old coalesced values:
 		oldVals, err := chartutil.CoalesceValues(current.Chart, current.Config)
 		if err != nil {
 			err := fmt.Errorf("failed to rebuild old values: %s", err)
-			s.Log("%s", err)
+			s.Log.Errorf("%s", err)
 			return err
 		}
 		nv, err := oldVals.YAML()
@@ -169,12 +188,46 @@ func (s *ReleaseServer) reuseValues(req *services.UpdateReleaseRequest, current
 		current.Config != nil &&
 		current.Config.Raw != "" &&
 		current.Config.Raw != "{}\n" {
-		s.Log("copying values from %s (v%d) to new release.", current.Name, current.Version)
+		s.Log.Infof("copying values from %s (v%d) to new release.", current.Name, current.Version)
 		req.Values = current.Config
 	}
 	return nil
 }
 
+// sensitiveValues returns the paths of the values that the chart marks as
+// sensitive, together with the given paths.
+func sensitiveValues(ch *chart.Chart, paths ...[]string) ([]string, error) {
+	chartPaths, err := chartutil.SensitiveValues(ch)
+	if err != nil {
+		return nil, err
+	}
+	set := map[string]bool{}
+	for _, list := range append(paths, chartPaths) {
+		for _, p := range list {
+			set[p] = true
+		}
+	}
+	sensitive := make([]string, 0, len(set))
+	for p := range set {
+		sensitive = append(sensitive, p)
+	}
+	sort.Strings(sensitive)
+	return sensitive, nil
+}
+
+// redactNotes masks the sensitive values of the rendered values in notes, as
+// they are shown by every status of the release.
+func redactNotes(notes string, values chartutil.Values, sensitive []string) string {
+	if notes == "" || len(sensitive) == 0 {
+		return notes
+	}
+	vals, err := values.Table("Values")
+	if err != nil {
+		return notes
+	}
+	return chartutil.RedactText(notes, chartutil.SensitiveStrings(vals, sensitive))
+}
+
 func (s *ReleaseServer) uniqName(start string, reuse bool) (string, error) {
 
 	// If a name is supplied, we check to see if that name is taken. If not, it
@@ -195,7 +248,7 @@ func (s *ReleaseServer) uniqName(start string, reuse bool) (string, error) {
 
 		if st := rel.Info.Status.Code; reuse && (st == release.Status_DELETED || st == release.Status_FAILED) {
 			// Allow re-use of names if the previous release is marked deleted.
-			s.Log("name %s exists but is not in use, reusing name", start)
+			s.Log.Infof("name %s exists but is not in use, reusing name", start)
 			return start, nil
 		} else if reuse {
 			return "", fmt.Errorf("a release named %s is in use, cannot re-use a name that is still in use", start)
@@ -210,7 +263,7 @@ func (s *ReleaseServer) uniqName(start string, reuse bool) (string, error) {
 		return "ERROR", err
 	}
 
-	s.Log("info: Created new release name %s", newname)
+	s.Log.Infof("Created new release name %s", newname)
 	return newname, nil
 
 }
@@ -227,9 +280,9 @@ func (s *ReleaseServer) createUniqName(m moniker.Namer) (string, error) {
 				return name, nil
 			}
 		}
-		s.Log("info: generated name %s is taken. Searching again.", name)
+		s.Log.Infof("generated name %s is taken. Searching again.", name)
 	}
-	s.Log("warning: No available release names found after %d tries", maxTries)
+	s.Log.Warnf("No available release names found after %d tries", maxTries)
 	return "ERROR", errors.New("no available release name found")
 }
 
@@ -239,14 +292,15 @@ func (s *ReleaseServer) engine(ch *chart.Chart) environment.Engine {
 		if r, ok := s.env.EngineYard.Get(ch.Metadata.Engine); ok {
 			renderer = r
 		} else {
-			s.Log("warning: %s requested non-existent template engine %s", ch.Metadata.Name, ch.Metadata.Engine)
+			s.Log.Warnf("%s requested non-existent template engine %s", ch.Metadata.Name, ch.Metadata.Engine)
 		}
 	}
 	return renderer
 }
 
-// capabilities builds a Capabilities from discovery information.
-func capabilities(disc discovery.DiscoveryInterface) (*chartutil.Capabilities, error) {
+// Capabilities builds a Capabilities from discovery information, as used to
+// render releases in the cluster described by disc.
+func Capabilities(disc discovery.DiscoveryInterface) (*chartutil.Capabilities, error) {
 	sv, err := disc.ServerVersion()
 	if err != nil {
 		return nil, err
@@ -335,6 +389,15 @@ func GetVersionSet(client discovery.ServerGroupsInterface) (chartutil.VersionSet
 }
 
 func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values, subNotes bool, vs chartutil.VersionSet) ([]*release.Hook, *bytes.Buffer, string, error) {
+	span := s.startSpan("tiller.render",
+		tracing.String("chart.name", ch.GetMetadata().GetName()),
+		tracing.String("chart.version", ch.GetMetadata().GetVersion()))
+	hs, manifests, notes, err := s.render(ch, values, subNotes, vs)
+	span.End(err)
+	return hs, manifests, notes, err
+}
+
+func (s *ReleaseServer) render(ch *chart.Chart, values chartutil.Values, subNotes bool, vs chartutil.VersionSet) ([]*release.Hook, *bytes.Buffer, string, error) {
 	// Guard to make sure Tiller is at the right version to handle this chart.
 	sver := version.GetVersion()
 	if ch.Metadata.TillerVersion != "" &&
@@ -351,7 +414,7 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 		}
 	}
 
-	s.Log("rendering %s chart using values", ch.GetMetadata().Name)
+	s.Log.Debugf("rendering %s chart using values", ch.GetMetadata().Name)
 	renderer := s.engine(ch)
 	files, err := renderer.Render(ch, values)
 	if err != nil {
@@ -415,10 +478,10 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 func (s *ReleaseServer) recordRelease(r *release.Release, reuse bool) {
 	if reuse {
 		if err := s.env.Releases.Update(r); err != nil {
-			s.Log("warning: Failed to update release %s: %s", r.Name, err)
+			s.Log.Warnf("Failed to update release %s: %s", r.Name, err)
 		}
 	} else if err := s.env.Releases.Create(r); err != nil {
-		s.Log("warning: Failed to record release %s: %s", r.Name, err)
+		s.Log.Warnf("Failed to record release %s: %s", r.Name, err)
 	}
 }
 
@@ -429,7 +492,7 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 		return fmt.Errorf("unknown hook %s", hook)
 	}
 
-	s.Log("executing %d %s hooks for %s", len(hs), hook, name)
+	s.Log.Infof("executing %d %s hooks for %s", len(hs), hook, name)
 	executingHooks := []*release.Hook{}
 	for _, h := range hs {
 		for _, e := range h.Events {
@@ -442,39 +505,13 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 	executingHooks = sortByHookWeight(executingHooks)
 
 	for _, h := range executingHooks {
-		if err := s.deleteHookByPolicy(h, hooks.BeforeHookCreation, name, namespace, hook, kubeCli); err != nil {
+		if err := s.runHook(h, name, namespace, hook, timeout); err != nil {
+			hookFailures.WithLabelValues(hook).Inc()
 			return err
 		}
-
-		b := bytes.NewBufferString(h.Manifest)
-		if err := kubeCli.Create(namespace, b, timeout, false); err != nil {
-			s.Log("warning: Release %s %s %s failed: %s", name, hook, h.Path, err)
-			return err
-		}
-		// No way to rewind a bytes.Buffer()?
-		b.Reset()
-		b.WriteString(h.Manifest)
-
-		// We can't watch CRDs, but need to wait until they reach the established state before continuing
-		if hook != hooks.CRDInstall {
-			if err := kubeCli.WatchUntilReady(namespace, b, timeout, false); err != nil {
-				s.Log("warning: Release %s %s %s could not complete: %s", name, hook, h.Path, err)
-				// If a hook is failed, checkout the annotation of the hook to determine whether the hook should be deleted
-				// under failed condition. If so, then clear the corresponding resource object in the hook
-				if err := s.deleteHookByPolicy(h, hooks.HookFailed, name, namespace, hook, kubeCli); err != nil {
-					return err
-				}
-				return err
-			}
-		} else {
-			if err := kubeCli.WaitUntilCRDEstablished(b, time.Duration(timeout)*time.Second); err != nil {
-				s.Log("warning: Release %s %s %s could not complete: %s", name, hook, h.Path, err)
-				return err
-			}
-		}
 	}
 
-	s.Log("hooks complete for %s %s", hook, name)
+	s.Log.Infof("hooks complete for %s %s", hook, name)
 	// If all hooks are succeeded, checkout the annotation of each hook to determine whether the hook should be deleted
 	// under succeeded condition. If so, then clear the corresponding resource object in each hook
 	for _, h := range executingHooks {
@@ -487,6 +524,50 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 	return nil
 }
 
+// runHook creates the resource of a hook and waits for it to complete.
+func (s *ReleaseServer) runHook(h *release.Hook, name, namespace, hook string, timeout int64) (err error) {
+	c, span := tracing.Start(s.ctx, "tiller.hook",
+		tracing.String("hook.event", hook),
+		tracing.String("hook.name", h.Name),
+		tracing.String("hook.path", h.Path),
+		tracing.String("release.name", name),
+		tracing.String("release.namespace", namespace))
+	defer func() { span.End(err) }()
+	kubeCli := tracedWithin(s.env.KubeClient, c)
+
+	if err := s.deleteHookByPolicy(h, hooks.BeforeHookCreation, name, namespace, hook, kubeCli); err != nil {
+		return err
+	}
+
+	b := bytes.NewBufferString(h.Manifest)
+	if err := kubeCli.Create(namespace, b, timeout, false); err != nil {
+		s.Log.Warnf("Release %s %s %s failed: %s", name, hook, h.Path, err)
+		return err
+	}
+	// No way to rewind a bytes.Buffer()?
+	b.Reset()
+	b.WriteString(h.Manifest)
+
+	// We can't watch CRDs, but need to wait until they reach the established state before continuing
+	if hook != hooks.CRDInstall {
+		if err := kubeCli.WatchUntilReady(namespace, b, timeout, false); err != nil {
+			s.Log.Warnf("Release %s %s %s could not complete: %s", name, hook, h.Path, err)
+			// If a hook is failed, checkout the annotation of the hook to determine whether the hook should be deleted
+			// under failed condition. If so, then clear the corresponding resource object in the hook
+			if err := s.deleteHookByPolicy(h, hooks.HookFailed, name, namespace, hook, kubeCli); err != nil {
+				return err
+			}
+			return err
+		}
+	} else {
+		if err := kubeCli.WaitUntilCRDEstablished(b, time.Duration(timeout)*time.Second); err != nil {
+			s.Log.Warnf("Release %s %s %s could not complete: %s", name, hook, h.Path, err)
+			return err
+		}
+	}
+	return nil
+}
+
 func validateManifest(c environment.KubeClient, ns string, manifest []byte) error {
 	r := bytes.NewReader(manifest)
 	return c.Validate(ns, r)
@@ -507,10 +588,10 @@ func validateReleaseName(releaseName string) error {
 func (s *ReleaseServer) deleteHookByPolicy(h *release.Hook, policy string, name, namespace, hook string, kubeCli environment.KubeClient) error {
 	b := bytes.NewBufferString(h.Manifest)
 	if hookHasDeletePolicy(h, policy) {
-		s.Log("deleting %s hook %s for release %s due to %q policy", hook, h.Name, name, policy)
+		s.Log.Infof("deleting %s hook %s for release %s due to %q policy", hook, h.Name, name, policy)
 		waitForDelete := h.DeleteTimeout > 0
 		if errHookDelete := kubeCli.DeleteWithTimeout(namespace, b, h.DeleteTimeout, waitForDelete); errHookDelete != nil {
-			s.Log("warning: Release %s %s %S could not be deleted: %s", name, hook, h.Path, errHookDelete)
+			s.Log.Warnf("Release %s %s %S could not be deleted: %s", name, hook, h.Path, errHookDelete)
 			return errHookDelete
 		}
 	}
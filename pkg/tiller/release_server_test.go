@@ -38,6 +38,7 @@ import (
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/hooks"
 	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/logging"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -160,6 +161,18 @@ func withKube(version string) chartOption {
 	}
 }
 
+func withType(chartType string) chartOption {
+	return func(opts *chartOptions) {
+		opts.Metadata.Type = chartType
+	}
+}
+
+func withTemplates(templates ...*chart.Template) chartOption {
+	return func(opts *chartOptions) {
+		opts.Templates = templates
+	}
+}
+
 func withTiller(version string) chartOption {
 	return func(opts *chartOptions) {
 		opts.Metadata.TillerVersion = version
@@ -628,6 +641,9 @@ func (kc *mockHooksKubeClient) Create(ns string, r io.Reader, timeout int64, sho
 
 	return nil
 }
+func (kc *mockHooksKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	return kc.Create(ns, r, opts.Timeout, opts.ShouldWait)
+}
 func (kc *mockHooksKubeClient) Get(ns string, r io.Reader) (string, error) {
 	return "", nil
 }
@@ -699,7 +715,7 @@ func deletePolicyStub(kubeClient *mockHooksKubeClient) *ReleaseServer {
 		},
 		env:       e,
 		clientset: clientset,
-		Log:       func(_ string, _ ...interface{}) {},
+		Log:       logging.Discard,
 	}
 }
 
@@ -1060,3 +1076,15 @@ func TestFailedThenSuccessfulHookWithMixedDeletePolicies(t *testing.T) {
 		t.Errorf("expected resource %s to be unexisting after hook succeeded", hook.Name)
 	}
 }
+
+// testLogger returns a logger writing to the log of the test.
+func testLogger(t *testing.T) logging.Logger {
+	return logging.New(testWriter{t}, logging.TextFormat, logging.DebugLevel)
+}
+
+type testWriter struct{ t *testing.T }
+
+func (w testWriter) Write(p []byte) (int, error) {
+	w.t.Log(string(p))
+	return len(p), nil
+}
@@ -28,7 +28,7 @@ const maxParallelism = 20
 func (s *ReleaseServer) RunReleaseTest(req *services.TestReleaseRequest, stream services.ReleaseService_RunReleaseTestServer) error {
 
 	if err := validateReleaseName(req.Name); err != nil {
-		s.Log("releaseTest: Release name is invalid: %s", req.Name)
+		s.Log.Errorf("releaseTest: Release name is invalid: %s", req.Name)
 		return err
 	}
 
@@ -51,35 +51,56 @@ func (s *ReleaseServer) RunReleaseTest(req *services.TestReleaseRequest, stream
 		Parallel:    req.Parallel,
 		Parallelism: parallelism,
 	}
-	s.Log("running tests for release %s", rel.Name)
+	s.Log.Infof("running tests for release %s", rel.Name)
 	tSuite, err := reltesting.NewTestSuite(rel)
 	if err != nil {
-		s.Log("error creating test suite for %s: %s", rel.Name, err)
+		s.Log.Errorf("error creating test suite for %s: %s", rel.Name, err)
 		return err
 	}
 
-	if err := tSuite.Run(testEnv); err != nil {
-		s.Log("error running test suite for %s: %s", rel.Name, err)
+	if err := tSuite.Filter(req.Filters); err != nil {
+		s.Log.Errorf("error filtering test suite for %s: %s", rel.Name, err)
 		return err
 	}
 
-	rel.Info.Status.LastTestSuiteRun = &release.TestSuite{
-		StartedAt:   tSuite.StartedAt,
-		CompletedAt: tSuite.CompletedAt,
-		Results:     tSuite.Results,
+	runErr := tSuite.Run(testEnv)
+	if runErr != nil {
+		s.Log.Errorf("error running test suite for %s: %s", rel.Name, runErr)
 	}
 
+	// keep the results of the tests that did run, even if the suite was
+	// aborted
+	if runErr == nil || len(tSuite.Results) > 0 {
+		rel.Info.Status.LastTestSuiteRun = &release.TestSuite{
+			StartedAt:   tSuite.StartedAt,
+			CompletedAt: tSuite.CompletedAt,
+			Results:     tSuite.Results,
+		}
+	}
+
+	// a report always carries the logs of the test pods, which matter most
+	// when the suite was aborted
 	if req.Logs {
-		testEnv.GetLogs(tSuite.TestManifests)
+		testEnv.GetLogs(tSuite.TestManifests, tSuite.Results)
+	} else if req.Results {
+		testEnv.RecordLogs(tSuite.TestManifests, tSuite.Results)
+	}
+
+	if req.Results {
+		if err := testEnv.StreamResults(tSuite.Results); err != nil {
+			s.Log.Errorf("test: Failed to stream results for %s: %s", rel.Name, err)
+		}
 	}
 
-	if req.Cleanup {
+	if runErr == nil && req.Cleanup {
 		testEnv.DeleteTestPods(tSuite.TestManifests)
 	}
 
-	if err := s.env.Releases.Update(rel); err != nil {
-		s.Log("test: Failed to store updated release: %s", err)
+	if runErr == nil || len(tSuite.Results) > 0 {
+		if err := s.env.Releases.Update(rel); err != nil {
+			s.Log.Errorf("test: Failed to store updated release: %s", err)
+		}
 	}
 
-	return nil
+	return runErr
 }
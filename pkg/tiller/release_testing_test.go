@@ -34,3 +34,14 @@ func TestRunReleaseTest(t *testing.T) {
 		t.Fatalf("failed to run release tests on %s: %s", rel.Name, err)
 	}
 }
+
+func TestRunReleaseTestInvalidFilter(t *testing.T) {
+	rs := rsFixture()
+	rel := namedReleaseStub("nemo", release.Status_DEPLOYED)
+	rs.env.Releases.Create(rel)
+
+	req := &services.TestReleaseRequest{Name: "nemo", Timeout: 2, Filters: []string{"kind=Pod"}}
+	if err := rs.RunReleaseTest(req, mockRunReleaseTestServer{}); err == nil {
+		t.Fatalf("expected an error for an unsupported filter on %s", rel.Name)
+	}
+}
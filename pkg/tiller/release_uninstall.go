@@ -30,15 +30,18 @@ import (
 )
 
 // UninstallRelease deletes all of the resources associated with this release, and marks the release DELETED.
-func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallReleaseRequest) (*services.UninstallReleaseResponse, error) {
+func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallReleaseRequest) (res *services.UninstallReleaseResponse, err error) {
+	s, op := s.startOperation(c, "uninstall", req.Name)
+	defer func() { op.end(res.GetRelease(), err) }()
+
 	if err := validateReleaseName(req.Name); err != nil {
-		s.Log("uninstallRelease: Release name is invalid: %s", req.Name)
+		s.Log.Errorf("uninstallRelease: Release name is invalid: %s", req.Name)
 		return nil, err
 	}
 
 	rels, err := s.env.Releases.History(req.Name)
 	if err != nil {
-		s.Log("uninstall: Release not loaded: %s", req.Name)
+		s.Log.Errorf("uninstall: Release not loaded: %s", req.Name)
 		return nil, err
 	}
 	if len(rels) < 1 {
@@ -53,7 +56,7 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 	if rel.Info.Status.Code == release.Status_DELETED {
 		if req.Purge {
 			if err := s.purgeReleases(rels...); err != nil {
-				s.Log("uninstall: Failed to purge the release: %s", err)
+				s.Log.Errorf("uninstall: Failed to purge the release: %s", err)
 				return nil, err
 			}
 			return &services.UninstallReleaseResponse{Release: rel}, nil
@@ -61,24 +64,24 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 		return nil, fmt.Errorf("the release named %q is already deleted", req.Name)
 	}
 
-	s.Log("uninstall: Deleting %s", req.Name)
+	s.Log.Infof("uninstall: Deleting %s", req.Name)
 	rel.Info.Status.Code = release.Status_DELETING
 	rel.Info.Deleted = timeconv.Now()
 	rel.Info.Description = "Deletion in progress (or silently failed)"
-	res := &services.UninstallReleaseResponse{Release: rel}
+	res = &services.UninstallReleaseResponse{Release: rel}
 
 	if !req.DisableHooks {
 		if err := s.execHook(rel.Hooks, rel.Name, rel.Namespace, hooks.PreDelete, req.Timeout); err != nil {
 			return res, err
 		}
 	} else {
-		s.Log("delete hooks disabled for %s", req.Name)
+		s.Log.Infof("delete hooks disabled for %s", req.Name)
 	}
 
 	// From here on out, the release is currently considered to be in Status_DELETING
 	// state.
 	if err := s.env.Releases.Update(rel); err != nil {
-		s.Log("uninstall: Failed to store updated release: %s", err)
+		s.Log.Errorf("uninstall: Failed to store updated release: %s", err)
 	}
 
 	kept, errs := s.ReleaseModule.Delete(rel, req, s.env)
@@ -86,7 +89,7 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 
 	es := make([]string, 0, len(errs))
 	for _, e := range errs {
-		s.Log("error: %v", e)
+		s.Log.Errorf("error: %v", e)
 		es = append(es, e.Error())
 	}
 
@@ -104,16 +107,16 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 	}
 
 	if req.Purge {
-		s.Log("purge requested for %s", req.Name)
+		s.Log.Infof("purge requested for %s", req.Name)
 		err := s.purgeReleases(rels...)
 		if err != nil {
-			s.Log("uninstall: Failed to purge the release: %s", err)
+			s.Log.Errorf("uninstall: Failed to purge the release: %s", err)
 		}
 		return res, err
 	}
 
 	if err := s.env.Releases.Update(rel); err != nil {
-		s.Log("uninstall: Failed to store updated release: %s", err)
+		s.Log.Errorf("uninstall: Failed to store updated release: %s", err)
 	}
 
 	if len(es) > 0 {
@@ -23,6 +23,7 @@ import (
 	ctx "golang.org/x/net/context"
 
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/deprecation"
 	"k8s.io/helm/pkg/hooks"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -30,38 +31,52 @@ import (
 )
 
 // UpdateRelease takes an existing release and new information, and upgrades the release.
-func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateReleaseRequest) (*services.UpdateReleaseResponse, error) {
+func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateReleaseRequest) (res *services.UpdateReleaseResponse, err error) {
+	s, op := s.startOperation(c, "upgrade", req.Name)
+	defer func() { op.end(res.GetRelease(), err) }()
+
 	if err := validateReleaseName(req.Name); err != nil {
-		s.Log("updateRelease: Release name is invalid: %s", req.Name)
+		s.Log.Errorf("updateRelease: Release name is invalid: %s", req.Name)
 		return nil, err
 	}
-	s.Log("preparing update for %s", req.Name)
+	s.Log.Infof("preparing update for %s", req.Name)
 	currentRelease, updatedRelease, err := s.prepareUpdate(req)
 	if err != nil {
-		s.Log("failed to prepare update: %s", err)
+		s.Log.Errorf("failed to prepare update: %s", err)
 		if req.Force {
 			// Use the --force, Luke.
-			s.Log("performing force update for %s", req.Name)
+			s.Log.Infof("performing force update for %s", req.Name)
 			return s.performUpdateForce(req)
 		}
 		return nil, err
 	}
 
 	if !req.DryRun {
-		s.Log("creating updated release for %s", req.Name)
+		s.Log.Infof("creating updated release for %s", req.Name)
 		if err := s.env.Releases.Create(updatedRelease); err != nil {
 			return nil, err
 		}
 	}
 
-	s.Log("performing update for %s", req.Name)
-	res, err := s.performUpdate(currentRelease, updatedRelease, req)
+	warnings, stale := s.apiVersionWarnings(currentRelease, updatedRelease)
+	for _, w := range warnings {
+		s.Log.Warnf("%s", w)
+	}
+
+	s.Log.Infof("performing update for %s", req.Name)
+	res, err = s.performUpdate(currentRelease, updatedRelease, req)
+	if res != nil {
+		res.Warnings = warnings
+	}
 	if err != nil {
+		if stale {
+			err = fmt.Errorf("%s: the deployed release uses apiVersions the cluster no longer serves, run 'helm release rewrite-apis %s' first", err, req.Name)
+		}
 		return res, err
 	}
 
 	if !req.DryRun {
-		s.Log("updating status for updated release for %s", req.Name)
+		s.Log.Infof("updating status for updated release for %s", req.Name)
 		if err := s.env.Releases.Update(updatedRelease); err != nil {
 			return res, err
 		}
@@ -70,11 +85,40 @@ func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateRelease
 	return res, nil
 }
 
+// apiVersionWarnings describes the objects of an update using deprecated
+// apiVersions. It also tells whether the deployed release uses apiVersions the
+// cluster no longer serves, which fails the update.
+func (s *ReleaseServer) apiVersionWarnings(current, updated *release.Release) ([]string, bool) {
+	sv, err := s.clientset.Discovery().ServerVersion()
+	if err != nil {
+		s.Log.Errorf("cannot check for deprecated apiVersions: %s", err)
+		return nil, false
+	}
+
+	var (
+		warnings []string
+		stale    bool
+	)
+	for _, f := range deprecation.Check(current.Manifest, sv.GitVersion) {
+		if f.Removed {
+			stale = true
+			warnings = append(warnings, fmt.Sprintf("deployed revision %d: %s", current.Version, f.Error()))
+		}
+	}
+	for _, f := range deprecation.Check(updated.Manifest, sv.GitVersion) {
+		warnings = append(warnings, f.Error())
+	}
+	return warnings, stale
+}
+
 // prepareUpdate builds an updated release for an update operation.
 func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*release.Release, *release.Release, error) {
 	if req.Chart == nil {
 		return nil, nil, errMissingChart
 	}
+	if chartutil.IsLibraryChart(req.Chart) {
+		return nil, nil, chartutil.ErrLibraryChart
+	}
 
 	// finds the deployed release with the given name
 	currentRelease, err := s.env.Releases.Deployed(req.Name)
@@ -106,7 +150,7 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 		Revision:  int(revision),
 	}
 
-	caps, err := capabilities(s.clientset.Discovery())
+	caps, err := Capabilities(s.clientset.Discovery())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -114,6 +158,15 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 	if err != nil {
 		return nil, nil, err
 	}
+	// Values reused from the current release stay sensitive.
+	var reused []string
+	if req.ReuseValues && !req.ResetValues {
+		reused = currentRelease.SensitiveValues
+	}
+	sensitive, err := sensitiveValues(req.Chart, req.SensitiveValues, reused)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	hooks, manifestDoc, notesTxt, err := s.renderResources(req.Chart, valuesToRender, req.SubNotes, caps.APIVersions)
 	if err != nil {
@@ -131,14 +184,17 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 			LastDeployed:  ts,
 			Status:        &release.Status{Code: release.Status_PENDING_UPGRADE},
 			Description:   "Preparing upgrade", // This should be overwritten later.
+			Owner:         s.Instance,
+			Timeout:       req.Timeout,
 		},
-		Version:  revision,
-		Manifest: manifestDoc.String(),
-		Hooks:    hooks,
+		Version:         revision,
+		Manifest:        manifestDoc.String(),
+		Hooks:           hooks,
+		SensitiveValues: sensitive,
 	}
 
 	if len(notesTxt) > 0 {
-		updatedRelease.Info.Status.Notes = notesTxt
+		updatedRelease.Info.Status.Notes = redactNotes(notesTxt, valuesToRender, sensitive)
 	}
 	err = validateManifest(s.env.KubeClient, currentRelease.Namespace, manifestDoc.Bytes())
 	return currentRelease, updatedRelease, err
@@ -166,7 +222,7 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 		Wait:         req.Wait,
 	})
 	if err != nil {
-		s.Log("failed update prepare step: %s", err)
+		s.Log.Errorf("failed update prepare step: %s", err)
 		// On dry run, append the manifest contents to a failed release. This is
 		// a stop-gap until we can revisit an error backchannel post-2.0.
 		if req.DryRun && strings.HasPrefix(err.Error(), "YAML parse error") {
@@ -180,7 +236,7 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 	res.Release = newRelease
 
 	if req.DryRun {
-		s.Log("dry run for %s", newRelease.Name)
+		s.Log.Infof("dry run for %s", newRelease.Name)
 		res.Release.Info.Description = "Dry run complete"
 		return res, nil
 	}
@@ -198,7 +254,7 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 			return res, err
 		}
 	} else {
-		s.Log("hooks disabled for %s", req.Name)
+		s.Log.Infof("hooks disabled for %s", req.Name)
 	}
 
 	// delete manifests from the old release
@@ -211,7 +267,7 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 	if len(errs) > 0 {
 		es := make([]string, 0, len(errs))
 		for _, e := range errs {
-			s.Log("error: %v", e)
+			s.Log.Errorf("error: %v", e)
 			es = append(es, e.Error())
 		}
 		return res, fmt.Errorf("Upgrade --force successfully deleted the previous release, but encountered %d error(s) and cannot continue: %s", len(es), strings.Join(es, "; "))
@@ -234,7 +290,7 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 	s.recordRelease(newRelease, false)
 	if err := s.ReleaseModule.Update(oldRelease, newRelease, req, s.env); err != nil {
 		msg := fmt.Sprintf("Upgrade %q failed: %s", newRelease.Name, err)
-		s.Log("warning: %s", msg)
+		s.Log.Warnf("%s", msg)
 		newRelease.Info.Status.Code = release.Status_FAILED
 		newRelease.Info.Description = msg
 		s.recordRelease(newRelease, true)
@@ -245,7 +301,7 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 	if !req.DisableHooks {
 		if err := s.execHook(newRelease.Hooks, newRelease.Name, newRelease.Namespace, hooks.PostInstall, req.Timeout); err != nil {
 			msg := fmt.Sprintf("Release %q failed post-install: %s", newRelease.Name, err)
-			s.Log("warning: %s", msg)
+			s.Log.Warnf("%s", msg)
 			newRelease.Info.Status.Code = release.Status_FAILED
 			newRelease.Info.Description = msg
 			s.recordRelease(newRelease, true)
@@ -268,7 +324,7 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 	res := &services.UpdateReleaseResponse{Release: updatedRelease}
 
 	if req.DryRun {
-		s.Log("dry run for %s", updatedRelease.Name)
+		s.Log.Infof("dry run for %s", updatedRelease.Name)
 		res.Release.Info.Description = "Dry run complete"
 		return res, nil
 	}
@@ -279,11 +335,11 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 			return res, err
 		}
 	} else {
-		s.Log("update hooks disabled for %s", req.Name)
+		s.Log.Infof("update hooks disabled for %s", req.Name)
 	}
 	if err := s.ReleaseModule.Update(originalRelease, updatedRelease, req, s.env); err != nil {
 		msg := fmt.Sprintf("Upgrade %q failed: %s", updatedRelease.Name, err)
-		s.Log("warning: %s", msg)
+		s.Log.Warnf("%s", msg)
 		updatedRelease.Info.Status.Code = release.Status_FAILED
 		updatedRelease.Info.Description = msg
 		s.recordRelease(originalRelease, true)
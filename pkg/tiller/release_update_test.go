@@ -373,6 +373,45 @@ func TestUpdateRelease_ReuseValues(t *testing.T) {
 	compareStoredAndReturnedRelease(t, *rs, *res)
 }
 
+func TestUpdateRelease_ReuseSensitiveValues(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.SensitiveValues = []string{"name"}
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name: rel.Name,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+				{Name: "templates/hooks", Data: []byte(manifestWithUpgradeHooks)},
+			},
+		},
+		Values:          &chart.Config{Raw: "token: s3cr3t-token"},
+		SensitiveValues: []string{"token"},
+		ReuseValues:     true,
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	// The paths marked when the release was installed are kept with the values.
+	if expect := "name,token"; strings.Join(res.Release.SensitiveValues, ",") != expect {
+		t.Errorf("Expected sensitive values %q, got %v", expect, res.Release.SensitiveValues)
+	}
+
+	req.ReuseValues = false
+	res, err = rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	if expect := "token"; strings.Join(res.Release.SensitiveValues, ",") != expect {
+		t.Errorf("Expected sensitive values %q, got %v", expect, res.Release.SensitiveValues)
+	}
+}
+
 func TestUpdateRelease_ResetReuseValues(t *testing.T) {
 	// This verifies that when both reset and reuse are set, reset wins.
 	c := helm.NewContext()
@@ -409,7 +448,7 @@ func TestUpdateReleaseFailure(t *testing.T) {
 	rel := releaseStub()
 	rs.env.Releases.Create(rel)
 	rs.env.KubeClient = newUpdateFailingKubeClient()
-	rs.Log = t.Logf
+	rs.Log = testLogger(t)
 
 	req := &services.UpdateReleaseRequest{
 		Name:         rel.Name,
@@ -452,7 +491,7 @@ func TestUpdateReleaseFailure_Force(t *testing.T) {
 	rs := rsFixture()
 	rel := namedReleaseStub("forceful-luke", release.Status_FAILED)
 	rs.env.Releases.Create(rel)
-	rs.Log = t.Logf
+	rs.Log = testLogger(t)
 
 	req := &services.UpdateReleaseRequest{
 		Name:         rel.Name,
@@ -26,6 +26,8 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
+	"k8s.io/helm/pkg/logging"
+	"k8s.io/helm/pkg/tracing"
 	"k8s.io/helm/pkg/version"
 )
 
@@ -57,20 +59,42 @@ func newUnaryInterceptor() grpc.UnaryServerInterceptor {
 				return nil, err
 			}
 		}
+		ctx, span := startCall(ctx, info.FullMethod)
+		defer func() { span.End(err) }()
 		return goprom.UnaryServerInterceptor(ctx, req, info, handler)
 	}
 }
 
 func newStreamInterceptor() grpc.StreamServerInterceptor {
-	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
 		if err := checkClientVersion(ss.Context()); err != nil {
 			log.Println(err)
 			return err
 		}
-		return goprom.StreamServerInterceptor(srv, ss, info, handler)
+		ctx, span := startCall(ss.Context(), info.FullMethod)
+		defer func() { span.End(err) }()
+		return goprom.StreamServerInterceptor(srv, &tracedStream{ss, ctx}, info, handler)
 	}
 }
 
+// startCall starts the span of a call, and attaches the request ID of the
+// caller, or a new one, to its context.
+func startCall(ctx context.Context, fullMethod string) (context.Context, *tracing.Span) {
+	ctx = logging.ExtractRequestID(tracing.Extract(ctx))
+	return tracing.Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		tracing.String("helm.request_id", logging.RequestIDFromContext(ctx)))
+}
+
+// tracedStream is a server stream whose context carries the span of the call.
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
+
 func splitMethod(fullMethod string) (string, string) {
 	if frags := strings.Split(fullMethod, "/"); len(frags) == 3 {
 		return frags[1], frags[2]
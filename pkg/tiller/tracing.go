@@ -0,0 +1,173 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/tiller/environment"
+	"k8s.io/helm/pkg/tracing"
+)
+
+// startSpan starts a span within the operation the server runs, if any.
+func (s *ReleaseServer) startSpan(name string, attrs ...tracing.Attribute) *tracing.Span {
+	_, span := tracing.Start(s.ctx, name, attrs...)
+	return span
+}
+
+// tracedDriver traces and measures the calls to a storage driver.
+type tracedDriver struct {
+	driver.Driver
+	ctx context.Context
+}
+
+func (d *tracedDriver) observe(call, key string) func(error) {
+	_, span := tracing.Start(d.ctx, "storage."+call,
+		tracing.String("storage.driver", d.Driver.Name()),
+		tracing.String("storage.key", key))
+	start := time.Now()
+	return func(err error) {
+		span.End(err)
+		storageDuration.WithLabelValues(d.Driver.Name(), call).Observe(time.Since(start).Seconds())
+	}
+}
+
+func (d *tracedDriver) record(rls *release.Release) {
+	storageRecordSize.WithLabelValues(d.Driver.Name()).Observe(float64(proto.Size(rls)))
+}
+
+func (d *tracedDriver) Create(key string, rls *release.Release) (err error) {
+	defer func(done func(error)) { done(err) }(d.observe("create", key))
+	d.record(rls)
+	return d.Driver.Create(key, rls)
+}
+
+func (d *tracedDriver) Update(key string, rls *release.Release) (err error) {
+	defer func(done func(error)) { done(err) }(d.observe("update", key))
+	d.record(rls)
+	return d.Driver.Update(key, rls)
+}
+
+func (d *tracedDriver) Delete(key string) (rls *release.Release, err error) {
+	defer func(done func(error)) { done(err) }(d.observe("delete", key))
+	return d.Driver.Delete(key)
+}
+
+func (d *tracedDriver) Get(key string) (rls *release.Release, err error) {
+	defer func(done func(error)) { done(err) }(d.observe("get", key))
+	return d.Driver.Get(key)
+}
+
+func (d *tracedDriver) List(filter func(*release.Release) bool) (rls []*release.Release, err error) {
+	defer func(done func(error)) { done(err) }(d.observe("list", ""))
+	return d.Driver.List(filter)
+}
+
+func (d *tracedDriver) Query(labels map[string]string) (rls []*release.Release, err error) {
+	defer func(done func(error)) { done(err) }(d.observe("query", fmt.Sprint(labels)))
+	return d.Driver.Query(labels)
+}
+
+// tracedKubeClient traces the calls to the Kubernetes client that change or
+// validate resources.
+type tracedKubeClient struct {
+	environment.KubeClient
+	ctx context.Context
+}
+
+// tracedWithin returns a client that traces its calls as children of the span
+// in c, if kc is traced at all.
+func tracedWithin(kc environment.KubeClient, c context.Context) environment.KubeClient {
+	if k, ok := kc.(*tracedKubeClient); ok {
+		return &tracedKubeClient{KubeClient: k.KubeClient, ctx: c}
+	}
+	return kc
+}
+
+func (k *tracedKubeClient) start(name, namespace string) (context.Context, *tracing.Span) {
+	return tracing.Start(k.ctx, name, tracing.String("kube.namespace", namespace))
+}
+
+func (k *tracedKubeClient) Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	_, span := k.start("kube.create", namespace)
+	err := k.KubeClient.Create(namespace, reader, timeout, shouldWait)
+	span.End(err)
+	return err
+}
+
+func (k *tracedKubeClient) CreateWithOptions(namespace string, reader io.Reader, opts kube.CreateOptions) error {
+	c, span := k.start("kube.create", namespace)
+	if opts.Context == nil {
+		opts.Context = c
+	}
+	err := k.KubeClient.CreateWithOptions(namespace, reader, opts)
+	span.End(err)
+	return err
+}
+
+func (k *tracedKubeClient) Update(namespace string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
+	_, span := k.start("kube.update", namespace)
+	err := k.KubeClient.Update(namespace, originalReader, modifiedReader, force, recreate, timeout, shouldWait)
+	span.End(err)
+	return err
+}
+
+func (k *tracedKubeClient) UpdateWithOptions(namespace string, originalReader, modifiedReader io.Reader, opts kube.UpdateOptions) error {
+	c, span := k.start("kube.update", namespace)
+	if opts.Context == nil {
+		opts.Context = c
+	}
+	err := k.KubeClient.UpdateWithOptions(namespace, originalReader, modifiedReader, opts)
+	span.End(err)
+	return err
+}
+
+func (k *tracedKubeClient) Delete(namespace string, reader io.Reader) error {
+	_, span := k.start("kube.delete", namespace)
+	err := k.KubeClient.Delete(namespace, reader)
+	span.End(err)
+	return err
+}
+
+func (k *tracedKubeClient) DeleteWithTimeout(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	_, span := k.start("kube.delete", namespace)
+	err := k.KubeClient.DeleteWithTimeout(namespace, reader, timeout, shouldWait)
+	span.End(err)
+	return err
+}
+
+func (k *tracedKubeClient) WatchUntilReady(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	_, span := k.start("kube.wait", namespace)
+	err := k.KubeClient.WatchUntilReady(namespace, reader, timeout, shouldWait)
+	span.End(err)
+	return err
+}
+
+func (k *tracedKubeClient) Validate(namespace string, reader io.Reader) error {
+	_, span := k.start("tiller.validate", namespace)
+	err := k.KubeClient.Validate(namespace, reader)
+	span.End(err)
+	return err
+}
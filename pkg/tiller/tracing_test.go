@@ -0,0 +1,127 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/tracing"
+)
+
+func spansByName(spans []*tracing.SpanData) map[string][]*tracing.SpanData {
+	m := map[string][]*tracing.SpanData{}
+	for _, s := range spans {
+		m[s.Name] = append(m[s.Name], s)
+	}
+	return m
+}
+
+func TestInstallReleaseTracing(t *testing.T) {
+	exp := &tracing.MemoryExporter{}
+	tracing.SetExporter(exp)
+	defer tracing.SetExporter(nil)
+
+	parent := tracing.NewSpanContext()
+	c := tracing.ContextWithRemoteSpanContext(helm.NewContext(), parent)
+	rs := rsFixture()
+
+	res, err := rs.InstallRelease(c, installRequest())
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	spans := spansByName(exp.Spans())
+	for _, name := range []string{"tiller.install", "tiller.render", "tiller.validate", "tiller.hook", "kube.create", "storage.create"} {
+		if len(spans[name]) == 0 {
+			t.Errorf("expected a %s span, got %v", name, spans)
+		}
+	}
+	for _, s := range exp.Spans() {
+		if s.SpanContext.TraceID != parent.TraceID {
+			t.Errorf("expected span %s to be part of trace %s, got %s", s.Name, parent.TraceID, s.SpanContext.TraceID)
+		}
+	}
+	if len(spans["tiller.install"]) != 1 {
+		t.Fatalf("expected one tiller.install span, got %d", len(spans["tiller.install"]))
+	}
+
+	op := spans["tiller.install"][0]
+	if op.ParentSpanID != parent.SpanID {
+		t.Errorf("expected tiller.install to be a child of %s, got %s", parent.SpanID, op.ParentSpanID)
+	}
+	if op.Status == tracing.StatusError {
+		t.Errorf("expected tiller.install to succeed, got %s", op.StatusMessage)
+	}
+	if got := op.Attribute("release.name"); got != res.Release.Name {
+		t.Errorf("expected release.name %q, got %v", res.Release.Name, got)
+	}
+	if got := op.Attribute("release.namespace"); got != "spaced" {
+		t.Errorf("expected release.namespace %q, got %v", "spaced", got)
+	}
+	if got := op.Attribute("release.revision"); got != int64(1) {
+		t.Errorf("expected release.revision 1, got %v", got)
+	}
+	for _, name := range []string{"tiller.render", "tiller.validate", "tiller.hook", "storage.create"} {
+		for _, s := range spans[name] {
+			if s.ParentSpanID != op.SpanContext.SpanID {
+				t.Errorf("expected %s to be a child of tiller.install", name)
+			}
+		}
+	}
+
+	hook := spans["tiller.hook"][0]
+	if got := hook.Attribute("hook.event"); got != "post-install" {
+		t.Errorf("expected hook.event post-install, got %v", got)
+	}
+	var hookCreated bool
+	for _, s := range spans["kube.create"] {
+		if s.ParentSpanID == hook.SpanContext.SpanID {
+			hookCreated = true
+		}
+	}
+	if !hookCreated {
+		t.Error("expected the hook resource to be created within the tiller.hook span")
+	}
+}
+
+func TestInstallReleaseHookFailureMetrics(t *testing.T) {
+	exp := &tracing.MemoryExporter{}
+	tracing.SetExporter(exp)
+	defer tracing.SetExporter(nil)
+
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.KubeClient = newHookFailingKubeClient()
+
+	before := testutil.ToFloat64(hookFailures.WithLabelValues("post-install"))
+	if _, err := rs.InstallRelease(c, installRequest()); err == nil {
+		t.Fatal("expected the install to fail")
+	}
+	if got := testutil.ToFloat64(hookFailures.WithLabelValues("post-install")) - before; got != 1 {
+		t.Errorf("expected 1 post-install hook failure, got %v", got)
+	}
+
+	spans := spansByName(exp.Spans())
+	for _, name := range []string{"tiller.hook", "tiller.install"} {
+		if len(spans[name]) != 1 || spans[name][0].Status != tracing.StatusError {
+			t.Errorf("expected one failed %s span, got %v", name, spans[name])
+		}
+	}
+}
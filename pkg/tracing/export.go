@@ -0,0 +1,193 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// Exporter receives finished spans.
+//
+// An Exporter must be safe for concurrent use.
+type Exporter interface {
+	ExportSpan(*SpanData)
+}
+
+var exporter struct {
+	sync.RWMutex
+	e Exporter
+}
+
+// SetExporter registers the exporter that receives all finished spans. Spans
+// are dropped while no exporter is registered.
+func SetExporter(e Exporter) {
+	exporter.Lock()
+	defer exporter.Unlock()
+	exporter.e = e
+}
+
+func getExporter() Exporter {
+	exporter.RLock()
+	defer exporter.RUnlock()
+	return exporter.e
+}
+
+// MemoryExporter keeps finished spans in memory. It is meant for tests.
+type MemoryExporter struct {
+	mu    sync.Mutex
+	spans []*SpanData
+}
+
+// ExportSpan implements Exporter.
+func (m *MemoryExporter) ExportSpan(d *SpanData) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spans = append(m.spans, d)
+}
+
+// Spans returns the finished spans, in the order they ended.
+func (m *MemoryExporter) Spans() []*SpanData {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*SpanData(nil), m.spans...)
+}
+
+// Reset drops all spans.
+func (m *MemoryExporter) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spans = nil
+}
+
+// JSONExporter writes each finished span as a line of OTLP JSON. Every line
+// is an ExportTraceServiceRequest holding the one span, which is the format
+// of the OpenTelemetry Collector's otlpjsonfile receiver.
+type JSONExporter struct {
+	mu      sync.Mutex
+	out     io.Writer
+	service string
+}
+
+// NewJSONExporter returns an exporter writing to out the spans of the named
+// service.
+func NewJSONExporter(out io.Writer, service string) *JSONExporter {
+	return &JSONExporter{out: out, service: service}
+}
+
+// scopeName is the instrumentation scope of all spans.
+const scopeName = "k8s.io/helm/pkg/tracing"
+
+type jsonTraces struct {
+	ResourceSpans []jsonResourceSpans `json:"resourceSpans"`
+}
+
+type jsonResourceSpans struct {
+	Resource   jsonResource     `json:"resource"`
+	ScopeSpans []jsonScopeSpans `json:"scopeSpans"`
+}
+
+type jsonResource struct {
+	Attributes []jsonAttribute `json:"attributes,omitempty"`
+}
+
+type jsonScopeSpans struct {
+	Scope jsonScope  `json:"scope"`
+	Spans []jsonSpan `json:"spans"`
+}
+
+type jsonScope struct {
+	Name string `json:"name"`
+}
+
+type jsonSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []jsonAttribute `json:"attributes,omitempty"`
+	Status            jsonStatus      `json:"status"`
+}
+
+type jsonAttribute struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+// spanKindInternal is the OTLP SPAN_KIND_INTERNAL.
+const spanKindInternal = 1
+
+// jsonStatus is an OTLP status. The values of StatusCode are those of the
+// OTLP status codes.
+type jsonStatus struct {
+	Code    StatusCode `json:"code"`
+	Message string     `json:"message,omitempty"`
+}
+
+// ExportSpan implements Exporter.
+func (j *JSONExporter) ExportSpan(d *SpanData) {
+	s := jsonSpan{
+		TraceID:           d.SpanContext.TraceID.String(),
+		SpanID:            d.SpanContext.SpanID.String(),
+		Name:              d.Name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(d.StartTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(d.EndTime.UnixNano(), 10),
+		Status:            jsonStatus{Code: d.Status, Message: d.StatusMessage},
+	}
+	if d.ParentSpanID.IsValid() {
+		s.ParentSpanID = d.ParentSpanID.String()
+	}
+	for _, a := range d.Attributes {
+		s.Attributes = append(s.Attributes, jsonAttribute{Key: a.Key, Value: jsonValue(a.Value)})
+	}
+
+	req := jsonTraces{ResourceSpans: []jsonResourceSpans{{
+		ScopeSpans: []jsonScopeSpans{{Scope: jsonScope{Name: scopeName}, Spans: []jsonSpan{s}}},
+	}}}
+	if j.service != "" {
+		req.ResourceSpans[0].Resource.Attributes = []jsonAttribute{{Key: "service.name", Value: jsonValue(j.service)}}
+	}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.out.Write(append(b, '\n'))
+}
+
+func jsonValue(v interface{}) map[string]interface{} {
+	switch v := v.(type) {
+	case string:
+		return map[string]interface{}{"stringValue": v}
+	case int64:
+		// OTLP encodes 64 bit integers as strings in JSON.
+		return map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+	case bool:
+		return map[string]interface{}{"boolValue": v}
+	default:
+		return map[string]interface{}{"stringValue": fmt.Sprint(v)}
+	}
+}
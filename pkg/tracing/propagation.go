@@ -0,0 +1,84 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+// TraceparentKey is the gRPC metadata key, and HTTP header, carrying the
+// trace context of the caller.
+const TraceparentKey = "traceparent"
+
+// Traceparent formats the span context as a W3C traceparent value.
+func (sc SpanContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-01", sc.TraceID, sc.SpanID)
+}
+
+// ParseTraceparent parses a W3C traceparent value, as found in the
+// TRACEPARENT environment variable of CI systems and in the traceparent
+// header.
+func ParseTraceparent(s string) (SpanContext, error) {
+	var sc SpanContext
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return sc, fmt.Errorf("invalid traceparent %q", s)
+	}
+	if len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return sc, fmt.Errorf("invalid traceparent %q", s)
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return sc, fmt.Errorf("invalid trace ID in traceparent %q", s)
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return sc, fmt.Errorf("invalid span ID in traceparent %q", s)
+	}
+	if !sc.IsValid() {
+		return sc, fmt.Errorf("invalid traceparent %q", s)
+	}
+	return sc, nil
+}
+
+// Inject adds the trace context of ctx to the outgoing gRPC metadata md.
+func Inject(ctx context.Context, md metadata.MD) {
+	if sc := SpanContextFromContext(ctx); sc.IsValid() {
+		md.Set(TraceparentKey, sc.Traceparent())
+	}
+}
+
+// Extract returns a context carrying the trace context found in the incoming
+// gRPC metadata of ctx, if any.
+func Extract(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	v := md.Get(TraceparentKey)
+	if len(v) == 0 {
+		return ctx
+	}
+	sc, err := ParseTraceparent(v[0])
+	if err != nil {
+		return ctx
+	}
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing records spans of work done by Helm and Tiller.
+//
+// The span model follows OpenTelemetry: spans belong to a trace, have a
+// parent, a start and end time, attributes and a status. Trace context is
+// propagated between processes in the W3C Trace Context format, which
+// OpenTelemetry uses by default, so spans can be joined with those of other
+// instrumented services. Finished spans are handed to the registered Exporter.
+package tracing // import "k8s.io/helm/pkg/tracing"
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// String returns the trace ID in hex.
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+
+// IsValid reports whether the trace ID is set.
+func (t TraceID) IsValid() bool { return t != TraceID{} }
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// String returns the span ID in hex.
+func (s SpanID) String() string { return hex.EncodeToString(s[:]) }
+
+// IsValid reports whether the span ID is set.
+func (s SpanID) IsValid() bool { return s != SpanID{} }
+
+// SpanContext identifies a span across process boundaries.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+// IsValid reports whether both the trace and span ID are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// NewSpanContext returns the context of a new root span.
+func NewSpanContext() SpanContext {
+	var sc SpanContext
+	rand.Read(sc.TraceID[:])
+	rand.Read(sc.SpanID[:])
+	return sc
+}
+
+// Attribute is a key-value pair describing a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute { return Attribute{Key: key, Value: value} }
+
+// Int64 returns an integer attribute.
+func Int64(key string, value int64) Attribute { return Attribute{Key: key, Value: value} }
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attribute { return Attribute{Key: key, Value: value} }
+
+// StatusCode is the status of a finished span.
+type StatusCode int
+
+const (
+	// StatusUnset is the status of a span that finished without error.
+	StatusUnset StatusCode = iota
+	// StatusOK marks a span explicitly as successful.
+	StatusOK
+	// StatusError is the status of a span that failed.
+	StatusError
+)
+
+// SpanData describes a finished span.
+type SpanData struct {
+	Name          string
+	SpanContext   SpanContext
+	ParentSpanID  SpanID
+	StartTime     time.Time
+	EndTime       time.Time
+	Attributes    []Attribute
+	Status        StatusCode
+	StatusMessage string
+}
+
+// Attribute returns the value of the attribute with the given key, or nil.
+func (d *SpanData) Attribute(key string) interface{} {
+	for _, a := range d.Attributes {
+		if a.Key == key {
+			return a.Value
+		}
+	}
+	return nil
+}
+
+// Span is an operation being traced. A Span is safe for concurrent use.
+type Span struct {
+	mu    sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+// SpanContext returns the context identifying the span.
+func (s *Span) SpanContext() SpanContext {
+	return s.data.SpanContext
+}
+
+// SetAttributes adds attributes to the span, replacing those with the same
+// keys.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+next:
+	for _, a := range attrs {
+		for i := range s.data.Attributes {
+			if s.data.Attributes[i].Key == a.Key {
+				s.data.Attributes[i] = a
+				continue next
+			}
+		}
+		s.data.Attributes = append(s.data.Attributes, a)
+	}
+}
+
+// End finishes the span and exports it. A non-nil err marks the span as
+// failed. Calls after the first have no effect.
+func (s *Span) End(err error) {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.EndTime = time.Now()
+	if err != nil {
+		s.data.Status = StatusError
+		s.data.StatusMessage = err.Error()
+	}
+	data := s.data
+	s.mu.Unlock()
+
+	if e := getExporter(); e != nil {
+		e.ExportSpan(&data)
+	}
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+// Start starts a span as a child of the span in ctx, or of the remote span
+// context in ctx. Without either, the span starts a new trace. The returned
+// context carries the new span.
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sc := NewSpanContext()
+	span := &Span{data: SpanData{
+		Name:       name,
+		StartTime:  time.Now(),
+		Attributes: attrs,
+	}}
+	if parent := SpanContextFromContext(ctx); parent.IsValid() {
+		sc.TraceID = parent.TraceID
+		span.data.ParentSpanID = parent.SpanID
+	}
+	span.data.SpanContext = sc
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SpanFromContext returns the span in ctx, or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// ContextWithRemoteSpanContext returns a context whose spans are children of
+// a span in another process.
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// SpanContextFromContext returns the context of the span in ctx, or else the
+// remote span context in ctx. The result is invalid if ctx has neither.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.SpanContext()
+	}
+	if ctx == nil {
+		return SpanContext{}
+	}
+	sc, _ := ctx.Value(remoteKey{}).(SpanContext)
+	return sc
+}
@@ -0,0 +1,160 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestStart(t *testing.T) {
+	exp := &MemoryExporter{}
+	SetExporter(exp)
+	defer SetExporter(nil)
+
+	ctx, root := Start(context.Background(), "root", String("release.name", "web"))
+	_, child := Start(ctx, "child")
+	child.SetAttributes(Int64("release.revision", 1))
+	child.SetAttributes(Int64("release.revision", 2))
+	child.End(errors.New("boom"))
+	root.End(nil)
+	root.End(nil)
+
+	spans := exp.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	c, r := spans[0], spans[1]
+	if r.ParentSpanID.IsValid() {
+		t.Errorf("expected root span to have no parent, got %s", r.ParentSpanID)
+	}
+	if c.SpanContext.TraceID != r.SpanContext.TraceID {
+		t.Errorf("expected child to be in trace %s, got %s", r.SpanContext.TraceID, c.SpanContext.TraceID)
+	}
+	if c.ParentSpanID != r.SpanContext.SpanID {
+		t.Errorf("expected child of %s, got %s", r.SpanContext.SpanID, c.ParentSpanID)
+	}
+	if c.Status != StatusError || c.StatusMessage != "boom" {
+		t.Errorf("expected child to have failed with boom, got %v %q", c.Status, c.StatusMessage)
+	}
+	if r.Status != StatusUnset {
+		t.Errorf("expected root status to be unset, got %v", r.Status)
+	}
+	if v := c.Attribute("release.revision"); v != int64(2) || len(c.Attributes) != 1 {
+		t.Errorf("expected revision attribute 2, got %v", c.Attributes)
+	}
+	if v := r.Attribute("release.name"); v != "web" {
+		t.Errorf("expected name attribute web, got %v", v)
+	}
+}
+
+func TestTraceparent(t *testing.T) {
+	const tp = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceparent(tp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" {
+		t.Errorf("unexpected span context %s %s", sc.TraceID, sc.SpanID)
+	}
+	if got := sc.Traceparent(); got != tp {
+		t.Errorf("expected %s, got %s", tp, got)
+	}
+
+	for _, bad := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",
+	} {
+		if _, err := ParseTraceparent(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestPropagation(t *testing.T) {
+	ctx, span := Start(context.Background(), "client")
+	md := metadata.Pairs()
+	Inject(ctx, md)
+
+	server := Extract(metadata.NewIncomingContext(context.Background(), md))
+	_, child := Start(server, "server")
+	if child.data.SpanContext.TraceID != span.SpanContext().TraceID {
+		t.Errorf("expected the server span to join trace %s", span.SpanContext().TraceID)
+	}
+	if child.data.ParentSpanID != span.SpanContext().SpanID {
+		t.Errorf("expected the server span to be a child of %s", span.SpanContext().SpanID)
+	}
+
+	if ctx := Extract(context.Background()); SpanContextFromContext(ctx).IsValid() {
+		t.Error("expected no span context without metadata")
+	}
+}
+
+func TestJSONExporter(t *testing.T) {
+	var buf bytes.Buffer
+	SetExporter(NewJSONExporter(&buf, "tiller"))
+	defer SetExporter(nil)
+
+	_, span := Start(context.Background(), "tiller.install", String("release.name", "web"), Int64("release.revision", 1))
+	span.End(errors.New("failed"))
+
+	var req struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes []map[string]interface{}
+			}
+			ScopeSpans []struct {
+				Scope struct{ Name string }
+				Spans []map[string]interface{}
+			}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &req); err != nil {
+		t.Fatalf("expected a line of JSON, got %q: %s", buf.String(), err)
+	}
+	if len(req.ResourceSpans) != 1 || len(req.ResourceSpans[0].ScopeSpans) != 1 || len(req.ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+		t.Fatalf("expected one span in an OTLP envelope, got %s", buf.String())
+	}
+	rs := req.ResourceSpans[0]
+	if attrs := rs.Resource.Attributes; len(attrs) != 1 || attrs[0]["key"] != "service.name" {
+		t.Errorf("expected the service name as resource attribute, got %v", attrs)
+	}
+	if rs.ScopeSpans[0].Scope.Name != scopeName {
+		t.Errorf("expected scope %q, got %q", scopeName, rs.ScopeSpans[0].Scope.Name)
+	}
+	out := rs.ScopeSpans[0].Spans[0]
+	if out["name"] != "tiller.install" || out["traceId"] != span.SpanContext().TraceID.String() {
+		t.Errorf("unexpected span %v", out)
+	}
+	attrs := out["attributes"].([]interface{})
+	rev := attrs[1].(map[string]interface{})["value"].(map[string]interface{})
+	if rev["intValue"] != "1" {
+		t.Errorf("expected revision to be encoded as an OTLP int, got %v", rev)
+	}
+	status := out["status"].(map[string]interface{})
+	if status["code"] != float64(StatusError) || status["message"] != "failed" {
+		t.Errorf("unexpected status %v", status)
+	}
+}